@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"techno-store/config"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+	"techno-store/internal/infrastructure/datastores/pg"
+
+	"github.com/joho/godotenv"
+)
+
+// attrFlags collects the repeated -attr code=value flags
+type attrFlags map[string]string
+
+func (a attrFlags) String() string {
+	return fmt.Sprint(map[string]string(a))
+}
+
+func (a attrFlags) Set(value string) error {
+	code, filter, ok := strings.Cut(value, "=")
+	if !ok || code == "" {
+		return fmt.Errorf("%q is not code=value", value)
+	}
+	a[code] = filter
+	return nil
+}
+
+// Exports the catalog, see GET /v1/products/export for the columns. The filters
+// are the ones of a product list.
+//
+//	go run ./cmd/export -format xlsx -category 3 -attr color=black -o phones.xlsx
+func main() {
+	var (
+		filter  bo.ProductFilter
+		brands  string
+		attr    = attrFlags{}
+		format  = flag.String("format", string(bo.ExportCSV), "csv, ndjson or xlsx")
+		columns = flag.String("columns", "", "comma separated columns, all the product columns by default")
+		output  = flag.String("o", "", "output file, the standard output by default")
+	)
+	flag.StringVar(&filter.Query, "q", "", "search the name, description, specifications, tags, brand and category")
+	flag.StringVar(&brands, "brand", "", "comma separated brand ids")
+	flag.Int64Var(&filter.CategoryFilter, "category", 0, "category id, including its subcategories")
+	flag.Int64Var(&filter.SupplierFilter, "supplier", 0, "supplier id")
+	flag.BoolVar(&filter.VerifiedSupplierFilter, "verified-supplier", false, "only the products of verified suppliers")
+	flag.Float64Var(&filter.PriceRangeFilter.Min, "min-price", 0, "minimum unit price")
+	flag.Float64Var(&filter.PriceRangeFilter.Max, "max-price", 0, "maximum unit price")
+	flag.Var(attr, "attr", "code=value attribute filter of the category, repeatable")
+	flag.Parse()
+
+	for _, brand := range strings.Split(brands, ",") {
+		if brand = strings.TrimSpace(brand); brand == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(brand, 10, 64)
+		if err != nil {
+			log.Fatalf("invalid brand id %q", brand)
+		}
+		filter.BrandFilter = append(filter.BrandFilter, id)
+	}
+	if len(attr) > 0 {
+		filter.Attributes = attr
+	}
+	query := bo.ProductExportQuery{Filter: filter, Format: bo.ProductExportFormat(*format)}
+	for _, column := range strings.Split(*columns, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			query.Columns = append(query.Columns, column)
+		}
+	}
+
+	if err := godotenv.Load("app.env"); err != nil {
+		log.Fatal("Error loading .env file")
+	}
+	appConfig, err := config.Parse()
+	if err != nil {
+		log.Fatalf("Error parsing config: %s", err)
+	}
+
+	ds := pg.GetInstance(appConfig.Db)
+	ctx := context.Background()
+	export, err := services.Product(ds.Product, ds.Category, nil).Export(ctx, query)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		if out, err = os.Create(*output); err != nil {
+			log.Fatal(err)
+		}
+	}
+	writer := bufio.NewWriter(out)
+	if err := export.Write(ctx, writer); err != nil {
+		log.Fatalf("unable to export products: %s", err)
+	}
+	if err := writer.Flush(); err != nil {
+		log.Fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
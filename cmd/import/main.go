@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+
+	"techno-store/config"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+	"techno-store/internal/infrastructure/datastores/pg"
+
+	"github.com/joho/godotenv"
+)
+
+// Imports a CSV file of products, see POST /v1/products/import for the columns.
+// It exits with 1 when a row is invalid, after printing the errors of every row.
+//
+//	go run ./cmd/import -dry-run products.csv
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only validate the file")
+	upsert := flag.Bool("upsert", false, "update the products of the same supplier and name")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-dry-run] [-upsert] file.csv\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load("app.env"); err != nil {
+		log.Fatal("Error loading .env file")
+	}
+	appConfig, err := config.Parse()
+	if err != nil {
+		log.Fatalf("Error parsing config: %s", err)
+	}
+
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	ds := pg.GetInstance(appConfig.Db)
+	report, err := services.ProductImport(ds.ProductImport, ds.Category).
+		Import(context.Background(), file, bo.ProductImportOptions{DryRun: *dryRun, Upsert: *upsert})
+	if err != nil {
+		slog.Error("unable to import products", "cause", err)
+		os.Exit(1)
+	}
+
+	for _, rowError := range report.Errors {
+		if rowError.Column != "" {
+			fmt.Printf("line %d, %s: %s\n", rowError.Line, rowError.Column, rowError.Message)
+		} else {
+			fmt.Printf("line %d: %s\n", rowError.Line, rowError.Message)
+		}
+	}
+	switch {
+	case len(report.Errors) > 0:
+		fmt.Printf("%d of %d rows are invalid, no product was imported\n", report.Rows-report.Valid, report.Rows)
+		os.Exit(1)
+	case report.DryRun:
+		fmt.Printf("%d rows are valid, no product was imported in a dry run\n", report.Rows)
+	default:
+		fmt.Printf("%d products created, %d updated\n", report.Created, report.Updated)
+	}
+}
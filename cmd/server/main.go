@@ -13,7 +13,11 @@ import (
 	"techno-store/config"
 	_ "techno-store/docs"
 	"techno-store/internal/api/web"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+	"techno-store/internal/infrastructure/blobstores"
 	"techno-store/internal/infrastructure/datastores/pg"
+	"techno-store/internal/infrastructure/payments"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -54,7 +58,43 @@ func main() {
 	// Get a datastore instance
 	ds := pg.GetInstance(appConfig.Db)
 
-	apiService := web.NewAPIService(*appConfig.Server, ds)
+	// Release stock held by abandoned reservations in the background
+	sweeperCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+	services.StockReservation(ds.StockReservation).StartSweeper(sweeperCtx, appConfig.Reservation.SweepInterval)
+
+	// Apply and revert the scheduled price changes in the background
+	services.ProductPrice(ds.ProductPrice).StartScheduler(sweeperCtx, appConfig.Price.ScheduleInterval)
+
+	// Generate the renditions of the uploaded images in the background
+	blobs := blobstores.GetInstance(appConfig.Media)
+	renditionSpecs := make([]bo.RenditionSpec, 0, len(appConfig.Media.Renditions))
+	for _, rendition := range appConfig.Media.Renditions {
+		renditionSpecs = append(renditionSpecs, bo.RenditionSpec{Name: rendition.Name, MaxSide: rendition.MaxSide})
+	}
+	services.MediaRendition(ds.ProductMedia, blobs, renditionSpecs).
+		StartWorkers(sweeperCtx, appConfig.Media.RenditionWorkers, appConfig.Media.RenditionInterval)
+
+	// Generate the product feed on a schedule, it is served from memory
+	feedSettings := bo.ProductFeedSettings{
+		Title:      appConfig.Feed.Title,
+		ProductURL: appConfig.Feed.ProductURL,
+		BaseURL:    appConfig.Feed.BaseURL,
+		Currency:   appConfig.Feed.Currency,
+	}
+	services.ProductFeed(ds.Product, ds.Currency, blobs, feedSettings).StartScheduler(sweeperCtx, appConfig.Feed.Interval)
+
+	taxSettings := bo.TaxSettings{
+		Pricing:      bo.TaxPricing(appConfig.Tax.Pricing),
+		Jurisdiction: appConfig.Tax.Jurisdiction,
+	}
+
+	apiService := web.NewAPIService(*appConfig.Server, ds).
+		WithPaymentGateway(payments.GetInstance(appConfig.Payment)).
+		WithSearchConfig(*appConfig.Search).
+		WithMediaStore(blobs, *appConfig.Media).
+		WithProductFeed(feedSettings).
+		WithTaxSettings(taxSettings)
 
 	// gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
@@ -84,6 +124,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	slog.Info("Shutdown Server ...")
+	stopSweeper()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -11,12 +11,26 @@ var (
 	config    *Config
 	sc        *ServerConfig
 	dbc       *DBConfig
+	rc        *ReservationConfig
+	pc        *PaymentConfig
+	src       *SearchConfig
+	mc        *MediaConfig
+	fc        *FeedConfig
+	prc       *PriceConfig
+	tc        *TaxConfig
 	configErr error
 )
 
 type Config struct {
-	Server *ServerConfig
-	Db     *DBConfig
+	Server      *ServerConfig
+	Db          *DBConfig
+	Reservation *ReservationConfig
+	Payment     *PaymentConfig
+	Search      *SearchConfig
+	Media       *MediaConfig
+	Feed        *FeedConfig
+	Price       *PriceConfig
+	Tax         *TaxConfig
 }
 
 func Get() *Config {
@@ -34,9 +48,44 @@ func Parse() (*Config, error) {
 		if configErr != nil {
 			return
 		}
+		rc, configErr = newReservationConfig()
+		if configErr != nil {
+			return
+		}
+		pc, configErr = newPaymentConfig()
+		if configErr != nil {
+			return
+		}
+		src, configErr = newSearchConfig()
+		if configErr != nil {
+			return
+		}
+		mc, configErr = newMediaConfig()
+		if configErr != nil {
+			return
+		}
+		fc, configErr = newFeedConfig()
+		if configErr != nil {
+			return
+		}
+		prc, configErr = newPriceConfig()
+		if configErr != nil {
+			return
+		}
+		tc, configErr = newTaxConfig()
+		if configErr != nil {
+			return
+		}
 		config = &Config{
-			Server: sc,
-			Db:     dbc,
+			Server:      sc,
+			Db:          dbc,
+			Reservation: rc,
+			Payment:     pc,
+			Search:      src,
+			Media:       mc,
+			Feed:        fc,
+			Price:       prc,
+			Tax:         tc,
 		}
 	})
 	return config, configErr
@@ -61,6 +110,56 @@ func get(key string) string {
 		return GetEnvWithFallback("DB_LOGGING", "false")
 	case "DB_MIGRATE":
 		return GetEnvWithFallback("DB_MIGRATE", "false")
+	case "RESERVATION_SWEEP_INTERVAL":
+		return GetEnvWithFallback("RESERVATION_SWEEP_INTERVAL", "1m")
+	case "PAYMENT_PROVIDER":
+		return GetEnvWithFallback("PAYMENT_PROVIDER", "fake")
+	case "PAYMENT_WEBHOOK_SECRET":
+		return GetEnvWithFallback("PAYMENT_WEBHOOK_SECRET", "")
+	case "SEARCH_SUGGEST_CACHE_SIZE":
+		return GetEnvWithFallback("SEARCH_SUGGEST_CACHE_SIZE", "1000")
+	case "SEARCH_SUGGEST_CACHE_TTL":
+		return GetEnvWithFallback("SEARCH_SUGGEST_CACHE_TTL", "5m")
+	case "MEDIA_STORAGE":
+		return GetEnvWithFallback("MEDIA_STORAGE", "local")
+	case "MEDIA_LOCAL_DIR":
+		return GetEnvWithFallback("MEDIA_LOCAL_DIR", "./media")
+	case "MEDIA_PUBLIC_URL":
+		return GetEnvWithFallback("MEDIA_PUBLIC_URL", "/v1/media")
+	case "MEDIA_MAX_UPLOAD_SIZE":
+		return GetEnvWithFallback("MEDIA_MAX_UPLOAD_SIZE", "10485760")
+	case "MEDIA_S3_ENDPOINT":
+		return GetEnvWithFallback("MEDIA_S3_ENDPOINT", "")
+	case "MEDIA_S3_BUCKET":
+		return GetEnvWithFallback("MEDIA_S3_BUCKET", "")
+	case "MEDIA_S3_REGION":
+		return GetEnvWithFallback("MEDIA_S3_REGION", "us-east-1")
+	case "MEDIA_S3_ACCESS_KEY":
+		return GetEnvWithFallback("MEDIA_S3_ACCESS_KEY", "")
+	case "MEDIA_S3_SECRET_KEY":
+		return GetEnvWithFallback("MEDIA_S3_SECRET_KEY", "")
+	case "MEDIA_RENDITIONS":
+		return GetEnvWithFallback("MEDIA_RENDITIONS", "thumbnail:160,medium:640,large:1280")
+	case "MEDIA_RENDITION_WORKERS":
+		return GetEnvWithFallback("MEDIA_RENDITION_WORKERS", "2")
+	case "MEDIA_RENDITION_INTERVAL":
+		return GetEnvWithFallback("MEDIA_RENDITION_INTERVAL", "30s")
+	case "FEED_TITLE":
+		return GetEnvWithFallback("FEED_TITLE", "technoStore")
+	case "FEED_PRODUCT_URL":
+		return GetEnvWithFallback("FEED_PRODUCT_URL", "http://localhost:3000/products/{id}")
+	case "FEED_BASE_URL":
+		return GetEnvWithFallback("FEED_BASE_URL", "http://localhost:8080")
+	case "FEED_CURRENCY":
+		return GetEnvWithFallback("FEED_CURRENCY", "BDT")
+	case "FEED_INTERVAL":
+		return GetEnvWithFallback("FEED_INTERVAL", "1h")
+	case "PRICE_SCHEDULE_INTERVAL":
+		return GetEnvWithFallback("PRICE_SCHEDULE_INTERVAL", "1m")
+	case "TAX_PRICING":
+		return GetEnvWithFallback("TAX_PRICING", "inclusive")
+	case "TAX_JURISDICTION":
+		return GetEnvWithFallback("TAX_JURISDICTION", "BD")
 	}
 	log.Fatalf("Undefined config key: %s", key)
 	return ""
@@ -74,4 +173,21 @@ func Print() {
 	fmt.Printf(" - %s:                 %s\n", "SERVER_PORT", get("PORT"))
 	fmt.Printf(" - %s:                  %s\n", "DB_LOGGING", get("DB_LOGGING"))
 	fmt.Printf(" - %s:                  %s\n", "DB_MIGRATE", get("DB_MIGRATE"))
+	fmt.Printf(" - %s:  %s\n", "RESERVATION_SWEEP_INTERVAL", get("RESERVATION_SWEEP_INTERVAL"))
+	fmt.Printf(" - %s:            %s\n", "PAYMENT_PROVIDER", get("PAYMENT_PROVIDER"))
+	fmt.Printf(" - %s:   %s\n", "SEARCH_SUGGEST_CACHE_SIZE", get("SEARCH_SUGGEST_CACHE_SIZE"))
+	fmt.Printf(" - %s:    %s\n", "SEARCH_SUGGEST_CACHE_TTL", get("SEARCH_SUGGEST_CACHE_TTL"))
+	fmt.Printf(" - %s:               %s\n", "MEDIA_STORAGE", get("MEDIA_STORAGE"))
+	fmt.Printf(" - %s:            %s\n", "MEDIA_PUBLIC_URL", get("MEDIA_PUBLIC_URL"))
+	fmt.Printf(" - %s:       %s\n", "MEDIA_MAX_UPLOAD_SIZE", get("MEDIA_MAX_UPLOAD_SIZE"))
+	fmt.Printf(" - %s:            %s\n", "MEDIA_RENDITIONS", get("MEDIA_RENDITIONS"))
+	fmt.Printf(" - %s:     %s\n", "MEDIA_RENDITION_WORKERS", get("MEDIA_RENDITION_WORKERS"))
+	fmt.Printf(" - %s:    %s\n", "MEDIA_RENDITION_INTERVAL", get("MEDIA_RENDITION_INTERVAL"))
+	fmt.Printf(" - %s:            %s\n", "FEED_PRODUCT_URL", get("FEED_PRODUCT_URL"))
+	fmt.Printf(" - %s:               %s\n", "FEED_BASE_URL", get("FEED_BASE_URL"))
+	fmt.Printf(" - %s:               %s\n", "FEED_CURRENCY", get("FEED_CURRENCY"))
+	fmt.Printf(" - %s:               %s\n", "FEED_INTERVAL", get("FEED_INTERVAL"))
+	fmt.Printf(" - %s:     %s\n", "PRICE_SCHEDULE_INTERVAL", get("PRICE_SCHEDULE_INTERVAL"))
+	fmt.Printf(" - %s:                 %s\n", "TAX_PRICING", get("TAX_PRICING"))
+	fmt.Printf(" - %s:            %s\n", "TAX_JURISDICTION", get("TAX_JURISDICTION"))
 }
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FeedConfig contains the product feed configuration
+type FeedConfig struct {
+	// Title names the store in the feed
+	Title string
+	// ProductURL is the page of a product on the store front, {id} is replaced by the product id
+	ProductURL string
+	// BaseURL makes the relative urls of the feed absolute, such as the image urls of
+	// the default MEDIA_PUBLIC_URL
+	BaseURL string
+	// Currency is the ISO 4217 code the prices are converted to, the one of the
+	// country the feed targets
+	Currency string
+	// Interval is how often the feed is generated again
+	Interval time.Duration
+}
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+func newFeedConfig() (*FeedConfig, error) {
+	feedConfig := &FeedConfig{
+		Title:      get("FEED_TITLE"),
+		ProductURL: get("FEED_PRODUCT_URL"),
+		BaseURL:    strings.TrimSuffix(get("FEED_BASE_URL"), "/"),
+		Currency:   get("FEED_CURRENCY"),
+	}
+
+	if feedConfig.Title == "" {
+		return nil, fmt.Errorf("FEED_TITLE must not be empty")
+	}
+	if !strings.Contains(feedConfig.ProductURL, "{id}") {
+		return nil, fmt.Errorf("FEED_PRODUCT_URL must contain {id}, got %q", feedConfig.ProductURL)
+	}
+	if err := absoluteURL(feedConfig.ProductURL); err != nil {
+		return nil, fmt.Errorf("invalid FEED_PRODUCT_URL: %w", err)
+	}
+	if err := absoluteURL(feedConfig.BaseURL); err != nil {
+		return nil, fmt.Errorf("invalid FEED_BASE_URL: %w", err)
+	}
+	if !currencyPattern.MatchString(feedConfig.Currency) {
+		return nil, fmt.Errorf("FEED_CURRENCY must be an ISO 4217 code, got %q", feedConfig.Currency)
+	}
+
+	interval, err := time.ParseDuration(get("FEED_INTERVAL"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid FEED_INTERVAL: %w", err)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("FEED_INTERVAL must be positive, got %s", interval)
+	}
+	feedConfig.Interval = interval
+
+	return feedConfig, nil
+}
+
+// absoluteURL checks that a url has an http or https scheme and a host
+func absoluteURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%q is not an absolute http url", rawURL)
+	}
+	return nil
+}
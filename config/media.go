@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MediaConfig contains the product media storage configuration
+type MediaConfig struct {
+	// Storage is the blob store backend, local or s3
+	Storage string
+	// LocalDir is where the local backend keeps the files
+	LocalDir string
+	// PublicURL prefixes the storage key of a file in its url, the default
+	// /v1/media serves the files through the API whatever the backend
+	PublicURL string
+	// MaxUploadSize is the largest accepted file, in bytes
+	MaxUploadSize int64
+	S3            S3Config
+	// Renditions are the scaled down copies generated for every image
+	Renditions []RenditionConfig
+	// RenditionWorkers generate the renditions in the background, each polling
+	// for pending images every RenditionInterval when no upload wakes it up
+	RenditionWorkers  int
+	RenditionInterval time.Duration
+}
+
+// RenditionConfig names a rendition, scaled down to fit MaxSide x MaxSide pixels
+type RenditionConfig struct {
+	Name    string
+	MaxSide int
+}
+
+// S3Config locates a bucket of an S3 compatible service, e.g. MinIO
+type S3Config struct {
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+}
+
+func newMediaConfig() (*MediaConfig, error) {
+	maxUploadSize, err := strconv.ParseInt(get("MEDIA_MAX_UPLOAD_SIZE"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MEDIA_MAX_UPLOAD_SIZE: %w", err)
+	}
+	if maxUploadSize <= 0 {
+		return nil, fmt.Errorf("MEDIA_MAX_UPLOAD_SIZE must be positive, got %d", maxUploadSize)
+	}
+
+	renditions, err := parseRenditions(get("MEDIA_RENDITIONS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MEDIA_RENDITIONS: %w", err)
+	}
+	renditionWorkers, err := strconv.Atoi(get("MEDIA_RENDITION_WORKERS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MEDIA_RENDITION_WORKERS: %w", err)
+	}
+	if renditionWorkers < 0 {
+		return nil, fmt.Errorf("MEDIA_RENDITION_WORKERS cannot be negative, got %d", renditionWorkers)
+	}
+	renditionInterval, err := time.ParseDuration(get("MEDIA_RENDITION_INTERVAL"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MEDIA_RENDITION_INTERVAL: %w", err)
+	}
+	if renditionInterval <= 0 {
+		return nil, fmt.Errorf("MEDIA_RENDITION_INTERVAL must be positive, got %s", renditionInterval)
+	}
+
+	mc := &MediaConfig{
+		Storage:       get("MEDIA_STORAGE"),
+		LocalDir:      get("MEDIA_LOCAL_DIR"),
+		PublicURL:     strings.TrimSuffix(get("MEDIA_PUBLIC_URL"), "/"),
+		MaxUploadSize: maxUploadSize,
+		S3: S3Config{
+			Endpoint:  strings.TrimSuffix(get("MEDIA_S3_ENDPOINT"), "/"),
+			Bucket:    get("MEDIA_S3_BUCKET"),
+			Region:    get("MEDIA_S3_REGION"),
+			AccessKey: get("MEDIA_S3_ACCESS_KEY"),
+			SecretKey: get("MEDIA_S3_SECRET_KEY"),
+		},
+		Renditions:        renditions,
+		RenditionWorkers:  renditionWorkers,
+		RenditionInterval: renditionInterval,
+	}
+
+	switch mc.Storage {
+	case "local":
+		if mc.LocalDir == "" {
+			return nil, fmt.Errorf("MEDIA_LOCAL_DIR is required by the local media storage")
+		}
+	case "s3":
+		if mc.S3.Endpoint == "" || mc.S3.Bucket == "" {
+			return nil, fmt.Errorf("MEDIA_S3_ENDPOINT and MEDIA_S3_BUCKET are required by the s3 media storage")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported MEDIA_STORAGE: %s", mc.Storage)
+	}
+
+	return mc, nil
+}
+
+// parseRenditions reads a comma separated list of name:maxSide, e.g. thumbnail:160,medium:640
+func parseRenditions(value string) ([]RenditionConfig, error) {
+	var renditions []RenditionConfig
+	names := map[string]bool{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, side, ok := strings.Cut(item, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q is not name:maxSide", item)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("rendition %s is listed twice", name)
+		}
+		maxSide, err := strconv.Atoi(strings.TrimSpace(side))
+		if err != nil || maxSide <= 0 {
+			return nil, fmt.Errorf("the max side of rendition %s must be a positive number of pixels", name)
+		}
+		names[name] = true
+		renditions = append(renditions, RenditionConfig{Name: name, MaxSide: maxSide})
+	}
+
+	return renditions, nil
+}
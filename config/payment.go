@@ -0,0 +1,25 @@
+package config
+
+import "fmt"
+
+// PaymentConfig contains the payment provider configuration
+type PaymentConfig struct {
+	Provider string
+	// WebhookSecret verifies provider callbacks, they are all rejected when empty
+	WebhookSecret string
+}
+
+func newPaymentConfig() (*PaymentConfig, error) {
+	pc := &PaymentConfig{
+		Provider:      get("PAYMENT_PROVIDER"),
+		WebhookSecret: get("PAYMENT_WEBHOOK_SECRET"),
+	}
+
+	switch pc.Provider {
+	case "fake":
+	default:
+		return nil, fmt.Errorf("unsupported PAYMENT_PROVIDER: %s", pc.Provider)
+	}
+
+	return pc, nil
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// PriceConfig contains the scheduled price change configuration
+type PriceConfig struct {
+	// ScheduleInterval is how often the due price schedules are applied or reverted
+	ScheduleInterval time.Duration
+}
+
+func newPriceConfig() (*PriceConfig, error) {
+	scheduleInterval, err := time.ParseDuration(get("PRICE_SCHEDULE_INTERVAL"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PRICE_SCHEDULE_INTERVAL: %w", err)
+	}
+	if scheduleInterval <= 0 {
+		return nil, fmt.Errorf("PRICE_SCHEDULE_INTERVAL must be positive, got %s", scheduleInterval)
+	}
+
+	return &PriceConfig{ScheduleInterval: scheduleInterval}, nil
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReservationConfig contains the stock reservation configuration
+type ReservationConfig struct {
+	// SweepInterval is how often expired reservations are released back to stock
+	SweepInterval time.Duration
+}
+
+func newReservationConfig() (*ReservationConfig, error) {
+	sweepInterval, err := time.ParseDuration(get("RESERVATION_SWEEP_INTERVAL"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RESERVATION_SWEEP_INTERVAL: %w", err)
+	}
+	if sweepInterval <= 0 {
+		return nil, fmt.Errorf("RESERVATION_SWEEP_INTERVAL must be positive, got %s", sweepInterval)
+	}
+
+	return &ReservationConfig{SweepInterval: sweepInterval}, nil
+}
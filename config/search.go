@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SearchConfig contains the search suggestion configuration
+type SearchConfig struct {
+	// SuggestCacheSize is how many prefixes keep their suggestions cached, 0 disables the cache
+	SuggestCacheSize int
+	// SuggestCacheTTL is how long cached suggestions are served before they are looked up again
+	SuggestCacheTTL time.Duration
+}
+
+func newSearchConfig() (*SearchConfig, error) {
+	cacheSize, err := strconv.Atoi(get("SEARCH_SUGGEST_CACHE_SIZE"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SEARCH_SUGGEST_CACHE_SIZE: %w", err)
+	}
+	if cacheSize < 0 {
+		return nil, fmt.Errorf("SEARCH_SUGGEST_CACHE_SIZE must not be negative, got %d", cacheSize)
+	}
+
+	cacheTTL, err := time.ParseDuration(get("SEARCH_SUGGEST_CACHE_TTL"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SEARCH_SUGGEST_CACHE_TTL: %w", err)
+	}
+	if cacheTTL <= 0 {
+		return nil, fmt.Errorf("SEARCH_SUGGEST_CACHE_TTL must be positive, got %s", cacheTTL)
+	}
+
+	return &SearchConfig{SuggestCacheSize: cacheSize, SuggestCacheTTL: cacheTTL}, nil
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// TaxConfig contains the tax calculation configuration
+type TaxConfig struct {
+	// Pricing is inclusive when the prices include the tax, exclusive when it is added on top
+	Pricing string
+	// Jurisdiction is the ISO 3166 country or subdivision code the tax is calculated for
+	// when a cart or an order does not name one
+	Jurisdiction string
+}
+
+var jurisdictionPattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+
+func newTaxConfig() (*TaxConfig, error) {
+	taxConfig := &TaxConfig{
+		Pricing:      get("TAX_PRICING"),
+		Jurisdiction: get("TAX_JURISDICTION"),
+	}
+
+	if taxConfig.Pricing != "inclusive" && taxConfig.Pricing != "exclusive" {
+		return nil, fmt.Errorf("TAX_PRICING must be inclusive or exclusive, got %q", taxConfig.Pricing)
+	}
+	if !jurisdictionPattern.MatchString(taxConfig.Jurisdiction) {
+		return nil, fmt.Errorf("TAX_JURISDICTION must be an ISO 3166 country or subdivision code, got %q", taxConfig.Jurisdiction)
+	}
+
+	return taxConfig, nil
+}
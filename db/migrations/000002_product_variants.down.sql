@@ -0,0 +1,2 @@
+ALTER TABLE product_stocks DROP COLUMN IF EXISTS variant_id;
+DROP TABLE IF EXISTS product_variants;
@@ -0,0 +1,16 @@
+-- Create product_variants table
+CREATE TABLE product_variants (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku VARCHAR(64) UNIQUE NOT NULL,
+    options JSONB NOT NULL DEFAULT '{}',
+    unit_price DECIMAL(10, 2) NOT NULL,
+    discount_price DECIMAL(10, 2),
+    status_id INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_variants_product_id ON product_variants(product_id);
+
+-- Track stock per variant, a NULL variant_id keeps the product level stock row
+ALTER TABLE product_stocks ADD COLUMN variant_id INT REFERENCES product_variants(id) ON DELETE CASCADE;
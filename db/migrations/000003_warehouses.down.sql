@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS uq_product_stocks_location;
+ALTER TABLE product_stocks DROP COLUMN IF EXISTS warehouse_id;
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,27 @@
+-- Create warehouses table
+CREATE TABLE warehouses (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(32) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    address TEXT,
+    status_id INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE product_stocks ADD COLUMN warehouse_id INT REFERENCES warehouses(id) ON DELETE CASCADE;
+
+-- Move the existing stock rows into a default warehouse, which is also used
+-- for new stock rows that do not name a warehouse
+DO $$
+DECLARE
+    main_warehouse_id INT;
+BEGIN
+    INSERT INTO warehouses (code, name, status_id) VALUES ('main', 'Main Warehouse', 1) RETURNING id INTO main_warehouse_id;
+    UPDATE product_stocks SET warehouse_id = main_warehouse_id;
+    EXECUTE format('ALTER TABLE product_stocks ALTER COLUMN warehouse_id SET DEFAULT %s', main_warehouse_id);
+END $$;
+
+ALTER TABLE product_stocks ALTER COLUMN warehouse_id SET NOT NULL;
+
+-- One stock row per product (variant) and warehouse
+CREATE UNIQUE INDEX uq_product_stocks_location ON product_stocks(product_id, COALESCE(variant_id, 0), warehouse_id);
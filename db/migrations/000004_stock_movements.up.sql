@@ -0,0 +1,32 @@
+-- Create stock_movements table, an append-only ledger of every stock change
+CREATE TABLE stock_movements (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id INT REFERENCES product_variants(id) ON DELETE CASCADE,
+    warehouse_id INT NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    quantity_delta INT NOT NULL CHECK (quantity_delta <> 0),
+    balance_after INT NOT NULL,
+    reason VARCHAR(32) NOT NULL CHECK (reason IN ('receipt', 'sale', 'return', 'adjustment', 'damage', 'transfer')),
+    reference VARCHAR(255),
+    created_by VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_product_id ON stock_movements(product_id, created_at DESC);
+
+-- Movements are never rewritten, rows only go away with their product, variant or warehouse
+CREATE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_stock_movements_append_only
+    BEFORE UPDATE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+-- Opening balance so the ledger reconciles with the existing stock rows
+INSERT INTO stock_movements (product_id, variant_id, warehouse_id, quantity_delta, balance_after, reason, reference)
+    SELECT product_id, variant_id, warehouse_id, stock_quantity, stock_quantity, 'adjustment', 'opening balance'
+    FROM product_stocks
+    WHERE stock_quantity <> 0;
@@ -0,0 +1,2 @@
+-- Row version used as an optimistic concurrency token for stock changes
+ALTER TABLE product_stocks ADD COLUMN version INT NOT NULL DEFAULT 0;
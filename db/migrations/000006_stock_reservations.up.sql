@@ -0,0 +1,14 @@
+-- Create stock_reservations table, holds on a stock row while a checkout is paid
+CREATE TABLE stock_reservations (
+    id BIGSERIAL PRIMARY KEY,
+    product_stock_id INT NOT NULL REFERENCES product_stocks(id) ON DELETE CASCADE,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(16) NOT NULL CHECK (status IN ('active', 'confirmed', 'released', 'expired')),
+    reference VARCHAR(255),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_reservations_active ON stock_reservations(product_stock_id) WHERE status = 'active';
+CREATE INDEX idx_stock_reservations_expiry ON stock_reservations(expires_at) WHERE status = 'active';
@@ -0,0 +1,27 @@
+-- Create carts table, the totals are recalculated on every line item change
+CREATE TABLE carts (
+    id BIGSERIAL PRIMARY KEY,
+    reference VARCHAR(255),
+    status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'checked_out')),
+    subtotal DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    discount_total DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    total DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create cart_items table, prices are a snapshot taken when the item is added
+CREATE TABLE cart_items (
+    id BIGSERIAL PRIMARY KEY,
+    cart_id BIGINT NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id INT REFERENCES product_variants(id) ON DELETE CASCADE,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(10, 2) NOT NULL,
+    discount_price DECIMAL(10, 2),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A product (variant) appears once per cart, adding it again raises the quantity
+CREATE UNIQUE INDEX uq_cart_items_line ON cart_items(cart_id, product_id, COALESCE(variant_id, 0));
@@ -0,0 +1,39 @@
+-- Create orders table, the status follows the order lifecycle state machine
+CREATE TABLE orders (
+    id BIGSERIAL PRIMARY KEY,
+    cart_id BIGINT REFERENCES carts(id) ON DELETE SET NULL,
+    reference VARCHAR(255),
+    status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'paid', 'packed', 'shipped', 'delivered', 'cancelled', 'refunded')),
+    subtotal DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    discount_total DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    total DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_orders_status ON orders(status);
+
+-- Create order_items table, prices are copied from the cart the order was placed from
+CREATE TABLE order_items (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    product_id INT NOT NULL REFERENCES products(id),
+    variant_id INT REFERENCES product_variants(id),
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(10, 2) NOT NULL,
+    discount_price DECIMAL(10, 2)
+);
+
+CREATE INDEX idx_order_items_order_id ON order_items(order_id);
+
+-- Create order_item_allocations table, the stock rows an item was taken from so
+-- a cancellation puts it back where it came from
+CREATE TABLE order_item_allocations (
+    id BIGSERIAL PRIMARY KEY,
+    order_item_id BIGINT NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    product_stock_id INT REFERENCES product_stocks(id) ON DELETE SET NULL,
+    quantity INT NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_order_item_allocations_item ON order_item_allocations(order_item_id);
@@ -0,0 +1,28 @@
+-- Create payments table, one row per authorization attempt of an order
+CREATE TABLE payments (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    provider VARCHAR(32) NOT NULL,
+    provider_reference VARCHAR(255) NOT NULL,
+    status VARCHAR(16) NOT NULL CHECK (status IN ('authorized', 'captured', 'refunded', 'voided', 'failed')),
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount >= 0),
+    captured_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    refunded_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_payments_provider_reference UNIQUE (provider, provider_reference)
+);
+
+CREATE INDEX idx_payments_order_id ON payments(order_id);
+
+-- Create payment_events table, every provider event is applied at most once
+CREATE TABLE payment_events (
+    id BIGSERIAL PRIMARY KEY,
+    provider VARCHAR(32) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    provider_reference VARCHAR(255) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_payment_events_event UNIQUE (provider, event_id)
+);
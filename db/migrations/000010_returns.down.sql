@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_return_events_append_only ON return_events;
+DROP FUNCTION IF EXISTS return_events_append_only();
+DROP TABLE IF EXISTS return_events;
+DROP TABLE IF EXISTS return_items;
+DROP TABLE IF EXISTS returns;
@@ -0,0 +1,53 @@
+-- Create returns table, a return merchandise authorization (RMA) against the
+-- lines of a shipped or delivered order
+CREATE TABLE returns (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'requested'
+        CHECK (status IN ('requested', 'approved', 'rejected', 'received', 'refunded')),
+    reason TEXT,
+    refund_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    payment_id BIGINT REFERENCES payments(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_returns_order_id ON returns(order_id);
+
+-- Create return_items table, the order lines being sent back and the condition
+-- they arrived in
+CREATE TABLE return_items (
+    id BIGSERIAL PRIMARY KEY,
+    return_id BIGINT NOT NULL REFERENCES returns(id) ON DELETE CASCADE,
+    order_item_id BIGINT NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    received_quantity INT NOT NULL DEFAULT 0 CHECK (received_quantity >= 0 AND received_quantity <= quantity),
+    condition VARCHAR(16) CHECK (condition IN ('sellable', 'damaged')),
+    UNIQUE (return_id, order_item_id)
+);
+
+CREATE INDEX idx_return_items_order_item_id ON return_items(order_item_id);
+
+-- Create return_events table, the audit trail of every step of a return
+CREATE TABLE return_events (
+    id BIGSERIAL PRIMARY KEY,
+    return_id BIGINT NOT NULL REFERENCES returns(id) ON DELETE CASCADE,
+    from_status VARCHAR(16),
+    to_status VARCHAR(16) NOT NULL,
+    note TEXT,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_return_events_return_id ON return_events(return_id, created_at ASC);
+
+-- Events are never rewritten, rows only go away with their return
+CREATE FUNCTION return_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'return_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_return_events_append_only
+    BEFORE UPDATE ON return_events
+    FOR EACH ROW EXECUTE FUNCTION return_events_append_only();
@@ -0,0 +1,34 @@
+-- Create promotions table, a discount rule scoped to the whole catalog, a brand,
+-- a category with its descendants, a supplier or a single product
+CREATE TABLE promotions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('percentage', 'fixed_amount', 'buy_x_get_y')),
+    value DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    buy_quantity INT NOT NULL DEFAULT 0,
+    get_quantity INT NOT NULL DEFAULT 0,
+    scope VARCHAR(16) NOT NULL CHECK (scope IN ('all', 'brand', 'category', 'supplier', 'product')),
+    scope_id INT,
+    coupon_code VARCHAR(64),
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    usage_limit INT CHECK (usage_limit > 0),
+    usage_count INT NOT NULL DEFAULT 0,
+    status_id INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((scope = 'all') = (scope_id IS NULL)),
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+-- Coupon codes are matched case-insensitively
+CREATE UNIQUE INDEX idx_promotions_coupon_code ON promotions(UPPER(coupon_code));
+
+-- Create promotion_redemptions table, one row per order a promotion was applied to
+CREATE TABLE promotion_redemptions (
+    id BIGSERIAL PRIMARY KEY,
+    promotion_id INT NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    discount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (promotion_id, order_id)
+);
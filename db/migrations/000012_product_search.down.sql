@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS trg_categories_search_vector ON categories;
+DROP FUNCTION IF EXISTS categories_search_vector_refresh();
+DROP TRIGGER IF EXISTS trg_brands_search_vector ON brands;
+DROP FUNCTION IF EXISTS brands_search_vector_refresh();
+DROP TRIGGER IF EXISTS trg_products_search_vector ON products;
+DROP FUNCTION IF EXISTS products_search_vector_refresh();
+DROP INDEX IF EXISTS idx_products_search_vector;
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS product_search_vector(TEXT, TEXT, TEXT, TEXT, INT, INT);
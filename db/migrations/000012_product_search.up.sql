@@ -0,0 +1,57 @@
+-- The weighted full-text document of a product: its name first, then its
+-- brand, category and tags, then the description and the specifications last
+CREATE FUNCTION product_search_vector(
+    p_name TEXT, p_description TEXT, p_specifications TEXT, p_tags TEXT, p_brand_id INT, p_category_id INT
+) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('english', COALESCE(p_name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE((SELECT name FROM brands WHERE id = p_brand_id), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE((SELECT name FROM categories WHERE id = p_category_id), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(p_tags, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(p_description, '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(p_specifications, '')), 'D');
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE products ADD COLUMN search_vector tsvector;
+
+UPDATE products SET search_vector = product_search_vector(name, description, specifications, tags, brand_id, category_id);
+
+CREATE INDEX idx_products_search_vector ON products USING GIN (search_vector);
+
+-- Keep the document of a product up to date with its own columns
+CREATE FUNCTION products_search_vector_refresh() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := product_search_vector(NEW.name, NEW.description, NEW.specifications, NEW.tags, NEW.brand_id, NEW.category_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_products_search_vector
+    BEFORE INSERT OR UPDATE OF name, description, specifications, tags, brand_id, category_id ON products
+    FOR EACH ROW EXECUTE FUNCTION products_search_vector_refresh();
+
+-- and with the names of its brand and category
+CREATE FUNCTION brands_search_vector_refresh() RETURNS trigger AS $$
+BEGIN
+    UPDATE products SET search_vector = product_search_vector(name, description, specifications, tags, brand_id, category_id)
+    WHERE brand_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_brands_search_vector
+    AFTER UPDATE OF name ON brands
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION brands_search_vector_refresh();
+
+CREATE FUNCTION categories_search_vector_refresh() RETURNS trigger AS $$
+BEGIN
+    UPDATE products SET search_vector = product_search_vector(name, description, specifications, tags, brand_id, category_id)
+    WHERE category_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_categories_search_vector
+    AFTER UPDATE OF name ON categories
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION categories_search_vector_refresh();
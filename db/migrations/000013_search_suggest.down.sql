@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS search_synonyms;
+DROP INDEX IF EXISTS idx_categories_name_trgm;
+DROP INDEX IF EXISTS idx_brands_name_trgm;
+DROP INDEX IF EXISTS idx_products_name_trgm;
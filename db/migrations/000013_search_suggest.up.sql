@@ -0,0 +1,15 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Trigram indexes for typo tolerant suggestions
+CREATE INDEX idx_products_name_trgm ON products USING GIN (LOWER(name) gin_trgm_ops);
+CREATE INDEX idx_brands_name_trgm ON brands USING GIN (LOWER(name) gin_trgm_ops);
+CREATE INDEX idx_categories_name_trgm ON categories USING GIN (LOWER(name) gin_trgm_ops);
+
+-- Create search_synonyms table, a suggestion for term is also looked up as synonym
+CREATE TABLE search_synonyms (
+    id SERIAL PRIMARY KEY,
+    term VARCHAR(100) NOT NULL CHECK (term = LOWER(term) AND term <> ''),
+    synonym VARCHAR(100) NOT NULL CHECK (synonym = LOWER(synonym) AND synonym <> '' AND synonym <> term),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (term, synonym)
+);
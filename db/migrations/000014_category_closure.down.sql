@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trg_categories_closure_move ON categories;
+DROP FUNCTION IF EXISTS categories_closure_move();
+DROP TRIGGER IF EXISTS trg_categories_closure_check ON categories;
+DROP FUNCTION IF EXISTS categories_closure_check();
+DROP TRIGGER IF EXISTS trg_categories_closure_insert ON categories;
+DROP FUNCTION IF EXISTS categories_closure_insert();
+DROP TABLE IF EXISTS category_closure;
@@ -0,0 +1,73 @@
+-- Create category_closure table, every category is linked to each of its
+-- ancestors and to itself at depth 0, so a subtree or a breadcrumb is one lookup
+CREATE TABLE category_closure (
+    ancestor_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    descendant_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    depth INT NOT NULL CHECK (depth >= 0),
+    PRIMARY KEY (ancestor_id, descendant_id)
+);
+
+CREATE INDEX idx_category_closure_descendant ON category_closure (descendant_id, depth);
+
+WITH RECURSIVE tree AS (
+    SELECT id AS ancestor_id, id AS descendant_id, 0 AS depth FROM categories
+    UNION ALL
+    SELECT tree.ancestor_id, c.id, tree.depth + 1
+    FROM tree INNER JOIN categories c ON c.parent_id = tree.descendant_id
+)
+INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+SELECT ancestor_id, descendant_id, depth FROM tree;
+
+-- A new category is linked to the ancestors of its parent
+CREATE FUNCTION categories_closure_insert() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+    SELECT ancestor_id, NEW.id, depth + 1 FROM category_closure WHERE descendant_id = NEW.parent_id
+    UNION ALL
+    SELECT NEW.id, NEW.id, 0;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_categories_closure_insert
+    AFTER INSERT ON categories
+    FOR EACH ROW EXECUTE FUNCTION categories_closure_insert();
+
+-- A category cannot move under itself or one of its descendants. Moves are
+-- serialized, so two concurrent ones cannot close a cycle together.
+CREATE FUNCTION categories_closure_check() RETURNS trigger AS $$
+BEGIN
+    LOCK TABLE category_closure IN SHARE ROW EXCLUSIVE MODE;
+    IF EXISTS (SELECT 1 FROM category_closure WHERE ancestor_id = NEW.id AND descendant_id = NEW.parent_id) THEN
+        RAISE EXCEPTION 'category % cannot move under its own subtree', NEW.id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'categories_acyclic';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_categories_closure_check
+    BEFORE UPDATE OF parent_id ON categories
+    FOR EACH ROW WHEN (OLD.parent_id IS DISTINCT FROM NEW.parent_id)
+    EXECUTE FUNCTION categories_closure_check();
+
+-- A moved subtree is unlinked from its old ancestors and linked to the new ones
+CREATE FUNCTION categories_closure_move() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM category_closure link
+    USING category_closure sub, category_closure sup
+    WHERE sub.ancestor_id = NEW.id AND link.descendant_id = sub.descendant_id
+        AND sup.descendant_id = NEW.id AND sup.ancestor_id <> NEW.id AND link.ancestor_id = sup.ancestor_id;
+
+    INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+    SELECT sup.ancestor_id, sub.descendant_id, sup.depth + sub.depth + 1
+    FROM category_closure sup CROSS JOIN category_closure sub
+    WHERE sup.descendant_id = NEW.parent_id AND sub.ancestor_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_categories_closure_move
+    AFTER UPDATE OF parent_id ON categories
+    FOR EACH ROW WHEN (OLD.parent_id IS DISTINCT FROM NEW.parent_id)
+    EXECUTE FUNCTION categories_closure_move();
@@ -0,0 +1,24 @@
+-- Create category_attributes table, the typed attributes of the products of a
+-- category and of all its subcategories. A code is defined once along a path
+-- of the tree, so a subcategory cannot redefine an inherited attribute.
+CREATE TABLE category_attributes (
+    id SERIAL PRIMARY KEY,
+    category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL CHECK (code ~ '^[a-z][a-z0-9_]*$'),
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('enum', 'number', 'boolean', 'text')),
+    unit VARCHAR(20),
+    options JSONB NOT NULL DEFAULT '[]',
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    filterable BOOLEAN NOT NULL DEFAULT FALSE,
+    sequence INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (category_id, code),
+    CHECK (type <> 'enum' OR jsonb_array_length(options) > 0),
+    CHECK (type <> 'text' OR NOT filterable)
+);
+
+-- The attribute values of a product by code, validated against its category's attributes
+ALTER TABLE products ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_products_attributes ON products USING GIN (attributes jsonb_path_ops);
@@ -0,0 +1,21 @@
+-- Images and documents of a product, the files live in the blob store under storage_key
+CREATE TABLE product_media (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('image', 'document')),
+    storage_key VARCHAR(255) UNIQUE NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(64) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    width INT,
+    height INT,
+    sequence INT NOT NULL DEFAULT 0,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT product_media_primary_image CHECK (NOT is_primary OR kind = 'image')
+);
+
+CREATE INDEX idx_product_media_product_id ON product_media(product_id, sequence);
+
+-- A product has at most one primary image
+CREATE UNIQUE INDEX idx_product_media_primary ON product_media(product_id) WHERE is_primary;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS product_media_renditions;
+DROP INDEX IF EXISTS idx_product_media_rendition_queue;
+ALTER TABLE product_media
+    DROP COLUMN IF EXISTS rendition_claimed_at,
+    DROP COLUMN IF EXISTS rendition_error,
+    DROP COLUMN IF EXISTS rendition_status;
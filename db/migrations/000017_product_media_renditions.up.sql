@@ -0,0 +1,25 @@
+-- Renditions are generated in the background from the original image,
+-- rendition_claimed_at lets a worker take over a claim abandoned by a crash
+ALTER TABLE product_media
+    ADD COLUMN rendition_status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (rendition_status IN ('pending', 'processing', 'ready', 'failed')),
+    ADD COLUMN rendition_error TEXT,
+    ADD COLUMN rendition_claimed_at TIMESTAMP;
+
+-- Documents have no renditions
+UPDATE product_media SET rendition_status = 'ready' WHERE kind = 'document';
+
+CREATE INDEX idx_product_media_rendition_queue ON product_media(id) WHERE rendition_status IN ('pending', 'processing');
+
+-- A scaled down copy of an image in one format, stored next to the original
+CREATE TABLE product_media_renditions (
+    media_id INT NOT NULL REFERENCES product_media(id) ON DELETE CASCADE,
+    name VARCHAR(32) NOT NULL,
+    format VARCHAR(8) NOT NULL CHECK (format IN ('jpeg', 'png', 'webp')),
+    storage_key VARCHAR(255) UNIQUE NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (media_id, name, format)
+);
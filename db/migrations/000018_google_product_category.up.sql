@@ -0,0 +1,3 @@
+-- The Google product taxonomy category of the products of a category in the
+-- product feed, a category without one takes the mapping of its closest ancestor
+ALTER TABLE categories ADD COLUMN google_product_category VARCHAR(255);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_products_price_history_update ON products;
+DROP TRIGGER IF EXISTS trg_products_price_history_insert ON products;
+DROP FUNCTION IF EXISTS products_price_history();
+DROP TABLE IF EXISTS product_price_history;
+DROP TABLE IF EXISTS product_price_schedules;
@@ -0,0 +1,73 @@
+-- Price changes planned for a product. A schedule sets the prices it has at
+-- starts_at and, when it has an end, puts the previous prices back at ends_at.
+-- The times are given by clients with their offset, hence TIMESTAMPTZ.
+CREATE TABLE product_price_schedules (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    unit_price DECIMAL(10, 2) CHECK (unit_price > 0),
+    discount_price DECIMAL(10, 2) CHECK (discount_price >= 0),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ CHECK (ends_at > starts_at),
+    status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'active', 'completed', 'cancelled', 'expired')),
+    -- the prices replaced when the schedule was applied, restored at its end
+    previous_unit_price DECIMAL(10, 2),
+    previous_discount_price DECIMAL(10, 2),
+    applied_at TIMESTAMPTZ,
+    reverted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (unit_price IS NOT NULL OR discount_price IS NOT NULL)
+);
+
+CREATE INDEX idx_product_price_schedules_product_id ON product_price_schedules(product_id, starts_at);
+CREATE INDEX idx_product_price_schedules_due ON product_price_schedules(starts_at) WHERE status = 'pending';
+CREATE INDEX idx_product_price_schedules_ending ON product_price_schedules(ends_at) WHERE status = 'active';
+
+-- Create product_price_history table, every price a product had, whatever wrote it
+CREATE TABLE product_price_history (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    unit_price DECIMAL(10, 2) NOT NULL,
+    discount_price DECIMAL(10, 2),
+    previous_unit_price DECIMAL(10, 2),
+    previous_discount_price DECIMAL(10, 2),
+    reason VARCHAR(16) NOT NULL CHECK (reason IN ('created', 'updated', 'scheduled', 'reverted')),
+    price_schedule_id BIGINT REFERENCES product_price_schedules(id) ON DELETE SET NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_price_history_product_id ON product_price_history(product_id, changed_at);
+
+-- A price change is recorded with the product write. The scheduler tells which
+-- schedule it applies or reverts with the transaction local settings
+-- techno_store.price_reason and techno_store.price_schedule_id.
+CREATE FUNCTION products_price_history() RETURNS trigger AS $$
+DECLARE
+    change_reason VARCHAR(16) := NULLIF(current_setting('techno_store.price_reason', true), '');
+    schedule_id BIGINT := NULLIF(current_setting('techno_store.price_schedule_id', true), '')::BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO product_price_history (product_id, unit_price, discount_price, reason)
+        VALUES (NEW.id, NEW.unit_price, NEW.discount_price, 'created');
+    ELSE
+        INSERT INTO product_price_history (product_id, unit_price, discount_price,
+            previous_unit_price, previous_discount_price, reason, price_schedule_id)
+        VALUES (NEW.id, NEW.unit_price, NEW.discount_price,
+            OLD.unit_price, OLD.discount_price, COALESCE(change_reason, 'updated'), schedule_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_products_price_history_insert
+    AFTER INSERT ON products
+    FOR EACH ROW EXECUTE FUNCTION products_price_history();
+
+CREATE TRIGGER trg_products_price_history_update
+    AFTER UPDATE OF unit_price, discount_price ON products
+    FOR EACH ROW WHEN (OLD.unit_price IS DISTINCT FROM NEW.unit_price OR OLD.discount_price IS DISTINCT FROM NEW.discount_price)
+    EXECUTE FUNCTION products_price_history();
+
+-- The current prices start the history of the existing products
+INSERT INTO product_price_history (product_id, unit_price, discount_price, reason)
+    SELECT id, unit_price, discount_price, 'created' FROM products;
@@ -0,0 +1,25 @@
+-- The prices of a product are in its currency, the existing products are priced in taka
+ALTER TABLE products ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BDT'
+    CHECK (currency ~ '^[A-Z]{3}$');
+
+-- One unit of base_currency buys rate of quote_currency, a pair is also used
+-- the other way round when its opposite pair is not set
+CREATE TABLE exchange_rates (
+    base_currency CHAR(3) NOT NULL CHECK (base_currency ~ '^[A-Z]{3}$'),
+    quote_currency CHAR(3) NOT NULL CHECK (quote_currency ~ '^[A-Z]{3}$'),
+    rate NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (base_currency, quote_currency),
+    CHECK (base_currency <> quote_currency)
+);
+
+-- The prices of a product in another currency, used instead of converting its prices.
+-- They keep three decimal places for the currencies with mills such as KWD
+CREATE TABLE product_currency_prices (
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    currency CHAR(3) NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    unit_price NUMERIC(12, 3) NOT NULL CHECK (unit_price > 0),
+    discount_price NUMERIC(12, 3) NOT NULL DEFAULT 0 CHECK (discount_price >= 0 AND discount_price <= unit_price),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, currency)
+);
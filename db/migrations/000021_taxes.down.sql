@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS order_taxes;
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS tax_jurisdiction,
+    DROP COLUMN IF EXISTS tax_pricing,
+    DROP COLUMN IF EXISTS tax_total;
+ALTER TABLE categories DROP COLUMN IF EXISTS tax_class_id;
+ALTER TABLE products DROP COLUMN IF EXISTS tax_class_id;
+DROP TABLE IF EXISTS tax_rates;
+DROP TABLE IF EXISTS tax_classes;
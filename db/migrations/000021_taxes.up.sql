@@ -0,0 +1,50 @@
+-- Tax classes group the products taxed alike, the default class applies to
+-- the products and categories without one
+CREATE TABLE tax_classes (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_tax_classes_default ON tax_classes (is_default) WHERE is_default;
+
+INSERT INTO tax_classes (code, name, is_default) VALUES ('standard', 'Standard rate', TRUE);
+
+-- The rates of a class in an ISO 3166 country or subdivision, the rates of the most
+-- specific jurisdiction apply and several rates of a jurisdiction add up
+CREATE TABLE tax_rates (
+    id SERIAL PRIMARY KEY,
+    tax_class_id INT NOT NULL REFERENCES tax_classes(id) ON DELETE CASCADE,
+    jurisdiction VARCHAR(6) NOT NULL CHECK (jurisdiction ~ '^[A-Z]{2}(-[A-Z0-9]{1,3})?$'),
+    name VARCHAR(255) NOT NULL,
+    rate NUMERIC(7, 4) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tax_class_id, jurisdiction, name)
+);
+
+-- A product takes its own class, else the one of its closest category having one
+ALTER TABLE products ADD COLUMN tax_class_id INT REFERENCES tax_classes(id) ON DELETE SET NULL;
+ALTER TABLE categories ADD COLUMN tax_class_id INT REFERENCES tax_classes(id) ON DELETE SET NULL;
+
+-- The tax of an order as it was placed, the total includes it whatever the pricing
+ALTER TABLE orders
+    ADD COLUMN tax_total DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_pricing VARCHAR(16) CHECK (tax_pricing IN ('inclusive', 'exclusive')),
+    ADD COLUMN tax_jurisdiction VARCHAR(6);
+
+-- What every rate levied over an order, copied so that editing a rate keeps past orders
+CREATE TABLE order_taxes (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    tax_rate_id INT REFERENCES tax_rates(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    jurisdiction VARCHAR(6) NOT NULL,
+    rate NUMERIC(7, 4) NOT NULL,
+    taxable DECIMAL(12, 2) NOT NULL,
+    tax DECIMAL(12, 2) NOT NULL
+);
+
+CREATE INDEX idx_order_taxes_order_id ON order_taxes(order_id);
@@ -0,0 +1,4 @@
+UPDATE returns SET status = 'received' WHERE status = 'refunding';
+ALTER TABLE returns DROP CONSTRAINT IF EXISTS returns_status_check;
+ALTER TABLE returns ADD CONSTRAINT returns_status_check
+    CHECK (status IN ('requested', 'approved', 'rejected', 'received', 'refunded'));
@@ -0,0 +1,5 @@
+-- A return is claimed with the refunding status while its refund is with the
+-- payment provider, so two refund requests cannot both pay it out
+ALTER TABLE returns DROP CONSTRAINT IF EXISTS returns_status_check;
+ALTER TABLE returns ADD CONSTRAINT returns_status_check
+    CHECK (status IN ('requested', 'approved', 'rejected', 'received', 'refunding', 'refunded'));
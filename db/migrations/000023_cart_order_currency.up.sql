@@ -0,0 +1,11 @@
+-- The prices of a cart are in the currency of its products, set when its first item is added
+ALTER TABLE carts ADD COLUMN currency CHAR(3) CHECK (currency ~ '^[A-Z]{3}$');
+
+UPDATE carts c SET currency = (
+    SELECT p.currency FROM cart_items ci INNER JOIN products p ON p.id = ci.product_id
+    WHERE ci.cart_id = c.id ORDER BY ci.id LIMIT 1
+);
+
+-- The prices of an order are in the currency of its cart, the existing orders are priced in taka
+ALTER TABLE orders ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BDT'
+    CHECK (currency ~ '^[A-Z]{3}$');
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS trg_stock_movements_no_truncate ON stock_movements;
+DROP TRIGGER IF EXISTS trg_stock_movements_no_delete ON stock_movements;
+
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
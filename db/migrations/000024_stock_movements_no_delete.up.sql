@@ -0,0 +1,18 @@
+-- Movements are neither rewritten nor deleted, rows only go away with their product,
+-- variant or warehouse, through a foreign key cascade which runs in a nested trigger
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND pg_trigger_depth() > 1 THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_stock_movements_no_delete
+    BEFORE DELETE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+CREATE TRIGGER trg_stock_movements_no_truncate
+    BEFORE TRUNCATE ON stock_movements
+    FOR EACH STATEMENT EXECUTE FUNCTION stock_movements_append_only();
@@ -0,0 +1 @@
+ALTER TABLE promotions DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,3 @@
+-- The value of a fixed amount promotion is in its currency, the existing promotions are in taka
+ALTER TABLE promotions ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BDT'
+    CHECK (currency ~ '^[A-Z]{3}$');
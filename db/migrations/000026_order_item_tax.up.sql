@@ -0,0 +1,3 @@
+-- The tax of an order line over its whole quantity, with exclusive pricing a return
+-- refunds the share of the items which came back. The existing lines have no tax recorded.
+ALTER TABLE order_items ADD COLUMN tax DECIMAL(12, 2) NOT NULL DEFAULT 0;
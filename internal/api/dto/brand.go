@@ -38,6 +38,10 @@ type PaginatedBrandCollection struct {
 	// This will always return the total of all records
 	Total int64           `json:"total"`
 	Data  BrandCollection `json:"data"`
+
+	// The cursors of the neighbouring pages, pass them as after and before
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func ToPaginatedBrand(bo bo.PaginatedBrandCollection) PaginatedBrandCollection {
@@ -47,15 +51,19 @@ func ToPaginatedBrand(bo bo.PaginatedBrandCollection) PaginatedBrandCollection {
 	}
 
 	return PaginatedBrandCollection{
-		Total: bo.Total,
-		Data:  brands,
+		Total:      bo.Total,
+		Data:       brands,
+		NextCursor: EncodeCursor(bo.Next),
+		PrevCursor: EncodeCursor(bo.Prev),
 	}
 }
 
 // BrandQuery represent Category model query parameter
 type BrandQuery struct {
-	Limit  int `form:"limit,default=20" json:"limit,omitempty" binding:"min=1"`
-	Offset int `form:"offset" json:"offset,omitempty" binding:"omitempty,min=0"`
+	Limit  int    `form:"limit,default=20" json:"limit,omitempty" binding:"min=1"`
+	Offset int    `form:"offset" json:"offset,omitempty" binding:"omitempty,min=0"`
+	After  string `form:"after" json:"after,omitempty"`
+	Before string `form:"before" json:"before,omitempty"`
 }
 
 func (q BrandQuery) Model() bo.BrandQuery {
@@ -68,8 +76,9 @@ func (q BrandQuery) Model() bo.BrandQuery {
 	}
 
 	return bo.BrandQuery{
-		Limit:  q.Limit,
-		Offset: q.Offset,
+		Limit:       q.Limit,
+		Offset:      q.Offset,
+		PageCursors: toPageCursors(q.After, q.Before),
 	}
 }
 
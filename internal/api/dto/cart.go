@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+// CartItemURI binds the cart and item ids of a cart item route
+type CartItemURI struct {
+	CartID int64 `uri:"id" binding:"required,min=1"`
+	ItemID int64 `uri:"item_id" binding:"required,min=1"`
+}
+
+type Cart struct {
+	ID        int64      `json:"id"`
+	Reference string     `json:"reference,omitempty"`
+	Status    string     `json:"status" enums:"open,checked_out"`
+	Items     []CartItem `json:"items"`
+	// Currency is the ISO 4217 currency of the prices, the one of the first item added
+	Currency      string          `json:"currency,omitempty" example:"BDT"`
+	Subtotal      decimal.Decimal `json:"subtotal" swaggertype:"number"`
+	DiscountTotal decimal.Decimal `json:"discount_total" swaggertype:"number"`
+	Total         decimal.Decimal `json:"total" swaggertype:"number"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type CartItem struct {
+	ID                int64            `json:"id"`
+	ProductID         int64            `json:"product_id"`
+	VariantID         int64            `json:"variant_id,omitempty"`
+	Quantity          int64            `json:"quantity"`
+	UnitPrice         decimal.Decimal  `json:"unit_price" swaggertype:"number"`
+	DiscountPrice     *decimal.Decimal `json:"discount_price,omitempty" swaggertype:"number"`
+	LineTotal         decimal.Decimal  `json:"line_total" swaggertype:"number"`
+	AvailableQuantity int64            `json:"available_quantity"`
+}
+
+func ToCartDTO(bo bo.Cart) Cart {
+	items := []CartItem{}
+	for _, i := range bo.Items {
+		items = append(items, CartItem{
+			ID:                i.ID,
+			ProductID:         i.ProductID,
+			VariantID:         i.VariantID,
+			Quantity:          i.Quantity,
+			UnitPrice:         i.UnitPrice.Amount,
+			DiscountPrice:     optionalAmount(i.DiscountPrice.Amount),
+			LineTotal:         i.LineTotal().Amount,
+			AvailableQuantity: i.AvailableQuantity,
+		})
+	}
+
+	return Cart{
+		ID:            bo.ID,
+		Reference:     bo.Reference,
+		Status:        string(bo.Status),
+		Items:         items,
+		Currency:      bo.Currency,
+		Subtotal:      bo.Subtotal.Amount,
+		DiscountTotal: bo.DiscountTotal.Amount,
+		Total:         bo.Total.Amount,
+		CreatedAt:     bo.CreatedAt,
+		UpdatedAt:     bo.UpdatedAt,
+	}
+}
+
+type CartCreate struct {
+	Reference string `json:"reference,omitempty" binding:"omitempty,max=255"`
+}
+
+func (c CartCreate) Model() bo.Cart {
+	return bo.Cart{
+		Reference: c.Reference,
+	}
+}
+
+// CartItemAdd adds a product (variant) to a cart, the price is taken from the catalog
+type CartItemAdd struct {
+	ProductID int64 `json:"product_id" binding:"required,min=1"`
+	VariantID int64 `json:"variant_id,omitempty" binding:"omitempty,min=1"`
+	Quantity  int64 `json:"quantity" binding:"required,min=1"`
+}
+
+func (a CartItemAdd) Model(cartID int64) bo.CartItem {
+	return bo.CartItem{
+		CartID:    cartID,
+		ProductID: a.ProductID,
+		VariantID: a.VariantID,
+		Quantity:  a.Quantity,
+	}
+}
+
+type CartItemUpdate struct {
+	Quantity int64 `json:"quantity" binding:"required,min=1"`
+}
+
+func (u CartItemUpdate) Model(uri CartItemURI) bo.CartItemUpdate {
+	return bo.CartItemUpdate{
+		ID:       uri.ItemID,
+		CartID:   uri.CartID,
+		Quantity: u.Quantity,
+	}
+}
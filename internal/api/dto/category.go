@@ -11,6 +11,12 @@ type Category struct {
 	ParentID int64  `json:"parent_id,omitempty"`
 	Sequence int64  `json:"sequence"`
 	StatusID int64  `json:"status_id"`
+	// GoogleProductCategory is a Google product taxonomy id or path, such as "267" or
+	// "Electronics > Communications > Telephony > Mobile Phones"
+	GoogleProductCategory string `json:"google_product_category,omitempty"`
+	// TaxClassID is the tax class of the products of the category, a category
+	// without one takes the class of its closest ancestor
+	TaxClassID int64 `json:"tax_class_id,omitempty" binding:"omitempty,min=1"`
 }
 
 func ToCategoryDTO(bo bo.Category) Category {
@@ -20,6 +26,9 @@ func ToCategoryDTO(bo bo.Category) Category {
 		ParentID: bo.ParentID,
 		Sequence: bo.Sequence,
 		StatusID: bo.StatusID,
+
+		GoogleProductCategory: bo.GoogleProductCategory,
+		TaxClassID:            bo.TaxClassID,
 	}
 }
 
@@ -30,6 +39,9 @@ func (c Category) Model() bo.Category {
 		ParentID: c.ParentID,
 		Sequence: c.Sequence,
 		StatusID: c.StatusID,
+
+		GoogleProductCategory: c.GoogleProductCategory,
+		TaxClassID:            c.TaxClassID,
 	}
 }
 
@@ -38,6 +50,12 @@ type CategoryUpdate struct {
 	Name     *string `json:"name"`
 	StatusID *int64  `json:"status_id"`
 	Sequence *int64  `json:"sequence"`
+	// ParentID moves the category with its subcategories, 0 makes it a root category
+	ParentID *int64 `json:"parent_id" binding:"omitempty,min=0"`
+	// GoogleProductCategory sets the mapping of the product feed, "" inherits the one of the parent
+	GoogleProductCategory *string `json:"google_product_category" binding:"omitempty,max=255"`
+	// TaxClassID sets the tax class, 0 inherits the one of the parent
+	TaxClassID *int64 `json:"tax_class_id" binding:"omitempty,min=0"`
 }
 
 func (c CategoryUpdate) Model() bo.CategoryUpdate {
@@ -46,9 +64,22 @@ func (c CategoryUpdate) Model() bo.CategoryUpdate {
 		Name:     c.Name,
 		StatusID: c.StatusID,
 		Sequence: c.Sequence,
+		ParentID: c.ParentID,
+
+		GoogleProductCategory: c.GoogleProductCategory,
+		TaxClassID:            c.TaxClassID,
 	}
 }
 
+// ToCategoriesDTO converts categories in their order, a breadcrumb stays root first
+func ToCategoriesDTO(categories bo.CategoryCollection) []Category {
+	categoryDTOs := []Category{}
+	for _, category := range categories {
+		categoryDTOs = append(categoryDTOs, ToCategoryDTO(category))
+	}
+	return categoryDTOs
+}
+
 type CategoryTree struct {
 	ID       int64           `json:"id"`
 	Name     string          `json:"category_name"`
@@ -76,56 +107,51 @@ func categoryCollectionDto(categories bo.CategoryCollection) []CategoryTree {
 }
 
 func CategoriesToTree(pCategories bo.PaginatedCategoryCollection) CategoriesTree {
-	tree := buildTree(categoryCollectionDto(pCategories.Data))
-	sortCategoryTree(tree)
+	return CategoryCollectionToTree(pCategories.Data)
+}
+
+// CategoryCollectionToTree nests the categories under their parents, a subtree is rooted at its top category
+func CategoryCollectionToTree(categories bo.CategoryCollection) CategoriesTree {
+	tree := buildTree(categoryCollectionDto(categories))
+	sortCategoryTree(tree, 1)
 
 	return CategoriesTree{
 		Data: tree,
 	}
 }
 
+// buildTree attaches every category to its parent at any depth, a category
+// whose parent is not listed is a root of the tree
 func buildTree(categories []CategoryTree) []*CategoryTree {
-	var tree []*CategoryTree
-	childrenMap := make(map[int64][]*CategoryTree)
-
-	for i, cat := range categories {
-		cat.Depth = 1 // Root nodes start with depth 1
-
-		if cat.ParentID == 0 {
-			// It's a root node
-			tree = append(tree, &categories[i])
-		} else {
-			// It's a child node
-			childrenMap[cat.ParentID] = append(childrenMap[cat.ParentID], &categories[i])
-		}
+	nodes := make(map[int64]*CategoryTree, len(categories))
+	for i := range categories {
+		nodes[categories[i].ID] = &categories[i]
 	}
 
-	for _, branch := range tree {
-		if children, ok := childrenMap[branch.ID]; ok {
-			// Increase the depth for each child
-			for _, child := range children {
-				child.Depth = branch.Depth + 1
-			}
-
-			// Only add children if parent's depth is less than 3
-			if branch.Depth < 3 {
-				branch.Children = children
-			}
+	var tree []*CategoryTree
+	for i := range categories {
+		category := &categories[i]
+		if parent, ok := nodes[category.ParentID]; ok && category.ParentID != 0 {
+			parent.Children = append(parent.Children, category)
+		} else {
+			tree = append(tree, category)
 		}
 	}
 
 	return tree
 }
 
-func sortCategoryTree(categories []*CategoryTree) {
+// sortCategoryTree orders every level by sequence and sets the depth of the categories, roots being at depth 1
+func sortCategoryTree(categories []*CategoryTree, depth int64) {
 	sort.Slice(categories, func(i, j int) bool {
 		return categories[i].Sequence < categories[j].Sequence
 	})
 
 	// Recursively sort the children of each category
 	for _, cat := range categories {
+		cat.Depth = depth
 		if len(cat.Children) > 0 {
-			sortCategoryTree(cat.Children)
+			sortCategoryTree(cat.Children, depth+1)
 		}
 	}
 }
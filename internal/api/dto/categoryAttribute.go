@@ -0,0 +1,63 @@
+package dto
+
+import "techno-store/internal/domain/bo"
+
+// CategoryAttributeURI binds the category and attribute ids of an attribute route
+type CategoryAttributeURI struct {
+	CategoryID  int64 `uri:"id" binding:"required,min=1"`
+	AttributeID int64 `uri:"attribute_id" binding:"required,min=1"`
+}
+
+// CategoryAttribute is a typed attribute of the products of a category and its subcategories.
+// Type is one of enum, number, boolean or text; only an enum has options and only a number a unit.
+type CategoryAttribute struct {
+	ID         int64    `json:"id,omitempty"`
+	CategoryID int64    `json:"category_id,omitempty"`
+	Code       string   `json:"code" binding:"required"`
+	Name       string   `json:"name" binding:"required"`
+	Type       string   `json:"type" binding:"required,oneof=enum number boolean text"`
+	Unit       string   `json:"unit,omitempty"`
+	Options    []string `json:"options,omitempty"`
+	Required   bool     `json:"required"`
+	Filterable bool     `json:"filterable"`
+	Sequence   int64    `json:"sequence"`
+}
+
+func ToCategoryAttributeDTO(bo bo.CategoryAttribute) CategoryAttribute {
+	return CategoryAttribute{
+		ID:         bo.ID,
+		CategoryID: bo.CategoryID,
+		Code:       bo.Code,
+		Name:       bo.Name,
+		Type:       string(bo.Type),
+		Unit:       bo.Unit,
+		Options:    bo.Options,
+		Required:   bo.Required,
+		Filterable: bo.Filterable,
+		Sequence:   bo.Sequence,
+	}
+}
+
+func (a CategoryAttribute) Model() bo.CategoryAttribute {
+	return bo.CategoryAttribute{
+		ID:         a.ID,
+		CategoryID: a.CategoryID,
+		Code:       a.Code,
+		Name:       a.Name,
+		Type:       bo.AttributeType(a.Type),
+		Unit:       a.Unit,
+		Options:    a.Options,
+		Required:   a.Required,
+		Filterable: a.Filterable,
+		Sequence:   a.Sequence,
+	}
+}
+
+// ToCategoryAttributesDTO converts the attribute template of a category, the inherited attributes first
+func ToCategoryAttributesDTO(attributes bo.CategoryAttributeCollection) []CategoryAttribute {
+	attributeDTOs := []CategoryAttribute{}
+	for _, attribute := range attributes {
+		attributeDTOs = append(attributeDTOs, ToCategoryAttributeDTO(attribute))
+	}
+	return attributeDTOs
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRate has its rate as an exact decimal, the requests may send it as a number
+// or a string
+type ExchangeRate struct {
+	Base string `json:"base" example:"USD"`
+	// Quote is the currency one unit of Base buys Rate of
+	Quote     string          `json:"quote" example:"BDT"`
+	Rate      decimal.Decimal `json:"rate" swaggertype:"number" example:"117.25"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+func ToExchangeRateDTOs(bo bo.ExchangeRateCollection) []ExchangeRate {
+	rates := []ExchangeRate{}
+	for _, rate := range bo {
+		rates = append(rates, ExchangeRate{
+			Base:      rate.Base,
+			Quote:     rate.Quote,
+			Rate:      rate.Rate,
+			UpdatedAt: rate.UpdatedAt,
+		})
+	}
+	return rates
+}
+
+type ExchangeRateRequest struct {
+	Base  string          `json:"base" binding:"required,iso4217" example:"USD"`
+	Quote string          `json:"quote" binding:"required,iso4217" example:"BDT"`
+	Rate  decimal.Decimal `json:"rate" swaggertype:"number" example:"117.25"`
+}
+
+// ExchangeRatesRequest adds or replaces the rates of its currency pairs, the other rates are kept
+type ExchangeRatesRequest struct {
+	Rates []ExchangeRateRequest `json:"rates" binding:"required,min=1,dive"`
+}
+
+func (r ExchangeRatesRequest) Model() bo.ExchangeRateCollection {
+	rates := make(bo.ExchangeRateCollection, 0, len(r.Rates))
+	for _, rate := range r.Rates {
+		rates = append(rates, bo.ExchangeRate{
+			Base:  rate.Base,
+			Quote: rate.Quote,
+			Rate:  rate.Rate,
+		})
+	}
+	return rates
+}
+
+// ExchangeRatePairURI binds the currencies of an exchange rate route
+type ExchangeRatePairURI struct {
+	Base  string `uri:"base" binding:"required,iso4217"`
+	Quote string `uri:"quote" binding:"required,iso4217"`
+}
+
+// ProductCurrencyPriceURI binds the product id and the currency of a currency price route
+type ProductCurrencyPriceURI struct {
+	ProductID int64  `uri:"id" binding:"required,min=1"`
+	Currency  string `uri:"currency" binding:"required,iso4217"`
+}
+
+type ProductCurrencyPrice struct {
+	ProductID     int64           `json:"product_id"`
+	Currency      string          `json:"currency" example:"USD"`
+	UnitPrice     decimal.Decimal `json:"unit_price" swaggertype:"number" example:"499.99"`
+	DiscountPrice decimal.Decimal `json:"discount_price" swaggertype:"number" example:"0"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+func ToProductCurrencyPriceDTO(bo bo.ProductCurrencyPrice) ProductCurrencyPrice {
+	return ProductCurrencyPrice{
+		ProductID:     bo.ProductID,
+		Currency:      bo.Currency,
+		UnitPrice:     bo.UnitPrice,
+		DiscountPrice: bo.DiscountPrice,
+		UpdatedAt:     bo.UpdatedAt,
+	}
+}
+
+func ToProductCurrencyPriceDTOs(bo bo.ProductCurrencyPriceCollection) []ProductCurrencyPrice {
+	prices := []ProductCurrencyPrice{}
+	for _, price := range bo {
+		prices = append(prices, ToProductCurrencyPriceDTO(price))
+	}
+	return prices
+}
+
+// ProductCurrencyPriceRequest sets the prices of a product in a currency, the discount price is optional
+type ProductCurrencyPriceRequest struct {
+	UnitPrice     decimal.Decimal `json:"unit_price" swaggertype:"number" example:"499.99"`
+	DiscountPrice decimal.Decimal `json:"discount_price" swaggertype:"number" example:"449.99"`
+}
+
+func (r ProductCurrencyPriceRequest) Model(productID int64, currency string) bo.ProductCurrencyPrice {
+	return bo.ProductCurrencyPrice{
+		ProductID:     productID,
+		Currency:      currency,
+		UnitPrice:     r.UnitPrice,
+		DiscountPrice: r.DiscountPrice,
+	}
+}
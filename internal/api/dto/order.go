@@ -0,0 +1,143 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+type Order struct {
+	ID        int64       `json:"id"`
+	CartID    int64       `json:"cart_id,omitempty"`
+	Reference string      `json:"reference,omitempty"`
+	Status    string      `json:"status" enums:"pending,paid,packed,shipped,delivered,cancelled,refunded"`
+	Items     []OrderItem `json:"items,omitempty"`
+	// Currency is the ISO 4217 currency of the prices, the one of the cart
+	Currency      string          `json:"currency" example:"BDT"`
+	Subtotal      decimal.Decimal `json:"subtotal" swaggertype:"number"`
+	DiscountTotal decimal.Decimal `json:"discount_total" swaggertype:"number"`
+	Total         decimal.Decimal `json:"total" swaggertype:"number"`
+	// TaxTotal is included in the total, Taxes break it down per rate
+	TaxTotal        decimal.Decimal `json:"tax_total" swaggertype:"number"`
+	TaxPricing      string          `json:"tax_pricing,omitempty" enums:"inclusive,exclusive"`
+	TaxJurisdiction string          `json:"tax_jurisdiction,omitempty" example:"BD"`
+	Taxes           []TaxTotal      `json:"taxes,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+type OrderItem struct {
+	ID            int64            `json:"id"`
+	ProductID     int64            `json:"product_id"`
+	VariantID     int64            `json:"variant_id,omitempty"`
+	Quantity      int64            `json:"quantity"`
+	UnitPrice     decimal.Decimal  `json:"unit_price" swaggertype:"number"`
+	DiscountPrice *decimal.Decimal `json:"discount_price,omitempty" swaggertype:"number"`
+	LineTotal     decimal.Decimal  `json:"line_total" swaggertype:"number"`
+}
+
+func ToOrderDTO(bo bo.Order) Order {
+	var items []OrderItem
+	for _, i := range bo.Items {
+		items = append(items, OrderItem{
+			ID:            i.ID,
+			ProductID:     i.ProductID,
+			VariantID:     i.VariantID,
+			Quantity:      i.Quantity,
+			UnitPrice:     i.UnitPrice.Amount,
+			DiscountPrice: optionalAmount(i.DiscountPrice.Amount),
+			LineTotal:     i.LineTotal().Amount,
+		})
+	}
+
+	return Order{
+		ID:            bo.ID,
+		CartID:        bo.CartID,
+		Reference:     bo.Reference,
+		Status:        string(bo.Status),
+		Items:         items,
+		Currency:      bo.Currency,
+		Subtotal:      bo.Subtotal.Amount,
+		DiscountTotal: bo.DiscountTotal.Amount,
+		Total:         bo.Total.Amount,
+
+		TaxTotal:        bo.TaxTotal.Amount,
+		TaxPricing:      string(bo.TaxPricing),
+		TaxJurisdiction: bo.TaxJurisdiction,
+		Taxes:           toTaxTotalDTOs(bo.Taxes),
+		CreatedAt:       bo.CreatedAt,
+		UpdatedAt:       bo.UpdatedAt,
+	}
+}
+
+// OrderCollection array
+type OrderCollection []Order
+
+// PaginatedOrderCollection model array with total record
+type PaginatedOrderCollection struct {
+	// This will always return the total of all records
+	Total int64           `json:"total"`
+	Data  OrderCollection `json:"data"`
+}
+
+func ToPaginatedOrder(bo bo.PaginatedOrderCollection) PaginatedOrderCollection {
+	orders := OrderCollection{}
+	for _, v := range bo.Data {
+		orders = append(orders, ToOrderDTO(v))
+	}
+
+	return PaginatedOrderCollection{
+		Total: bo.Total,
+		Data:  orders,
+	}
+}
+
+type OrderQuery struct {
+	Limit  int    `form:"limit,default=20" json:"limit,omitempty" binding:"min=1"`
+	Offset int    `form:"offset" json:"offset,omitempty" binding:"omitempty,min=0"`
+	Status string `form:"status" json:"status,omitempty" binding:"omitempty,oneof=pending paid packed shipped delivered cancelled refunded"`
+}
+
+func (q OrderQuery) Model() bo.OrderQuery {
+	// Setup some default behavior
+	if q.Limit <= 0 {
+		q.Limit = 20
+	}
+	if q.Offset <= 0 {
+		q.Offset = 0
+	}
+
+	return bo.OrderQuery{
+		Limit:  q.Limit,
+		Offset: q.Offset,
+		Status: bo.OrderStatus(q.Status),
+	}
+}
+
+// OrderPlacement places an order for the content of an open cart
+type OrderPlacement struct {
+	CartID     int64  `json:"cart_id" binding:"required,min=1"`
+	Reference  string `json:"reference,omitempty" binding:"omitempty,max=255"`
+	ChangedBy  string `json:"changed_by,omitempty"`
+	CouponCode string `json:"coupon_code,omitempty" binding:"omitempty,max=64"`
+	// Jurisdiction is the ISO 3166 country or subdivision code the order is taxed in, the store default when left out
+	Jurisdiction string `json:"jurisdiction,omitempty" binding:"omitempty,max=6" example:"BD"`
+}
+
+func (p OrderPlacement) Model() bo.OrderPlacement {
+	return bo.OrderPlacement{
+		CartID:       p.CartID,
+		Reference:    p.Reference,
+		ChangedBy:    p.ChangedBy,
+		CouponCode:   p.CouponCode,
+		Jurisdiction: p.Jurisdiction,
+	}
+}
+
+// OrderTransition moves an order to its next status
+type OrderTransition struct {
+	Status    string `json:"status" binding:"required" enums:"paid,packed,shipped,delivered,cancelled,refunded"`
+	ChangedBy string `json:"changed_by,omitempty"`
+}
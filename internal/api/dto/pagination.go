@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"techno-store/internal/domain/bo"
+)
+
+// pageCursor is the opaque after and before value of a keyset paged list
+type pageCursor struct {
+	Sort string `json:"s"`
+	Key  any    `json:"k,omitempty"`
+	ID   int64  `json:"i"`
+}
+
+// EncodeCursor returns the url safe cursor of a page position, empty for none
+func EncodeCursor(cursor *bo.PageCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	value, err := json.Marshal(pageCursor{Sort: cursor.Sort, Key: cursor.Key, ID: cursor.ID})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// DecodeCursor returns the page position of a cursor, nil for none. A cursor
+// which cannot be decoded has no sort, so a list rejects it with ErrInvalidCursor.
+func DecodeCursor(value string) *bo.PageCursor {
+	if value == "" {
+		return nil
+	}
+
+	var cursor pageCursor
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || json.Unmarshal(raw, &cursor) != nil {
+		return &bo.PageCursor{}
+	}
+	return &bo.PageCursor{Sort: cursor.Sort, Key: cursor.Key, ID: cursor.ID}
+}
+
+func toPageCursors(after, before string) bo.PageCursors {
+	return bo.PageCursors{
+		After:  DecodeCursor(after),
+		Before: DecodeCursor(before),
+	}
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+type Payment struct {
+	ID                int64           `json:"id"`
+	OrderID           int64           `json:"order_id"`
+	Provider          string          `json:"provider"`
+	ProviderReference string          `json:"provider_reference"`
+	Status            string          `json:"status" enums:"authorized,captured,refunded,voided,failed"`
+	Amount            decimal.Decimal `json:"amount" swaggertype:"number"`
+	CapturedAmount    decimal.Decimal `json:"captured_amount" swaggertype:"number"`
+	RefundedAmount    decimal.Decimal `json:"refunded_amount" swaggertype:"number"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+func ToPaymentDTO(bo bo.Payment) Payment {
+	return Payment{
+		ID:                bo.ID,
+		OrderID:           bo.OrderID,
+		Provider:          bo.Provider,
+		ProviderReference: bo.ProviderReference,
+		Status:            string(bo.Status),
+		Amount:            bo.Amount,
+		CapturedAmount:    bo.CapturedAmount,
+		RefundedAmount:    bo.RefundedAmount,
+		CreatedAt:         bo.CreatedAt,
+		UpdatedAt:         bo.UpdatedAt,
+	}
+}
+
+func ToPaymentCollectionDTO(bo bo.PaymentCollection) []Payment {
+	payments := []Payment{}
+	for _, p := range bo {
+		payments = append(payments, ToPaymentDTO(p))
+	}
+	return payments
+}
+
+// PaymentAuthorization authorizes the order total on a tokenized payment method
+type PaymentAuthorization struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// PaymentRefund refunds part of a captured payment, all of it when amount is omitted
+type PaymentRefund struct {
+	Amount decimal.Decimal `json:"amount,omitempty" binding:"omitempty,gt=0" swaggertype:"number"`
+}
@@ -1,27 +1,49 @@
 package dto
 
-import "techno-store/internal/domain/bo"
+import (
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
 
 type IDWrapper struct {
 	ID int64 `uri:"id" json:"id,omitempty" binding:"required,min=1"`
 }
 
 type Product struct {
-	ID             int64   `json:"id,omitempty"`
-	Name           string  `json:"name"`
-	Description    string  `json:"description,omitempty"`
-	Specifications string  `json:"specifications,omitempty"`
-	BrandID        int64   `json:"brand_id"`
-	CategoryID     int64   `json:"category_id"`
-	SupplierID     int64   `json:"supplier_id"`
-	UnitPrice      float64 `json:"unit_price"`
-	DiscountPrice  float64 `json:"discount_price,omitempty"`
-	Tags           string  `json:"tags,omitempty"`
-	StatusID       int64   `json:"status_id"`
+	ID             int64            `json:"id,omitempty"`
+	Name           string           `json:"name"`
+	Description    string           `json:"description,omitempty"`
+	Specifications string           `json:"specifications,omitempty"`
+	BrandID        int64            `json:"brand_id"`
+	CategoryID     int64            `json:"category_id"`
+	SupplierID     int64            `json:"supplier_id"`
+	UnitPrice      decimal.Decimal  `json:"unit_price" swaggertype:"number"`
+	DiscountPrice  *decimal.Decimal `json:"discount_price,omitempty" swaggertype:"number"`
+	Tags           string           `json:"tags,omitempty"`
+	StatusID       int64            `json:"status_id"`
+	// Currency is the ISO 4217 currency of the prices, BDT when left out on creation
+	Currency string `json:"currency,omitempty" binding:"omitempty,iso4217" example:"BDT"`
+	// TaxClassID is the tax class of the product, it takes the one of its category when left out
+	TaxClassID int64 `json:"tax_class_id,omitempty" binding:"omitempty,min=1"`
+
+	// Attributes are the values of the category attributes by code
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// Images are read only, the primary image first; upload them to /v1/product/{id}/media
+	Images []ProductImage `json:"images,omitempty"`
+
+	// Highlight is only returned when the products are searched with q
+	Highlight *ProductHighlight `json:"highlight,omitempty"`
+}
+
+// ProductHighlight has the words matching q wrapped in <mark> tags
+type ProductHighlight struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
 }
 
 func ToProductDTO(bo bo.Product) Product {
-	return Product{
+	product := Product{
 		ID:             bo.ID,
 		Name:           bo.Name,
 		Description:    bo.Description,
@@ -29,15 +51,26 @@ func ToProductDTO(bo bo.Product) Product {
 		BrandID:        bo.BrandID,
 		CategoryID:     bo.CategoryID,
 		SupplierID:     bo.SupplierID,
-		UnitPrice:      bo.UnitPrice,
-		DiscountPrice:  bo.DiscountPrice,
+		UnitPrice:      bo.UnitPrice.Amount,
+		DiscountPrice:  optionalAmount(bo.DiscountPrice.Amount),
 		Tags:           bo.Tags,
 		StatusID:       bo.StatusID,
+		Currency:       bo.Currency(),
+		TaxClassID:     bo.TaxClassID,
+		Attributes:     bo.Attributes,
+		Images:         toProductImages(bo.Images),
 	}
+	if bo.Highlight.Name != "" || bo.Highlight.Description != "" {
+		product.Highlight = &ProductHighlight{
+			Name:        bo.Highlight.Name,
+			Description: bo.Highlight.Description,
+		}
+	}
+	return product
 }
 
 func (p Product) Model() bo.Product {
-	return bo.Product{
+	product := bo.Product{
 		ID:             p.ID,
 		Name:           p.Name,
 		Description:    p.Description,
@@ -45,11 +78,29 @@ func (p Product) Model() bo.Product {
 		BrandID:        p.BrandID,
 		CategoryID:     p.CategoryID,
 		SupplierID:     p.SupplierID,
-		UnitPrice:      p.UnitPrice,
-		DiscountPrice:  p.DiscountPrice,
 		Tags:           p.Tags,
 		StatusID:       p.StatusID,
+		TaxClassID:     p.TaxClassID,
+		Attributes:     p.Attributes,
+	}
+	product.SetPrices(p.UnitPrice, amountOrZero(p.DiscountPrice), p.Currency)
+	return product
+}
+
+// optionalAmount leaves a zero amount out of the response like omitempty did for the
+// float prices
+func optionalAmount(amount decimal.Decimal) *decimal.Decimal {
+	if amount.IsZero() {
+		return nil
+	}
+	return &amount
+}
+
+func amountOrZero(amount *decimal.Decimal) decimal.Decimal {
+	if amount == nil {
+		return decimal.Zero
 	}
+	return *amount
 }
 
 type ProductCollection []Product
@@ -59,6 +110,10 @@ type PaginatedProduct struct {
 	// This will always return the total of all records
 	Total int64             `json:"total"`
 	Data  ProductCollection `json:"data"`
+
+	// The cursors of the neighbouring pages, pass them as after and before
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func ToPaginatedProduct(bo bo.PaginatedProductCollection) PaginatedProduct {
@@ -67,8 +122,10 @@ func ToPaginatedProduct(bo bo.PaginatedProductCollection) PaginatedProduct {
 		products = append(products, ToProductDTO(prod))
 	}
 	return PaginatedProduct{
-		Data:  products,
-		Total: bo.Total,
+		Data:       products,
+		Total:      bo.Total,
+		NextCursor: EncodeCursor(bo.Next),
+		PrevCursor: EncodeCursor(bo.Prev),
 	}
 }
 
@@ -85,6 +142,12 @@ type ProductQuery struct {
 	Brand            []int64 `form:"brand" json:"brand,omitempty"`
 	Category         int64   `form:"category" json:"category,omitempty"`
 	Q                string  `form:"q" json:"q,omitempty"`
+	After            string  `form:"after" json:"after,omitempty"`
+	Before           string  `form:"before" json:"before,omitempty"`
+	Currency         string  `form:"currency" json:"currency,omitempty" binding:"omitempty,iso4217"`
+
+	// Attr are the attr[code] values of the category attributes, bound by the handler
+	Attr map[string]string `form:"-" json:"attr,omitempty"`
 }
 
 func (p ProductQuery) Model() bo.ProductSearchQuery {
@@ -95,12 +158,11 @@ func (p ProductQuery) Model() bo.ProductSearchQuery {
 	if p.Offset <= 0 {
 		p.Offset = 0
 	}
-	if p.Sort == "" {
-		p.Sort = "unit_price"
-	}
+	// An empty sort is left to the store, by relevance with q and by unit_price without
 	if p.Order == "" {
 		p.Order = "ASC"
 	}
+	// offset is the page index, in pages of limit products
 	offset := p.Offset * p.Limit
 
 	return bo.ProductSearchQuery{
@@ -114,30 +176,39 @@ func (p ProductQuery) Model() bo.ProductSearchQuery {
 			CategoryFilter:         p.Category,
 			SupplierFilter:         p.Supplier,
 			VerifiedSupplierFilter: p.VerifiedSupplier,
+			Attributes:             p.Attr,
 		},
 		Paging: bo.ProductPaging{
-			Limit:  p.Limit,
-			Offset: offset,
+			Limit:       p.Limit,
+			Offset:      offset,
+			PageCursors: toPageCursors(p.After, p.Before),
 		},
 		Sort: bo.ProductSort{
 			Field: p.Sort,
 			Order: p.Order,
 		},
+		Currency: p.Currency,
 	}
 }
 
 type ProductUpdate struct {
-	ID             int64    `json:"id"`
-	Name           *string  `json:"name,omitempty"`
-	Description    *string  `json:"description,omitempty"`
-	Specifications *string  `json:"specifications,omitempty"`
-	BrandID        *int64   `json:"brand_id,omitempty"`
-	CategoryID     *int64   `json:"category_id,omitempty"`
-	SupplierID     *int64   `json:"supplier_id,omitempty"`
-	UnitPrice      *float64 `json:"unit_price,omitempty"`
-	DiscountPrice  *float64 `json:"discount_price,omitempty"`
-	Tags           *string  `json:"tags,omitempty"`
-	StatusID       *int64   `json:"status_id,omitempty"`
+	ID             int64            `json:"id"`
+	Name           *string          `json:"name,omitempty"`
+	Description    *string          `json:"description,omitempty"`
+	Specifications *string          `json:"specifications,omitempty"`
+	BrandID        *int64           `json:"brand_id,omitempty"`
+	CategoryID     *int64           `json:"category_id,omitempty"`
+	SupplierID     *int64           `json:"supplier_id,omitempty"`
+	UnitPrice      *decimal.Decimal `json:"unit_price,omitempty" swaggertype:"number"`
+	DiscountPrice  *decimal.Decimal `json:"discount_price,omitempty" swaggertype:"number"`
+	Tags           *string          `json:"tags,omitempty"`
+	StatusID       *int64           `json:"status_id,omitempty"`
+	Currency       *string          `json:"currency,omitempty" binding:"omitempty,iso4217"`
+	// TaxClassID assigns a tax class, 0 lets the product take the one of its category again
+	TaxClassID *int64 `json:"tax_class_id,omitempty" binding:"omitempty,min=0"`
+
+	// Attributes replace all the attribute values of the product
+	Attributes map[string]any `json:"attributes,omitempty"`
 }
 
 func (p ProductUpdate) Model() bo.ProductUpdate {
@@ -153,5 +224,8 @@ func (p ProductUpdate) Model() bo.ProductUpdate {
 		DiscountPrice:  p.DiscountPrice,
 		Tags:           p.Tags,
 		StatusID:       p.StatusID,
+		Currency:       p.Currency,
+		TaxClassID:     p.TaxClassID,
+		Attributes:     p.Attributes,
 	}
 }
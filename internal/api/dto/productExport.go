@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"strings"
+
+	"techno-store/internal/domain/bo"
+)
+
+// ProductExportQuery is the format and the comma separated columns of an export,
+// its products are filtered with the parameters of a product list
+type ProductExportQuery struct {
+	Format  string `form:"format,default=csv"`
+	Columns string `form:"columns"`
+}
+
+func (q ProductExportQuery) Model(filter bo.ProductFilter) bo.ProductExportQuery {
+	var columns []string
+	for _, column := range strings.Split(q.Columns, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return bo.ProductExportQuery{
+		Filter:  filter,
+		Format:  bo.ProductExportFormat(strings.ToLower(q.Format)),
+		Columns: columns,
+	}
+}
@@ -0,0 +1,91 @@
+package dto
+
+import "techno-store/internal/domain/bo"
+
+type ProductFacets struct {
+	Brands           []FacetCount                 `json:"brands"`
+	Categories       []FacetCount                 `json:"categories"`
+	Suppliers        []FacetCount                 `json:"suppliers"`
+	VerifiedSupplier []VerifiedSupplierFacetCount `json:"verified_supplier"`
+	PriceBuckets     []PriceBucketFacetCount      `json:"price_buckets"`
+	Attributes       []AttributeFacet             `json:"attributes"`
+}
+
+type FacetCount struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type VerifiedSupplierFacetCount struct {
+	Verified bool  `json:"verified"`
+	Count    int64 `json:"count"`
+}
+
+// PriceBucketFacetCount counts the prices from min up to but not including max,
+// the last bucket has no max
+type PriceBucketFacetCount struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int64    `json:"count"`
+}
+
+// AttributeFacet counts the products per value of a filterable attribute of the
+// filtered category, a value is filtered on as attr[code]=value
+type AttributeFacet struct {
+	Code   string                `json:"code"`
+	Name   string                `json:"name"`
+	Type   string                `json:"type"`
+	Unit   string                `json:"unit,omitempty"`
+	Values []AttributeFacetCount `json:"values"`
+}
+
+type AttributeFacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+func ToProductFacetsDTO(bo bo.ProductFacets) ProductFacets {
+	facets := ProductFacets{
+		Brands:           toFacetCounts(bo.Brands),
+		Categories:       toFacetCounts(bo.Categories),
+		Suppliers:        toFacetCounts(bo.Suppliers),
+		VerifiedSupplier: []VerifiedSupplierFacetCount{},
+		PriceBuckets:     []PriceBucketFacetCount{},
+		Attributes:       []AttributeFacet{},
+	}
+	for _, c := range bo.VerifiedSupplier {
+		facets.VerifiedSupplier = append(facets.VerifiedSupplier, VerifiedSupplierFacetCount{
+			Verified: c.Verified,
+			Count:    c.Count,
+		})
+	}
+	for _, c := range bo.PriceBuckets {
+		bucket := PriceBucketFacetCount{Min: c.Min, Count: c.Count}
+		if c.Max > 0 {
+			upper := c.Max
+			bucket.Max = &upper
+		}
+		facets.PriceBuckets = append(facets.PriceBuckets, bucket)
+	}
+	for _, a := range bo.Attributes {
+		facet := AttributeFacet{Code: a.Code, Name: a.Name, Type: string(a.Type), Unit: a.Unit, Values: []AttributeFacetCount{}}
+		for _, c := range a.Values {
+			facet.Values = append(facet.Values, AttributeFacetCount{Value: c.Value, Count: c.Count})
+		}
+		facets.Attributes = append(facets.Attributes, facet)
+	}
+	return facets
+}
+
+func toFacetCounts(counts []bo.FacetCount) []FacetCount {
+	facet := []FacetCount{}
+	for _, c := range counts {
+		facet = append(facet, FacetCount{
+			ID:    c.ID,
+			Name:  c.Name,
+			Count: c.Count,
+		})
+	}
+	return facet
+}
@@ -0,0 +1,54 @@
+package dto
+
+import "techno-store/internal/domain/bo"
+
+// ProductImportQuery tells how to import a file, dry_run only validates it and upsert
+// updates the products of the same supplier and name instead of rejecting them
+type ProductImportQuery struct {
+	DryRun bool `form:"dry_run"`
+	Upsert bool `form:"upsert"`
+}
+
+func (q ProductImportQuery) Model() bo.ProductImportOptions {
+	return bo.ProductImportOptions{
+		DryRun: q.DryRun,
+		Upsert: q.Upsert,
+	}
+}
+
+// ProductImportError is the reason a row was rejected, line 1 is the header
+type ProductImportError struct {
+	Line    int    `json:"line"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProductImportReport lists the errors of every invalid row, no product is imported
+// unless all the rows are valid
+type ProductImportReport struct {
+	DryRun  bool                 `json:"dry_run"`
+	Rows    int                  `json:"rows"`
+	Valid   int                  `json:"valid"`
+	Created int                  `json:"created"`
+	Updated int                  `json:"updated"`
+	Errors  []ProductImportError `json:"errors"`
+}
+
+func ToProductImportReportDTO(bo bo.ProductImportReport) ProductImportReport {
+	report := ProductImportReport{
+		DryRun:  bo.DryRun,
+		Rows:    bo.Rows,
+		Valid:   bo.Valid,
+		Created: bo.Created,
+		Updated: bo.Updated,
+		Errors:  []ProductImportError{},
+	}
+	for _, rowError := range bo.Errors {
+		report.Errors = append(report.Errors, ProductImportError{
+			Line:    rowError.Line,
+			Column:  rowError.Column,
+			Message: rowError.Message,
+		})
+	}
+	return report
+}
@@ -0,0 +1,132 @@
+package dto
+
+import "techno-store/internal/domain/bo"
+
+// ProductMediaURI binds the product and media ids of a media route
+type ProductMediaURI struct {
+	ProductID int64 `uri:"id" binding:"required,min=1"`
+	MediaID   int64 `uri:"media_id" binding:"required,min=1"`
+}
+
+// ProductMediaUpload is the form of a multipart upload, along with its file field
+type ProductMediaUpload struct {
+	Sequence int64 `form:"sequence" binding:"omitempty,min=0"`
+}
+
+// ProductMedia is an image or a document of a product, only images have their width, height
+// and renditions. The renditions are listed once rendition_status is ready.
+type ProductMedia struct {
+	ID        int64  `json:"id"`
+	ProductID int64  `json:"product_id"`
+	Kind      string `json:"kind"`
+	URL       string `json:"url"`
+	FileName  string `json:"file_name"`
+	MimeType  string `json:"mime_type"`
+	SizeBytes int64  `json:"size_bytes"`
+	Width     int64  `json:"width,omitempty"`
+	Height    int64  `json:"height,omitempty"`
+	Sequence  int64  `json:"sequence"`
+	Primary   bool   `json:"primary"`
+	// RenditionStatus is pending, processing, ready or failed, a document is always ready
+	RenditionStatus string                  `json:"rendition_status,omitempty"`
+	RenditionError  string                  `json:"rendition_error,omitempty"`
+	Renditions      []ProductMediaRendition `json:"renditions,omitempty"`
+}
+
+// ProductMediaRendition is a scaled down copy of an image in one format, e.g. the thumbnail in webp
+type ProductMediaRendition struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	URL    string `json:"url"`
+	Width  int64  `json:"width"`
+	Height int64  `json:"height"`
+}
+
+func toProductMediaRenditions(renditions []bo.ProductMediaRendition) []ProductMediaRendition {
+	if len(renditions) == 0 {
+		return nil
+	}
+	mediaRenditions := make([]ProductMediaRendition, 0, len(renditions))
+	for _, rendition := range renditions {
+		mediaRenditions = append(mediaRenditions, ProductMediaRendition{
+			Name:   rendition.Name,
+			Format: rendition.Format,
+			URL:    rendition.URL,
+			Width:  rendition.Width,
+			Height: rendition.Height,
+		})
+	}
+	return mediaRenditions
+}
+
+func ToProductMediaDTO(bo bo.ProductMedia) ProductMedia {
+	return ProductMedia{
+		ID:              bo.ID,
+		ProductID:       bo.ProductID,
+		Kind:            string(bo.Kind),
+		URL:             bo.URL,
+		FileName:        bo.FileName,
+		MimeType:        bo.MimeType,
+		SizeBytes:       bo.SizeBytes,
+		Width:           bo.Width,
+		Height:          bo.Height,
+		Sequence:        bo.Sequence,
+		Primary:         bo.Primary,
+		RenditionStatus: string(bo.RenditionStatus),
+		RenditionError:  bo.RenditionError,
+		Renditions:      toProductMediaRenditions(bo.Renditions),
+	}
+}
+
+type ProductMediaCollection []ProductMedia
+
+type ProductMediaList struct {
+	Data ProductMediaCollection `json:"data"`
+}
+
+func ToProductMediaList(bo bo.ProductMediaCollection) ProductMediaList {
+	mediaCollection := ProductMediaCollection{}
+	for _, media := range bo {
+		mediaCollection = append(mediaCollection, ToProductMediaDTO(media))
+	}
+	return ProductMediaList{Data: mediaCollection}
+}
+
+// ProductMediaUpdate reorders a media file, primary true makes an image the primary one
+type ProductMediaUpdate struct {
+	Sequence *int64 `json:"sequence,omitempty" binding:"omitempty,min=0"`
+	Primary  *bool  `json:"primary,omitempty"`
+}
+
+func (m ProductMediaUpdate) Model(productID, mediaID int64) bo.ProductMediaUpdate {
+	return bo.ProductMediaUpdate{
+		ID:        mediaID,
+		ProductID: productID,
+		Sequence:  m.Sequence,
+		Primary:   m.Primary,
+	}
+}
+
+// ProductImage is an image listed along with its product
+type ProductImage struct {
+	ID         int64                   `json:"id"`
+	URL        string                  `json:"url"`
+	Primary    bool                    `json:"primary"`
+	Renditions []ProductMediaRendition `json:"renditions,omitempty"`
+}
+
+func toProductImages(images []bo.ProductImage) []ProductImage {
+	if len(images) == 0 {
+		return nil
+	}
+	productImages := make([]ProductImage, 0, len(images))
+	for _, image := range images {
+		productImages = append(productImages, ProductImage{
+			ID:         image.ID,
+			URL:        image.URL,
+			Primary:    image.Primary,
+			Renditions: toProductMediaRenditions(image.Renditions),
+		})
+	}
+	return productImages
+}
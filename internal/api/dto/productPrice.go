@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+// ProductPriceScheduleURI binds the product and schedule ids of a price schedule route
+type ProductPriceScheduleURI struct {
+	ProductID  int64 `uri:"id" binding:"required,min=1"`
+	ScheduleID int64 `uri:"schedule_id" binding:"required,min=1"`
+}
+
+type ProductPriceSchedule struct {
+	ID            int64            `json:"id"`
+	ProductID     int64            `json:"product_id"`
+	UnitPrice     *decimal.Decimal `json:"unit_price,omitempty" swaggertype:"number"`
+	DiscountPrice *decimal.Decimal `json:"discount_price,omitempty" swaggertype:"number"`
+	StartsAt      time.Time        `json:"starts_at"`
+	EndsAt        *time.Time       `json:"ends_at,omitempty"`
+	Status        string           `json:"status" enums:"pending,active,completed,cancelled,expired"`
+	// PreviousUnitPrice and PreviousDiscountPrice are the prices replaced when the schedule was applied
+	PreviousUnitPrice     *decimal.Decimal `json:"previous_unit_price,omitempty" swaggertype:"number"`
+	PreviousDiscountPrice *decimal.Decimal `json:"previous_discount_price,omitempty" swaggertype:"number"`
+	AppliedAt             *time.Time       `json:"applied_at,omitempty"`
+	RevertedAt            *time.Time       `json:"reverted_at,omitempty"`
+	CreatedAt             time.Time        `json:"created_at"`
+}
+
+func ToProductPriceScheduleDTO(bo bo.ProductPriceSchedule) ProductPriceSchedule {
+	schedule := ProductPriceSchedule{
+		ID:            bo.ID,
+		ProductID:     bo.ProductID,
+		UnitPrice:     bo.UnitPrice,
+		DiscountPrice: bo.DiscountPrice,
+		StartsAt:      bo.StartsAt,
+		Status:        string(bo.Status),
+		CreatedAt:     bo.CreatedAt,
+	}
+	if !bo.EndsAt.IsZero() {
+		schedule.EndsAt = &bo.EndsAt
+	}
+	if !bo.AppliedAt.IsZero() {
+		schedule.AppliedAt = &bo.AppliedAt
+		schedule.PreviousUnitPrice = &bo.PreviousUnitPrice
+		schedule.PreviousDiscountPrice = &bo.PreviousDiscountPrice
+	}
+	if !bo.RevertedAt.IsZero() {
+		schedule.RevertedAt = &bo.RevertedAt
+	}
+	return schedule
+}
+
+// ProductPriceScheduleRequest plans a price change, an omitted price is left as it is.
+// Without ends_at the change is permanent, with it the previous prices are put back at the end.
+type ProductPriceScheduleRequest struct {
+	UnitPrice     *decimal.Decimal `json:"unit_price,omitempty" binding:"omitempty,gt=0" swaggertype:"number"`
+	DiscountPrice *decimal.Decimal `json:"discount_price,omitempty" binding:"omitempty,min=0" swaggertype:"number"`
+	StartsAt      time.Time        `json:"starts_at" binding:"required"`
+	EndsAt        *time.Time       `json:"ends_at,omitempty"`
+}
+
+func (r ProductPriceScheduleRequest) Model(productID int64) bo.ProductPriceSchedule {
+	schedule := bo.ProductPriceSchedule{
+		ProductID:     productID,
+		UnitPrice:     r.UnitPrice,
+		DiscountPrice: r.DiscountPrice,
+		StartsAt:      r.StartsAt,
+	}
+	if r.EndsAt != nil {
+		schedule.EndsAt = *r.EndsAt
+	}
+	return schedule
+}
+
+type ProductPriceChange struct {
+	UnitPrice             decimal.Decimal  `json:"unit_price" swaggertype:"number"`
+	DiscountPrice         *decimal.Decimal `json:"discount_price,omitempty" swaggertype:"number"`
+	PreviousUnitPrice     *decimal.Decimal `json:"previous_unit_price,omitempty" swaggertype:"number"`
+	PreviousDiscountPrice *decimal.Decimal `json:"previous_discount_price,omitempty" swaggertype:"number"`
+	Reason                string           `json:"reason" enums:"created,updated,scheduled,reverted"`
+	// PriceScheduleID is the schedule which set or reverted the prices
+	PriceScheduleID int64     `json:"price_schedule_id,omitempty"`
+	ChangedAt       time.Time `json:"changed_at"`
+}
+
+// ProductPriceTimeline is the current prices of a product, its price history oldest
+// first and its price schedules by start
+type ProductPriceTimeline struct {
+	ProductID     int64                  `json:"product_id"`
+	UnitPrice     decimal.Decimal        `json:"unit_price" swaggertype:"number"`
+	DiscountPrice *decimal.Decimal       `json:"discount_price,omitempty" swaggertype:"number"`
+	History       []ProductPriceChange   `json:"history"`
+	Schedules     []ProductPriceSchedule `json:"schedules"`
+}
+
+func ToProductPriceTimelineDTO(bo bo.ProductPriceTimeline) ProductPriceTimeline {
+	timeline := ProductPriceTimeline{
+		ProductID:     bo.ProductID,
+		UnitPrice:     bo.UnitPrice,
+		DiscountPrice: optionalAmount(bo.DiscountPrice),
+		History:       []ProductPriceChange{},
+		Schedules:     []ProductPriceSchedule{},
+	}
+	for _, change := range bo.History {
+		timeline.History = append(timeline.History, ProductPriceChange{
+			UnitPrice:             change.UnitPrice,
+			DiscountPrice:         optionalAmount(change.DiscountPrice),
+			PreviousUnitPrice:     optionalAmount(change.PreviousUnitPrice),
+			PreviousDiscountPrice: optionalAmount(change.PreviousDiscountPrice),
+			Reason:                string(change.Reason),
+			PriceScheduleID:       change.PriceScheduleID,
+			ChangedAt:             change.ChangedAt,
+		})
+	}
+	for _, schedule := range bo.Schedules {
+		timeline.Schedules = append(timeline.Schedules, ToProductPriceScheduleDTO(schedule))
+	}
+	return timeline
+}
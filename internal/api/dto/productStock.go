@@ -5,14 +5,26 @@ import "techno-store/internal/domain/bo"
 type ProductStock struct {
 	ID            int64 `json:"id,omitempty"`
 	ProductID     int64 `json:"product_id"`
+	VariantID     int64 `json:"variant_id,omitempty"`
+	WarehouseID   int64 `json:"warehouse_id,omitempty"`
 	StockQuantity int64 `json:"stock_quantity"`
+	Version       int64 `json:"version"`
+	// ReservedQuantity and AvailableQuantity are read only
+	ReservedQuantity  int64 `json:"reserved_quantity"`
+	AvailableQuantity int64 `json:"available_quantity"`
 }
 
 func ToProductStockDTO(bo bo.ProductStock) ProductStock {
 	return ProductStock{
 		ID:            bo.ID,
 		ProductID:     bo.ProductID,
+		VariantID:     bo.VariantID,
+		WarehouseID:   bo.WarehouseID,
 		StockQuantity: bo.StockQuantity,
+		Version:       bo.Version,
+
+		ReservedQuantity:  bo.ReservedQuantity,
+		AvailableQuantity: bo.AvailableQuantity(),
 	}
 }
 
@@ -20,19 +32,51 @@ func (b ProductStock) Model() bo.ProductStock {
 	return bo.ProductStock{
 		ID:            b.ID,
 		ProductID:     b.ProductID,
+		VariantID:     b.VariantID,
+		WarehouseID:   b.WarehouseID,
 		StockQuantity: b.StockQuantity,
 	}
 }
 
 type ProductStockUpdate struct {
 	ProductID     int64  `json:"product_id"`
+	VariantID     int64  `json:"variant_id,omitempty"`
+	WarehouseID   int64  `json:"warehouse_id,omitempty"`
 	StockQuantity *int64 `json:"stock_quantity"`
+	Reason        string `json:"reason,omitempty" enums:"receipt,sale,return,adjustment,damage,transfer"`
+	Reference     string `json:"reference,omitempty"`
+	ChangedBy     string `json:"changed_by,omitempty"`
 }
 
 func (b ProductStockUpdate) Model() bo.ProductStockUpdate {
 	return bo.ProductStockUpdate{
 		ProductID:     b.ProductID,
+		VariantID:     b.VariantID,
+		WarehouseID:   b.WarehouseID,
 		StockQuantity: b.StockQuantity,
+		Reason:        bo.StockMovementReason(b.Reason),
+		Reference:     b.Reference,
+		ChangedBy:     b.ChangedBy,
+	}
+}
+
+// ProductStockAdjustment is a relative change of a stock row
+type ProductStockAdjustment struct {
+	Delta           int64  `json:"delta" binding:"required,ne=0"`
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
+	Reason          string `json:"reason,omitempty" enums:"receipt,sale,return,adjustment,damage,transfer"`
+	Reference       string `json:"reference,omitempty"`
+	ChangedBy       string `json:"changed_by,omitempty"`
+}
+
+func (a ProductStockAdjustment) Model(productStockID int64) bo.ProductStockAdjustment {
+	return bo.ProductStockAdjustment{
+		ProductStockID:  productStockID,
+		Delta:           a.Delta,
+		ExpectedVersion: a.ExpectedVersion,
+		Reason:          bo.StockMovementReason(a.Reason),
+		Reference:       a.Reference,
+		ChangedBy:       a.ChangedBy,
 	}
 }
 
@@ -44,6 +88,10 @@ type PaginatedProductStockCollection struct {
 	// This will always return the total of all records
 	Total int64                  `json:"total"`
 	Data  ProductStockCollection `json:"data"`
+
+	// The cursors of the neighbouring pages, pass them as after and before
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func ToPaginatedProductStock(bo bo.PaginatedProductStockCollection) PaginatedProductStockCollection {
@@ -53,14 +101,19 @@ func ToPaginatedProductStock(bo bo.PaginatedProductStockCollection) PaginatedPro
 	}
 
 	return PaginatedProductStockCollection{
-		Total: bo.Total,
-		Data:  dto,
+		Total:      bo.Total,
+		Data:       dto,
+		NextCursor: EncodeCursor(bo.Next),
+		PrevCursor: EncodeCursor(bo.Prev),
 	}
 }
 
 type ProductStockQuery struct {
-	Limit  int `form:"limit,default=20" json:"limit,omitempty" binding:"min=1"`
-	Offset int `form:"offset" json:"offset,omitempty" binding:"omitempty,min=0"`
+	Limit     int    `form:"limit,default=20" json:"limit,omitempty" binding:"min=1"`
+	Offset    int    `form:"offset" json:"offset,omitempty" binding:"omitempty,min=0"`
+	Warehouse int64  `form:"warehouse" json:"warehouse,omitempty" binding:"omitempty,min=0"`
+	After     string `form:"after" json:"after,omitempty"`
+	Before    string `form:"before" json:"before,omitempty"`
 }
 
 func (q ProductStockQuery) Model() bo.ProductStockQuery {
@@ -73,7 +126,47 @@ func (q ProductStockQuery) Model() bo.ProductStockQuery {
 	}
 
 	return bo.ProductStockQuery{
-		Limit:  q.Limit,
-		Offset: q.Offset,
+		Limit:       q.Limit,
+		Offset:      q.Offset,
+		WarehouseID: q.Warehouse,
+		PageCursors: toPageCursors(q.After, q.Before),
+	}
+}
+
+type ProductStockLocation struct {
+	WarehouseID       int64 `json:"warehouse_id"`
+	VariantID         int64 `json:"variant_id,omitempty"`
+	StockQuantity     int64 `json:"stock_quantity"`
+	ReservedQuantity  int64 `json:"reserved_quantity"`
+	AvailableQuantity int64 `json:"available_quantity"`
+}
+
+// ProductStockLevel is the aggregated stock of a product with its per-location breakdown
+type ProductStockLevel struct {
+	ProductID         int64                  `json:"product_id"`
+	StockQuantity     int64                  `json:"stock_quantity"`
+	ReservedQuantity  int64                  `json:"reserved_quantity"`
+	AvailableQuantity int64                  `json:"available_quantity"`
+	Locations         []ProductStockLocation `json:"locations"`
+}
+
+func ToProductStockLevelDTO(bo bo.ProductStockLevel) ProductStockLevel {
+	locations := []ProductStockLocation{}
+	for _, l := range bo.Locations {
+		locations = append(locations, ProductStockLocation{
+			WarehouseID:       l.WarehouseID,
+			VariantID:         l.VariantID,
+			StockQuantity:     l.StockQuantity,
+			ReservedQuantity:  l.ReservedQuantity,
+			AvailableQuantity: l.StockQuantity - l.ReservedQuantity,
+		})
+	}
+
+	return ProductStockLevel{
+		ProductID:         bo.ProductID,
+		StockQuantity:     bo.StockQuantity,
+		ReservedQuantity:  bo.ReservedQuantity,
+		AvailableQuantity: bo.StockQuantity - bo.ReservedQuantity,
+		Locations:         locations,
 	}
 }
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+// ProductVariantURI binds the product and variant ids of a variant route
+type ProductVariantURI struct {
+	ProductID int64 `uri:"id" binding:"required,min=1"`
+	VariantID int64 `uri:"variant_id" binding:"required,min=1"`
+}
+
+type ProductVariant struct {
+	ID            int64             `json:"id,omitempty"`
+	ProductID     int64             `json:"product_id,omitempty"`
+	SKU           string            `json:"sku" binding:"required"`
+	Options       map[string]string `json:"options,omitempty"`
+	UnitPrice     decimal.Decimal   `json:"unit_price" binding:"required" swaggertype:"number"`
+	DiscountPrice *decimal.Decimal  `json:"discount_price,omitempty" swaggertype:"number"`
+	StatusID      int64             `json:"status_id" binding:"required"`
+}
+
+func ToProductVariantDTO(bo bo.ProductVariant) ProductVariant {
+	return ProductVariant{
+		ID:            bo.ID,
+		ProductID:     bo.ProductID,
+		SKU:           bo.SKU,
+		Options:       bo.Options,
+		UnitPrice:     bo.UnitPrice,
+		DiscountPrice: optionalAmount(bo.DiscountPrice),
+		StatusID:      bo.StatusID,
+	}
+}
+
+func (v ProductVariant) Model() bo.ProductVariant {
+	return bo.ProductVariant{
+		ID:            v.ID,
+		ProductID:     v.ProductID,
+		SKU:           v.SKU,
+		Options:       v.Options,
+		UnitPrice:     v.UnitPrice,
+		DiscountPrice: amountOrZero(v.DiscountPrice),
+		StatusID:      v.StatusID,
+	}
+}
+
+type ProductVariantUpdate struct {
+	ID            int64             `json:"id"`
+	ProductID     int64             `json:"product_id"`
+	SKU           *string           `json:"sku,omitempty"`
+	Options       map[string]string `json:"options,omitempty"`
+	UnitPrice     *decimal.Decimal  `json:"unit_price,omitempty" swaggertype:"number"`
+	DiscountPrice *decimal.Decimal  `json:"discount_price,omitempty" swaggertype:"number"`
+	StatusID      *int64            `json:"status_id,omitempty"`
+}
+
+func (v ProductVariantUpdate) Model() bo.ProductVariantUpdate {
+	return bo.ProductVariantUpdate{
+		ID:            v.ID,
+		ProductID:     v.ProductID,
+		SKU:           v.SKU,
+		Options:       v.Options,
+		UnitPrice:     v.UnitPrice,
+		DiscountPrice: v.DiscountPrice,
+		StatusID:      v.StatusID,
+	}
+}
+
+type ProductVariantCollection []ProductVariant
+
+// ProductVariants wraps the variants of a product
+type ProductVariants struct {
+	Data ProductVariantCollection `json:"data"`
+}
+
+func ToProductVariants(bo bo.ProductVariantCollection) ProductVariants {
+	variants := ProductVariantCollection{}
+	for _, v := range bo {
+		variants = append(variants, ToProductVariantDTO(v))
+	}
+
+	return ProductVariants{
+		Data: variants,
+	}
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"testing"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductQueryPaging(t *testing.T) {
+	testCases := []struct {
+		name   string
+		query  ProductQuery
+		paging bo.ProductPaging
+	}{
+		{name: "FirstPage", query: ProductQuery{Limit: 10}, paging: bo.ProductPaging{Limit: 10}},
+		{name: "PageIndex", query: ProductQuery{Limit: 10, Offset: 2}, paging: bo.ProductPaging{Limit: 10, Offset: 20}},
+		{name: "DefaultLimit", query: ProductQuery{Offset: 3}, paging: bo.ProductPaging{Limit: 20, Offset: 60}},
+		{name: "NegativeOffset", query: ProductQuery{Limit: 10, Offset: -1}, paging: bo.ProductPaging{Limit: 10}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.paging, tc.query.Model().Paging)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+type Promotion struct {
+	ID          int64            `json:"id,omitempty"`
+	Name        string           `json:"name" binding:"required,max=255"`
+	Kind        string           `json:"kind" binding:"required,oneof=percentage fixed_amount buy_x_get_y"`
+	Value       *decimal.Decimal `json:"value,omitempty" binding:"omitempty,gt=0" swaggertype:"number"`
+	BuyQuantity int64            `json:"buy_quantity,omitempty" binding:"omitempty,min=1"`
+	GetQuantity int64            `json:"get_quantity,omitempty" binding:"omitempty,min=1"`
+	Scope       string           `json:"scope" binding:"required,oneof=all brand category supplier product"`
+	ScopeID     int64            `json:"scope_id,omitempty" binding:"omitempty,min=1"`
+	CouponCode  string           `json:"coupon_code,omitempty" binding:"omitempty,max=64"`
+	StartsAt    *time.Time       `json:"starts_at,omitempty"`
+	EndsAt      *time.Time       `json:"ends_at,omitempty"`
+	UsageLimit  int64            `json:"usage_limit,omitempty" binding:"omitempty,min=1"`
+	UsageCount  int64            `json:"usage_count"`
+	StatusID    int64            `json:"status_id" binding:"required"`
+	Active      bool             `json:"active"`
+	// Currency is the ISO 4217 currency of a fixed amount value, BDT when left out on creation
+	Currency string `json:"currency,omitempty" binding:"omitempty,iso4217" example:"BDT"`
+}
+
+func (p Promotion) Model() bo.Promotion {
+	promotion := bo.Promotion{
+		ID:          p.ID,
+		Name:        p.Name,
+		Kind:        bo.PromotionKind(p.Kind),
+		Value:       amountOrZero(p.Value),
+		BuyQuantity: p.BuyQuantity,
+		GetQuantity: p.GetQuantity,
+		Scope:       bo.PromotionScope(p.Scope),
+		ScopeID:     p.ScopeID,
+		CouponCode:  p.CouponCode,
+		UsageLimit:  p.UsageLimit,
+		StatusID:    p.StatusID,
+		Currency:    p.Currency,
+	}
+	if p.StartsAt != nil {
+		promotion.StartsAt = *p.StartsAt
+	}
+	if p.EndsAt != nil {
+		promotion.EndsAt = *p.EndsAt
+	}
+	return promotion
+}
+
+// PromotionUpdate changes a promotion, fields left out are unchanged and a zero
+// starts_at, ends_at or usage_limit clears it. Kind, scope, coupon code and currency cannot be changed.
+type PromotionUpdate struct {
+	ID         int64            `json:"id"`
+	Name       *string          `json:"name"`
+	Value      *decimal.Decimal `json:"value" swaggertype:"number"`
+	StartsAt   *time.Time       `json:"starts_at"`
+	EndsAt     *time.Time       `json:"ends_at"`
+	UsageLimit *int64           `json:"usage_limit"`
+	StatusID   *int64           `json:"status_id"`
+}
+
+func (p PromotionUpdate) Model() bo.PromotionUpdate {
+	return bo.PromotionUpdate{
+		ID:         p.ID,
+		Name:       p.Name,
+		Value:      p.Value,
+		StartsAt:   p.StartsAt,
+		EndsAt:     p.EndsAt,
+		UsageLimit: p.UsageLimit,
+		StatusID:   p.StatusID,
+	}
+}
+
+// PromotionCollection array
+type PromotionCollection []Promotion
+
+// PaginatedPromotionCollection model array with total record
+type PaginatedPromotionCollection struct {
+	// This will always return the total of all records
+	Total int64               `json:"total"`
+	Data  PromotionCollection `json:"data"`
+}
+
+func ToPaginatedPromotion(bo bo.PaginatedPromotionCollection) PaginatedPromotionCollection {
+	promotions := []Promotion{}
+	for _, promotion := range bo.Data {
+		promotions = append(promotions, ToPromotionDTO(promotion))
+	}
+
+	return PaginatedPromotionCollection{
+		Total: bo.Total,
+		Data:  promotions,
+	}
+}
+
+// PromotionQuery represent Promotion model query parameter
+type PromotionQuery struct {
+	Limit  int `form:"limit,default=20" json:"limit,omitempty" binding:"min=1"`
+	Offset int `form:"offset" json:"offset,omitempty" binding:"omitempty,min=0"`
+}
+
+func (q PromotionQuery) Model() bo.PromotionQuery {
+	// Setup some default behavior
+	if q.Limit <= 0 {
+		q.Limit = 20
+	}
+	if q.Offset <= 0 {
+		q.Offset = 0
+	}
+
+	return bo.PromotionQuery{
+		Limit:  q.Limit,
+		Offset: q.Offset,
+	}
+}
+
+// Convert BO to DTO
+func ToPromotionDTO(bo bo.Promotion) Promotion {
+	promotion := Promotion{
+		ID:          bo.ID,
+		Name:        bo.Name,
+		Kind:        string(bo.Kind),
+		Value:       optionalAmount(bo.Value),
+		BuyQuantity: bo.BuyQuantity,
+		GetQuantity: bo.GetQuantity,
+		Scope:       string(bo.Scope),
+		ScopeID:     bo.ScopeID,
+		CouponCode:  bo.CouponCode,
+		UsageLimit:  bo.UsageLimit,
+		UsageCount:  bo.UsageCount,
+		StatusID:    bo.StatusID,
+		Active:      bo.ActiveAt(time.Now()),
+		Currency:    bo.Currency,
+	}
+	if !bo.StartsAt.IsZero() {
+		promotion.StartsAt = &bo.StartsAt
+	}
+	if !bo.EndsAt.IsZero() {
+		promotion.EndsAt = &bo.EndsAt
+	}
+	return promotion
+}
+
+// PriceQuoteQuery prices with the promotion of a coupon code on top of the automatic ones
+type PriceQuoteQuery struct {
+	CouponCode string `form:"coupon_code" binding:"omitempty,max=64"`
+	// Jurisdiction taxes the quote in an ISO 3166 country or subdivision, the store default when left out
+	Jurisdiction string `form:"jurisdiction" binding:"omitempty,max=6"`
+}
+
+type PriceQuote struct {
+	// Currency is the ISO 4217 currency of every amount of the quote
+	Currency      string             `json:"currency,omitempty" example:"BDT"`
+	Lines         []PriceQuoteLine   `json:"lines"`
+	Subtotal      decimal.Decimal    `json:"subtotal" swaggertype:"number"`
+	DiscountTotal decimal.Decimal    `json:"discount_total" swaggertype:"number"`
+	Total         decimal.Decimal    `json:"total" swaggertype:"number"`
+	Promotions    []AppliedPromotion `json:"promotions"`
+	// Tax is only returned for a cart, Tax.Gross is what is charged
+	Tax *TaxBreakdown `json:"tax,omitempty"`
+}
+
+type PriceQuoteLine struct {
+	CartItemID  int64           `json:"cart_item_id,omitempty"`
+	ProductID   int64           `json:"product_id"`
+	VariantID   int64           `json:"variant_id,omitempty"`
+	Quantity    int64           `json:"quantity"`
+	UnitPrice   decimal.Decimal `json:"unit_price" swaggertype:"number"`
+	Price       decimal.Decimal `json:"price" swaggertype:"number"`
+	LineTotal   decimal.Decimal `json:"line_total" swaggertype:"number"`
+	PromotionID int64           `json:"promotion_id,omitempty"`
+}
+
+type AppliedPromotion struct {
+	PromotionID int64           `json:"promotion_id"`
+	Name        string          `json:"name"`
+	CouponCode  string          `json:"coupon_code,omitempty"`
+	Discount    decimal.Decimal `json:"discount" swaggertype:"number"`
+}
+
+func ToPriceQuoteDTO(bo bo.PriceQuote) PriceQuote {
+	lines := []PriceQuoteLine{}
+	for _, l := range bo.Lines {
+		lines = append(lines, PriceQuoteLine{
+			CartItemID:  l.CartItemID,
+			ProductID:   l.ProductID,
+			VariantID:   l.VariantID,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			Price:       l.Price,
+			LineTotal:   l.LineTotal,
+			PromotionID: l.PromotionID,
+		})
+	}
+
+	promotions := []AppliedPromotion{}
+	for _, p := range bo.Promotions {
+		promotions = append(promotions, AppliedPromotion{
+			PromotionID: p.PromotionID,
+			Name:        p.Name,
+			CouponCode:  p.CouponCode,
+			Discount:    p.Discount,
+		})
+	}
+
+	quote := PriceQuote{
+		Currency:      bo.Currency,
+		Lines:         lines,
+		Subtotal:      bo.Subtotal,
+		DiscountTotal: bo.DiscountTotal,
+		Total:         bo.Total,
+		Promotions:    promotions,
+	}
+	if bo.Tax != nil {
+		tax := ToTaxBreakdownDTO(*bo.Tax)
+		quote.Tax = &tax
+	}
+	return quote
+}
@@ -0,0 +1,165 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+type Return struct {
+	ID               int64           `json:"id"`
+	OrderID          int64           `json:"order_id"`
+	Status           string          `json:"status" enums:"requested,approved,rejected,received,refunding,refunded"`
+	Reason           string          `json:"reason,omitempty"`
+	Items            []ReturnItem    `json:"items"`
+	RefundableAmount decimal.Decimal `json:"refundable_amount" swaggertype:"number"`
+	RefundAmount     decimal.Decimal `json:"refund_amount" swaggertype:"number"`
+	PaymentID        int64           `json:"payment_id,omitempty"`
+	Events           []ReturnEvent   `json:"events"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+type ReturnItem struct {
+	ID               int64           `json:"id"`
+	OrderItemID      int64           `json:"order_item_id"`
+	ProductID        int64           `json:"product_id"`
+	VariantID        int64           `json:"variant_id,omitempty"`
+	Quantity         int64           `json:"quantity"`
+	ReceivedQuantity int64           `json:"received_quantity"`
+	Condition        string          `json:"condition,omitempty" enums:"sellable,damaged"`
+	UnitPrice        decimal.Decimal `json:"unit_price" swaggertype:"number"`
+}
+
+// ReturnEvent is one step of the return audit trail
+type ReturnEvent struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	Note       string    `json:"note,omitempty"`
+	CreatedBy  string    `json:"created_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func ToReturnDTO(bo bo.Return) Return {
+	items := []ReturnItem{}
+	for _, i := range bo.Items {
+		items = append(items, ReturnItem{
+			ID:               i.ID,
+			OrderItemID:      i.OrderItemID,
+			ProductID:        i.ProductID,
+			VariantID:        i.VariantID,
+			Quantity:         i.Quantity,
+			ReceivedQuantity: i.ReceivedQuantity,
+			Condition:        string(i.Condition),
+			UnitPrice:        i.UnitPrice,
+		})
+	}
+
+	events := []ReturnEvent{}
+	for _, e := range bo.Events {
+		events = append(events, ReturnEvent{
+			FromStatus: string(e.FromStatus),
+			ToStatus:   string(e.ToStatus),
+			Note:       e.Note,
+			CreatedBy:  e.CreatedBy,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+
+	return Return{
+		ID:               bo.ID,
+		OrderID:          bo.OrderID,
+		Status:           string(bo.Status),
+		Reason:           bo.Reason,
+		Items:            items,
+		RefundableAmount: bo.RefundableAmount(),
+		RefundAmount:     bo.RefundAmount,
+		PaymentID:        bo.PaymentID,
+		Events:           events,
+		CreatedAt:        bo.CreatedAt,
+		UpdatedAt:        bo.UpdatedAt,
+	}
+}
+
+func ToReturnCollectionDTO(bo bo.ReturnCollection) []Return {
+	returns := []Return{}
+	for _, r := range bo {
+		returns = append(returns, ToReturnDTO(r))
+	}
+	return returns
+}
+
+// ReturnRequest asks to send back part of the lines of an order
+type ReturnRequest struct {
+	Reason      string              `json:"reason,omitempty"`
+	Items       []ReturnRequestItem `json:"items" binding:"required,min=1,dive"`
+	RequestedBy string              `json:"requested_by,omitempty"`
+}
+
+type ReturnRequestItem struct {
+	OrderItemID int64 `json:"order_item_id" binding:"required,min=1"`
+	Quantity    int64 `json:"quantity" binding:"required,min=1"`
+}
+
+func (r ReturnRequest) Model(orderID int64) bo.ReturnRequest {
+	var items []bo.ReturnRequestItem
+	for _, i := range r.Items {
+		items = append(items, bo.ReturnRequestItem{OrderItemID: i.OrderItemID, Quantity: i.Quantity})
+	}
+
+	return bo.ReturnRequest{
+		OrderID:     orderID,
+		Reason:      r.Reason,
+		Items:       items,
+		RequestedBy: r.RequestedBy,
+	}
+}
+
+// ReturnDecision approves or rejects a requested return
+type ReturnDecision struct {
+	Note      string `json:"note,omitempty"`
+	ChangedBy string `json:"changed_by,omitempty"`
+}
+
+// ReturnReceipt records the items of an approved return arriving back, items
+// which are not listed did not come back
+type ReturnReceipt struct {
+	Items     []ReturnItemReceipt `json:"items" binding:"required,min=1,dive"`
+	Note      string              `json:"note,omitempty"`
+	ChangedBy string              `json:"changed_by,omitempty"`
+}
+
+// ReturnItemReceipt is the quantity of a return item which came back, all of
+// it when quantity is omitted
+type ReturnItemReceipt struct {
+	ReturnItemID int64  `json:"return_item_id" binding:"required,min=1"`
+	Quantity     int64  `json:"quantity,omitempty" binding:"omitempty,min=1"`
+	Condition    string `json:"condition" binding:"required,oneof=sellable damaged"`
+}
+
+func (r ReturnReceipt) Model(returnID int64) bo.ReturnReceipt {
+	var items []bo.ReturnItemReceipt
+	for _, i := range r.Items {
+		items = append(items, bo.ReturnItemReceipt{
+			ReturnItemID: i.ReturnItemID,
+			Quantity:     i.Quantity,
+			Condition:    bo.ReturnCondition(i.Condition),
+		})
+	}
+
+	return bo.ReturnReceipt{
+		ReturnID:  returnID,
+		Items:     items,
+		Note:      r.Note,
+		ChangedBy: r.ChangedBy,
+	}
+}
+
+// ReturnRefund refunds part of the received items, all of them when amount is omitted
+type ReturnRefund struct {
+	Amount    decimal.Decimal `json:"amount,omitempty" binding:"omitempty,gt=0" swaggertype:"number"`
+	Note      string          `json:"note,omitempty"`
+	ChangedBy string          `json:"changed_by,omitempty"`
+}
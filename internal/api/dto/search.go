@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+)
+
+// SuggestQuery represent the search suggestion query parameter
+type SuggestQuery struct {
+	Q     string `form:"q" json:"q" binding:"required,max=100"`
+	Limit int    `form:"limit,default=10" json:"limit,omitempty" binding:"min=1,max=50"`
+}
+
+func (q SuggestQuery) Model() bo.SuggestQuery {
+	return bo.SuggestQuery{
+		Prefix: q.Q,
+		Limit:  q.Limit,
+	}
+}
+
+type Suggestion struct {
+	Kind       string `json:"kind"`
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Popularity int64  `json:"popularity"`
+}
+
+func ToSuggestionsDTO(bo bo.SuggestionCollection) []Suggestion {
+	suggestions := []Suggestion{}
+	for _, s := range bo {
+		suggestions = append(suggestions, Suggestion{
+			Kind:       string(s.Kind),
+			ID:         s.ID,
+			Name:       s.Name,
+			Popularity: s.Popularity,
+		})
+	}
+	return suggestions
+}
+
+// SearchSynonym makes the suggestions for term include the ones for synonym
+type SearchSynonym struct {
+	ID        int64     `json:"id,omitempty"`
+	Term      string    `json:"term" binding:"required,max=100"`
+	Synonym   string    `json:"synonym" binding:"required,max=100"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func (s SearchSynonym) Model() bo.SearchSynonym {
+	return bo.SearchSynonym{
+		ID:      s.ID,
+		Term:    s.Term,
+		Synonym: s.Synonym,
+	}
+}
+
+func ToSearchSynonymDTO(bo bo.SearchSynonym) SearchSynonym {
+	return SearchSynonym{
+		ID:        bo.ID,
+		Term:      bo.Term,
+		Synonym:   bo.Synonym,
+		CreatedAt: bo.CreatedAt,
+	}
+}
+
+func ToSearchSynonymsDTO(bo bo.SearchSynonymCollection) []SearchSynonym {
+	synonyms := []SearchSynonym{}
+	for _, s := range bo {
+		synonyms = append(synonyms, ToSearchSynonymDTO(s))
+	}
+	return synonyms
+}
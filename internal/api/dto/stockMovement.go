@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+)
+
+type StockMovement struct {
+	ID            int64     `json:"id"`
+	ProductID     int64     `json:"product_id"`
+	VariantID     int64     `json:"variant_id,omitempty"`
+	WarehouseID   int64     `json:"warehouse_id"`
+	QuantityDelta int64     `json:"quantity_delta"`
+	BalanceAfter  int64     `json:"balance_after"`
+	Reason        string    `json:"reason"`
+	Reference     string    `json:"reference,omitempty"`
+	CreatedBy     string    `json:"created_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func ToStockMovementDTO(bo bo.StockMovement) StockMovement {
+	return StockMovement{
+		ID:            bo.ID,
+		ProductID:     bo.ProductID,
+		VariantID:     bo.VariantID,
+		WarehouseID:   bo.WarehouseID,
+		QuantityDelta: bo.QuantityDelta,
+		BalanceAfter:  bo.BalanceAfter,
+		Reason:        string(bo.Reason),
+		Reference:     bo.Reference,
+		CreatedBy:     bo.CreatedBy,
+		CreatedAt:     bo.CreatedAt,
+	}
+}
+
+// StockMovementCollection array
+type StockMovementCollection []StockMovement
+
+// PaginatedStockMovementCollection model array with total record
+type PaginatedStockMovementCollection struct {
+	// This will always return the total of all records
+	Total int64                   `json:"total"`
+	Data  StockMovementCollection `json:"data"`
+}
+
+func ToPaginatedStockMovement(bo bo.PaginatedStockMovementCollection) PaginatedStockMovementCollection {
+	movements := StockMovementCollection{}
+	for _, v := range bo.Data {
+		movements = append(movements, ToStockMovementDTO(v))
+	}
+
+	return PaginatedStockMovementCollection{
+		Total: bo.Total,
+		Data:  movements,
+	}
+}
+
+// StockMovementQuery represent StockMovement model query parameter
+type StockMovementQuery struct {
+	Limit  int `form:"limit,default=20" json:"limit,omitempty" binding:"min=1"`
+	Offset int `form:"offset" json:"offset,omitempty" binding:"omitempty,min=0"`
+}
+
+func (q StockMovementQuery) Model(productID int64) bo.StockMovementQuery {
+	// Setup some default behavior
+	if q.Limit <= 0 {
+		q.Limit = 20
+	}
+	if q.Offset <= 0 {
+		q.Offset = 0
+	}
+
+	return bo.StockMovementQuery{
+		ProductID: productID,
+		Limit:     q.Limit,
+		Offset:    q.Offset,
+	}
+}
+
+type StockDiscrepancy struct {
+	WarehouseID    int64 `json:"warehouse_id"`
+	VariantID      int64 `json:"variant_id,omitempty"`
+	StockQuantity  int64 `json:"stock_quantity"`
+	LedgerQuantity int64 `json:"ledger_quantity"`
+}
+
+// StockReconciliation lists the stock rows of a product that disagree with the ledger
+type StockReconciliation struct {
+	ProductID     int64              `json:"product_id"`
+	Balanced      bool               `json:"balanced"`
+	Discrepancies []StockDiscrepancy `json:"discrepancies"`
+}
+
+func ToStockReconciliation(productID int64, bo []bo.StockDiscrepancy) StockReconciliation {
+	discrepancies := []StockDiscrepancy{}
+	for _, d := range bo {
+		discrepancies = append(discrepancies, StockDiscrepancy{
+			WarehouseID:    d.WarehouseID,
+			VariantID:      d.VariantID,
+			StockQuantity:  d.StockQuantity,
+			LedgerQuantity: d.LedgerQuantity,
+		})
+	}
+
+	return StockReconciliation{
+		ProductID:     productID,
+		Balanced:      len(discrepancies) == 0,
+		Discrepancies: discrepancies,
+	}
+}
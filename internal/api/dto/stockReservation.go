@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+)
+
+type StockReservation struct {
+	ID             int64     `json:"id"`
+	ProductStockID int64     `json:"product_stock_id"`
+	ProductID      int64     `json:"product_id"`
+	VariantID      int64     `json:"variant_id,omitempty"`
+	WarehouseID    int64     `json:"warehouse_id"`
+	Quantity       int64     `json:"quantity"`
+	Status         string    `json:"status" enums:"active,confirmed,released,expired"`
+	Reference      string    `json:"reference,omitempty"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func ToStockReservationDTO(bo bo.StockReservation) StockReservation {
+	return StockReservation{
+		ID:             bo.ID,
+		ProductStockID: bo.ProductStockID,
+		ProductID:      bo.ProductID,
+		VariantID:      bo.VariantID,
+		WarehouseID:    bo.WarehouseID,
+		Quantity:       bo.Quantity,
+		Status:         string(bo.Status),
+		Reference:      bo.Reference,
+		ExpiresAt:      bo.ExpiresAt,
+		CreatedAt:      bo.CreatedAt,
+		UpdatedAt:      bo.UpdatedAt,
+	}
+}
+
+// StockReservationRequest holds stock of a product (variant) for a checkout,
+// the warehouse is picked automatically when it is not given
+type StockReservationRequest struct {
+	ProductID   int64  `json:"product_id" binding:"required,min=1"`
+	VariantID   int64  `json:"variant_id,omitempty" binding:"omitempty,min=1"`
+	WarehouseID int64  `json:"warehouse_id,omitempty" binding:"omitempty,min=1"`
+	Quantity    int64  `json:"quantity" binding:"required,min=1"`
+	Reference   string `json:"reference,omitempty"`
+	// TTLSeconds defaults to 15 minutes
+	TTLSeconds int64 `json:"ttl_seconds,omitempty" binding:"omitempty,min=1,max=86400"`
+}
+
+func (r StockReservationRequest) Model() bo.StockReservation {
+	return bo.StockReservation{
+		ProductID:   r.ProductID,
+		VariantID:   r.VariantID,
+		WarehouseID: r.WarehouseID,
+		Quantity:    r.Quantity,
+		Reference:   r.Reference,
+		TTL:         time.Duration(r.TTLSeconds) * time.Second,
+	}
+}
@@ -61,6 +61,10 @@ type PaginatedSupplierCollection struct {
 	// This will always return the total of all records
 	Total int64              `json:"total"`
 	Data  SupplierCollection `json:"data"`
+
+	// The cursors of the neighbouring pages, pass them as after and before
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func ToPaginatedSupplier(bo bo.PaginatedSupplierCollection) PaginatedSupplierCollection {
@@ -70,15 +74,19 @@ func ToPaginatedSupplier(bo bo.PaginatedSupplierCollection) PaginatedSupplierCol
 	}
 
 	return PaginatedSupplierCollection{
-		Total: bo.Total,
-		Data:  suppliers,
+		Total:      bo.Total,
+		Data:       suppliers,
+		NextCursor: EncodeCursor(bo.Next),
+		PrevCursor: EncodeCursor(bo.Prev),
 	}
 }
 
 // SupplierQuery represent Category model query parameter
 type SupplierQuery struct {
-	Limit  int `form:"limit,default=20" json:"limit,omitempty" binding:"min=1"`
-	Offset int `form:"offset" json:"offset,omitempty" binding:"omitempty,min=0"`
+	Limit  int    `form:"limit,default=20" json:"limit,omitempty" binding:"min=1"`
+	Offset int    `form:"offset" json:"offset,omitempty" binding:"omitempty,min=0"`
+	After  string `form:"after" json:"after,omitempty"`
+	Before string `form:"before" json:"before,omitempty"`
 }
 
 func (q SupplierQuery) Model() bo.SupplierQuery {
@@ -91,7 +99,8 @@ func (q SupplierQuery) Model() bo.SupplierQuery {
 	}
 
 	return bo.SupplierQuery{
-		Limit:  q.Limit,
-		Offset: q.Offset,
+		Limit:       q.Limit,
+		Offset:      q.Offset,
+		PageCursors: toPageCursors(q.After, q.Before),
 	}
 }
@@ -0,0 +1,203 @@
+package dto
+
+import (
+	"time"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+// TaxRateURI binds the class and rate ids of a tax rate route
+type TaxRateURI struct {
+	ClassID int64 `uri:"id" binding:"required,min=1"`
+	RateID  int64 `uri:"rate_id" binding:"required,min=1"`
+}
+
+type TaxClass struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code" example:"standard"`
+	Name      string    `json:"name" example:"Standard rate"`
+	Default   bool      `json:"default"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ToTaxClassDTO(bo bo.TaxClass) TaxClass {
+	return TaxClass{
+		ID:        bo.ID,
+		Code:      bo.Code,
+		Name:      bo.Name,
+		Default:   bo.Default,
+		CreatedAt: bo.CreatedAt,
+	}
+}
+
+func ToTaxClassDTOs(bo bo.TaxClassCollection) []TaxClass {
+	classes := []TaxClass{}
+	for _, class := range bo {
+		classes = append(classes, ToTaxClassDTO(class))
+	}
+	return classes
+}
+
+// TaxClassRequest adds a class, a default class replaces the previous default
+type TaxClassRequest struct {
+	Code    string `json:"code" binding:"required,max=64" example:"reduced"`
+	Name    string `json:"name" binding:"required,max=255" example:"Reduced rate"`
+	Default bool   `json:"default"`
+}
+
+func (r TaxClassRequest) Model() bo.TaxClass {
+	return bo.TaxClass{
+		Code:    r.Code,
+		Name:    r.Name,
+		Default: r.Default,
+	}
+}
+
+// TaxClassUpdate renames a class or makes it the default one, the code cannot change
+type TaxClassUpdate struct {
+	Name    *string `json:"name" binding:"omitempty,max=255"`
+	Default *bool   `json:"default"`
+}
+
+func (u TaxClassUpdate) Model(classID int64) bo.TaxClassUpdate {
+	return bo.TaxClassUpdate{
+		ID:      classID,
+		Name:    u.Name,
+		Default: u.Default,
+	}
+}
+
+// TaxRate has its rate as an exact decimal percentage such as 15
+type TaxRate struct {
+	ID           int64           `json:"id"`
+	TaxClassID   int64           `json:"tax_class_id"`
+	Jurisdiction string          `json:"jurisdiction" example:"BD"`
+	Name         string          `json:"name" example:"VAT"`
+	Rate         decimal.Decimal `json:"rate" swaggertype:"number" example:"15"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+func ToTaxRateDTO(bo bo.TaxRate) TaxRate {
+	return TaxRate{
+		ID:           bo.ID,
+		TaxClassID:   bo.TaxClassID,
+		Jurisdiction: bo.Jurisdiction,
+		Name:         bo.Name,
+		Rate:         bo.Rate,
+		CreatedAt:    bo.CreatedAt,
+		UpdatedAt:    bo.UpdatedAt,
+	}
+}
+
+func ToTaxRateDTOs(bo bo.TaxRateCollection) []TaxRate {
+	rates := []TaxRate{}
+	for _, rate := range bo {
+		rates = append(rates, ToTaxRateDTO(rate))
+	}
+	return rates
+}
+
+// TaxRateRequest adds a rate to a class, the rates of a jurisdiction add up
+type TaxRateRequest struct {
+	// Jurisdiction is an ISO 3166 country or subdivision code, such as BD or US-CA
+	Jurisdiction string          `json:"jurisdiction" binding:"required,max=6" example:"BD"`
+	Name         string          `json:"name" binding:"required,max=255" example:"VAT"`
+	Rate         decimal.Decimal `json:"rate" swaggertype:"number" example:"15"`
+}
+
+func (r TaxRateRequest) Model(classID int64) bo.TaxRate {
+	return bo.TaxRate{
+		TaxClassID:   classID,
+		Jurisdiction: r.Jurisdiction,
+		Name:         r.Name,
+		Rate:         r.Rate,
+	}
+}
+
+// TaxRateUpdate renames a rate or changes its percentage, the jurisdiction cannot change
+type TaxRateUpdate struct {
+	Name *string          `json:"name" binding:"omitempty,max=255"`
+	Rate *decimal.Decimal `json:"rate" swaggertype:"number" example:"7.5"`
+}
+
+func (u TaxRateUpdate) Model(classID, rateID int64) bo.TaxRateUpdate {
+	return bo.TaxRateUpdate{
+		ID:         rateID,
+		TaxClassID: classID,
+		Name:       u.Name,
+		Rate:       u.Rate,
+	}
+}
+
+// TaxBreakdown is the tax per line and per rate. Gross is what is charged: the
+// prices themselves with inclusive pricing, the prices and the tax with exclusive pricing.
+type TaxBreakdown struct {
+	Pricing      string          `json:"pricing" enums:"inclusive,exclusive"`
+	Jurisdiction string          `json:"jurisdiction" example:"BD"`
+	Lines        []TaxLine       `json:"lines"`
+	Totals       []TaxTotal      `json:"totals"`
+	Net          decimal.Decimal `json:"net" swaggertype:"number"`
+	Tax          decimal.Decimal `json:"tax" swaggertype:"number"`
+	Gross        decimal.Decimal `json:"gross" swaggertype:"number"`
+}
+
+type TaxLine struct {
+	CartItemID int64           `json:"cart_item_id,omitempty"`
+	ProductID  int64           `json:"product_id"`
+	TaxClassID int64           `json:"tax_class_id,omitempty"`
+	Net        decimal.Decimal `json:"net" swaggertype:"number"`
+	Tax        decimal.Decimal `json:"tax" swaggertype:"number"`
+	Gross      decimal.Decimal `json:"gross" swaggertype:"number"`
+}
+
+// TaxTotal is what a rate levied over all the lines, on their taxable net amount
+type TaxTotal struct {
+	TaxRateID    int64           `json:"tax_rate_id,omitempty"`
+	Name         string          `json:"name" example:"VAT"`
+	Jurisdiction string          `json:"jurisdiction" example:"BD"`
+	Rate         decimal.Decimal `json:"rate" swaggertype:"number" example:"15"`
+	Taxable      decimal.Decimal `json:"taxable" swaggertype:"number"`
+	Tax          decimal.Decimal `json:"tax" swaggertype:"number"`
+}
+
+func ToTaxBreakdownDTO(bo bo.TaxBreakdown) TaxBreakdown {
+	lines := []TaxLine{}
+	for _, l := range bo.Lines {
+		lines = append(lines, TaxLine{
+			CartItemID: l.CartItemID,
+			ProductID:  l.ProductID,
+			TaxClassID: l.TaxClassID,
+			Net:        l.Net,
+			Tax:        l.Tax,
+			Gross:      l.Gross,
+		})
+	}
+
+	return TaxBreakdown{
+		Pricing:      string(bo.Pricing),
+		Jurisdiction: bo.Jurisdiction,
+		Lines:        lines,
+		Totals:       toTaxTotalDTOs(bo.Totals),
+		Net:          bo.Net,
+		Tax:          bo.Tax,
+		Gross:        bo.Gross,
+	}
+}
+
+func toTaxTotalDTOs(totals []bo.TaxTotal) []TaxTotal {
+	taxTotals := []TaxTotal{}
+	for _, t := range totals {
+		taxTotals = append(taxTotals, TaxTotal{
+			TaxRateID:    t.TaxRateID,
+			Name:         t.Name,
+			Jurisdiction: t.Jurisdiction,
+			Rate:         t.Rate,
+			Taxable:      t.Taxable,
+			Tax:          t.Tax,
+		})
+	}
+	return taxTotals
+}
@@ -0,0 +1,93 @@
+package dto
+
+import "techno-store/internal/domain/bo"
+
+type Warehouse struct {
+	ID       int64  `json:"id,omitempty"`
+	Code     string `json:"code" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Address  string `json:"address,omitempty"`
+	StatusID int64  `json:"status_id" binding:"required"`
+}
+
+func (w Warehouse) Model() bo.Warehouse {
+	return bo.Warehouse{
+		ID:       w.ID,
+		Code:     w.Code,
+		Name:     w.Name,
+		Address:  w.Address,
+		StatusID: w.StatusID,
+	}
+}
+
+type WarehouseUpdate struct {
+	ID       int64   `json:"id"`
+	Code     *string `json:"code"`
+	Name     *string `json:"name"`
+	Address  *string `json:"address"`
+	StatusID *int64  `json:"status_id"`
+}
+
+func (w WarehouseUpdate) Model() bo.WarehouseUpdate {
+	return bo.WarehouseUpdate{
+		ID:       w.ID,
+		Code:     w.Code,
+		Name:     w.Name,
+		Address:  w.Address,
+		StatusID: w.StatusID,
+	}
+}
+
+// WarehouseCollection array
+type WarehouseCollection []Warehouse
+
+// PaginatedWarehouseCollection model array with total record
+type PaginatedWarehouseCollection struct {
+	// This will always return the total of all records
+	Total int64               `json:"total"`
+	Data  WarehouseCollection `json:"data"`
+}
+
+func ToPaginatedWarehouse(bo bo.PaginatedWarehouseCollection) PaginatedWarehouseCollection {
+	warehouses := []Warehouse{}
+	for _, warehouse := range bo.Data {
+		warehouses = append(warehouses, ToWarehouseDTO(warehouse))
+	}
+
+	return PaginatedWarehouseCollection{
+		Total: bo.Total,
+		Data:  warehouses,
+	}
+}
+
+// WarehouseQuery represent Warehouse model query parameter
+type WarehouseQuery struct {
+	Limit  int `form:"limit,default=20" json:"limit,omitempty" binding:"min=1"`
+	Offset int `form:"offset" json:"offset,omitempty" binding:"omitempty,min=0"`
+}
+
+func (q WarehouseQuery) Model() bo.WarehouseQuery {
+	// Setup some default behavior
+	if q.Limit <= 0 {
+		q.Limit = 20
+	}
+	if q.Offset <= 0 {
+		q.Offset = 0
+	}
+
+	return bo.WarehouseQuery{
+		Limit:  q.Limit,
+		Offset: q.Offset,
+	}
+}
+
+// Convert BO to DTO
+func ToWarehouseDTO(bo bo.Warehouse) Warehouse {
+	return Warehouse{
+		ID:       bo.ID,
+		Code:     bo.Code,
+		Name:     bo.Name,
+		Address:  bo.Address,
+		StatusID: bo.StatusID,
+	}
+}
@@ -0,0 +1,270 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Get Cart godoc
+// @Summary      Get a Cart by id
+// @Description  Get a Cart with its items, totals and the current availability of every item
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Cart ID"
+// @Success      200  {object}  dto.Cart
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/carts/{id} [get]
+func (r *repos) getCart(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse cart id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getCartCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cart, err := services.Cart(r.ds.Cart).GetByID(getCartCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrCartNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("cart not found"))
+			return
+		}
+		slog.Error("unable to get cart from database: ", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToCartDTO(cart))
+}
+
+// Add Cart godoc
+// @Summary      Add a new Cart
+// @Description  Create a new empty Cart
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CartCreate  false  "Cart params"
+// @Success      201  {object}  dto.Cart
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/carts [post]
+func (r *repos) addCart(ctx *gin.Context) {
+	cartDto := dto.CartCreate{}
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&cartDto); err != nil {
+			slog.Error("unable to parse cart from request body", "cause", err)
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+			return
+		}
+	}
+
+	model := cartDto.Model()
+
+	addCartCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.Cart(r.ds.Cart).Create(addCartCtx, &model); err != nil {
+		slog.Error("unable to create cart", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToCartDTO(model))
+}
+
+// DeleteCart godoc
+// @Summary      Delete a Cart by id
+// @Description  Delete a Cart and its items
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Cart ID"
+// @Success      204  {string}  "Cart delete processed"
+// @Failure      400  {string} 	string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/carts/{id} [delete]
+func (r *repos) deleteCart(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse cart id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deleteCartCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.Cart(r.ds.Cart).Delete(deleteCartCtx, wrappedID.ID); err != nil {
+		if err == bo.ErrCartNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("cart not found"))
+			return
+		}
+		slog.Error("unable to delete cart", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "cart deleted"})
+}
+
+// AddCartItem godoc
+// @Summary      Add an item to a Cart
+// @Description  Add a product (variant) at its current price, adding it again raises the quantity, a product priced in another currency than the cart is rejected
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CartItemAdd  true  "Cart item params"
+// @Param        id   path      int  true  "Cart ID"
+// @Success      200  {object}  dto.Cart
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/carts/{id}/items [post]
+func (r *repos) addCartItem(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse cart id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var itemDto dto.CartItemAdd
+	if err := ctx.ShouldBindJSON(&itemDto); err != nil {
+		slog.Error("unable to parse cart item from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	model := itemDto.Model(wrappedID.ID)
+
+	addCartItemCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cart, err := services.Cart(r.ds.Cart).AddItem(addCartItemCtx, &model)
+	if err != nil {
+		switch err {
+		case bo.ErrCartNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("cart not found"))
+		case bo.ErrProductNotFound, bo.ErrProductVariantNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage(err.Error()))
+		case bo.ErrCartNotOpen, bo.ErrInsufficientStock, bo.ErrCartCurrencyMismatch:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to add cart item", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToCartDTO(cart))
+}
+
+// UpdateCartItem godoc
+// @Summary      Update the quantity of a Cart item
+// @Description  Update the quantity of a Cart item, the price snapshot is kept
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CartItemUpdate  true  "Cart item params"
+// @Param        id   path      int  true  "Cart ID"
+// @Param        item_id   path      int  true  "Cart item ID"
+// @Success      200  {object}  dto.Cart
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/carts/{id}/items/{item_id} [patch]
+func (r *repos) updateCartItem(ctx *gin.Context) {
+	var itemURI dto.CartItemURI
+	if err := ctx.ShouldBindUri(&itemURI); err != nil {
+		slog.Error("unable to parse cart item id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var itemDto dto.CartItemUpdate
+	if err := ctx.ShouldBindJSON(&itemDto); err != nil {
+		slog.Error("unable to parse cart item from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	updateCartItemCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cart, err := services.Cart(r.ds.Cart).UpdateItem(updateCartItemCtx, itemDto.Model(itemURI))
+	if err != nil {
+		switch err {
+		case bo.ErrCartNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("cart not found"))
+		case bo.ErrCartItemNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("cart item not found"))
+		case bo.ErrCartNotOpen, bo.ErrInsufficientStock:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to update cart item", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToCartDTO(cart))
+}
+
+// RemoveCartItem godoc
+// @Summary      Remove an item from a Cart
+// @Description  Remove an item from a Cart and recalculate its totals
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Cart ID"
+// @Param        item_id   path      int  true  "Cart item ID"
+// @Success      200  {object}  dto.Cart
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/carts/{id}/items/{item_id} [delete]
+func (r *repos) removeCartItem(ctx *gin.Context) {
+	var itemURI dto.CartItemURI
+	if err := ctx.ShouldBindUri(&itemURI); err != nil {
+		slog.Error("unable to parse cart item id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	removeCartItemCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cart, err := services.Cart(r.ds.Cart).RemoveItem(removeCartItemCtx, itemURI.CartID, itemURI.ItemID)
+	if err != nil {
+		switch err {
+		case bo.ErrCartNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("cart not found"))
+		case bo.ErrCartItemNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("cart item not found"))
+		case bo.ErrCartNotOpen:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to remove cart item", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToCartDTO(cart))
+}
@@ -0,0 +1,141 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"techno-store/config"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddCartItemAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appConfig, err := config.Parse()
+	if err != nil {
+		slog.Error("Error parsing config", "cause", err)
+	}
+
+	// Services are singletons, so every case shares one mockdb datastore
+	ds := mockdb.GetInstance(ctrl)
+	apiService := NewAPIService(*appConfig.Server, ds)
+	cartStore := ds.Cart.(*mockdb.MockCartRepository)
+
+	router := gin.Default()
+	apiService.InstallRoutes(router)
+
+	bdt := func(amount int64) bo.Money {
+		return bo.NewMoney(decimal.NewFromInt(amount), "BDT")
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func()
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"product_id": 7, "quantity": 2},
+			buildStubs: func() {
+				cartStore.EXPECT().
+					AddCartItem(gomock.Any(), gomock.Eq(&bo.CartItem{CartID: 1, ProductID: 7, Quantity: 2})).
+					Times(1).
+					Return(bo.Cart{
+						ID:            1,
+						Status:        bo.CartOpen,
+						Currency:      "BDT",
+						Subtotal:      bdt(200),
+						DiscountTotal: bdt(20),
+						Total:         bdt(180),
+						Items: []bo.CartItem{
+							{ID: 3, CartID: 1, ProductID: 7, Quantity: 2, UnitPrice: bdt(100), DiscountPrice: bdt(90), AvailableQuantity: 5},
+						},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"line_total":180`)
+				require.Contains(t, recorder.Body.String(), `"total":180`)
+				require.Contains(t, recorder.Body.String(), `"currency":"BDT"`)
+			},
+		},
+		{
+			name: "InsufficientStock",
+			body: gin.H{"product_id": 7, "quantity": 50},
+			buildStubs: func() {
+				cartStore.EXPECT().
+					AddCartItem(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(bo.Cart{}, bo.ErrInsufficientStock)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			body: gin.H{"product_id": 8, "quantity": 1},
+			buildStubs: func() {
+				cartStore.EXPECT().
+					AddCartItem(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(bo.Cart{}, bo.ErrCartCurrencyMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), bo.ErrCartCurrencyMismatch.Error())
+			},
+		},
+		{
+			name: "ProductNotFound",
+			body: gin.H{"product_id": 99, "quantity": 1},
+			buildStubs: func() {
+				cartStore.EXPECT().
+					AddCartItem(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(bo.Cart{}, bo.ErrProductNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "ZeroQuantity",
+			body:       gin.H{"product_id": 7, "quantity": 0},
+			buildStubs: func() {},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStubs()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/v1/carts/%d/items", 1)
+			req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -73,6 +73,80 @@ func (r *repos) getCategory(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, dto.ToCategoryDTO(category))
 }
 
+// Get Category Ancestors godoc
+// @Summary      Get the breadcrumb of a Category
+// @Description  Get the ancestors of a Category from its root down to the Category itself
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {array}   dto.Category
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/category/{id}/ancestors [get]
+func (r *repos) getCategoryAncestors(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse category id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getAncestorsCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ancestors, err := services.Category(r.ds.Category).Ancestors(getAncestorsCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrCategoryNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("category not found"))
+			return
+		}
+		slog.Error("unable to get category ancestors", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToCategoriesDTO(ancestors))
+}
+
+// Get Category Subtree godoc
+// @Summary      Get the subtree of a Category
+// @Description  Get a Category with all of its subcategories nested at any depth
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {object}  dto.CategoriesTree
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/category/{id}/subtree [get]
+func (r *repos) getCategorySubtree(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse category id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getSubtreeCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subtree, err := services.Category(r.ds.Category).Subtree(getSubtreeCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrCategoryNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("category not found"))
+			return
+		}
+		slog.Error("unable to get category subtree", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.CategoryCollectionToTree(subtree))
+}
+
 // Add Category godoc
 // @Summary      Add a new Category
 // @Description  Create a new Category in the system
@@ -100,6 +174,10 @@ func (r *repos) addCategory(ctx *gin.Context) {
 
 	id, err := services.Category(r.ds.Category).CreateCategory(addCategoryCtx, model)
 	if err != nil {
+		if err == bo.ErrTaxClassNotFound {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
 		slog.Error("unable to create category", "cause", err)
 		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
 		return
@@ -110,7 +188,7 @@ func (r *repos) addCategory(ctx *gin.Context) {
 
 // UpdateCategory godoc
 // @Summary      Update a Category by id
-// @Description  Update a Category by id
+// @Description  Update a Category by id, a new parent_id moves it along with its subcategories
 // @Tags         Category
 // @Accept       json
 // @Produce      json
@@ -119,6 +197,7 @@ func (r *repos) addCategory(ctx *gin.Context) {
 // @Success      204  {string}  "CategoryDto updated"
 // @Failure      400  {string} string  "Invalid request body"
 // @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
 // @Failure      500  {string}  string  "Error"
 // @Router       /v1/category/{id} [patch]
 func (r *repos) updateCategory(ctx *gin.Context) {
@@ -141,9 +220,16 @@ func (r *repos) updateCategory(ctx *gin.Context) {
 
 	categoryDto.ID = wrappedID.ID
 	if err := services.Category(r.ds.Category).UpdateCategory(updateCategoryCtx, categoryDto.Model()); err != nil {
-		if err == bo.ErrCategoryNotFound {
+		switch err {
+		case bo.ErrCategoryNotFound:
 			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("category not found"))
 			return
+		case bo.ErrCategoryParentNotFound, bo.ErrTaxClassNotFound:
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		case bo.ErrCategoryCycle:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+			return
 		}
 		slog.Error("unable to update category", "cause", err)
 		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
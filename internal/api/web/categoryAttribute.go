@@ -0,0 +1,137 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Get Category Attributes godoc
+// @Summary      Get the attribute template of a Category
+// @Description  Get the attributes of the products of a Category, the ones inherited from its parents first
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {array}   dto.CategoryAttribute
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/category/{id}/attributes [get]
+func (r *repos) getCategoryAttributes(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse category id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getAttributesCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attributes, err := services.Category(r.ds.Category).Attributes(getAttributesCtx, wrappedID.ID)
+	if err != nil {
+		slog.Error("unable to get category attributes", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToCategoryAttributesDTO(attributes))
+}
+
+// Add Category Attribute godoc
+// @Summary      Add an attribute to a Category
+// @Description  Add a typed attribute to the products of a Category and its subcategories. The code must not be defined by a parent or a subcategory.
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Param        request body dto.CategoryAttribute  true  "Attribute params"
+// @Success      201  {object}  dto.CategoryAttribute
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/category/{id}/attributes [post]
+func (r *repos) addCategoryAttribute(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse category id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var attributeDto dto.CategoryAttribute
+	if err := ctx.ShouldBindJSON(&attributeDto); err != nil {
+		slog.Error("unable to parse category attribute from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	addAttributeCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attributeDto.CategoryID = wrappedID.ID
+	attribute, err := services.Category(r.ds.Category).CreateAttribute(addAttributeCtx, attributeDto.Model())
+	if err != nil {
+		switch {
+		case errors.Is(err, bo.ErrInvalidCategoryAttribute):
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		case errors.Is(err, bo.ErrCategoryNotFound):
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("category not found"))
+			return
+		case errors.Is(err, bo.ErrCategoryAttributeExists):
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+			return
+		}
+		slog.Error("unable to create category attribute", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToCategoryAttributeDTO(attribute))
+}
+
+// Delete Category Attribute godoc
+// @Summary      Delete an attribute of a Category
+// @Description  Delete an attribute of a Category along with its values on the products of the Category and its subcategories
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Param        attribute_id   path      int  true  "Attribute ID"
+// @Success      204  {string}  "Category attribute delete processed"
+// @Failure      400  {string} 	string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/category/{id}/attributes/{attribute_id} [delete]
+func (r *repos) deleteCategoryAttribute(ctx *gin.Context) {
+	var uri dto.CategoryAttributeURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		slog.Error("unable to parse category attribute id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deleteAttributeCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.Category(r.ds.Category).DeleteAttribute(deleteAttributeCtx, uri.CategoryID, uri.AttributeID); err != nil {
+		if err == bo.ErrCategoryAttributeNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("category attribute not found"))
+			return
+		}
+		slog.Error("unable to delete category attribute", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "category attribute deleted"})
+}
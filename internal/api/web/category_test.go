@@ -0,0 +1,104 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"techno-store/config"
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCategoryTreeAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appConfig, err := config.Parse()
+	if err != nil {
+		slog.Error("Error parsing config", "cause", err)
+	}
+
+	ds := mockdb.GetInstance(ctrl)
+	apiService := NewAPIService(*appConfig.Server, ds)
+	router := gin.Default()
+	apiService.InstallRoutes(router)
+
+	categoryStore := ds.Category.(*mockdb.MockCategoryRepository)
+
+	t.Run("SubtreeAtAnyDepth", func(t *testing.T) {
+		categoryStore.EXPECT().
+			ListCategorySubtree(gomock.Any(), gomock.Eq(int64(1))).
+			Times(1).
+			Return(bo.CategoryCollection{
+				{ID: 1, Name: "mobile", ParentID: 9, Sequence: 1},
+				{ID: 3, Name: "android", ParentID: 1, Sequence: 2},
+				{ID: 2, Name: "ios", ParentID: 1, Sequence: 1},
+				{ID: 4, Name: "iphone", ParentID: 2, Sequence: 1},
+				{ID: 5, Name: "iphone 15", ParentID: 4, Sequence: 1},
+			}, nil)
+
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/v1/category/1/subtree", nil)
+		require.NoError(t, err)
+		router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var tree dto.CategoriesTree
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &tree))
+		require.Len(t, tree.Data, 1)
+		mobile := tree.Data[0]
+		require.Equal(t, int64(1), mobile.ID)
+		require.Len(t, mobile.Children, 2)
+		require.Equal(t, "ios", mobile.Children[0].Name)
+		require.Equal(t, "android", mobile.Children[1].Name)
+		require.Equal(t, "iphone 15", mobile.Children[0].Children[0].Children[0].Name)
+	})
+
+	t.Run("Breadcrumb", func(t *testing.T) {
+		categoryStore.EXPECT().
+			ListCategoryAncestors(gomock.Any(), gomock.Eq(int64(4))).
+			Times(1).
+			Return(bo.CategoryCollection{{ID: 1, Name: "mobile"}, {ID: 2, Name: "ios", ParentID: 1}, {ID: 4, Name: "iphone", ParentID: 2}}, nil)
+
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/v1/category/4/ancestors", nil)
+		require.NoError(t, err)
+		router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var breadcrumb []dto.Category
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &breadcrumb))
+		require.Equal(t, []string{"mobile", "ios", "iphone"}, []string{breadcrumb[0].Name, breadcrumb[1].Name, breadcrumb[2].Name})
+	})
+
+	t.Run("MoveUnderItself", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest("PATCH", "/v1/category/4", bytes.NewBufferString(`{"parent_id": 4}`))
+		require.NoError(t, err)
+		router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusConflict, recorder.Code)
+	})
+
+	t.Run("MoveUnderDescendant", func(t *testing.T) {
+		parentID := int64(5)
+		categoryStore.EXPECT().
+			UpdateCategory(gomock.Any(), gomock.Eq(bo.CategoryUpdate{ID: 2, ParentID: &parentID})).
+			Times(1).
+			Return(bo.ErrCategoryCycle)
+
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest("PATCH", "/v1/category/2", bytes.NewBufferString(`{"parent_id": 5}`))
+		require.NoError(t, err)
+		router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusConflict, recorder.Code)
+	})
+}
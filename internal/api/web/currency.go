@@ -0,0 +1,286 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxExchangeRateImportSize is the largest accepted exchange rate file, in bytes
+const maxExchangeRateImportSize = 1 << 20
+
+// GetExchangeRates godoc
+// @Summary      Get the exchange rates
+// @Description  Get the exchange rates by base and quote currency. A rate is also used the other way round, inverted, when the opposite pair has none.
+// @Tags         Currency
+// @Produce      json
+// @Success      200  {array}   dto.ExchangeRate
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/exchange-rates [get]
+func (r *repos) getExchangeRates(ctx *gin.Context) {
+	getRatesCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rates, err := services.Currency(r.ds.Currency).ExchangeRates(getRatesCtx)
+	if err != nil {
+		slog.Error("unable to get exchange rates", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToExchangeRateDTOs(rates))
+}
+
+// SetExchangeRates godoc
+// @Summary      Set exchange rates
+// @Description  Add or replace the rates of the given currency pairs, all of them or none. The rates of the other pairs are kept.
+// @Tags         Currency
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ExchangeRatesRequest  true  "exchange rates"
+// @Success      200  {array}   dto.ExchangeRate
+// @Failure      400  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/exchange-rates [put]
+func (r *repos) setExchangeRates(ctx *gin.Context) {
+	var ratesDto dto.ExchangeRatesRequest
+	if err := ctx.ShouldBindJSON(&ratesDto); err != nil {
+		slog.Error("unable to parse exchange rates from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	setRatesCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rates, err := services.Currency(r.ds.Currency).SetExchangeRates(setRatesCtx, ratesDto.Model())
+	if err != nil {
+		r.exchangeRateError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToExchangeRateDTOs(rates))
+}
+
+// ImportExchangeRates godoc
+// @Summary      Import exchange rates from a CSV file
+// @Description  Add or replace the rates of a CSV file with a base, quote and rate header row, all of them or none. The rates of the other pairs are kept.
+// @Tags         Currency
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "CSV file"
+// @Success      200  {array}   dto.ExchangeRate
+// @Failure      400  {object}  dto.Error
+// @Failure      413  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/exchange-rates/import [post]
+func (r *repos) importExchangeRates(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxExchangeRateImportSize+multipartOverhead)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, dto.Builder().SetMessage("the import file is too large"))
+			return
+		}
+		slog.Error("unable to parse the uploaded file", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+	if fileHeader.Size > maxExchangeRateImportSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, dto.Builder().SetMessage("the import file is too large"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		slog.Error("unable to open the uploaded file", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+	defer file.Close()
+
+	importCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rates, err := services.Currency(r.ds.Currency).ImportExchangeRates(importCtx, file)
+	if err != nil {
+		r.exchangeRateError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToExchangeRateDTOs(rates))
+}
+
+func (r *repos) exchangeRateError(ctx *gin.Context, err error) {
+	if errors.Is(err, bo.ErrInvalidExchangeRate) || errors.Is(err, bo.ErrInvalidCurrency) {
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		return
+	}
+	slog.Error("unable to set exchange rates", "cause", err)
+	ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+}
+
+// DeleteExchangeRate godoc
+// @Summary      Delete an exchange rate
+// @Description  Delete the rate of a currency pair
+// @Tags         Currency
+// @Produce      json
+// @Param        base   path      string  true  "Base currency"
+// @Param        quote  path      string  true  "Quote currency"
+// @Success      204  {string}  "Exchange rate delete processed"
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/exchange-rates/{base}/{quote} [delete]
+func (r *repos) deleteExchangeRate(ctx *gin.Context) {
+	var pair dto.ExchangeRatePairURI
+	if err := ctx.ShouldBindUri(&pair); err != nil {
+		slog.Error("unable to parse currency pair", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deleteRateCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.Currency(r.ds.Currency).DeleteExchangeRate(deleteRateCtx, pair.Base, pair.Quote); err != nil {
+		if err == bo.ErrExchangeRateNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("exchange rate not found"))
+			return
+		}
+		slog.Error("unable to delete exchange rate", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "exchange rate deleted"})
+}
+
+// GetProductCurrencyPrices godoc
+// @Summary      Get the currency prices of a product
+// @Description  The prices of a product in other currencies, used instead of converting its prices
+// @Tags         Currency
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {array}   dto.ProductCurrencyPrice
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/currency-prices [get]
+func (r *repos) getProductCurrencyPrices(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getPricesCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	prices, err := services.Currency(r.ds.Currency).ProductPrices(getPricesCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrProductNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product not found"))
+			return
+		}
+		slog.Error("unable to get product currency prices", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToProductCurrencyPriceDTOs(prices))
+}
+
+// SetProductCurrencyPrice godoc
+// @Summary      Set the prices of a product in a currency
+// @Description  Products listed in that currency show these prices instead of their converted prices. The prices cannot have more decimal places than the currency.
+// @Tags         Currency
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int     true  "Product ID"
+// @Param        currency  path      string  true  "Currency"
+// @Param        request body dto.ProductCurrencyPriceRequest  true  "currency prices"
+// @Success      200  {object}  dto.ProductCurrencyPrice
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/currency-prices/{currency} [put]
+func (r *repos) setProductCurrencyPrice(ctx *gin.Context) {
+	var priceURI dto.ProductCurrencyPriceURI
+	if err := ctx.ShouldBindUri(&priceURI); err != nil {
+		slog.Error("unable to parse product currency price uri", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var priceDto dto.ProductCurrencyPriceRequest
+	if err := ctx.ShouldBindJSON(&priceDto); err != nil {
+		slog.Error("unable to parse currency price from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	setPriceCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	price, err := services.Currency(r.ds.Currency).SetProductPrice(setPriceCtx, priceDto.Model(priceURI.ProductID, priceURI.Currency))
+	if err != nil {
+		switch {
+		case errors.Is(err, bo.ErrInvalidCurrencyPrice) || errors.Is(err, bo.ErrInvalidCurrency):
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		case err == bo.ErrProductNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product not found"))
+		default:
+			slog.Error("unable to set product currency price", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToProductCurrencyPriceDTO(price))
+}
+
+// DeleteProductCurrencyPrice godoc
+// @Summary      Delete the prices of a product in a currency
+// @Description  The prices of the product are converted again when it is listed in that currency
+// @Tags         Currency
+// @Produce      json
+// @Param        id        path      int     true  "Product ID"
+// @Param        currency  path      string  true  "Currency"
+// @Success      204  {string}  "Product currency price delete processed"
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/currency-prices/{currency} [delete]
+func (r *repos) deleteProductCurrencyPrice(ctx *gin.Context) {
+	var priceURI dto.ProductCurrencyPriceURI
+	if err := ctx.ShouldBindUri(&priceURI); err != nil {
+		slog.Error("unable to parse product currency price uri", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deletePriceCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.Currency(r.ds.Currency).DeleteProductPrice(deletePriceCtx, priceURI.ProductID, priceURI.Currency); err != nil {
+		if err == bo.ErrProductCurrencyPriceNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product currency price not found"))
+			return
+		}
+		slog.Error("unable to delete product currency price", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "product currency price deleted"})
+}
@@ -2,12 +2,17 @@ package web
 
 import (
 	"log/slog"
+	"reflect"
 
 	"techno-store/config"
+	"techno-store/internal/domain/bo"
 	"techno-store/internal/domain/definition"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
 )
 
 func CORS(router *gin.Engine) {
@@ -39,8 +44,14 @@ func CORS(router *gin.Engine) {
 }
 
 type repos struct {
-	config config.ServerConfig
-	ds     definition.DataStore
+	config   config.ServerConfig
+	ds       definition.DataStore
+	payments definition.PaymentGateway
+	search   config.SearchConfig
+	blobs    definition.BlobStore
+	media    config.MediaConfig
+	feed     bo.ProductFeedSettings
+	tax      bo.TaxSettings
 }
 
 func NewAPIService(cfg config.ServerConfig, ds definition.DataStore) *repos {
@@ -50,8 +61,55 @@ func NewAPIService(cfg config.ServerConfig, ds definition.DataStore) *repos {
 	}
 }
 
+// WithPaymentGateway sets the provider used by the payment routes
+func (r *repos) WithPaymentGateway(gateway definition.PaymentGateway) *repos {
+	r.payments = gateway
+	return r
+}
+
+// WithSearchConfig sets the suggestion cache of the search routes
+func (r *repos) WithSearchConfig(cfg config.SearchConfig) *repos {
+	r.search = cfg
+	return r
+}
+
+// WithMediaStore sets the blob store and the upload limit of the product media routes
+func (r *repos) WithMediaStore(blobs definition.BlobStore, cfg config.MediaConfig) *repos {
+	r.blobs = blobs
+	r.media = cfg
+	return r
+}
+
+// WithProductFeed sets how the store is described in the product feed
+func (r *repos) WithProductFeed(settings bo.ProductFeedSettings) *repos {
+	r.feed = settings
+	return r
+}
+
+// WithTaxSettings sets the pricing and the default jurisdiction of the tax calculation
+func (r *repos) WithTaxSettings(settings bo.TaxSettings) *repos {
+	r.tax = settings
+	return r
+}
+
+// registerDecimalValidation validates the decimal amounts of the requests as numbers,
+// so that rules like gt=0 apply to them
+func registerDecimalValidation() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		if amount, ok := field.Interface().(decimal.Decimal); ok {
+			return amount.InexactFloat64()
+		}
+		return nil
+	}, decimal.Decimal{})
+}
+
 func (r *repos) InstallRoutes(router *gin.Engine) {
 	CORS(router)
+	registerDecimalValidation()
 	router.GET("", health)
 	router.GET("/health", health)
 
@@ -74,6 +132,11 @@ func (r *repos) InstallRoutes(router *gin.Engine) {
 	{
 		categoriesGroup.GET("", r.getCategories)
 		categoryGroup.GET("/:id", r.getCategory)
+		categoryGroup.GET("/:id/ancestors", r.getCategoryAncestors)
+		categoryGroup.GET("/:id/subtree", r.getCategorySubtree)
+		categoryGroup.GET("/:id/attributes", r.getCategoryAttributes)
+		categoryGroup.POST("/:id/attributes", r.addCategoryAttribute)
+		categoryGroup.DELETE("/:id/attributes/:attribute_id", r.deleteCategoryAttribute)
 		categoryGroup.POST("", r.addCategory)
 		categoryGroup.PATCH("/:id", r.updateCategory)
 		categoryGroup.DELETE("/:id", r.deleteCategory)
@@ -84,10 +147,36 @@ func (r *repos) InstallRoutes(router *gin.Engine) {
 	productGroup := v1.Group("/product")
 	{
 		productsGroup.GET("", r.getProducts)
+		productsGroup.GET("/facets", r.getProductFacets)
+		productsGroup.POST("/import", r.importProducts)
+		productsGroup.GET("/export", r.exportProducts)
 		productGroup.GET("/:id", r.getProduct)
 		productGroup.POST("", r.addProduct)
 		productGroup.PATCH("/:id", r.updateProduct)
 		productGroup.DELETE("/:id", r.deleteProduct)
+
+		productGroup.GET("/:id/variants", r.getProductVariants)
+		productGroup.POST("/:id/variants", r.addProductVariant)
+		productGroup.GET("/:id/variants/:variant_id", r.getProductVariant)
+		productGroup.PATCH("/:id/variants/:variant_id", r.updateProductVariant)
+		productGroup.DELETE("/:id/variants/:variant_id", r.deleteProductVariant)
+
+		productGroup.GET("/:id/media", r.getProductMedia)
+		productGroup.POST("/:id/media", r.uploadProductMedia)
+		productGroup.PATCH("/:id/media/:media_id", r.updateProductMedia)
+		productGroup.DELETE("/:id/media/:media_id", r.deleteProductMedia)
+		productGroup.POST("/:id/media/:media_id/renditions", r.reprocessProductMedia)
+
+		productGroup.GET("/:id/stock", r.getProductStockLevel)
+		productGroup.GET("/:id/stock/movements", r.getStockMovements)
+		productGroup.GET("/:id/stock/reconciliation", r.getStockReconciliation)
+		productGroup.GET("/:id/price", r.getProductPrice)
+		productGroup.GET("/:id/price-timeline", r.getProductPrices)
+		productGroup.POST("/:id/price-schedules", r.addProductPriceSchedule)
+		productGroup.DELETE("/:id/price-schedules/:schedule_id", r.cancelProductPriceSchedule)
+		productGroup.GET("/:id/currency-prices", r.getProductCurrencyPrices)
+		productGroup.PUT("/:id/currency-prices/:currency", r.setProductCurrencyPrice)
+		productGroup.DELETE("/:id/currency-prices/:currency", r.deleteProductCurrencyPrice)
 	}
 
 	// Supplier group
@@ -110,5 +199,125 @@ func (r *repos) InstallRoutes(router *gin.Engine) {
 		productStockGroup.POST("", r.addProductStock)
 		productStockGroup.PATCH("/:id", r.updateProductStock)
 		productStockGroup.DELETE("/:id", r.deleteProductStock)
+		productStockGroup.POST("/:id/adjustments", r.adjustProductStock)
+	}
+
+	// Warehouse group
+	warehousesGroup := v1.Group("/warehouses")
+	warehouseGroup := v1.Group("/warehouse")
+	{
+		warehousesGroup.GET("", r.getWarehouses)
+		warehouseGroup.GET("/:id", r.getWarehouse)
+		warehouseGroup.POST("", r.addWarehouse)
+		warehouseGroup.PATCH("/:id", r.updateWarehouse)
+		warehouseGroup.DELETE("/:id", r.deleteWarehouse)
+	}
+
+	// StockReservation group
+	stockReservationGroup := v1.Group("/stock-reservation")
+	{
+		stockReservationGroup.GET("/:id", r.getStockReservation)
+		stockReservationGroup.POST("", r.addStockReservation)
+		stockReservationGroup.POST("/:id/confirm", r.confirmStockReservation)
+		stockReservationGroup.POST("/:id/release", r.releaseStockReservation)
+	}
+
+	// Cart group
+	cartsGroup := v1.Group("/carts")
+	{
+		cartsGroup.POST("", r.addCart)
+		cartsGroup.GET("/:id", r.getCart)
+		cartsGroup.DELETE("/:id", r.deleteCart)
+		cartsGroup.POST("/:id/items", r.addCartItem)
+		cartsGroup.PATCH("/:id/items/:item_id", r.updateCartItem)
+		cartsGroup.DELETE("/:id/items/:item_id", r.removeCartItem)
+		cartsGroup.GET("/:id/price", r.getCartPrice)
 	}
+
+	// Order group
+	ordersGroup := v1.Group("/orders")
+	orderGroup := v1.Group("/order")
+	{
+		ordersGroup.GET("", r.getOrders)
+		orderGroup.GET("/:id", r.getOrder)
+		orderGroup.POST("", r.placeOrder)
+		orderGroup.POST("/:id/transitions", r.transitionOrder)
+		orderGroup.GET("/:id/payments", r.getOrderPayments)
+		orderGroup.POST("/:id/payments", r.authorizePayment)
+		orderGroup.GET("/:id/returns", r.getOrderReturns)
+		orderGroup.POST("/:id/returns", r.requestReturn)
+	}
+
+	// Payment group
+	paymentsGroup := v1.Group("/payments")
+	paymentGroup := v1.Group("/payment")
+	{
+		paymentsGroup.POST("/webhook", r.paymentWebhook)
+		paymentGroup.GET("/:id", r.getPayment)
+		paymentGroup.POST("/:id/capture", r.capturePayment)
+		paymentGroup.POST("/:id/refund", r.refundPayment)
+		paymentGroup.POST("/:id/void", r.voidPayment)
+	}
+
+	// Return group
+	returnGroup := v1.Group("/return")
+	{
+		returnGroup.GET("/:id", r.getReturn)
+		returnGroup.POST("/:id/approve", r.approveReturn)
+		returnGroup.POST("/:id/reject", r.rejectReturn)
+		returnGroup.POST("/:id/receive", r.receiveReturn)
+		returnGroup.POST("/:id/refund", r.refundReturn)
+	}
+
+	// Promotion group
+	promotionsGroup := v1.Group("/promotions")
+	promotionGroup := v1.Group("/promotion")
+	{
+		promotionsGroup.GET("", r.getPromotions)
+		promotionGroup.GET("/:id", r.getPromotion)
+		promotionGroup.POST("", r.addPromotion)
+		promotionGroup.PATCH("/:id", r.updatePromotion)
+		promotionGroup.DELETE("/:id", r.deletePromotion)
+	}
+
+	// Search group
+	searchGroup := v1.Group("/search")
+	{
+		searchGroup.GET("/suggest", r.suggest)
+		searchGroup.GET("/synonyms", r.getSearchSynonyms)
+		searchGroup.POST("/synonyms", r.addSearchSynonym)
+		searchGroup.DELETE("/synonym/:id", r.deleteSearchSynonym)
+	}
+
+	// Exchange rate group
+	exchangeRatesGroup := v1.Group("/exchange-rates")
+	{
+		exchangeRatesGroup.GET("", r.getExchangeRates)
+		exchangeRatesGroup.PUT("", r.setExchangeRates)
+		exchangeRatesGroup.POST("/import", r.importExchangeRates)
+		exchangeRatesGroup.DELETE("/:base/:quote", r.deleteExchangeRate)
+	}
+
+	// Tax group
+	taxClassesGroup := v1.Group("/tax-classes")
+	taxClassGroup := v1.Group("/tax-class")
+	{
+		taxClassesGroup.GET("", r.getTaxClasses)
+		taxClassGroup.POST("", r.addTaxClass)
+		taxClassGroup.PATCH("/:id", r.updateTaxClass)
+		taxClassGroup.DELETE("/:id", r.deleteTaxClass)
+		taxClassGroup.GET("/:id/rates", r.getTaxRates)
+		taxClassGroup.POST("/:id/rates", r.addTaxRate)
+		taxClassGroup.PATCH("/:id/rates/:rate_id", r.updateTaxRate)
+		taxClassGroup.DELETE("/:id/rates/:rate_id", r.deleteTaxRate)
+	}
+
+	// Feed group
+	feedsGroup := v1.Group("/feeds")
+	{
+		feedsGroup.GET("/google", r.getGoogleFeed)
+	}
+
+	// Media files, served through the API by the default MEDIA_PUBLIC_URL
+	v1.GET("/media/*key", r.getMediaFile)
 }
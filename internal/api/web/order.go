@@ -0,0 +1,198 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Get Orders godoc
+// @Summary      Get Orders
+// @Description  Get Orders, newest first, optionally filtered by status
+// @Tags         Order
+// @Accept       json
+// @Produce      json
+// @Param        limit   query   int  false  "limit"
+// @Param        offset  query   int  false  "offset"
+// @Param        status  query   string  false  "status"  Enums(pending, paid, packed, shipped, delivered, cancelled, refunded)
+// @Success      200  {object}  dto.PaginatedOrderCollection
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/orders [get]
+func (r *repos) getOrders(ctx *gin.Context) {
+	var orderQueryDto dto.OrderQuery
+	if err := ctx.ShouldBindQuery(&orderQueryDto); err != nil {
+		slog.Error("unable to parse query url", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getOrdersCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pbc, err := services.Order(r.ds.Order).List(getOrdersCtx, orderQueryDto.Model())
+	if err != nil {
+		slog.Error("unable to get orders", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPaginatedOrder(pbc))
+}
+
+// Get Order godoc
+// @Summary      Get an Order by id
+// @Description  Get an Order with its items
+// @Tags         Order
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  dto.Order
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/order/{id} [get]
+func (r *repos) getOrder(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse order id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getOrderCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	order, err := services.Order(r.ds.Order).GetByID(getOrderCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrOrderNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("order not found"))
+			return
+		}
+		slog.Error("unable to get order from database: ", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToOrderDTO(order))
+}
+
+// PlaceOrder godoc
+// @Summary      Place an Order
+// @Description  Place a pending Order for the content of an open Cart, the ordered stock is taken off hand.
+// @Description  Items are charged their promotional price, including the promotion of the coupon code.
+// @Description  The charged prices are taxed in the jurisdiction, the tax is added to the total with exclusive pricing.
+// @Tags         Order
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.OrderPlacement  true  "Order params"
+// @Success      201  {object}  dto.Order
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/order [post]
+func (r *repos) placeOrder(ctx *gin.Context) {
+	placementDto := dto.OrderPlacement{}
+	if err := ctx.ShouldBindJSON(&placementDto); err != nil {
+		slog.Error("unable to parse order from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	placeOrderCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the cart is priced with the current promotions before it is locked for placement
+	placement := placementDto.Model()
+	quote, err := services.Pricing(r.ds.Promotion, r.ds.Product, r.ds.Cart, r.ds.Currency).
+		QuoteCart(placeOrderCtx, placement.CartID, placement.CouponCode)
+	if err == nil {
+		err = r.taxQuote(placeOrderCtx, &quote, placement.Jurisdiction)
+	}
+
+	var order bo.Order
+	if err == nil {
+		placement.Quote = quote
+		order, err = services.Order(r.ds.Order).Place(placeOrderCtx, placement)
+	}
+	if err != nil {
+		if errors.Is(err, bo.ErrInvalidJurisdiction) {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
+		switch err {
+		case bo.ErrCartNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("cart not found"))
+		case bo.ErrCartEmpty, bo.ErrCouponNotFound:
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		case bo.ErrCartNotOpen, bo.ErrInsufficientStock, bo.ErrPromotionUsageExceeded:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to place order", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToOrderDTO(order))
+}
+
+// TransitionOrder godoc
+// @Summary      Move an Order to its next status
+// @Description  pending → paid → packed → shipped → delivered, an Order can be cancelled before it is shipped
+// @Description  and refunded once paid. Cancelling or refunding before shipping restocks the ordered quantity.
+// @Tags         Order
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.OrderTransition  true  "Transition params"
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  dto.Order
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/order/{id}/transitions [post]
+func (r *repos) transitionOrder(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse order id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var transitionDto dto.OrderTransition
+	if err := ctx.ShouldBindJSON(&transitionDto); err != nil {
+		slog.Error("unable to parse order transition from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	transitionOrderCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	order, err := services.Order(r.ds.Order).Transition(transitionOrderCtx, wrappedID.ID, bo.OrderStatus(transitionDto.Status), transitionDto.ChangedBy)
+	if err != nil {
+		switch err {
+		case bo.ErrOrderNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("order not found"))
+		case bo.ErrInvalidOrderStatus:
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		case bo.ErrInvalidOrderTransition, bo.ErrOrderStatusConflict:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to transition order", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToOrderDTO(order))
+}
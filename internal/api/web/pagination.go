@@ -0,0 +1,35 @@
+package web
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setPageLinks sets the RFC 8288 Link header of the neighbouring pages of a
+// keyset paged list. Their url is the request's with a cursor instead of an offset.
+func setPageLinks(ctx *gin.Context, next, prev string) {
+	var links []string
+	for _, page := range []struct{ rel, param, cursor string }{
+		{rel: "next", param: "after", cursor: next},
+		{rel: "prev", param: "before", cursor: prev},
+	} {
+		if page.cursor == "" {
+			continue
+		}
+
+		pageURL := *ctx.Request.URL
+		values := pageURL.Query()
+		values.Del("offset")
+		values.Del("after")
+		values.Del("before")
+		values.Set(page.param, page.cursor)
+		pageURL.RawQuery = values.Encode()
+		links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, pageURL.RequestURI(), page.rel))
+	}
+
+	if len(links) > 0 {
+		ctx.Header("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,310 @@
+package web
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// paymentSignatureHeader carries the provider signature of a webhook payload
+const paymentSignatureHeader = "X-Payment-Signature"
+
+// Get Order Payments godoc
+// @Summary      Get the Payments of an Order
+// @Description  Get every Payment attempt of an Order, oldest first
+// @Tags         Payment
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {array}   dto.Payment
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/order/{id}/payments [get]
+func (r *repos) getOrderPayments(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse order id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getOrderPaymentsCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	payments, err := services.Payment(r.ds.Payment, r.ds.Order, r.payments).ListByOrder(getOrderPaymentsCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrOrderNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("order not found"))
+			return
+		}
+		slog.Error("unable to get order payments", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPaymentCollectionDTO(payments))
+}
+
+// AuthorizePayment godoc
+// @Summary      Authorize a Payment for an Order
+// @Description  Hold the total of a pending Order on a tokenized payment method, a declined attempt is stored as failed
+// @Tags         Payment
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.PaymentAuthorization  true  "Authorization params"
+// @Param        id   path      int  true  "Order ID"
+// @Success      201  {object}  dto.Payment
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      402  {object}  dto.Payment
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/order/{id}/payments [post]
+func (r *repos) authorizePayment(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse order id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var authorizationDto dto.PaymentAuthorization
+	if err := ctx.ShouldBindJSON(&authorizationDto); err != nil {
+		slog.Error("unable to parse payment authorization from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	authorizePaymentCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	payment, err := services.Payment(r.ds.Payment, r.ds.Order, r.payments).Authorize(authorizePaymentCtx, wrappedID.ID, authorizationDto.Token)
+	if err != nil {
+		switch err {
+		case bo.ErrPaymentDeclined:
+			ctx.JSON(http.StatusPaymentRequired, dto.ToPaymentDTO(payment))
+		case bo.ErrOrderNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("order not found"))
+		case bo.ErrOrderNotPayable:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to authorize payment", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToPaymentDTO(payment))
+}
+
+// Get Payment godoc
+// @Summary      Get a Payment by id
+// @Description  Get a Payment by id
+// @Tags         Payment
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Payment ID"
+// @Success      200  {object}  dto.Payment
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/payment/{id} [get]
+func (r *repos) getPayment(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse payment id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getPaymentCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	payment, err := services.Payment(r.ds.Payment, r.ds.Order, r.payments).GetByID(getPaymentCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrPaymentNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("payment not found"))
+			return
+		}
+		slog.Error("unable to get payment from database: ", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPaymentDTO(payment))
+}
+
+// CapturePayment godoc
+// @Summary      Capture a Payment
+// @Description  Collect an authorized Payment, its Order is marked as paid
+// @Tags         Payment
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Payment ID"
+// @Success      200  {object}  dto.Payment
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/payment/{id}/capture [post]
+func (r *repos) capturePayment(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse payment id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	capturePaymentCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	payment, err := services.Payment(r.ds.Payment, r.ds.Order, r.payments).Capture(capturePaymentCtx, wrappedID.ID)
+	if err != nil {
+		r.paymentError(ctx, err, "unable to capture payment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPaymentDTO(payment))
+}
+
+// RefundPayment godoc
+// @Summary      Refund a Payment
+// @Description  Refund part of a captured Payment, or all of it. A full refund also refunds its Order.
+// @Tags         Payment
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.PaymentRefund  false  "Refund params"
+// @Param        id   path      int  true  "Payment ID"
+// @Success      200  {object}  dto.Payment
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/payment/{id}/refund [post]
+func (r *repos) refundPayment(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse payment id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var refundDto dto.PaymentRefund
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&refundDto); err != nil {
+			slog.Error("unable to parse payment refund from request body", "cause", err)
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+			return
+		}
+	}
+
+	refundPaymentCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	payment, err := services.Payment(r.ds.Payment, r.ds.Order, r.payments).Refund(refundPaymentCtx, wrappedID.ID, refundDto.Amount)
+	if err != nil {
+		r.paymentError(ctx, err, "unable to refund payment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPaymentDTO(payment))
+}
+
+// VoidPayment godoc
+// @Summary      Void a Payment
+// @Description  Release an authorized Payment which was not captured
+// @Tags         Payment
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Payment ID"
+// @Success      200  {object}  dto.Payment
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/payment/{id}/void [post]
+func (r *repos) voidPayment(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse payment id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	voidPaymentCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	payment, err := services.Payment(r.ds.Payment, r.ds.Order, r.payments).Void(voidPaymentCtx, wrappedID.ID)
+	if err != nil {
+		r.paymentError(ctx, err, "unable to void payment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPaymentDTO(payment))
+}
+
+// PaymentWebhook godoc
+// @Summary      Payment provider callback
+// @Description  Apply a payment status change reported by the provider. Events are applied once,
+// @Description  a repeated delivery returns the payment unchanged.
+// @Tags         Payment
+// @Accept       json
+// @Produce      json
+// @Param        X-Payment-Signature  header  string  false  "Provider signature of the payload"
+// @Success      200  {object}  dto.Payment
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/payments/webhook [post]
+func (r *repos) paymentWebhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		slog.Error("unable to read payment webhook body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	paymentWebhookCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	payment, err := services.Payment(r.ds.Payment, r.ds.Order, r.payments).
+		HandleWebhook(paymentWebhookCtx, payload, ctx.GetHeader(paymentSignatureHeader))
+	if err != nil {
+		switch err {
+		case bo.ErrInvalidWebhookSignature:
+			ctx.JSON(http.StatusUnauthorized, dto.Builder().SetMessage(err.Error()))
+		case bo.ErrInvalidWebhookPayload:
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		case bo.ErrPaymentNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("payment not found"))
+		default:
+			slog.Error("unable to apply payment webhook", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPaymentDTO(payment))
+}
+
+// paymentError maps the errors shared by the payment operations
+func (r *repos) paymentError(ctx *gin.Context, err error, message string) {
+	switch err {
+	case bo.ErrPaymentNotFound:
+		ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("payment not found"))
+	case bo.ErrInvalidPaymentTransition, bo.ErrInvalidRefundAmount:
+		ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+	default:
+		slog.Error(message, "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+	}
+}
@@ -0,0 +1,133 @@
+package web
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"techno-store/config"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+	"techno-store/internal/infrastructure/payments"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPaymentWebhookAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appConfig, err := config.Parse()
+	if err != nil {
+		slog.Error("Error parsing config", "cause", err)
+	}
+
+	// Services are singletons, so every case shares one mockdb datastore
+	ds := mockdb.GetInstance(ctrl)
+	gateway := payments.NewFakeGateway("s3cret", 0)
+	apiService := NewAPIService(*appConfig.Server, ds).WithPaymentGateway(gateway)
+	paymentStore := ds.Payment.(*mockdb.MockPaymentRepository)
+	orderStore := ds.Order.(*mockdb.MockOrderRepository)
+
+	router := gin.Default()
+	apiService.InstallRoutes(router)
+
+	captured := []byte(`{"id":"evt_1","type":"payment.captured","payment":"fake_pay_a_1","amount":25}`)
+	event := bo.PaymentEvent{
+		EventID:           "evt_1",
+		Provider:          payments.FakeProvider,
+		ProviderReference: "fake_pay_a_1",
+		Status:            bo.PaymentCaptured,
+		Amount:            decimal.RequireFromString("25"),
+	}
+	payment := bo.Payment{ID: 9, OrderID: 4, Provider: payments.FakeProvider, Status: bo.PaymentCaptured, Amount: event.Amount, CapturedAmount: event.Amount}
+
+	testCases := []struct {
+		name          string
+		payload       []byte
+		signature     string
+		buildStubs    func()
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Captured",
+			payload:   captured,
+			signature: gateway.Sign(captured),
+			buildStubs: func() {
+				paymentStore.EXPECT().
+					ApplyPaymentEvent(gomock.Any(), gomock.Eq(event)).
+					Times(1).
+					Return(payment, true, nil)
+				orderStore.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(int64(4))).
+					Times(1).
+					Return(bo.Order{ID: 4, Status: bo.OrderPending}, nil)
+				orderStore.EXPECT().
+					UpdateOrderStatus(gomock.Any(), gomock.Eq(bo.OrderStatusChange{
+						OrderID:   4,
+						From:      bo.OrderPending,
+						To:        bo.OrderPaid,
+						ChangedBy: payments.FakeProvider,
+					})).
+					Times(1).
+					Return(bo.Order{ID: 4, Status: bo.OrderPaid}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"status":"captured"`)
+			},
+		},
+		{
+			name:      "Redelivered",
+			payload:   captured,
+			signature: gateway.Sign(captured),
+			buildStubs: func() {
+				paymentStore.EXPECT().
+					ApplyPaymentEvent(gomock.Any(), gomock.Eq(event)).
+					Times(1).
+					Return(payment, false, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"status":"captured"`)
+			},
+		},
+		{
+			name:       "InvalidSignature",
+			payload:    captured,
+			signature:  "forged",
+			buildStubs: func() {},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidPayload",
+			payload:    []byte(`{"id":"evt_2","type":"payment.lost"}`),
+			signature:  gateway.Sign([]byte(`{"id":"evt_2","type":"payment.lost"}`)),
+			buildStubs: func() {},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStubs()
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest("POST", "/v1/payments/webhook", bytes.NewReader(tc.payload))
+			require.NoError(t, err)
+			req.Header.Set(paymentSignatureHeader, tc.signature)
+
+			router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package web
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -18,18 +19,23 @@ import (
 // @Tags         Product
 // @Accept       json
 // @Produce      json
-// @Param        q       query  string  false  "q is query string of product name"
+// @Param        q       query  string  false  "q searches the name, description, specifications, tags, brand and category"
 // @Param        brand   query   []int  false  "brand"
-// @Param        category  query   int  false  "category"
+// @Param        category  query   int  false  "category, including its subcategories"
 // @Param        supplier  query   int  false  "supplier"
 // @Param        verified_supplier  query   bool  false  "verified_supplier"
 // @Param        min_price  query   float64  false  "min_price"
 // @Param        max_price  query   float64  false  "max_price"
-// @Param        sort  query   string  false  "sort"
-// @Param        order  query   string  false  "order"
+// @Param        attr[code]  query   string  false  "attr[code] filters on a filterable attribute of the category: comma separated enum options, true or false, a number or a min..max range"
+// @Param        sort  query   string  false  "sort, one of id, name, unit_price or discount_price, by default the relevance of q or unit_price"
+// @Param        order  query   string  false  "order, ASC or DESC"
 // @Param        limit   query   int  false  "limit"
-// @Param        offset  query   int  false  "offset"
+// @Param        offset  query   int  false  "page index, in pages of limit products, ignored with after or before"
+// @Param        after   query   string  false  "after, the next_cursor of the previous page"
+// @Param        before  query   string  false  "before, the prev_cursor of the next page"
+// @Param        currency  query  string  false  "currency, an ISO 4217 code to list the prices in: the prices set for the product in that currency, else its prices converted at the exchange rate and rounded half away from zero to the minor units of the currency. min_price, max_price and sort apply to the prices in the currency of each product."
 // @Success      200  {object}  dto.PaginatedProduct
+// @Header       200  {string}  Link  "the next and prev pages"
 // @Failure      400  {string} string  "Invalid request body"
 // @Failure      500  {string}  string  "Error"
 // @Router       /v1/products [get]
@@ -40,19 +46,80 @@ func (r *repos) getProducts(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
 		return
 	}
+	productQueryDto.Attr = ctx.QueryMap("attr")
 
 	getProductCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	queryModel := productQueryDto.Model()
-	products, err := services.Product(r.ds.Product).List(getProductCtx, queryModel)
+	products, err := services.Product(r.ds.Product, r.ds.Category, r.blobs).List(getProductCtx, queryModel)
 	if err != nil {
 		slog.Error("unable to get products", "cause", err)
+		if errors.Is(err, bo.ErrInvalidProductSort) || errors.Is(err, bo.ErrInvalidCursor) || errors.Is(err, bo.ErrInvalidAttributeFilter) {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	if err := services.Currency(r.ds.Currency).ConvertProducts(getProductCtx, products.Data, queryModel.Currency); err != nil {
+		if errors.Is(err, bo.ErrExchangeRateNotFound) {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
+		slog.Error("unable to convert product prices", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	paginated := dto.ToPaginatedProduct(products)
+	setPageLinks(ctx, paginated.NextCursor, paginated.PrevCursor)
+	ctx.JSON(http.StatusOK, paginated)
+}
+
+// Get Product Facets godoc
+// @Summary      Count the products matching a filter per facet
+// @Description  Count the products matching a filter per brand, category, supplier, verified supplier, price bucket and filterable attribute of the category. Each facet is counted without its own filter.
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        q       query  string  false  "q searches the name, description, specifications, tags, brand and category"
+// @Param        brand   query   []int  false  "brand"
+// @Param        category  query   int  false  "category, including its subcategories"
+// @Param        supplier  query   int  false  "supplier"
+// @Param        verified_supplier  query   bool  false  "verified_supplier"
+// @Param        min_price  query   float64  false  "min_price"
+// @Param        max_price  query   float64  false  "max_price"
+// @Param        attr[code]  query   string  false  "attr[code] filters on a filterable attribute of the category: comma separated enum options, true or false, a number or a min..max range"
+// @Success      200  {object}  dto.ProductFacets
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/products/facets [get]
+func (r *repos) getProductFacets(ctx *gin.Context) {
+	var productQueryDto dto.ProductQuery
+	if err := ctx.ShouldBindQuery(&productQueryDto); err != nil {
+		slog.Error("unable to parse query url", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+	productQueryDto.Attr = ctx.QueryMap("attr")
+
+	getFacetsCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	facets, err := services.Product(r.ds.Product, r.ds.Category, r.blobs).Facets(getFacetsCtx, productQueryDto.Model().Filter)
+	if err != nil {
+		slog.Error("unable to get product facets", "cause", err)
+		if errors.Is(err, bo.ErrInvalidAttributeFilter) {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, dto.ToPaginatedProduct(products))
+	ctx.JSON(http.StatusOK, dto.ToProductFacetsDTO(facets))
 }
 
 // Get Product godoc
@@ -78,7 +145,7 @@ func (r *repos) getProduct(ctx *gin.Context) {
 	getProductCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	product, err := services.Product(r.ds.Product).GetProductByID(getProductCtx, wrappedID.ID)
+	product, err := services.Product(r.ds.Product, r.ds.Category, r.blobs).GetProductByID(getProductCtx, wrappedID.ID)
 	if err != nil {
 		if err == bo.ErrProductNotFound {
 			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product not found"))
@@ -117,9 +184,13 @@ func (r *repos) addProduct(ctx *gin.Context) {
 	addProductCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	id, err := services.Product(r.ds.Product).CreateProduct(addProductCtx, model)
+	id, err := services.Product(r.ds.Product, r.ds.Category, r.blobs).CreateProduct(addProductCtx, model)
 	if err != nil {
 		slog.Error("unable to create product", "cause", err)
+		if errors.Is(err, bo.ErrInvalidProductAttribute) || errors.Is(err, bo.ErrTaxClassNotFound) {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
 		return
 	}
@@ -159,11 +230,15 @@ func (r *repos) updateProduct(ctx *gin.Context) {
 	defer cancel()
 
 	productDto.ID = wrappedID.ID
-	if err := services.Product(r.ds.Product).UpdateProduct(updateProductCtx, productDto.Model()); err != nil {
+	if err := services.Product(r.ds.Product, r.ds.Category, r.blobs).UpdateProduct(updateProductCtx, productDto.Model()); err != nil {
 		if err == bo.ErrProductNotFound {
 			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product not found"))
 			return
 		}
+		if errors.Is(err, bo.ErrInvalidProductAttribute) || errors.Is(err, bo.ErrTaxClassNotFound) {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
 		slog.Error("unable to update product", "cause", err)
 		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
 		return
@@ -195,7 +270,7 @@ func (r *repos) deleteProduct(ctx *gin.Context) {
 	deleteProductCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	if err := services.Product(r.ds.Product).DeleteProduct(deleteProductCtx, wrappedID.ID); err != nil {
+	if err := services.Product(r.ds.Product, r.ds.Category, r.blobs).DeleteProduct(deleteProductCtx, wrappedID.ID); err != nil {
 		if err == bo.ErrProductNotFound {
 			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product not found"))
 			return
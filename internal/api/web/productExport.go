@@ -0,0 +1,84 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// productExportContentTypes are the content types of the export formats
+var productExportContentTypes = map[bo.ProductExportFormat]string{
+	bo.ExportCSV:    "text/csv; charset=utf-8",
+	bo.ExportNDJSON: "application/x-ndjson",
+	bo.ExportXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Export Products godoc
+// @Summary      Export the catalog
+// @Description  Download the products matching the filter of a product list, by id, with their brand, category path, supplier and available stock. The file is streamed as the products are read.
+// @Description  The columns are id, name, description, specifications, brand_id, brand, category_id, category_path, supplier_id, supplier, unit_price, discount_price, tags, status_id, stock and attributes, all of them by default, and attr.<code> for an attribute value.
+// @Tags         Product
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format   query  string  false  "csv, ndjson or xlsx, csv by default"
+// @Param        columns  query  string  false  "comma separated columns, in order"
+// @Param        q       query  string  false  "q searches the name, description, specifications, tags, brand and category"
+// @Param        brand   query   []int  false  "brand"
+// @Param        category  query   int  false  "category, including its subcategories"
+// @Param        supplier  query   int  false  "supplier"
+// @Param        verified_supplier  query   bool  false  "verified_supplier"
+// @Param        min_price  query   float64  false  "min_price"
+// @Param        max_price  query   float64  false  "max_price"
+// @Param        attr[code]  query   string  false  "attr[code] filters on a filterable attribute of the category: comma separated enum options, true or false, a number or a min..max range"
+// @Success      200  {file}  file
+// @Failure      400  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/products/export [get]
+func (r *repos) exportProducts(ctx *gin.Context) {
+	var productQueryDto dto.ProductQuery
+	if err := ctx.ShouldBindQuery(&productQueryDto); err != nil {
+		slog.Error("unable to parse query url", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+	productQueryDto.Attr = ctx.QueryMap("attr")
+
+	var exportQueryDto dto.ProductExportQuery
+	if err := ctx.ShouldBindQuery(&exportQueryDto); err != nil {
+		slog.Error("unable to parse query url", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+	exportQuery := exportQueryDto.Model(productQueryDto.Model().Filter)
+
+	// the export goes on as long as the client reads it
+	exportCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+
+	export, err := services.Product(r.ds.Product, r.ds.Category, r.blobs).Export(exportCtx, exportQuery)
+	if err != nil {
+		if errors.Is(err, bo.ErrInvalidProductExport) || errors.Is(err, bo.ErrInvalidAttributeFilter) {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
+		slog.Error("unable to export products", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.Header("Content-Type", productExportContentTypes[exportQuery.Format])
+	ctx.Header("Content-Disposition", `attachment; filename="products.`+string(exportQuery.Format)+`"`)
+	ctx.Status(http.StatusOK)
+	if err := export.Write(exportCtx, ctx.Writer); err != nil {
+		// the status is sent with the first row, the client gets a truncated file
+		slog.Error("unable to send product export", "cause", err)
+	}
+}
@@ -0,0 +1,62 @@
+package web
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// productFeedContentTypes are the content types of the feed formats
+var productFeedContentTypes = map[bo.ProductFeedFormat]string{
+	bo.FeedXML: "application/xml; charset=utf-8",
+	bo.FeedTSV: "text/tab-separated-values; charset=utf-8",
+}
+
+// Get Google Feed godoc
+// @Summary      Get the Google Merchant Center product feed
+// @Description  The active products in stock with an image, with their price and sale price converted to FEED_CURRENCY, brand, availability, Google product category and category path.
+// @Description  The feed is generated on a schedule and served from memory, it is revalidated with If-None-Match or If-Modified-Since.
+// @Tags         Feed
+// @Produce      application/xml
+// @Produce      text/tab-separated-values
+// @Param        format  query  string  false  "xml or tsv, xml by default"
+// @Success      200  {file}  file
+// @Success      304
+// @Failure      400  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/feeds/google [get]
+func (r *repos) getGoogleFeed(ctx *gin.Context) {
+	format := bo.ProductFeedFormat(ctx.DefaultQuery("format", string(bo.FeedXML)))
+	contentType, ok := productFeedContentTypes[format]
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(bo.ErrInvalidProductFeedFormat.Error()))
+		return
+	}
+
+	feed, err := services.ProductFeed(r.ds.Product, r.ds.Currency, r.blobs, r.feed).Get(ctx.Request.Context())
+	if err != nil {
+		slog.Error("unable to generate the product feed", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+	file := feed.Files[format]
+
+	// caches may keep the feed until it is generated again
+	cacheControl := "no-cache"
+	if !feed.ExpiresAt.IsZero() {
+		maxAge := max(int(time.Until(feed.ExpiresAt).Seconds()), 0)
+		cacheControl = "public, max-age=" + strconv.Itoa(maxAge)
+	}
+	ctx.Header("Cache-Control", cacheControl)
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("ETag", file.ETag)
+	http.ServeContent(ctx.Writer, ctx.Request, "", feed.GeneratedAt, bytes.NewReader(file.Content))
+}
@@ -0,0 +1,87 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxProductImportSize is the largest accepted import file, in bytes
+const maxProductImportSize = 20 << 20
+
+// Import Products godoc
+// @Summary      Import products from a CSV file
+// @Description  Create products in bulk from a CSV file with a header row. The columns are name, brand, category, supplier and unit_price, and optionally description, specifications, discount_price, currency (BDT by default), tags, status_id (1 by default) and attr.<code> for the attribute values. brand, category and supplier take an id or a name.
+// @Description  Every row is validated first, the products are only written, in one transaction, when all the rows are valid. Otherwise the report lists the errors of every invalid row with a 422.
+// @Tags         Product
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file  true   "CSV file"
+// @Param        dry_run  query     bool  false  "Only validate the file"
+// @Param        upsert   query     bool  false  "Update the products of the same supplier and name"
+// @Success      200  {object}  dto.ProductImportReport
+// @Failure      400  {object}  dto.Error
+// @Failure      413  {object}  dto.Error
+// @Failure      422  {object}  dto.ProductImportReport
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/products/import [post]
+func (r *repos) importProducts(ctx *gin.Context) {
+	var importQuery dto.ProductImportQuery
+	if err := ctx.ShouldBindQuery(&importQuery); err != nil {
+		slog.Error("unable to parse product import query", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxProductImportSize+multipartOverhead)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, dto.Builder().SetMessage("the import file is too large"))
+			return
+		}
+		slog.Error("unable to parse the uploaded file", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+	if fileHeader.Size > maxProductImportSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, dto.Builder().SetMessage("the import file is too large"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		slog.Error("unable to open the uploaded file", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+	defer file.Close()
+
+	importCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	report, err := services.ProductImport(r.ds.ProductImport, r.ds.Category).Import(importCtx, file, importQuery.Model())
+	if err != nil {
+		if errors.Is(err, bo.ErrInvalidProductImport) {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
+		slog.Error("unable to import products", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, dto.ToProductImportReportDTO(report))
+		return
+	}
+	ctx.JSON(http.StatusOK, dto.ToProductImportReportDTO(report))
+}
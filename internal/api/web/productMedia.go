@@ -0,0 +1,290 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left for the multipart boundaries and fields
+// around a file of the maximum upload size
+const multipartOverhead = 1 << 20
+
+// Get ProductMedia godoc
+// @Summary      Get the images and documents of a Product
+// @Description  Get the images and documents of a Product, the primary image first then by sequence
+// @Tags         ProductMedia
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {object}  dto.ProductMediaList
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/media [get]
+func (r *repos) getProductMedia(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getMediaCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mediaCollection, err := services.ProductMedia(r.ds.ProductMedia, r.blobs, r.media.MaxUploadSize).List(getMediaCtx, wrappedID.ID)
+	if err != nil {
+		slog.Error("unable to get product media", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToProductMediaList(mediaCollection))
+}
+
+// Upload ProductMedia godoc
+// @Summary      Upload an image or a document of a Product
+// @Description  Upload a JPEG, PNG or GIF image whose sides are from 100 to 8000 pixels, or a PDF document. The type is told by the content of the file. The first image of a product becomes its primary image.
+// @Tags         ProductMedia
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id        path      int   true   "Product ID"
+// @Param        file      formData  file  true   "Image or document"
+// @Param        sequence  formData  int   false  "Position among the media of the product"
+// @Success      201  {object}  dto.ProductMedia
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      413  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/media [post]
+func (r *repos) uploadProductMedia(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, r.media.MaxUploadSize+multipartOverhead)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, dto.Builder().SetMessage(bo.ErrProductMediaTooLarge.Error()))
+			return
+		}
+		slog.Error("unable to parse the uploaded file", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	var uploadDto dto.ProductMediaUpload
+	if err := ctx.ShouldBind(&uploadDto); err != nil {
+		slog.Error("unable to parse product media form", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		slog.Error("unable to open the uploaded file", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+	defer file.Close()
+
+	uploadCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	media, err := services.ProductMedia(r.ds.ProductMedia, r.blobs, r.media.MaxUploadSize).Upload(uploadCtx, wrappedID.ID, fileHeader.Filename, file, uploadDto.Sequence)
+	if err != nil {
+		switch {
+		case errors.Is(err, bo.ErrInvalidProductMedia):
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		case errors.Is(err, bo.ErrProductMediaTooLarge):
+			ctx.JSON(http.StatusRequestEntityTooLarge, dto.Builder().SetMessage(err.Error()))
+			return
+		case errors.Is(err, bo.ErrProductNotFound):
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product not found"))
+			return
+		}
+		slog.Error("unable to upload product media", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToProductMediaDTO(media))
+}
+
+// UpdateProductMedia godoc
+// @Summary      Reorder a media file or make an image the primary one
+// @Description  Set the sequence of a media file, primary true makes an image the primary image of the product instead of the previous one
+// @Tags         ProductMedia
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int  true  "Product ID"
+// @Param        media_id  path      int  true  "ProductMedia ID"
+// @Param        request body dto.ProductMediaUpdate  true  "ProductMedia params"
+// @Success      204  {string}  "product media updated"
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/media/{media_id} [patch]
+func (r *repos) updateProductMedia(ctx *gin.Context) {
+	var mediaURI dto.ProductMediaURI
+	if err := ctx.ShouldBindUri(&mediaURI); err != nil {
+		slog.Error("unable to parse product media id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var mediaDto dto.ProductMediaUpdate
+	if err := ctx.ShouldBindJSON(&mediaDto); err != nil {
+		slog.Error("unable to parse product media from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	updateMediaCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.ProductMedia(r.ds.ProductMedia, r.blobs, r.media.MaxUploadSize).UpdateProductMedia(updateMediaCtx, mediaDto.Model(mediaURI.ProductID, mediaURI.MediaID)); err != nil {
+		switch {
+		case errors.Is(err, bo.ErrProductMediaNotFound):
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product media not found"))
+			return
+		case errors.Is(err, bo.ErrInvalidProductMedia):
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
+		slog.Error("unable to update product media", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "product media updated"})
+}
+
+// DeleteProductMedia godoc
+// @Summary      Delete an image or a document of a Product
+// @Description  Delete a media file of a Product, the next image becomes the primary one when the primary image is deleted
+// @Tags         ProductMedia
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int  true  "Product ID"
+// @Param        media_id  path      int  true  "ProductMedia ID"
+// @Success      204  {string}  "Product media delete processed"
+// @Failure      400  {string} 	string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/media/{media_id} [delete]
+func (r *repos) deleteProductMedia(ctx *gin.Context) {
+	var mediaURI dto.ProductMediaURI
+	if err := ctx.ShouldBindUri(&mediaURI); err != nil {
+		slog.Error("unable to parse product media id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deleteMediaCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.ProductMedia(r.ds.ProductMedia, r.blobs, r.media.MaxUploadSize).DeleteProductMedia(deleteMediaCtx, mediaURI.ProductID, mediaURI.MediaID); err != nil {
+		if err == bo.ErrProductMediaNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product media not found"))
+			return
+		}
+		slog.Error("unable to delete product media", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "product media deleted"})
+}
+
+// ReprocessProductMedia godoc
+// @Summary      Generate the renditions of an image again
+// @Description  Queue an image for its renditions to be generated again in the background, e.g. after the configured renditions changed or after they failed
+// @Tags         ProductMedia
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int  true  "Product ID"
+// @Param        media_id  path      int  true  "ProductMedia ID"
+// @Success      202  {string}  "product media renditions queued"
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/media/{media_id}/renditions [post]
+func (r *repos) reprocessProductMedia(ctx *gin.Context) {
+	var mediaURI dto.ProductMediaURI
+	if err := ctx.ShouldBindUri(&mediaURI); err != nil {
+		slog.Error("unable to parse product media id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	reprocessCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.ProductMedia(r.ds.ProductMedia, r.blobs, r.media.MaxUploadSize).Reprocess(reprocessCtx, mediaURI.ProductID, mediaURI.MediaID); err != nil {
+		if errors.Is(err, bo.ErrProductMediaNotFound) {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product image not found"))
+			return
+		}
+		slog.Error("unable to queue product media renditions", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"message": "product media renditions queued"})
+}
+
+// Get Media File godoc
+// @Summary      Download a media file
+// @Description  Download a stored file by its storage key, the default media url serves the files through the API
+// @Tags         ProductMedia
+// @Produce      octet-stream
+// @Param        key   path      string  true  "Storage key"
+// @Success      200  {file}  file
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/media/{key} [get]
+func (r *repos) getMediaFile(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	file, err := services.ProductMedia(r.ds.ProductMedia, r.blobs, r.media.MaxUploadSize).Open(ctx.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, bo.ErrBlobNotFound) || path.Clean(key) != key {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("file not found"))
+			return
+		}
+		slog.Error("unable to open media file", slog.String("key", key), "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	// a storage key is never reused, so a file never changes
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	ctx.Header("Content-Type", contentType)
+	ctx.Status(http.StatusOK)
+	if _, err := io.Copy(ctx.Writer, file); err != nil {
+		slog.Error("unable to send media file", slog.String("key", key), "cause", err)
+	}
+}
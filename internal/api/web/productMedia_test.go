@@ -0,0 +1,120 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"techno-store/config"
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/blobstores"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUploadProductMediaAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appConfig, err := config.Parse()
+	if err != nil {
+		slog.Error("Error parsing config", "cause", err)
+	}
+
+	ds := mockdb.GetInstance(ctrl)
+	mediaConfig := config.MediaConfig{Storage: blobstores.LocalStorage, PublicURL: "/v1/media", MaxUploadSize: 64 << 10}
+	apiService := NewAPIService(*appConfig.Server, ds).WithMediaStore(blobstores.NewLocalStore(t.TempDir(), mediaConfig.PublicURL), mediaConfig)
+	router := gin.Default()
+	apiService.InstallRoutes(router)
+
+	mediaStore := ds.ProductMedia.(*mockdb.MockProductMediaRepository)
+
+	upload := func(t *testing.T, fileName string, content []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile("file", fileName)
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, form.WriteField("sequence", "2"))
+		require.NoError(t, form.Close())
+
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest("POST", "/v1/product/7/media", &body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	pngImage := func(width, height int) []byte {
+		var content bytes.Buffer
+		require.NoError(t, png.Encode(&content, image.NewGray(image.Rect(0, 0, width, height))))
+		return content.Bytes()
+	}
+
+	t.Run("Image", func(t *testing.T) {
+		content := pngImage(320, 240)
+		mediaStore.EXPECT().
+			CreateProductMedia(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ any, media *bo.ProductMedia) error {
+				require.Equal(t, bo.MediaImage, media.Kind)
+				require.Equal(t, "image/png", media.MimeType)
+				require.Equal(t, "front.png", media.FileName)
+				require.Equal(t, int64(2), media.Sequence)
+				media.ID, media.Primary = 1, true
+				return nil
+			})
+
+		recorder := upload(t, "../front.png", content)
+		require.Equal(t, http.StatusCreated, recorder.Code, recorder.Body.String())
+
+		var media dto.ProductMedia
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &media))
+		require.True(t, media.Primary)
+		require.Equal(t, int64(320), media.Width)
+		require.Equal(t, int64(240), media.Height)
+		require.Regexp(t, `^/v1/media/products/7/[0-9a-f]{24}\.png$`, media.URL)
+
+		recorder = httptest.NewRecorder()
+		req, err := http.NewRequest("GET", media.URL, nil)
+		require.NoError(t, err)
+		router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+		require.Equal(t, content, recorder.Body.Bytes())
+	})
+
+	t.Run("ImageTooSmall", func(t *testing.T) {
+		recorder := upload(t, "icon.png", pngImage(32, 32))
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		recorder := upload(t, "front.png", []byte("<html><body>not an image</body></html>"))
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		recorder := upload(t, "manual.pdf", append([]byte("%PDF-1.7\n"), make([]byte, 64<<10)...))
+		require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/v1/media/products/7/missing.png", nil)
+		require.NoError(t, err)
+		router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
@@ -0,0 +1,143 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetProductPrices godoc
+// @Summary      Get the price timeline of a product
+// @Description  The current prices of a product, every price change it went through oldest first, and its scheduled price changes by start
+// @Tags         ProductPrice
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {object}  dto.ProductPriceTimeline
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/price-timeline [get]
+func (r *repos) getProductPrices(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getPricesCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	timeline, err := services.ProductPrice(r.ds.ProductPrice).Timeline(getPricesCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrProductNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product not found"))
+			return
+		}
+		slog.Error("unable to get product prices", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToProductPriceTimelineDTO(timeline))
+}
+
+// AddProductPriceSchedule godoc
+// @Summary      Schedule a price change of a product
+// @Description  Set the unit price, the discount price or both at starts_at, an omitted price is left as it is. With ends_at the previous prices are put back at the end, each one only if it was not changed in the meantime.
+// @Description  A schedule cannot overlap a pending or active schedule of the product.
+// @Tags         ProductPrice
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Param        request body dto.ProductPriceScheduleRequest  true  "price schedule params"
+// @Success      201  {object}  dto.ProductPriceSchedule
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/price-schedules [post]
+func (r *repos) addProductPriceSchedule(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var scheduleDto dto.ProductPriceScheduleRequest
+	if err := ctx.ShouldBindJSON(&scheduleDto); err != nil {
+		slog.Error("unable to parse price schedule from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	addScheduleCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schedule, err := services.ProductPrice(r.ds.ProductPrice).Schedule(addScheduleCtx, scheduleDto.Model(wrappedID.ID))
+	if err != nil {
+		switch {
+		case errors.Is(err, bo.ErrInvalidPriceSchedule):
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		case err == bo.ErrProductNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product not found"))
+		case err == bo.ErrPriceScheduleOverlap:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to schedule product price", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToProductPriceScheduleDTO(schedule))
+}
+
+// CancelProductPriceSchedule godoc
+// @Summary      Cancel a price schedule of a product
+// @Description  A pending schedule is dropped, an active one ends right away and the previous prices are put back
+// @Tags         ProductPrice
+// @Produce      json
+// @Param        id           path      int  true  "Product ID"
+// @Param        schedule_id  path      int  true  "Price schedule ID"
+// @Success      200  {object}  dto.ProductPriceSchedule
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/price-schedules/{schedule_id} [delete]
+func (r *repos) cancelProductPriceSchedule(ctx *gin.Context) {
+	var scheduleURI dto.ProductPriceScheduleURI
+	if err := ctx.ShouldBindUri(&scheduleURI); err != nil {
+		slog.Error("unable to parse price schedule id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	cancelScheduleCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schedule, err := services.ProductPrice(r.ds.ProductPrice).CancelSchedule(cancelScheduleCtx, scheduleURI.ProductID, scheduleURI.ScheduleID)
+	if err != nil {
+		switch err {
+		case bo.ErrPriceScheduleNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("price schedule not found"))
+		case bo.ErrPriceScheduleNotCancellable:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to cancel price schedule", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToProductPriceScheduleDTO(schedule))
+}
@@ -19,8 +19,12 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        limit   query   int  false  "limit"
-// @Param        offset  query   int  false  "offset"
+// @Param        offset  query   int  false  "offset, ignored with after or before"
+// @Param        after   query   string  false  "after, the next_cursor of the previous page"
+// @Param        before  query   string  false  "before, the prev_cursor of the next page"
+// @Param        warehouse  query   int  false  "warehouse"
 // @Success      200  {object}  dto.PaginatedProductStockCollection
+// @Header       200  {string}  Link  "the next and prev pages"
 // @Failure      400  {string} string  "Invalid request body"
 // @Failure      500  {string}  string  "Error"
 // @Router       /v1/product-stocks [get]
@@ -37,12 +41,18 @@ func (r *repos) getProductStocks(ctx *gin.Context) {
 
 	pbc, err := services.ProductStock(r.ds.ProductStock).List(getProductStockCtx, productStockQueryDto.Model())
 	if err != nil {
+		if err == bo.ErrInvalidCursor {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
 		slog.Error("unable to get productStocks", "cause", err)
 		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, dto.ToPaginatedProductStock(pbc))
+	paginated := dto.ToPaginatedProductStock(pbc)
+	setPageLinks(ctx, paginated.NextCursor, paginated.PrevCursor)
+	ctx.JSON(http.StatusOK, paginated)
 }
 
 // Get ProductStock godoc
@@ -82,6 +92,43 @@ func (r *repos) getProductStock(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, dto.ToProductStockDTO(productStock))
 }
 
+// Get ProductStockLevel godoc
+// @Summary      Get the stock of a Product
+// @Description  Get the aggregated stock of a Product with its per-warehouse quantities
+// @Tags         ProductStock
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {object}  dto.ProductStockLevel
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/stock [get]
+func (r *repos) getProductStockLevel(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getProductStockCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stockLevel, err := services.ProductStock(r.ds.ProductStock).GetProductStockLevel(getProductStockCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrProductStockNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("productStock not found"))
+			return
+		}
+		slog.Error("unable to get product stock level from database: ", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToProductStockLevelDTO(stockLevel))
+}
+
 // Add ProductStock godoc
 // @Summary      Add a new ProductStock
 // @Description  Create a new ProductStock in the system
@@ -119,7 +166,7 @@ func (r *repos) addProductStock(ctx *gin.Context) {
 
 // UpdateProductStock godoc
 // @Summary      Update a ProductStock by id
-// @Description  Update a ProductStock by id
+// @Description  Set the quantity of the product's stock row at warehouse_id, for variant_id or for the product itself when variant_id is omitted. Without warehouse_id the row of the product's only warehouse is set, else its row in the main warehouse. Without warehouse_id the row of the product's only warehouse is set, else its row in the main warehouse
 // @Tags         ProductStock
 // @Accept       json
 // @Produce      json
@@ -128,6 +175,7 @@ func (r *repos) addProductStock(ctx *gin.Context) {
 // @Success      204  {string}  "ProductStockDto updated"
 // @Failure      400  {string} string  "Invalid request body"
 // @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
 // @Failure      500  {string}  string  "Error"
 // @Router       /v1/product-stock/{id} [patch]
 func (r *repos) updateProductStock(ctx *gin.Context) {
@@ -154,6 +202,18 @@ func (r *repos) updateProductStock(ctx *gin.Context) {
 			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("productStock not found"))
 			return
 		}
+		if err == bo.ErrInvalidStockMovementReason {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("invalid stock movement reason"))
+			return
+		}
+		if err == bo.ErrStockLocationRequired {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
+		if err == bo.ErrInsufficientStock {
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage("the quantity is below the units held by reservations"))
+			return
+		}
 		slog.Error("unable to update productStock", "cause", err)
 		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
 		return
@@ -162,6 +222,57 @@ func (r *repos) updateProductStock(ctx *gin.Context) {
 	ctx.JSON(http.StatusNoContent, gin.H{"message": "productStock updated"})
 }
 
+// AdjustProductStock godoc
+// @Summary      Adjust a ProductStock by a relative quantity
+// @Description  Atomically add a signed delta to a ProductStock, optionally guarded by the expected version
+// @Tags         ProductStock
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ProductStockAdjustment  true  "Adjustment params"
+// @Param        id   path      int  true  "ProductStock ID"
+// @Success      200  {object}  dto.ProductStock
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product-stock/{id}/adjustments [post]
+func (r *repos) adjustProductStock(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse productStock id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var adjustmentDto dto.ProductStockAdjustment
+	if err := ctx.ShouldBindJSON(&adjustmentDto); err != nil {
+		slog.Error("unable to parse productStock adjustment from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	adjustProductStockCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	productStock, err := services.ProductStock(r.ds.ProductStock).AdjustProductStock(adjustProductStockCtx, adjustmentDto.Model(wrappedID.ID))
+	if err != nil {
+		switch err {
+		case bo.ErrProductStockNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("productStock not found"))
+		case bo.ErrInsufficientStock, bo.ErrStockVersionConflict:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		case bo.ErrInvalidStockAdjustment, bo.ErrInvalidStockMovementReason:
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to adjust productStock", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToProductStockDTO(productStock))
+}
+
 // DeleteProductStock godoc
 // @Summary      Delete a ProductStock by id
 // @Description  Delete a ProductStock by id
@@ -0,0 +1,115 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"techno-store/config"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdjustProductStockAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appConfig, err := config.Parse()
+	if err != nil {
+		slog.Error("Error parsing config", "cause", err)
+	}
+
+	// Services are singletons, so every case shares one mockdb datastore
+	ds := mockdb.GetInstance(ctrl)
+	apiService := NewAPIService(*appConfig.Server, ds)
+	productStockStore := ds.ProductStock.(*mockdb.MockProductStockRepository)
+
+	router := gin.Default()
+	apiService.InstallRoutes(router)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func()
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"delta": -2, "reason": "sale"},
+			buildStubs: func() {
+				productStockStore.EXPECT().
+					AdjustProductStock(gomock.Any(), gomock.Eq(bo.ProductStockAdjustment{
+						ProductStockID: 1,
+						Delta:          -2,
+						Reason:         bo.StockMovementSale,
+					})).
+					Times(1).
+					Return(bo.ProductStock{ID: 1, ProductID: 7, StockQuantity: 3, Version: 4, ReservedQuantity: 1}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"stock_quantity":3`)
+				require.Contains(t, recorder.Body.String(), `"available_quantity":2`)
+			},
+		},
+		{
+			name: "InsufficientStock",
+			body: gin.H{"delta": -20},
+			buildStubs: func() {
+				productStockStore.EXPECT().
+					AdjustProductStock(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(bo.ProductStock{}, bo.ErrInsufficientStock)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "VersionConflict",
+			body: gin.H{"delta": 5, "expected_version": 1},
+			buildStubs: func() {
+				productStockStore.EXPECT().
+					AdjustProductStock(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(bo.ProductStock{}, bo.ErrStockVersionConflict)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:       "ZeroDelta",
+			body:       gin.H{"delta": 0},
+			buildStubs: func() {},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStubs()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/v1/product-stock/%d/adjustments", 1)
+			req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
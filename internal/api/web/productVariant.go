@@ -0,0 +1,210 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Get ProductVariants godoc
+// @Summary      Get the variants of a Product
+// @Description  Get the variants of a Product
+// @Tags         ProductVariant
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {object}  dto.ProductVariants
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/variants [get]
+func (r *repos) getProductVariants(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getProductVariantCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	variants, err := services.ProductVariant(r.ds.ProductVariant).List(getProductVariantCtx, wrappedID.ID)
+	if err != nil {
+		slog.Error("unable to get product variants", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToProductVariants(variants))
+}
+
+// Get ProductVariant godoc
+// @Summary      Get a ProductVariant by id
+// @Description  Get a ProductVariant by id
+// @Tags         ProductVariant
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int  true  "Product ID"
+// @Param        variant_id  path      int  true  "ProductVariant ID"
+// @Success      200  {object}  dto.ProductVariant
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/variants/{variant_id} [get]
+func (r *repos) getProductVariant(ctx *gin.Context) {
+	var variantURI dto.ProductVariantURI
+	if err := ctx.ShouldBindUri(&variantURI); err != nil {
+		slog.Error("unable to parse product variant id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getProductVariantCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	variant, err := services.ProductVariant(r.ds.ProductVariant).GetProductVariantByID(getProductVariantCtx, variantURI.ProductID, variantURI.VariantID)
+	if err != nil {
+		if err == bo.ErrProductVariantNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product variant not found"))
+			return
+		}
+		slog.Error("unable to get product variant from database: ", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToProductVariantDTO(variant))
+}
+
+// Add ProductVariant godoc
+// @Summary      Add a new ProductVariant
+// @Description  Create a new variant of a Product
+// @Tags         ProductVariant
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Param        request body dto.ProductVariant  true  "ProductVariant params"
+// @Success      201  {object}  dto.IDWrapper
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/variants [post]
+func (r *repos) addProductVariant(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	variantDto := dto.ProductVariant{}
+	if err := ctx.ShouldBindJSON(&variantDto); err != nil {
+		slog.Error("unable to parse product variant from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	variantDto.ProductID = wrappedID.ID
+	model := variantDto.Model()
+
+	addProductVariantCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	id, err := services.ProductVariant(r.ds.ProductVariant).CreateProductVariant(addProductVariantCtx, model)
+	if err != nil {
+		slog.Error("unable to create product variant", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.IDWrapper{ID: id})
+}
+
+// UpdateProductVariant godoc
+// @Summary      Update a ProductVariant by id
+// @Description  Update a ProductVariant by id
+// @Tags         ProductVariant
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ProductVariantUpdate  true  "ProductVariant params"
+// @Param        id          path      int  true  "Product ID"
+// @Param        variant_id  path      int  true  "ProductVariant ID"
+// @Success      204  {string}  "ProductVariantDto updated"
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/variants/{variant_id} [patch]
+func (r *repos) updateProductVariant(ctx *gin.Context) {
+	var variantURI dto.ProductVariantURI
+	if err := ctx.ShouldBindUri(&variantURI); err != nil {
+		slog.Error("unable to parse product variant id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var variantDto dto.ProductVariantUpdate
+	if err := ctx.ShouldBindJSON(&variantDto); err != nil {
+		slog.Error("unable to parse product variant from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	updateProductVariantCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	variantDto.ID = variantURI.VariantID
+	variantDto.ProductID = variantURI.ProductID
+	if err := services.ProductVariant(r.ds.ProductVariant).UpdateProductVariant(updateProductVariantCtx, variantDto.Model()); err != nil {
+		if err == bo.ErrProductVariantNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product variant not found"))
+			return
+		}
+		slog.Error("unable to update product variant", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "product variant updated"})
+}
+
+// DeleteProductVariant godoc
+// @Summary      Delete a ProductVariant by id
+// @Description  Delete a ProductVariant by id
+// @Tags         ProductVariant
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int  true  "Product ID"
+// @Param        variant_id  path      int  true  "ProductVariant ID"
+// @Success      204  {string}  "ProductVariant delete processed"
+// @Failure      400  {string} 	string  "Invalid request body"
+// @Failure      404  {object}  string  "ProductVariant not found"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/variants/{variant_id} [delete]
+func (r *repos) deleteProductVariant(ctx *gin.Context) {
+	var variantURI dto.ProductVariantURI
+	if err := ctx.ShouldBindUri(&variantURI); err != nil {
+		slog.Error("unable to parse product variant id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deleteProductVariantCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.ProductVariant(r.ds.ProductVariant).DeleteProductVariant(deleteProductVariantCtx, variantURI.ProductID, variantURI.VariantID); err != nil {
+		if err == bo.ErrProductVariantNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product variant not found"))
+			return
+		}
+		slog.Error("unable to delete product variant", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "product variant deleted"})
+}
@@ -0,0 +1,69 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"techno-store/config"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetProductsAttributeFilterAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appConfig, err := config.Parse()
+	if err != nil {
+		slog.Error("Error parsing config", "cause", err)
+	}
+
+	ds := mockdb.GetInstance(ctrl)
+	apiService := NewAPIService(*appConfig.Server, ds)
+	router := gin.Default()
+	apiService.InstallRoutes(router)
+
+	productStore := ds.Product.(*mockdb.MockProductRepository)
+	categoryStore := ds.Category.(*mockdb.MockCategoryRepository)
+	categoryStore.EXPECT().
+		ListCategoryAttributes(gomock.Any(), gomock.Eq(int64(7))).
+		AnyTimes().
+		Return(bo.CategoryAttributeCollection{
+			{ID: 1, CategoryID: 7, Code: "color", Type: bo.AttributeEnum, Options: []string{"black", "blue"}, Filterable: true},
+			{ID: 2, CategoryID: 7, Code: "ram", Type: bo.AttributeNumber, Unit: "GB", Filterable: true},
+		}, nil)
+
+	t.Run("Filtered", func(t *testing.T) {
+		sixteen := 16.0
+		productStore.EXPECT().
+			ListProducts(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ any, query bo.ProductSearchQuery) (bo.PaginatedProductCollection, error) {
+				require.Equal(t, []bo.AttributeFilter{
+					{Code: "color", Values: []any{"black"}},
+					{Code: "ram", Min: &sixteen},
+				}, query.Filter.AttributeFilters)
+				return bo.PaginatedProductCollection{}, nil
+			})
+
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/v1/products?category=7&attr[color]=black&attr[ram]=16..", nil)
+		require.NoError(t, err)
+		router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("UnknownAttribute", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/v1/products?category=7&attr[weight]=1", nil)
+		require.NoError(t, err)
+		router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
@@ -0,0 +1,309 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Get Promotions godoc
+// @Summary      Get Promotions
+// @Description  Get Promotions, newest first
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Param        limit   query   int  false  "limit"
+// @Param        offset  query   int  false  "offset"
+// @Success      200  {object}  dto.PaginatedPromotionCollection
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/promotions [get]
+func (r *repos) getPromotions(ctx *gin.Context) {
+	var promotionQueryDto dto.PromotionQuery
+	if err := ctx.ShouldBindQuery(&promotionQueryDto); err != nil {
+		slog.Error("unable to parse query url", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getPromotionCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pbc, err := services.Promotion(r.ds.Promotion).List(getPromotionCtx, promotionQueryDto.Model())
+	if err != nil {
+		slog.Error("unable to get promotions", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPaginatedPromotion(pbc))
+}
+
+// Get Promotion godoc
+// @Summary      Get a Promotion by id
+// @Description  Get a Promotion by id
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Promotion ID"
+// @Success      200  {object}  dto.Promotion
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/promotion/{id} [get]
+func (r *repos) getPromotion(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse promotion id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getPromotionCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	promotion, err := services.Promotion(r.ds.Promotion).GetPromotionByID(getPromotionCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrPromotionNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("promotion not found"))
+			return
+		}
+		slog.Error("unable to get promotion from database: ", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPromotionDTO(promotion))
+}
+
+// Add Promotion godoc
+// @Summary      Add a new Promotion
+// @Description  Create a discount rule scoped to the whole catalog, a brand, a category with its descendants,
+// @Description  a supplier or a product. A Promotion with a coupon code only applies when the code is redeemed.
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.Promotion  true  "Promotion params"
+// @Success      201  {object}  dto.IDWrapper
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/promotion [post]
+func (r *repos) addPromotion(ctx *gin.Context) {
+	promotionDto := dto.Promotion{}
+	if err := ctx.ShouldBindJSON(&promotionDto); err != nil {
+		slog.Error("unable to parse promotion from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	addPromotionCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	id, err := services.Promotion(r.ds.Promotion).CreatePromotion(addPromotionCtx, promotionDto.Model())
+	if err != nil {
+		if err == bo.ErrInvalidPromotion {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
+		slog.Error("unable to create promotion", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.IDWrapper{ID: id})
+}
+
+// UpdatePromotion godoc
+// @Summary      Update a Promotion by id
+// @Description  Update a Promotion by id
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.PromotionUpdate  true  "Promotion params"
+// @Param        id   path      int  true  "Promotion ID"
+// @Success      204  {string}  "Promotion updated"
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/promotion/{id} [patch]
+func (r *repos) updatePromotion(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse promotion id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var promotionDto dto.PromotionUpdate
+	if err := ctx.ShouldBindJSON(&promotionDto); err != nil {
+		slog.Error("unable to parse promotion from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	updatePromotionCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	promotionDto.ID = wrappedID.ID
+	if err := services.Promotion(r.ds.Promotion).UpdatePromotion(updatePromotionCtx, promotionDto.Model()); err != nil {
+		switch err {
+		case bo.ErrPromotionNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("promotion not found"))
+		case bo.ErrInvalidPromotion:
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to update promotion", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "promotion updated"})
+}
+
+// DeletePromotion godoc
+// @Summary      Delete a Promotion by id
+// @Description  Delete a Promotion by id
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Promotion ID"
+// @Success      204  {string}  "Promotion delete processed"
+// @Failure      400  {string} 	string  "Invalid request body"
+// @Failure      404  {object}  string  "Promotion not found"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/promotion/{id} [delete]
+func (r *repos) deletePromotion(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse promotion id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deletePromotionCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.Promotion(r.ds.Promotion).DeletePromotion(deletePromotionCtx, wrappedID.ID); err != nil {
+		if err == bo.ErrPromotionNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("promotion not found"))
+			return
+		}
+		slog.Error("unable to delete promotion", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "promotion deleted"})
+}
+
+// Get Product Price godoc
+// @Summary      Get the effective price of a Product
+// @Description  Price one unit of a Product with the promotions which apply now
+// @Tags         Pricing
+// @Accept       json
+// @Produce      json
+// @Param        id           path   int     true   "Product ID"
+// @Param        coupon_code  query  string  false  "Coupon code"
+// @Success      200  {object}  dto.PriceQuote
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/price [get]
+func (r *repos) getProductPrice(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var quoteQueryDto dto.PriceQuoteQuery
+	if err := ctx.ShouldBindQuery(&quoteQueryDto); err != nil {
+		slog.Error("unable to parse query url", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getProductPriceCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	quote, err := services.Pricing(r.ds.Promotion, r.ds.Product, r.ds.Cart, r.ds.Currency).QuoteProduct(getProductPriceCtx, wrappedID.ID, quoteQueryDto.CouponCode)
+	if err != nil {
+		r.pricingError(ctx, err, "unable to price product")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPriceQuoteDTO(quote))
+}
+
+// Get Cart Price godoc
+// @Summary      Get the effective price of a Cart
+// @Description  Price the items of a Cart with the promotions which apply now, the Cart totals are list prices.
+// @Description  The effective prices are taxed in the jurisdiction, tax.gross is what is charged.
+// @Tags         Pricing
+// @Accept       json
+// @Produce      json
+// @Param        id            path   int     true   "Cart ID"
+// @Param        coupon_code   query  string  false  "Coupon code"
+// @Param        jurisdiction  query  string  false  "ISO 3166 country or subdivision code, the store default when left out"
+// @Success      200  {object}  dto.PriceQuote
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/carts/{id}/price [get]
+func (r *repos) getCartPrice(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse cart id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var quoteQueryDto dto.PriceQuoteQuery
+	if err := ctx.ShouldBindQuery(&quoteQueryDto); err != nil {
+		slog.Error("unable to parse query url", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getCartPriceCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	quote, err := services.Pricing(r.ds.Promotion, r.ds.Product, r.ds.Cart, r.ds.Currency).QuoteCart(getCartPriceCtx, wrappedID.ID, quoteQueryDto.CouponCode)
+	if err == nil {
+		err = r.taxQuote(getCartPriceCtx, &quote, quoteQueryDto.Jurisdiction)
+	}
+	if err != nil {
+		r.pricingError(ctx, err, "unable to price cart")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPriceQuoteDTO(quote))
+}
+
+// pricingError maps the errors shared by the price quotes
+func (r *repos) pricingError(ctx *gin.Context, err error, message string) {
+	if errors.Is(err, bo.ErrInvalidJurisdiction) {
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		return
+	}
+	switch err {
+	case bo.ErrProductNotFound:
+		ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("product not found"))
+	case bo.ErrCartNotFound:
+		ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("cart not found"))
+	case bo.ErrCouponNotFound:
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+	default:
+		slog.Error(message, "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+	}
+}
@@ -0,0 +1,312 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Get Order Returns godoc
+// @Summary      Get the Returns of an Order
+// @Description  Get every Return requested for an Order, oldest first
+// @Tags         Return
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {array}   dto.Return
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/order/{id}/returns [get]
+func (r *repos) getOrderReturns(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse order id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getOrderReturnsCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	returns, err := services.Return(r.ds.Return, r.ds.Order, r.ds.Payment, r.payments).ListByOrder(getOrderReturnsCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrOrderNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("order not found"))
+			return
+		}
+		slog.Error("unable to get order returns", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToReturnCollectionDTO(returns))
+}
+
+// RequestReturn godoc
+// @Summary      Request a Return for an Order
+// @Description  Request to send back lines of a shipped or delivered Order
+// @Tags         Return
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ReturnRequest  true  "Return params"
+// @Param        id   path      int  true  "Order ID"
+// @Success      201  {object}  dto.Return
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/order/{id}/returns [post]
+func (r *repos) requestReturn(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse order id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var requestDto dto.ReturnRequest
+	if err := ctx.ShouldBindJSON(&requestDto); err != nil {
+		slog.Error("unable to parse return request from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	requestReturnCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ret, err := services.Return(r.ds.Return, r.ds.Order, r.ds.Payment, r.payments).Request(requestReturnCtx, requestDto.Model(wrappedID.ID))
+	if err != nil {
+		switch err {
+		case bo.ErrOrderNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("order not found"))
+		case bo.ErrReturnEmpty, bo.ErrReturnItemNotFound:
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		case bo.ErrOrderNotReturnable, bo.ErrReturnQuantityExceeded:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to request return", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToReturnDTO(ret))
+}
+
+// Get Return godoc
+// @Summary      Get a Return by id
+// @Description  Get a Return by id with its items and audit trail
+// @Tags         Return
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.Return
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/return/{id} [get]
+func (r *repos) getReturn(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse return id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getReturnCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ret, err := services.Return(r.ds.Return, r.ds.Order, r.ds.Payment, r.payments).GetByID(getReturnCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrReturnNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("return not found"))
+			return
+		}
+		slog.Error("unable to get return from database: ", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToReturnDTO(ret))
+}
+
+// ApproveReturn godoc
+// @Summary      Approve a Return
+// @Description  Approve a requested Return so its items can be sent back
+// @Tags         Return
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ReturnDecision  false  "Decision params"
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.Return
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/return/{id}/approve [post]
+func (r *repos) approveReturn(ctx *gin.Context) {
+	r.decideReturn(ctx, bo.ReturnApproved)
+}
+
+// RejectReturn godoc
+// @Summary      Reject a Return
+// @Description  Reject a requested Return, its quantity can be requested again
+// @Tags         Return
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ReturnDecision  false  "Decision params"
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.Return
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/return/{id}/reject [post]
+func (r *repos) rejectReturn(ctx *gin.Context) {
+	r.decideReturn(ctx, bo.ReturnRejected)
+}
+
+func (r *repos) decideReturn(ctx *gin.Context, decision bo.ReturnStatus) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse return id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var decisionDto dto.ReturnDecision
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&decisionDto); err != nil {
+			slog.Error("unable to parse return decision from request body", "cause", err)
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+			return
+		}
+	}
+
+	decideReturnCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service := services.Return(r.ds.Return, r.ds.Order, r.ds.Payment, r.payments)
+	decide := service.Approve
+	if decision == bo.ReturnRejected {
+		decide = service.Reject
+	}
+
+	ret, err := decide(decideReturnCtx, wrappedID.ID, decisionDto.ChangedBy, decisionDto.Note)
+	if err != nil {
+		r.returnError(ctx, err, "unable to decide return")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToReturnDTO(ret))
+}
+
+// ReceiveReturn godoc
+// @Summary      Receive the items of a Return
+// @Description  Record the items of an approved Return arriving back. Sellable items go back on hand,
+// @Description  damaged items are booked back and written off.
+// @Tags         Return
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ReturnReceipt  true  "Receipt params"
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.Return
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/return/{id}/receive [post]
+func (r *repos) receiveReturn(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse return id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var receiptDto dto.ReturnReceipt
+	if err := ctx.ShouldBindJSON(&receiptDto); err != nil {
+		slog.Error("unable to parse return receipt from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	receiveReturnCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ret, err := services.Return(r.ds.Return, r.ds.Order, r.ds.Payment, r.payments).Receive(receiveReturnCtx, receiptDto.Model(wrappedID.ID))
+	if err != nil {
+		r.returnError(ctx, err, "unable to receive return")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToReturnDTO(ret))
+}
+
+// RefundReturn godoc
+// @Summary      Refund a Return
+// @Description  Refund what was charged for the received items of a Return, or part of it,
+// @Description  through a captured Payment of its Order
+// @Tags         Return
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ReturnRefund  false  "Refund params"
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.Return
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/return/{id}/refund [post]
+func (r *repos) refundReturn(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse return id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var refundDto dto.ReturnRefund
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&refundDto); err != nil {
+			slog.Error("unable to parse return refund from request body", "cause", err)
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+			return
+		}
+	}
+
+	refundReturnCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ret, err := services.Return(r.ds.Return, r.ds.Order, r.ds.Payment, r.payments).
+		Refund(refundReturnCtx, wrappedID.ID, refundDto.Amount, refundDto.ChangedBy, refundDto.Note)
+	if err != nil {
+		r.returnError(ctx, err, "unable to refund return")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToReturnDTO(ret))
+}
+
+// returnError maps the errors shared by the return operations
+func (r *repos) returnError(ctx *gin.Context, err error, message string) {
+	switch err {
+	case bo.ErrReturnNotFound:
+		ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("return not found"))
+	case bo.ErrReturnItemNotFound, bo.ErrInvalidReturnCondition:
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+	case bo.ErrInvalidReturnTransition, bo.ErrReturnStatusConflict, bo.ErrReturnQuantityExceeded,
+		bo.ErrInvalidRefundAmount, bo.ErrNoRefundablePayment, bo.ErrInvalidPaymentTransition, bo.ErrProductStockNotFound:
+		ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+	default:
+		slog.Error(message, "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+	}
+}
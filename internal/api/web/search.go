@@ -0,0 +1,146 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Suggest godoc
+// @Summary      Suggest search terms
+// @Description  Product, brand and category names for what was typed so far, the ones starting with it first,
+// @Description  then the most similar and the most sold. Typos are tolerated and synonyms are looked up as well.
+// @Tags         Search
+// @Accept       json
+// @Produce      json
+// @Param        q      query  string  true   "what was typed so far"
+// @Param        limit  query  int     false  "limit, at most 50"
+// @Success      200  {array}   dto.Suggestion
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/search/suggest [get]
+func (r *repos) suggest(ctx *gin.Context) {
+	var suggestQueryDto dto.SuggestQuery
+	if err := ctx.ShouldBindQuery(&suggestQueryDto); err != nil {
+		slog.Error("unable to parse query url", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	suggestCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	suggestions, err := services.Search(r.ds.Search, r.search.SuggestCacheSize, r.search.SuggestCacheTTL).Suggest(suggestCtx, suggestQueryDto.Model())
+	if err != nil {
+		slog.Error("unable to suggest search terms", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToSuggestionsDTO(suggestions))
+}
+
+// Get Search Synonyms godoc
+// @Summary      Get the search synonyms
+// @Description  Get the search synonyms, ordered by term
+// @Tags         Search
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   dto.SearchSynonym
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/search/synonyms [get]
+func (r *repos) getSearchSynonyms(ctx *gin.Context) {
+	getSynonymsCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	synonyms, err := services.Search(r.ds.Search, r.search.SuggestCacheSize, r.search.SuggestCacheTTL).ListSynonyms(getSynonymsCtx)
+	if err != nil {
+		slog.Error("unable to get search synonyms", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToSearchSynonymsDTO(synonyms))
+}
+
+// Add Search Synonym godoc
+// @Summary      Add a search synonym
+// @Description  Make the suggestions for a term include the ones for its synonym, both are stored in lower case
+// @Tags         Search
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.SearchSynonym  true  "Search synonym params"
+// @Success      201  {object}  dto.SearchSynonym
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/search/synonyms [post]
+func (r *repos) addSearchSynonym(ctx *gin.Context) {
+	synonymDto := dto.SearchSynonym{}
+	if err := ctx.ShouldBindJSON(&synonymDto); err != nil {
+		slog.Error("unable to parse search synonym from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	addSynonymCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	synonym, err := services.Search(r.ds.Search, r.search.SuggestCacheSize, r.search.SuggestCacheTTL).CreateSynonym(addSynonymCtx, synonymDto.Model())
+	if err != nil {
+		switch err {
+		case bo.ErrInvalidSearchSynonym:
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+		case bo.ErrSearchSynonymExists:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to create search synonym", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToSearchSynonymDTO(synonym))
+}
+
+// Delete Search Synonym godoc
+// @Summary      Delete a search synonym by id
+// @Description  Delete a search synonym by id
+// @Tags         Search
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Search synonym ID"
+// @Success      204  {string}  "Search synonym deleted"
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/search/synonym/{id} [delete]
+func (r *repos) deleteSearchSynonym(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse search synonym id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deleteSynonymCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.Search(r.ds.Search, r.search.SuggestCacheSize, r.search.SuggestCacheTTL).DeleteSynonym(deleteSynonymCtx, wrappedID.ID); err != nil {
+		if err == bo.ErrSearchSynonymNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("search synonym not found"))
+			return
+		}
+		slog.Error("unable to delete search synonym", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "search synonym deleted"})
+}
@@ -0,0 +1,85 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Get StockMovements godoc
+// @Summary      Get the stock movement history of a Product
+// @Description  Get the stock movement history of a Product, newest first
+// @Tags         StockMovement
+// @Accept       json
+// @Produce      json
+// @Param        id      path    int  true   "Product ID"
+// @Param        limit   query   int  false  "limit"
+// @Param        offset  query   int  false  "offset"
+// @Success      200  {object}  dto.PaginatedStockMovementCollection
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/stock/movements [get]
+func (r *repos) getStockMovements(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var stockMovementQueryDto dto.StockMovementQuery
+	if err := ctx.ShouldBindQuery(&stockMovementQueryDto); err != nil {
+		slog.Error("unable to parse query url", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getStockMovementCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	movements, err := services.StockMovement(r.ds.StockMovement).List(getStockMovementCtx, stockMovementQueryDto.Model(wrappedID.ID))
+	if err != nil {
+		slog.Error("unable to get stock movements", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPaginatedStockMovement(movements))
+}
+
+// Get StockReconciliation godoc
+// @Summary      Reconcile the stock of a Product against its ledger
+// @Description  List the stock rows of a Product whose quantity differs from the sum of its stock movements
+// @Tags         StockMovement
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {object}  dto.StockReconciliation
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/product/{id}/stock/reconciliation [get]
+func (r *repos) getStockReconciliation(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse product id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	reconcileCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	discrepancies, err := services.StockMovement(r.ds.StockMovement).Reconcile(reconcileCtx, wrappedID.ID)
+	if err != nil {
+		slog.Error("unable to reconcile product stock", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToStockReconciliation(wrappedID.ID, discrepancies))
+}
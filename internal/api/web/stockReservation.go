@@ -0,0 +1,173 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Get StockReservation godoc
+// @Summary      Get a StockReservation by id
+// @Description  Get a StockReservation by id
+// @Tags         StockReservation
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "StockReservation ID"
+// @Success      200  {object}  dto.StockReservation
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/stock-reservation/{id} [get]
+func (r *repos) getStockReservation(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse stockReservation id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getStockReservationCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reservation, err := services.StockReservation(r.ds.StockReservation).GetByID(getStockReservationCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrStockReservationNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("stockReservation not found"))
+			return
+		}
+		slog.Error("unable to get stockReservation from database: ", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToStockReservationDTO(reservation))
+}
+
+// Add StockReservation godoc
+// @Summary      Reserve stock
+// @Description  Hold stock of a product for a checkout until it is confirmed, released or expires
+// @Tags         StockReservation
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.StockReservationRequest  true  "Reservation params"
+// @Success      201  {object}  dto.StockReservation
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/stock-reservation [post]
+func (r *repos) addStockReservation(ctx *gin.Context) {
+	reservationDto := dto.StockReservationRequest{}
+	if err := ctx.ShouldBindJSON(&reservationDto); err != nil {
+		slog.Error("unable to parse stockReservation from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	model := reservationDto.Model()
+
+	addStockReservationCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.StockReservation(r.ds.StockReservation).Create(addStockReservationCtx, &model); err != nil {
+		switch err {
+		case bo.ErrProductStockNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("productStock not found"))
+		case bo.ErrInsufficientStock:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to create stockReservation", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToStockReservationDTO(model))
+}
+
+// ConfirmStockReservation godoc
+// @Summary      Confirm a StockReservation
+// @Description  Turn an active StockReservation into a sale, taking its quantity off hand
+// @Tags         StockReservation
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "StockReservation ID"
+// @Success      200  {object}  dto.StockReservation
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/stock-reservation/{id}/confirm [post]
+func (r *repos) confirmStockReservation(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse stockReservation id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	confirmStockReservationCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reservation, err := services.StockReservation(r.ds.StockReservation).Confirm(confirmStockReservationCtx, wrappedID.ID)
+	if err != nil {
+		switch err {
+		case bo.ErrStockReservationNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("stockReservation not found"))
+		case bo.ErrStockReservationNotActive, bo.ErrInsufficientStock:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to confirm stockReservation", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToStockReservationDTO(reservation))
+}
+
+// ReleaseStockReservation godoc
+// @Summary      Release a StockReservation
+// @Description  Give the stock held by an active StockReservation back
+// @Tags         StockReservation
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "StockReservation ID"
+// @Success      204  {string}  "StockReservation released"
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/stock-reservation/{id}/release [post]
+func (r *repos) releaseStockReservation(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse stockReservation id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	releaseStockReservationCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.StockReservation(r.ds.StockReservation).Release(releaseStockReservationCtx, wrappedID.ID); err != nil {
+		switch err {
+		case bo.ErrStockReservationNotFound:
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("stockReservation not found"))
+		case bo.ErrStockReservationNotActive:
+			ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+		default:
+			slog.Error("unable to release stockReservation", "cause", err)
+			ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "stockReservation released"})
+}
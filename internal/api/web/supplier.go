@@ -19,8 +19,11 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        limit   query   int  false  "limit"
-// @Param        offset  query   int  false  "offset"
+// @Param        offset  query   int  false  "offset, ignored with after or before"
+// @Param        after   query   string  false  "after, the next_cursor of the previous page"
+// @Param        before  query   string  false  "before, the prev_cursor of the next page"
 // @Success      200  {object}  dto.PaginatedSupplierCollection
+// @Header       200  {string}  Link  "the next and prev pages"
 // @Failure      400  {string} string  "Invalid request body"
 // @Failure      500  {string}  string  "Error"
 // @Router       /v1/suppliers [get]
@@ -37,12 +40,18 @@ func (r *repos) getSuppliers(ctx *gin.Context) {
 
 	pbc, err := services.Supplier(r.ds.Supplier).List(getSupplierCtx, supplierQueryDto.Model())
 	if err != nil {
+		if err == bo.ErrInvalidCursor {
+			ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+			return
+		}
 		slog.Error("unable to get suppliers", "cause", err)
 		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, dto.ToPaginatedSupplier(pbc))
+	paginated := dto.ToPaginatedSupplier(pbc)
+	setPageLinks(ctx, paginated.NextCursor, paginated.PrevCursor)
+	ctx.JSON(http.StatusOK, paginated)
 }
 
 // Get Supplier godoc
@@ -0,0 +1,63 @@
+package web
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"techno-store/config"
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetSuppliersPageLinksAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appConfig, err := config.Parse()
+	if err != nil {
+		slog.Error("Error parsing config", "cause", err)
+	}
+
+	ds := mockdb.GetInstance(ctrl)
+	apiService := NewAPIService(*appConfig.Server, ds)
+	router := gin.Default()
+	apiService.InstallRoutes(router)
+
+	after := &bo.PageCursor{Sort: "name", Key: "Acme", ID: 4}
+	next := &bo.PageCursor{Sort: "name", Key: "Zeta", ID: 9}
+
+	supplierStore := ds.Supplier.(*mockdb.MockSupplierRepository)
+	supplierStore.EXPECT().
+		ListSuppliers(gomock.Any(), gomock.Eq(bo.SupplierQuery{Limit: 2, Offset: 6, PageCursors: bo.PageCursors{After: after}})).
+		Times(1).
+		Return(bo.PaginatedSupplierCollection{Total: 10, PageLinks: bo.PageLinks{Next: next, Prev: after}}, nil)
+
+	recorder := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/v1/suppliers?limit=2&offset=6&after="+dto.EncodeCursor(after), nil)
+	require.NoError(t, err)
+	router.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, fmt.Sprintf(`</v1/suppliers?after=%s&limit=2>; rel="next", </v1/suppliers?before=%s&limit=2>; rel="prev"`,
+		dto.EncodeCursor(next), dto.EncodeCursor(after)), recorder.Header().Get("Link"))
+
+	supplierStore.EXPECT().
+		ListSuppliers(gomock.Any(), gomock.Eq(bo.SupplierQuery{Limit: 20, PageCursors: bo.PageCursors{Before: &bo.PageCursor{}}})).
+		Times(1).
+		Return(bo.PaginatedSupplierCollection{}, bo.ErrInvalidCursor)
+
+	recorder = httptest.NewRecorder()
+	req, err = http.NewRequest("GET", "/v1/suppliers?before=not-a-cursor", nil)
+	require.NoError(t, err)
+	router.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
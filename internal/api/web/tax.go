@@ -0,0 +1,340 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// taxCalculator taxes the price quotes with the rate tables of the store
+func (r *repos) taxCalculator() definition.TaxCalculator {
+	return services.Tax(r.ds.Tax, r.tax)
+}
+
+// taxQuote taxes the effective prices of a quote in a jurisdiction, the default one when empty
+func (r *repos) taxQuote(ctx context.Context, quote *bo.PriceQuote, jurisdiction string) error {
+	tax, err := r.taxCalculator().Calculate(ctx, bo.TaxRequest{Jurisdiction: jurisdiction, Currency: quote.Currency, Lines: quote.TaxableLines()})
+	if err != nil {
+		return err
+	}
+	quote.Tax = &tax
+	return nil
+}
+
+// GetTaxClasses godoc
+// @Summary      Get the tax classes
+// @Description  Get the tax classes, the default one first. A product takes its own class, else the one of its closest category having one, else the default class.
+// @Tags         Tax
+// @Produce      json
+// @Success      200  {array}   dto.TaxClass
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/tax-classes [get]
+func (r *repos) getTaxClasses(ctx *gin.Context) {
+	getClassesCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	classes, err := services.Tax(r.ds.Tax, r.tax).Classes(getClassesCtx)
+	if err != nil {
+		slog.Error("unable to get tax classes", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToTaxClassDTOs(classes))
+}
+
+// AddTaxClass godoc
+// @Summary      Add a tax class
+// @Description  Add a tax class, a default class replaces the previous default
+// @Tags         Tax
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.TaxClassRequest  true  "tax class"
+// @Success      201  {object}  dto.TaxClass
+// @Failure      400  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/tax-class [post]
+func (r *repos) addTaxClass(ctx *gin.Context) {
+	var classDto dto.TaxClassRequest
+	if err := ctx.ShouldBindJSON(&classDto); err != nil {
+		slog.Error("unable to parse tax class from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	addClassCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	class, err := services.Tax(r.ds.Tax, r.tax).CreateClass(addClassCtx, classDto.Model())
+	if err != nil {
+		r.taxClassError(ctx, err, "unable to create tax class")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToTaxClassDTO(class))
+}
+
+// UpdateTaxClass godoc
+// @Summary      Update a tax class
+// @Description  Rename a tax class or make it the default one, the code cannot change
+// @Tags         Tax
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tax class ID"
+// @Param        request body dto.TaxClassUpdate  true  "tax class"
+// @Success      200  {object}  dto.TaxClass
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/tax-class/{id} [patch]
+func (r *repos) updateTaxClass(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse tax class id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var classDto dto.TaxClassUpdate
+	if err := ctx.ShouldBindJSON(&classDto); err != nil {
+		slog.Error("unable to parse tax class from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	updateClassCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	class, err := services.Tax(r.ds.Tax, r.tax).UpdateClass(updateClassCtx, classDto.Model(wrappedID.ID))
+	if err != nil {
+		r.taxClassError(ctx, err, "unable to update tax class")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToTaxClassDTO(class))
+}
+
+func (r *repos) taxClassError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, bo.ErrInvalidTaxClass):
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+	case err == bo.ErrTaxClassExists:
+		ctx.JSON(http.StatusConflict, dto.Builder().SetMessage(err.Error()))
+	case err == bo.ErrTaxClassNotFound:
+		ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("tax class not found"))
+	default:
+		slog.Error(message, "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+	}
+}
+
+// DeleteTaxClass godoc
+// @Summary      Delete a tax class
+// @Description  Delete a tax class with its rates, its products and categories fall back to the default class
+// @Tags         Tax
+// @Produce      json
+// @Param        id   path      int  true  "Tax class ID"
+// @Success      204  {string}  "Tax class delete processed"
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/tax-class/{id} [delete]
+func (r *repos) deleteTaxClass(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse tax class id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deleteClassCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.Tax(r.ds.Tax, r.tax).DeleteClass(deleteClassCtx, wrappedID.ID); err != nil {
+		if err == bo.ErrTaxClassNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("tax class not found"))
+			return
+		}
+		slog.Error("unable to delete tax class", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "tax class deleted"})
+}
+
+// GetTaxRates godoc
+// @Summary      Get the rates of a tax class
+// @Description  Get the rate table of a tax class by jurisdiction. The rates of the most specific jurisdiction apply, those of US-CA rather than those of US, and the rates of a jurisdiction add up.
+// @Tags         Tax
+// @Produce      json
+// @Param        id   path      int  true  "Tax class ID"
+// @Success      200  {array}   dto.TaxRate
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/tax-class/{id}/rates [get]
+func (r *repos) getTaxRates(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse tax class id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getRatesCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rates, err := services.Tax(r.ds.Tax, r.tax).Rates(getRatesCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrTaxClassNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("tax class not found"))
+			return
+		}
+		slog.Error("unable to get tax rates", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToTaxRateDTOs(rates))
+}
+
+// AddTaxRate godoc
+// @Summary      Add a rate to a tax class
+// @Description  Add a percentage levied on the products of the class in an ISO 3166 country or subdivision
+// @Tags         Tax
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tax class ID"
+// @Param        request body dto.TaxRateRequest  true  "tax rate"
+// @Success      201  {object}  dto.TaxRate
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/tax-class/{id}/rates [post]
+func (r *repos) addTaxRate(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse tax class id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var rateDto dto.TaxRateRequest
+	if err := ctx.ShouldBindJSON(&rateDto); err != nil {
+		slog.Error("unable to parse tax rate from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	addRateCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rate, err := services.Tax(r.ds.Tax, r.tax).CreateRate(addRateCtx, rateDto.Model(wrappedID.ID))
+	if err != nil {
+		r.taxRateError(ctx, err, "unable to create tax rate")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToTaxRateDTO(rate))
+}
+
+// UpdateTaxRate godoc
+// @Summary      Update a tax rate
+// @Description  Rename a rate or change its percentage, the orders already placed keep the rate they were taxed at
+// @Tags         Tax
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Tax class ID"
+// @Param        rate_id  path      int  true  "Tax rate ID"
+// @Param        request body dto.TaxRateUpdate  true  "tax rate"
+// @Success      200  {object}  dto.TaxRate
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/tax-class/{id}/rates/{rate_id} [patch]
+func (r *repos) updateTaxRate(ctx *gin.Context) {
+	var rateURI dto.TaxRateURI
+	if err := ctx.ShouldBindUri(&rateURI); err != nil {
+		slog.Error("unable to parse tax rate uri", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var rateDto dto.TaxRateUpdate
+	if err := ctx.ShouldBindJSON(&rateDto); err != nil {
+		slog.Error("unable to parse tax rate from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	updateRateCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rate, err := services.Tax(r.ds.Tax, r.tax).UpdateRate(updateRateCtx, rateDto.Model(rateURI.ClassID, rateURI.RateID))
+	if err != nil {
+		r.taxRateError(ctx, err, "unable to update tax rate")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToTaxRateDTO(rate))
+}
+
+func (r *repos) taxRateError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, bo.ErrInvalidTaxRate) || errors.Is(err, bo.ErrInvalidJurisdiction):
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage(err.Error()))
+	case err == bo.ErrTaxClassNotFound:
+		ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("tax class not found"))
+	case err == bo.ErrTaxRateNotFound:
+		ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("tax rate not found"))
+	default:
+		slog.Error(message, "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+	}
+}
+
+// DeleteTaxRate godoc
+// @Summary      Delete a tax rate
+// @Description  Delete a rate of a tax class, the orders already placed keep the tax they were charged
+// @Tags         Tax
+// @Produce      json
+// @Param        id       path      int  true  "Tax class ID"
+// @Param        rate_id  path      int  true  "Tax rate ID"
+// @Success      204  {string}  "Tax rate delete processed"
+// @Failure      400  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/tax-class/{id}/rates/{rate_id} [delete]
+func (r *repos) deleteTaxRate(ctx *gin.Context) {
+	var rateURI dto.TaxRateURI
+	if err := ctx.ShouldBindUri(&rateURI); err != nil {
+		slog.Error("unable to parse tax rate uri", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deleteRateCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.Tax(r.ds.Tax, r.tax).DeleteRate(deleteRateCtx, rateURI.ClassID, rateURI.RateID); err != nil {
+		if err == bo.ErrTaxRateNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("tax rate not found"))
+			return
+		}
+		slog.Error("unable to delete tax rate", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "tax rate deleted"})
+}
@@ -0,0 +1,199 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"techno-store/internal/api/dto"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Get Warehouses godoc
+// @Summary      Get Warehouses
+// @Description  Get Warehouses
+// @Tags         Warehouse
+// @Accept       json
+// @Produce      json
+// @Param        limit   query   int  false  "limit"
+// @Param        offset  query   int  false  "offset"
+// @Success      200  {object}  dto.PaginatedWarehouseCollection
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/warehouses [get]
+func (r *repos) getWarehouses(ctx *gin.Context) {
+	var warehouseQueryDto dto.WarehouseQuery
+	if err := ctx.ShouldBindQuery(&warehouseQueryDto); err != nil {
+		slog.Error("unable to parse query url", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getWarehouseCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pbc, err := services.Warehouse(r.ds.Warehouse).List(getWarehouseCtx, warehouseQueryDto.Model())
+	if err != nil {
+		slog.Error("unable to get warehouses", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToPaginatedWarehouse(pbc))
+}
+
+// Get Warehouse godoc
+// @Summary      Get a Warehouse by id
+// @Description  Get a Warehouse by id
+// @Tags         Warehouse
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Warehouse ID"
+// @Success      200  {object}  dto.Warehouse
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/warehouse/{id} [get]
+func (r *repos) getWarehouse(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse warehouse id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	getWarehouseCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	warehouse, err := services.Warehouse(r.ds.Warehouse).GetWarehouseByID(getWarehouseCtx, wrappedID.ID)
+	if err != nil {
+		if err == bo.ErrWarehouseNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("warehouse not found"))
+			return
+		}
+		slog.Error("unable to get warehouse from database: ", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToWarehouseDTO(warehouse))
+}
+
+// Add Warehouse godoc
+// @Summary      Add a new Warehouse
+// @Description  Create a new Warehouse in the system
+// @Tags         Warehouse
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.Warehouse  true  "Warehouse params"
+// @Success      201  {object}  dto.IDWrapper
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/warehouse [post]
+func (r *repos) addWarehouse(ctx *gin.Context) {
+	warehouseDto := dto.Warehouse{}
+	if err := ctx.ShouldBindJSON(&warehouseDto); err != nil {
+		slog.Error("unable to parse warehouse from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	model := warehouseDto.Model()
+
+	addWarehouseCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	id, err := services.Warehouse(r.ds.Warehouse).CreateWarehouse(addWarehouseCtx, model)
+	if err != nil {
+		slog.Error("unable to create warehouse", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.IDWrapper{ID: id})
+}
+
+// UpdateWarehouse godoc
+// @Summary      Update a Warehouse by id
+// @Description  Update a Warehouse by id
+// @Tags         Warehouse
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.WarehouseUpdate  true  "Warehouse params"
+// @Param        id   path      int  true  "Warehouse ID"
+// @Success      204  {string}  "WarehouseDto updated"
+// @Failure      400  {string} string  "Invalid request body"
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/warehouse/{id} [patch]
+func (r *repos) updateWarehouse(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse warehouse id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	var warehouseDto dto.WarehouseUpdate
+	if err := ctx.ShouldBindJSON(&warehouseDto); err != nil {
+		slog.Error("unable to parse warehouse from request body", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid request body"))
+		return
+	}
+
+	updateWarehouseCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	warehouseDto.ID = wrappedID.ID
+	if err := services.Warehouse(r.ds.Warehouse).UpdateWarehouse(updateWarehouseCtx, warehouseDto.Model()); err != nil {
+		if err == bo.ErrWarehouseNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("warehouse not found"))
+			return
+		}
+		slog.Error("unable to update warehouse", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "warehouse updated"})
+}
+
+// DeleteWarehouse godoc
+// @Summary      Delete a Warehouse by id
+// @Description  Delete a Warehouse by id
+// @Tags         Warehouse
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Warehouse ID"
+// @Success      204  {string}  "Warehouse delete processed"
+// @Failure      400  {string} 	string  "Invalid request body"
+// @Failure      404  {object}  string  "Warehouse not found"
+// @Failure      500  {string}  string  "Error"
+// @Router       /v1/warehouse/{id} [delete]
+func (r *repos) deleteWarehouse(ctx *gin.Context) {
+	var wrappedID dto.IDWrapper
+	if err := ctx.ShouldBindUri(&wrappedID); err != nil {
+		slog.Error("unable to parse warehouse id", "cause", err)
+		ctx.JSON(http.StatusBadRequest, dto.Builder().SetMessage("Invalid query value"))
+		return
+	}
+
+	deleteWarehouseCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := services.Warehouse(r.ds.Warehouse).DeleteWarehouse(deleteWarehouseCtx, wrappedID.ID); err != nil {
+		if err == bo.ErrWarehouseNotFound {
+			ctx.JSON(http.StatusNotFound, dto.Builder().SetMessage("warehouse not found"))
+			return
+		}
+		slog.Error("unable to delete warehouse", "cause", err)
+		ctx.JSON(http.StatusInternalServerError, dto.Builder().SetMessage("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "warehouse deleted"})
+}
@@ -13,6 +13,7 @@ var (
 type BrandQuery struct {
 	Limit  int
 	Offset int
+	PageCursors
 }
 
 type Brand struct {
@@ -30,6 +31,7 @@ type PaginatedBrandCollection struct {
 	// This will always return the total of all records
 	Total int64
 	Data  BrandCollection
+	PageLinks
 }
 
 type BrandUpdate struct {
@@ -0,0 +1,66 @@
+package bo
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCartNotFound         = errors.New("the cart was not found")
+	ErrCartItemNotFound     = errors.New("the cart item was not found")
+	ErrCartNotOpen          = errors.New("the cart is no longer open")
+	ErrCartCurrencyMismatch = errors.New("the product is priced in another currency than the cart")
+)
+
+// CartStatus is the lifecycle state of a cart
+type CartStatus string
+
+const (
+	CartOpen       CartStatus = "open"
+	CartCheckedOut CartStatus = "checked_out"
+)
+
+type Cart struct {
+	ID        int64      `db:"id"`
+	Reference string     `db:"reference"`
+	Status    CartStatus `db:"status"`
+	// Currency is the currency of the cart prices, the one of the first item added
+	Currency      string    `db:"currency"`
+	Subtotal      Money     `db:"subtotal"`
+	DiscountTotal Money     `db:"discount_total"`
+	Total         Money     `db:"total"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+	Items         []CartItem
+}
+
+// CartItem is a line of a cart, UnitPrice and DiscountPrice are the product
+// (variant) prices at the time the item was added
+type CartItem struct {
+	ID            int64 `db:"id"`
+	CartID        int64 `db:"cart_id"`
+	ProductID     int64 `db:"product_id"`
+	VariantID     int64 `db:"variant_id"`
+	Quantity      int64 `db:"quantity"`
+	UnitPrice     Money `db:"unit_price"`
+	DiscountPrice Money `db:"discount_price"`
+
+	// AvailableQuantity is the current stock of the product (variant) over all warehouses
+	AvailableQuantity int64 `db:"-"`
+}
+
+// Price is the price charged per unit, the discount price when one applies
+func (i CartItem) Price() Money {
+	return SalePrice(i.UnitPrice, i.DiscountPrice)
+}
+
+// LineTotal is the price charged for the whole line
+func (i CartItem) LineTotal() Money {
+	return i.Price().Mul(i.Quantity)
+}
+
+type CartItemUpdate struct {
+	ID       int64
+	CartID   int64
+	Quantity int64
+}
@@ -6,7 +6,9 @@ import (
 )
 
 var (
-	ErrCategoryNotFound = errors.New("the category was not found")
+	ErrCategoryNotFound       = errors.New("the category was not found")
+	ErrCategoryParentNotFound = errors.New("the parent category was not found")
+	ErrCategoryCycle          = errors.New("a category cannot be moved under itself or one of its subcategories")
 )
 
 type Category struct {
@@ -15,6 +17,12 @@ type Category struct {
 	ParentID  int64    `db:"parent_id"` // Pointer to handle NULL values
 	Sequence  int64     `db:"sequence"`
 	StatusID  int64     `db:"status_id"`
+	// GoogleProductCategory maps the category to the Google product taxonomy in the
+	// product feed, a category without one takes the mapping of its closest ancestor
+	GoogleProductCategory string `db:"google_product_category"`
+	// TaxClassID is the tax class of the products of the category without one of their own,
+	// 0 takes the one of the closest ancestor
+	TaxClassID int64 `db:"tax_class_id"`
 	CreatedAt time.Time `db:"created_at"`
 }
 
@@ -30,4 +38,10 @@ type CategoryUpdate struct {
 	Name     *string
 	StatusID *int64
 	Sequence *int64
+	// ParentID moves the category and its subtree, 0 makes it a root category
+	ParentID *int64
+	// GoogleProductCategory sets the taxonomy mapping, "" removes it
+	GoogleProductCategory *string
+	// TaxClassID sets the tax class, 0 removes it
+	TaxClassID *int64
 }
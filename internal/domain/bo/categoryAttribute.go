@@ -0,0 +1,78 @@
+package bo
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCategoryAttributeNotFound = errors.New("the category attribute was not found")
+	ErrCategoryAttributeExists   = errors.New("the attribute code is already defined by the category, one of its parents or subcategories")
+	ErrInvalidCategoryAttribute  = errors.New("the category attribute definition is not valid")
+	ErrInvalidProductAttribute   = errors.New("the product attribute is not valid for its category")
+	ErrInvalidAttributeFilter    = errors.New("the attribute filter is not valid for the category")
+)
+
+// AttributeType is the type of the values of a category attribute
+type AttributeType string
+
+const (
+	// AttributeEnum values are one of the attribute options
+	AttributeEnum    AttributeType = "enum"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+	AttributeText    AttributeType = "text"
+)
+
+// CategoryAttribute is a typed attribute of the products of a category, its
+// subcategories inherit it. Unit is the unit of a number, e.g. GB or inch.
+type CategoryAttribute struct {
+	ID         int64         `db:"id"`
+	CategoryID int64         `db:"category_id"`
+	Code       string        `db:"code"`
+	Name       string        `db:"name"`
+	Type       AttributeType `db:"type"`
+	Unit       string        `db:"unit"`
+	Options    []string      `db:"options"`
+	Required   bool          `db:"required"`
+	Filterable bool          `db:"filterable"`
+	Sequence   int64         `db:"sequence"`
+	CreatedAt  time.Time     `db:"created_at"`
+}
+
+// CategoryAttributeCollection is the attribute template of a category, the
+// inherited attributes first
+type CategoryAttributeCollection []CategoryAttribute
+
+// Find returns the attribute of the template with the code
+func (t CategoryAttributeCollection) Find(code string) (CategoryAttribute, bool) {
+	for _, attribute := range t {
+		if attribute.Code == code {
+			return attribute, true
+		}
+	}
+	return CategoryAttribute{}, false
+}
+
+// AttributeFilter matches the products whose attribute is one of Values, or
+// for a number lies between Min and Max, a nil bound is open
+type AttributeFilter struct {
+	Code   string
+	Values []any
+	Min    *float64
+	Max    *float64
+}
+
+// AttributeFacet is the number of matching products per value of a filterable attribute
+type AttributeFacet struct {
+	Code   string
+	Name   string
+	Type   AttributeType
+	Unit   string
+	Values []AttributeFacetCount
+}
+
+type AttributeFacetCount struct {
+	Value string
+	Count int64
+}
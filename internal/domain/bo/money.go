@@ -0,0 +1,131 @@
+package bo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidCurrency              = errors.New("the currency is not an ISO 4217 code")
+	ErrInvalidExchangeRate          = errors.New("the exchange rate is invalid")
+	ErrExchangeRateNotFound         = errors.New("the exchange rate was not found")
+	ErrInvalidCurrencyPrice         = errors.New("the currency price is invalid")
+	ErrProductCurrencyPriceNotFound = errors.New("the product currency price was not found")
+)
+
+func init() {
+	// Amounts stay JSON numbers as they were when they were floats, the decimal
+	// only keeps them exact
+	decimal.MarshalJSONWithoutQuotes = true
+}
+
+// DefaultCurrency is the currency of the store, the prices are in it when their currency is left out
+const DefaultCurrency = "BDT"
+
+// currencyMinorUnits are the ISO 4217 decimal places of the currencies which do not use cents
+var currencyMinorUnits = map[string]int32{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// ValidCurrency reports whether code has the shape of an ISO 4217 code, three upper case letters
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// CurrencyMinorUnits is the number of decimal places of a currency, 2 unless ISO 4217 says otherwise
+func CurrencyMinorUnits(currency string) int32 {
+	if places, ok := currencyMinorUnits[currency]; ok {
+		return places
+	}
+	return 2
+}
+
+// Money is an exact amount in an ISO 4217 currency
+type Money struct {
+	Amount   decimal.Decimal
+	Currency string
+}
+
+// NewMoney returns amount in currency
+func NewMoney(amount decimal.Decimal, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Round rounds the amount to the minor units of its currency, halves away from zero
+func (m Money) Round() Money {
+	return Money{Amount: m.Amount.Round(CurrencyMinorUnits(m.Currency)), Currency: m.Currency}
+}
+
+// Convert converts the amount at rate into currency and rounds it to the minor units of currency.
+// The product is exact, only the final rounding loses precision.
+func (m Money) Convert(rate decimal.Decimal, currency string) Money {
+	return Money{Amount: m.Amount.Mul(rate), Currency: currency}.Round()
+}
+
+// Mul is the amount of quantity units priced m
+func (m Money) Mul(quantity int64) Money {
+	return Money{Amount: m.Amount.Mul(decimal.NewFromInt(quantity)), Currency: m.Currency}
+}
+
+// SalePrice is the price charged per unit: discount when it is set and lower than the unit price
+func SalePrice(unit, discount Money) Money {
+	if discount.Amount.IsPositive() && discount.Amount.LessThan(unit.Amount) {
+		return discount
+	}
+	return unit
+}
+
+// ExchangeRate is the amount of Quote currency one unit of Base currency buys
+type ExchangeRate struct {
+	Base      string
+	Quote     string
+	Rate      decimal.Decimal
+	UpdatedAt time.Time
+}
+
+type ExchangeRateCollection []ExchangeRate
+
+// exchangeRateDivisionPlaces is the precision of an inverted rate
+const exchangeRateDivisionPlaces = 12
+
+// Rate finds the rate from one currency to another: 1 for the same currency, the rate
+// of the pair, else the inverse of the rate of the opposite pair
+func (c ExchangeRateCollection) Rate(from, to string) (decimal.Decimal, bool) {
+	if from == to {
+		return decimal.NewFromInt(1), true
+	}
+	for _, rate := range c {
+		if rate.Base == from && rate.Quote == to {
+			return rate.Rate, true
+		}
+	}
+	for _, rate := range c {
+		if rate.Base == to && rate.Quote == from {
+			return decimal.NewFromInt(1).DivRound(rate.Rate, exchangeRateDivisionPlaces), true
+		}
+	}
+	return decimal.Decimal{}, false
+}
+
+// ProductCurrencyPrice overrides the converted prices of a product in a currency
+type ProductCurrencyPrice struct {
+	ProductID     int64
+	Currency      string
+	UnitPrice     decimal.Decimal
+	DiscountPrice decimal.Decimal
+	UpdatedAt     time.Time
+}
+
+type ProductCurrencyPriceCollection []ProductCurrencyPrice
@@ -0,0 +1,151 @@
+package bo
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrOrderNotFound          = errors.New("the order was not found")
+	ErrCartEmpty              = errors.New("the cart has no items")
+	ErrInvalidOrderStatus     = errors.New("the order status is not valid")
+	ErrInvalidOrderTransition = errors.New("the order status transition is not allowed")
+	ErrOrderStatusConflict    = errors.New("the order status was changed concurrently")
+)
+
+// OrderStatus is the lifecycle state of an order
+type OrderStatus string
+
+const (
+	OrderPending   OrderStatus = "pending"
+	OrderPaid      OrderStatus = "paid"
+	OrderPacked    OrderStatus = "packed"
+	OrderShipped   OrderStatus = "shipped"
+	OrderDelivered OrderStatus = "delivered"
+	OrderCancelled OrderStatus = "cancelled"
+	OrderRefunded  OrderStatus = "refunded"
+)
+
+// orderTransitions lists every status an order may move to from its current one,
+// cancelled and refunded are final
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending:   {OrderPaid, OrderCancelled},
+	OrderPaid:      {OrderPacked, OrderCancelled, OrderRefunded},
+	OrderPacked:    {OrderShipped, OrderCancelled},
+	OrderShipped:   {OrderDelivered},
+	OrderDelivered: {OrderRefunded},
+}
+
+func (s OrderStatus) Valid() bool {
+	switch s {
+	case OrderPending, OrderPaid, OrderPacked, OrderShipped, OrderDelivered, OrderCancelled, OrderRefunded:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether an order in status s may move to next
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Restocks reports whether moving from s to next puts the ordered quantity
+// back on hand, which is the case when the order ends before it was shipped
+func (s OrderStatus) Restocks(next OrderStatus) bool {
+	if next != OrderCancelled && next != OrderRefunded {
+		return false
+	}
+	return s == OrderPending || s == OrderPaid || s == OrderPacked
+}
+
+// Returnable reports whether items of an order in status s may be sent back
+func (s OrderStatus) Returnable() bool {
+	return s == OrderShipped || s == OrderDelivered
+}
+
+// OrderQuery represent Order model query parameter
+type OrderQuery struct {
+	Limit  int
+	Offset int
+	Status OrderStatus
+}
+
+type Order struct {
+	ID        int64       `db:"id"`
+	CartID    int64       `db:"cart_id"`
+	Reference string      `db:"reference"`
+	Status    OrderStatus `db:"status"`
+	// Currency is the currency of the order prices, the one of its cart
+	Currency      string `db:"currency"`
+	Subtotal      Money  `db:"subtotal"`
+	DiscountTotal Money  `db:"discount_total"`
+	Total         Money  `db:"total"`
+	// TaxTotal is the tax of the order, Total includes it whatever the pricing
+	TaxTotal        Money      `db:"tax_total"`
+	TaxPricing      TaxPricing `db:"tax_pricing"`
+	TaxJurisdiction string     `db:"tax_jurisdiction"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+	Items           []OrderItem
+	// Taxes are what every rate levied over the order as it was placed
+	Taxes []TaxTotal
+}
+
+type OrderItem struct {
+	ID            int64 `db:"id"`
+	OrderID       int64 `db:"order_id"`
+	ProductID     int64 `db:"product_id"`
+	VariantID     int64 `db:"variant_id"`
+	Quantity      int64 `db:"quantity"`
+	UnitPrice     Money `db:"unit_price"`
+	DiscountPrice Money `db:"discount_price"`
+}
+
+// Price is the price charged per unit, the discount price when one applies
+func (i OrderItem) Price() Money {
+	return SalePrice(i.UnitPrice, i.DiscountPrice)
+}
+
+// LineTotal is the price charged for the whole line
+func (i OrderItem) LineTotal() Money {
+	return i.Price().Mul(i.Quantity)
+}
+
+type OrderCollection []Order
+
+// PaginatedOrderCollection model array with total record
+type PaginatedOrderCollection struct {
+	Data OrderCollection
+
+	// This will always return the total of all records
+	Total int64
+}
+
+// OrderPlacement places an order for the content of an open cart. Quote is the
+// promotional pricing of the cart, an item which changed since it was quoted
+// is charged its cart price. The tax of the quote, when it has one, is
+// calculated again on the charged prices at the same rates.
+type OrderPlacement struct {
+	CartID     int64
+	Reference  string
+	ChangedBy  string
+	CouponCode string
+	// Jurisdiction is where the order is taxed, the default jurisdiction when empty
+	Jurisdiction string
+	Quote        PriceQuote
+}
+
+// OrderStatusChange moves an order from one status to the next, From guards
+// against a concurrent change and Restock puts the ordered quantity back on hand
+type OrderStatusChange struct {
+	OrderID   int64
+	From      OrderStatus
+	To        OrderStatus
+	Restock   bool
+	ChangedBy string
+}
@@ -0,0 +1,29 @@
+package bo
+
+import "errors"
+
+var (
+	ErrInvalidCursor = errors.New("the page cursor is not valid for this list")
+)
+
+// PageCursor is the position of a row in a keyset paged list: the value the
+// list is sorted on and the id breaking ties. Sort names the order it was taken
+// from, a cursor is only valid for a list in the same order.
+type PageCursor struct {
+	Sort string
+	Key  any
+	ID   int64
+}
+
+// PageCursors select the rows after or before a cursor instead of an offset,
+// After wins when both are set
+type PageCursors struct {
+	After  *PageCursor
+	Before *PageCursor
+}
+
+// PageLinks are the cursors of the neighbouring pages, nil when there is none
+type PageLinks struct {
+	Next *PageCursor
+	Prev *PageCursor
+}
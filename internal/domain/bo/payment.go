@@ -0,0 +1,129 @@
+package bo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrPaymentNotFound          = errors.New("the payment was not found")
+	ErrPaymentDeclined          = errors.New("the payment was declined by the provider")
+	ErrInvalidPaymentTransition = errors.New("the payment status does not allow this operation")
+	ErrInvalidRefundAmount      = errors.New("the refund amount exceeds the captured amount")
+	ErrInvalidWebhookSignature  = errors.New("the payment webhook signature is not valid")
+	ErrInvalidWebhookPayload    = errors.New("the payment webhook payload is not valid")
+	ErrOrderNotPayable          = errors.New("the order is not awaiting payment")
+)
+
+// PaymentStatus is the lifecycle state of a payment
+type PaymentStatus string
+
+const (
+	PaymentAuthorized PaymentStatus = "authorized"
+	PaymentCaptured   PaymentStatus = "captured"
+	PaymentRefunded   PaymentStatus = "refunded"
+	PaymentVoided     PaymentStatus = "voided"
+	PaymentFailed     PaymentStatus = "failed"
+)
+
+// paymentTransitions lists every status a payment may move to from its current one,
+// a partial refund keeps the payment captured
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentAuthorized: {PaymentCaptured, PaymentVoided, PaymentFailed},
+	PaymentCaptured:   {PaymentRefunded},
+}
+
+func (s PaymentStatus) Valid() bool {
+	switch s {
+	case PaymentAuthorized, PaymentCaptured, PaymentRefunded, PaymentVoided, PaymentFailed:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a payment in status s may move to next
+func (s PaymentStatus) CanTransitionTo(next PaymentStatus) bool {
+	for _, allowed := range paymentTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type Payment struct {
+	ID                int64           `db:"id"`
+	OrderID           int64           `db:"order_id"`
+	Provider          string          `db:"provider"`
+	ProviderReference string          `db:"provider_reference"`
+	Status            PaymentStatus   `db:"status"`
+	Amount            decimal.Decimal `db:"amount"`
+	CapturedAmount    decimal.Decimal `db:"captured_amount"`
+	RefundedAmount    decimal.Decimal `db:"refunded_amount"`
+	CreatedAt         time.Time       `db:"created_at"`
+	UpdatedAt         time.Time       `db:"updated_at"`
+}
+
+type PaymentCollection []Payment
+
+// RefundableAmount is the captured amount which was not refunded yet
+func (p Payment) RefundableAmount() decimal.Decimal {
+	return p.CapturedAmount.Sub(p.RefundedAmount)
+}
+
+// Apply returns the payment after the event, and false when the event does not
+// change it, e.g. an event arriving after the payment already moved past it.
+// Refund events are partial until the whole captured amount is refunded.
+func (p Payment) Apply(event PaymentEvent) (Payment, bool) {
+	switch event.Status {
+	case PaymentCaptured:
+		if !p.Status.CanTransitionTo(PaymentCaptured) {
+			return p, false
+		}
+		p.CapturedAmount = p.Amount
+		if event.Amount.IsPositive() && event.Amount.LessThan(p.Amount) {
+			p.CapturedAmount = event.Amount
+		}
+	case PaymentRefunded:
+		if p.Status != PaymentCaptured || event.Amount.GreaterThan(p.RefundableAmount()) {
+			return p, false
+		}
+		refund := event.Amount
+		if !refund.IsPositive() {
+			refund = p.RefundableAmount()
+		}
+		p.RefundedAmount = p.RefundedAmount.Add(refund)
+		if p.RefundableAmount().IsPositive() {
+			return p, true
+		}
+	case PaymentAuthorized:
+		return p, false
+	default:
+		if !p.Status.CanTransitionTo(event.Status) {
+			return p, false
+		}
+	}
+
+	p.Status = event.Status
+	return p, true
+}
+
+// PaymentRequest asks the provider to authorize an amount for an order
+type PaymentRequest struct {
+	OrderID int64
+	Amount  Money
+	// Token is the provider specific payment method, e.g. a tokenized card
+	Token string
+}
+
+// PaymentEvent is a status change reported by the provider, either as the
+// result of an operation or through its webhook. EventID makes it idempotent.
+type PaymentEvent struct {
+	EventID           string
+	Provider          string
+	ProviderReference string
+	Status            PaymentStatus
+	Amount            decimal.Decimal
+}
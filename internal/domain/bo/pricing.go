@@ -0,0 +1,126 @@
+package bo
+
+import "github.com/shopspring/decimal"
+
+// PriceLine is a product (variant) quantity to price, UnitPrice and
+// DiscountPrice are the list and static discount prices per unit in the
+// currency of the quote
+type PriceLine struct {
+	CartItemID    int64
+	ProductID     int64
+	VariantID     int64
+	BrandID       int64
+	CategoryID    int64
+	SupplierID    int64
+	Quantity      int64
+	UnitPrice     decimal.Decimal
+	DiscountPrice decimal.Decimal
+}
+
+// BasePrice is the price per unit without promotions, the discount price when one applies
+func (l PriceLine) BasePrice() decimal.Decimal {
+	if l.DiscountPrice.IsPositive() && l.DiscountPrice.LessThan(l.UnitPrice) {
+		return l.DiscountPrice
+	}
+	return l.UnitPrice
+}
+
+// PriceQuote is the effective price of a set of lines and the promotions which lowered it
+type PriceQuote struct {
+	// Currency is the currency of every amount of the quote
+	Currency      string
+	Lines         []PriceQuoteLine
+	Subtotal      decimal.Decimal
+	DiscountTotal decimal.Decimal
+	Total         decimal.Decimal
+	Promotions    []AppliedPromotion
+	// Tax is the tax of the effective prices, when the quote was taxed
+	Tax *TaxBreakdown
+}
+
+type PriceQuoteLine struct {
+	CartItemID int64
+	ProductID  int64
+	VariantID  int64
+	Quantity   int64
+	UnitPrice  decimal.Decimal
+	// Price is the effective price per unit, LineTotal divided over the quantity
+	Price       decimal.Decimal
+	LineTotal   decimal.Decimal
+	PromotionID int64
+}
+
+// AppliedPromotion is a promotion which set the price of at least one line,
+// Discount is what it took off the list price over all of those lines
+type AppliedPromotion struct {
+	PromotionID int64
+	Name        string
+	CouponCode  string
+	Discount    decimal.Decimal
+}
+
+// QuotePrices prices every line with the best of its static discount price and
+// the promotions covering it. Promotions do not stack, a line gets at most one
+// and a tie goes to the promotion listed first. A promotion never makes a line free.
+// A fixed amount promotion in another currency is skipped, convert it with InCurrency.
+// The amounts are rounded to the minor units of currency.
+func QuotePrices(currency string, lines []PriceLine, promotions PromotionCollection) PriceQuote {
+	quote := PriceQuote{Currency: currency, Lines: []PriceQuoteLine{}, Promotions: []AppliedPromotion{}}
+	applied := map[int64]int{}
+	places := CurrencyMinorUnits(currency)
+
+	for _, line := range lines {
+		quantity := decimal.NewFromInt(line.Quantity)
+		listTotal := line.UnitPrice.Mul(quantity).Round(places)
+		lineTotal := line.BasePrice().Mul(quantity).Round(places)
+
+		var best *Promotion
+		for i := range promotions {
+			if promotions[i].Kind == PromotionFixedAmount && promotions[i].Currency != currency {
+				continue
+			}
+			if !promotions[i].Covers(line) {
+				continue
+			}
+			total := listTotal.Sub(promotions[i].Discount(line)).Round(places)
+			if total.IsPositive() && total.LessThan(lineTotal) {
+				lineTotal = total
+				best = &promotions[i]
+			}
+		}
+
+		quoteLine := PriceQuoteLine{
+			CartItemID: line.CartItemID,
+			ProductID:  line.ProductID,
+			VariantID:  line.VariantID,
+			Quantity:   line.Quantity,
+			UnitPrice:  line.UnitPrice,
+			LineTotal:  lineTotal,
+		}
+		if line.Quantity > 0 {
+			quoteLine.Price = lineTotal.DivRound(quantity, places)
+		}
+
+		if best != nil {
+			quoteLine.PromotionID = best.ID
+			i, ok := applied[best.ID]
+			if !ok {
+				i = len(quote.Promotions)
+				applied[best.ID] = i
+				quote.Promotions = append(quote.Promotions, AppliedPromotion{
+					PromotionID: best.ID,
+					Name:        best.Name,
+					CouponCode:  best.CouponCode,
+				})
+			}
+			quote.Promotions[i].Discount = quote.Promotions[i].Discount.Add(listTotal).Sub(lineTotal)
+		}
+
+		quote.Lines = append(quote.Lines, quoteLine)
+		quote.Subtotal = quote.Subtotal.Add(listTotal)
+		quote.Total = quote.Total.Add(lineTotal)
+	}
+
+	quote.DiscountTotal = quote.Subtotal.Sub(quote.Total)
+	return quote
+}
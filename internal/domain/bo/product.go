@@ -1,9 +1,14 @@
 package bo
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
 
 var (
-	ErrProductNotFound = errors.New("the product was not found")
+	ErrProductNotFound    = errors.New("the product was not found")
+	ErrInvalidProductSort = errors.New("the products cannot be sorted by that field or order")
 )
 
 type PriceRangeFilter struct {
@@ -11,6 +16,9 @@ type PriceRangeFilter struct {
 	Max float64
 }
 
+// ProductFilter narrows a product list, CategoryFilter also matches the subcategories.
+// Attributes are the requested attribute values by code, the product service
+// types them against the attributes of the filtered category as AttributeFilters.
 type ProductFilter struct {
 	Query                  string
 	PriceRangeFilter       PriceRangeFilter
@@ -18,11 +26,14 @@ type ProductFilter struct {
 	CategoryFilter         int64
 	SupplierFilter         int64
 	VerifiedSupplierFilter bool
+	Attributes             map[string]string
+	AttributeFilters       []AttributeFilter
 }
 
 type ProductPaging struct {
 	Limit  int
 	Offset int
+	PageCursors
 }
 
 type ProductSort struct {
@@ -35,20 +46,55 @@ type ProductSearchQuery struct {
 	Filter ProductFilter
 	Paging ProductPaging
 	Sort   ProductSort
+	// Currency converts the listed prices, they are left in the currency of each product when empty
+	Currency string
 }
 
 type Product struct {
-	ID             int64   `db:"id"`
-	Name           string  `db:"name"`
-	Description    string  `db:"description"`
-	Specifications string  `db:"specifications"`
-	BrandID        int64   `db:"brand_id"`
-	CategoryID     int64   `db:"category_id"`
-	SupplierID     int64   `db:"supplier_id"`
-	UnitPrice      float64 `db:"unit_price"`
-	DiscountPrice  float64 `db:"discount_price"`
-	Tags           string  `db:"tags"`
-	StatusID       int64   `db:"status_id"`
+	ID             int64  `db:"id"`
+	Name           string `db:"name"`
+	Description    string `db:"description"`
+	Specifications string `db:"specifications"`
+	BrandID        int64  `db:"brand_id"`
+	CategoryID     int64  `db:"category_id"`
+	SupplierID     int64  `db:"supplier_id"`
+	// UnitPrice and DiscountPrice are in the currency of the product, a zero
+	// DiscountPrice has no discount
+	UnitPrice     Money  `db:"unit_price"`
+	DiscountPrice Money  `db:"discount_price"`
+	Tags          string `db:"tags"`
+	StatusID      int64  `db:"status_id"`
+	// TaxClassID is the tax class assigned to the product, 0 takes the one of its category
+	TaxClassID int64 `db:"tax_class_id"`
+	// Attributes are the values of the category attributes by code
+	Attributes map[string]any `db:"attributes"`
+	// Images are the product images, the primary one first
+	Images []ProductImage `db:"-"`
+
+	// Highlight is the text matching a search, only set when products are listed with a query
+	Highlight ProductHighlight `db:"-"`
+}
+
+// Currency is the ISO 4217 currency of the product prices
+func (p Product) Currency() string {
+	return p.UnitPrice.Currency
+}
+
+// SetPrices sets the prices of the product in currency
+func (p *Product) SetPrices(unitPrice, discountPrice decimal.Decimal, currency string) {
+	p.UnitPrice = NewMoney(unitPrice, currency)
+	p.DiscountPrice = NewMoney(discountPrice, currency)
+}
+
+// Price is the price charged per unit, the discount price when one applies
+func (p Product) Price() Money {
+	return SalePrice(p.UnitPrice, p.DiscountPrice)
+}
+
+// ProductHighlight has the matched words of a product wrapped in <mark> tags
+type ProductHighlight struct {
+	Name        string
+	Description string
 }
 
 type ProductCollection []Product
@@ -59,6 +105,7 @@ type PaginatedProductCollection struct {
 
 	// This will always return the total of all records
 	Total int64
+	PageLinks
 }
 
 type ProductUpdate struct {
@@ -69,8 +116,13 @@ type ProductUpdate struct {
 	BrandID        *int64
 	CategoryID     *int64
 	SupplierID     *int64
-	UnitPrice      *float64
-	DiscountPrice  *float64
+	UnitPrice      *decimal.Decimal
+	DiscountPrice  *decimal.Decimal
 	Tags           *string
 	StatusID       *int64
+	Currency       *string
+	// TaxClassID assigns a tax class, 0 lets the product take the one of its category again
+	TaxClassID *int64
+	// Attributes replace all the attribute values when set
+	Attributes map[string]any
 }
@@ -0,0 +1,37 @@
+package bo
+
+import "errors"
+
+var ErrInvalidProductExport = errors.New("the product export format or columns are not valid")
+
+// ProductExportFormat is the file format of a catalog export
+type ProductExportFormat string
+
+const (
+	ExportCSV ProductExportFormat = "csv"
+	// ExportNDJSON writes a JSON object per product and line
+	ExportNDJSON ProductExportFormat = "ndjson"
+	ExportXLSX   ProductExportFormat = "xlsx"
+)
+
+// ProductExportQuery selects the exported products with the filter of a product list,
+// Columns are the exported columns in order, all the product columns when empty
+type ProductExportQuery struct {
+	Filter  ProductFilter
+	Format  ProductExportFormat
+	Columns []string
+}
+
+// ProductExportRow is an exported product with its brand, its category path from the root
+// category, its supplier and its available stock. GoogleProductCategory is the mapping of
+// its category or of the closest ancestor having one, ImageKeys are the storage keys of
+// its images, the primary one first.
+type ProductExportRow struct {
+	Product
+	BrandName             string
+	CategoryPath          []string
+	SupplierName          string
+	Stock                 int64
+	GoogleProductCategory string
+	ImageKeys             []string
+}
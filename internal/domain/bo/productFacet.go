@@ -0,0 +1,38 @@
+package bo
+
+// ProductPriceBuckets are the lower bounds of the price facet buckets, the
+// last bucket has no upper bound
+var ProductPriceBuckets = []float64{0, 50, 100, 250, 500, 1000}
+
+// ProductFacets are the product counts per value of every filter dimension.
+// Each facet is counted under all filters except its own, so selecting one
+// value does not hide the others.
+type ProductFacets struct {
+	Brands           []FacetCount
+	Categories       []FacetCount
+	Suppliers        []FacetCount
+	VerifiedSupplier []VerifiedSupplierFacetCount
+	PriceBuckets     []PriceBucketFacetCount
+	// Attributes are only counted within a category, for its filterable attributes
+	Attributes []AttributeFacet
+}
+
+// FacetCount is the number of matching products of one brand, category or supplier
+type FacetCount struct {
+	ID    int64
+	Name  string
+	Count int64
+}
+
+type VerifiedSupplierFacetCount struct {
+	Verified bool
+	Count    int64
+}
+
+// PriceBucketFacetCount is the number of matching products priced from Min up
+// to but not including Max, a zero Max is unbounded
+type PriceBucketFacetCount struct {
+	Min   float64
+	Max   float64
+	Count int64
+}
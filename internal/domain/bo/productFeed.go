@@ -0,0 +1,71 @@
+package bo
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidProductFeedFormat = errors.New("the product feed format is not xml or tsv")
+
+// ProductFeedFormat is the file format of a product feed
+type ProductFeedFormat string
+
+const (
+	// FeedXML is an RSS 2.0 document with the Google Merchant Center g: namespace
+	FeedXML ProductFeedFormat = "xml"
+	// FeedTSV is a tab separated file with a header row of the attribute names
+	FeedTSV ProductFeedFormat = "tsv"
+)
+
+// FeedAvailability is the availability of a product as understood by Merchant Center
+type FeedAvailability string
+
+const (
+	FeedInStock    FeedAvailability = "in_stock"
+	FeedOutOfStock FeedAvailability = "out_of_stock"
+)
+
+// ProductFeedSettings describe the store in its feed. ProductURL is the page of a product
+// with {id} in place of its id, BaseURL makes the relative image urls absolute and Currency
+// is the ISO 4217 code of the prices of the products which have no currency.
+type ProductFeedSettings struct {
+	Title      string
+	ProductURL string
+	BaseURL    string
+	Currency   string
+}
+
+// ProductFeedItem is a product with the attributes of a Merchant Center feed,
+// the prices are formatted with their currency such as "499.50 USD"
+type ProductFeedItem struct {
+	ID                    string
+	Title                 string
+	Description           string
+	Link                  string
+	ImageLink             string
+	AdditionalImageLinks  []string
+	Availability          FeedAvailability
+	Price                 string
+	SalePrice             string
+	Brand                 string
+	Condition             string
+	GoogleProductCategory string
+	ProductType           string
+}
+
+// ProductFeedFile is a generated feed in one format, ETag identifies its content
+type ProductFeedFile struct {
+	Content []byte
+	ETag    string
+}
+
+// ProductFeed is a generated feed in every format. Skipped counts the products left out
+// for lack of an image, which Merchant Center requires. ExpiresAt is when the feed is
+// generated again, zero when it is not generated on a schedule.
+type ProductFeed struct {
+	Files       map[ProductFeedFormat]ProductFeedFile
+	Items       int
+	Skipped     int
+	GeneratedAt time.Time
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,60 @@
+package bo
+
+import "errors"
+
+var ErrInvalidProductImport = errors.New("the product import file is not valid")
+
+// ProductReferenceKind is the table a product column refers to
+type ProductReferenceKind string
+
+const (
+	ReferenceBrand    ProductReferenceKind = "brand"
+	ReferenceCategory ProductReferenceKind = "category"
+	ReferenceSupplier ProductReferenceKind = "supplier"
+)
+
+// ProductReference is a brand, a category or a supplier found by its id or its name
+type ProductReference struct {
+	ID   int64
+	Name string
+}
+
+// ProductKey identifies a product, a supplier has one product by name
+type ProductKey struct {
+	SupplierID int64
+	Name       string
+}
+
+// ProductImportOptions tells how an import writes the products. DryRun only
+// validates the rows. Upsert updates the product of the same supplier and
+// name instead of reporting it as a duplicate.
+type ProductImportOptions struct {
+	DryRun bool
+	Upsert bool
+}
+
+// ProductImportRow is a valid row of an import file, Line counts the header
+type ProductImportRow struct {
+	Line    int
+	Product Product
+}
+
+// ProductImportError is the reason a row cannot be imported, Column is empty
+// when the whole row is concerned
+type ProductImportError struct {
+	Line    int
+	Column  string
+	Message string
+}
+
+// ProductImportReport sums up an import: either every row was valid and
+// Created and Updated count the written products, or Errors has the invalid
+// rows and nothing was written. A dry run never writes.
+type ProductImportReport struct {
+	DryRun  bool
+	Rows    int
+	Valid   int
+	Created int
+	Updated int
+	Errors  []ProductImportError
+}
@@ -0,0 +1,109 @@
+package bo
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrProductMediaNotFound = errors.New("the product media was not found")
+	ErrInvalidProductMedia  = errors.New("the file is not a supported product image or document")
+	ErrProductMediaTooLarge = errors.New("the file is larger than the upload limit")
+	ErrBlobNotFound         = errors.New("the file was not found in the blob store")
+)
+
+// MediaKind is what a product media file is used for
+type MediaKind string
+
+const (
+	MediaImage    MediaKind = "image"
+	MediaDocument MediaKind = "document"
+)
+
+// The accepted sides of a product image, in pixels
+const (
+	MinImageDimension = 100
+	MaxImageDimension = 8000
+)
+
+// ProductMedia is an image or a document of a product. The file is stored in
+// the blob store under StorageKey and served from URL. Images have their
+// Width and Height, a product has at most one Primary image.
+type ProductMedia struct {
+	ID         int64     `db:"id"`
+	ProductID  int64     `db:"product_id"`
+	Kind       MediaKind `db:"kind"`
+	StorageKey string    `db:"storage_key"`
+	FileName   string    `db:"file_name"`
+	MimeType   string    `db:"mime_type"`
+	SizeBytes  int64     `db:"size_bytes"`
+	Width      int64     `db:"width"`
+	Height     int64     `db:"height"`
+	Sequence   int64     `db:"sequence"`
+	Primary    bool      `db:"is_primary"`
+	CreatedAt  time.Time `db:"created_at"`
+	URL        string    `db:"-"`
+
+	// RenditionStatus tells whether the renditions of an image are generated,
+	// RenditionError is why they could not be
+	RenditionStatus RenditionStatus         `db:"rendition_status"`
+	RenditionError  string                  `db:"rendition_error"`
+	Renditions      []ProductMediaRendition `db:"-"`
+}
+
+// ProductMediaCollection is ordered by sequence, the primary image first
+type ProductMediaCollection []ProductMedia
+
+// ProductMediaUpdate reorders a media file or makes an image the primary one,
+// the previous primary image of the product then loses the flag
+type ProductMediaUpdate struct {
+	ID        int64
+	ProductID int64
+	Sequence  *int64
+	Primary   *bool
+}
+
+// ProductImage is an image of a product as listed along with the product
+type ProductImage struct {
+	ID         int64
+	StorageKey string
+	URL        string
+	Primary    bool
+	Renditions []ProductMediaRendition
+}
+
+// RenditionStatus is the progress of the renditions of an image
+type RenditionStatus string
+
+const (
+	RenditionPending    RenditionStatus = "pending"
+	RenditionProcessing RenditionStatus = "processing"
+	RenditionReady      RenditionStatus = "ready"
+	RenditionFailed     RenditionStatus = "failed"
+)
+
+// The formats of the renditions, each rendition is generated in the format of
+// its original, PNG for a GIF, and in WebP
+const (
+	RenditionJPEG = "jpeg"
+	RenditionPNG  = "png"
+	RenditionWebP = "webp"
+)
+
+// RenditionSpec is a configured rendition, the image scaled down so that its
+// longest side is at most MaxSide pixels. A smaller image keeps its size.
+type RenditionSpec struct {
+	Name    string
+	MaxSide int
+}
+
+// ProductMediaRendition is a generated rendition of an image in one format
+type ProductMediaRendition struct {
+	Name       string
+	Format     string
+	StorageKey string
+	Width      int64
+	Height     int64
+	SizeBytes  int64
+	URL        string
+}
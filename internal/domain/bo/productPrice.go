@@ -0,0 +1,84 @@
+package bo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrPriceScheduleNotFound       = errors.New("the price schedule was not found")
+	ErrInvalidPriceSchedule        = errors.New("the price schedule is not valid")
+	ErrPriceScheduleOverlap        = errors.New("the price schedule overlaps another pending or active schedule of the product")
+	ErrPriceScheduleNotCancellable = errors.New("the price schedule is already over")
+)
+
+// PriceScheduleStatus is the lifecycle state of a price schedule
+type PriceScheduleStatus string
+
+const (
+	// PriceSchedulePending waits for its start
+	PriceSchedulePending PriceScheduleStatus = "pending"
+	// PriceScheduleActive has set its prices and waits for its end to revert them
+	PriceScheduleActive PriceScheduleStatus = "active"
+	// PriceScheduleCompleted has set its prices, and reverted them when it has an end
+	PriceScheduleCompleted PriceScheduleStatus = "completed"
+	PriceScheduleCancelled PriceScheduleStatus = "cancelled"
+	// PriceScheduleExpired ended before the scheduler could apply it, its prices were never set
+	PriceScheduleExpired PriceScheduleStatus = "expired"
+)
+
+// PriceChangeReason tells what changed the prices of a product
+type PriceChangeReason string
+
+const (
+	PriceCreated PriceChangeReason = "created"
+	// PriceUpdated is any write of the prices besides a schedule, an update or an import
+	PriceUpdated   PriceChangeReason = "updated"
+	PriceScheduled PriceChangeReason = "scheduled"
+	PriceReverted  PriceChangeReason = "reverted"
+)
+
+// ProductPriceSchedule sets the prices of a product at StartsAt, a nil price is left as it
+// is. With an EndsAt, the previous prices are put back at the end, each one only if it was
+// not changed in the meantime. A schedule without an end is a permanent price change.
+type ProductPriceSchedule struct {
+	ID            int64               `db:"id"`
+	ProductID     int64               `db:"product_id"`
+	UnitPrice     *decimal.Decimal    `db:"unit_price"`
+	DiscountPrice *decimal.Decimal    `db:"discount_price"`
+	StartsAt      time.Time           `db:"starts_at"`
+	EndsAt        time.Time           `db:"ends_at"`
+	Status        PriceScheduleStatus `db:"status"`
+	// PreviousUnitPrice and PreviousDiscountPrice are the prices replaced when the schedule was applied
+	PreviousUnitPrice     decimal.Decimal `db:"previous_unit_price"`
+	PreviousDiscountPrice decimal.Decimal `db:"previous_discount_price"`
+	AppliedAt             time.Time       `db:"applied_at"`
+	RevertedAt            time.Time       `db:"reverted_at"`
+	CreatedAt             time.Time       `db:"created_at"`
+}
+
+// ProductPriceChange is an entry of the price history of a product, PriceScheduleID is
+// the schedule which set or reverted the prices
+type ProductPriceChange struct {
+	ID                    int64             `db:"id"`
+	ProductID             int64             `db:"product_id"`
+	UnitPrice             decimal.Decimal   `db:"unit_price"`
+	DiscountPrice         decimal.Decimal   `db:"discount_price"`
+	PreviousUnitPrice     decimal.Decimal   `db:"previous_unit_price"`
+	PreviousDiscountPrice decimal.Decimal   `db:"previous_discount_price"`
+	Reason                PriceChangeReason `db:"reason"`
+	PriceScheduleID       int64             `db:"price_schedule_id"`
+	ChangedAt             time.Time         `db:"changed_at"`
+}
+
+// ProductPriceTimeline is the current prices of a product, every change it went through
+// oldest first and its schedules by start
+type ProductPriceTimeline struct {
+	ProductID     int64
+	UnitPrice     decimal.Decimal
+	DiscountPrice decimal.Decimal
+	History       []ProductPriceChange
+	Schedules     []ProductPriceSchedule
+}
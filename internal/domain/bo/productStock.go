@@ -6,20 +6,36 @@ import (
 )
 
 var (
-	ErrProductStockNotFound = errors.New("the product stock was not found")
+	ErrProductStockNotFound   = errors.New("the product stock was not found")
+	ErrInsufficientStock      = errors.New("the product stock is insufficient for the adjustment")
+	ErrStockVersionConflict   = errors.New("the product stock was modified concurrently")
+	ErrInvalidStockAdjustment = errors.New("the stock adjustment delta must not be zero")
+	ErrStockLocationRequired  = errors.New("the product is stocked in several warehouses, an absolute stock update needs the warehouse of the stock row")
 )
 
 // ProductStockQuery represent ProductStock model query parameter
 type ProductStockQuery struct {
-	Limit  int
-	Offset int
+	Limit       int
+	Offset      int
+	WarehouseID int64
+	PageCursors
 }
 
 type ProductStock struct {
 	ID            int64     `db:"id"`
 	ProductID     int64     `db:"product_id"`
+	VariantID     int64     `db:"variant_id"`
+	WarehouseID   int64     `db:"warehouse_id"`
 	StockQuantity int64     `db:"stock_quantity"`
+	Version       int64     `db:"version"`
 	UpdatedAt     time.Time `db:"updated_at"`
+	// ReservedQuantity is held by live reservations, it is still part of StockQuantity
+	ReservedQuantity int64 `db:"reserved_quantity"`
+}
+
+// AvailableQuantity is the stock which can still be reserved or sold
+func (ps ProductStock) AvailableQuantity() int64 {
+	return ps.StockQuantity - ps.ReservedQuantity
 }
 
 type ProductStockCollection []ProductStock
@@ -30,9 +46,47 @@ type PaginatedProductStockCollection struct {
 
 	// This will always return the total of all records
 	Total int64
+	PageLinks
 }
 
+// ProductStockUpdate sets the quantity of the single stock row of a product at
+// WarehouseID, for VariantID or for the product itself when VariantID is 0
 type ProductStockUpdate struct {
 	ProductID     int64
+	VariantID     int64
+	WarehouseID   int64
 	StockQuantity *int64
+
+	// Reason, Reference and ChangedBy are recorded on the stock movement ledger
+	Reason    StockMovementReason
+	Reference string
+	ChangedBy string
+}
+
+// ProductStockAdjustment applies a signed delta to a single stock row
+type ProductStockAdjustment struct {
+	ProductStockID int64
+	Delta          int64
+	// ExpectedVersion rejects the adjustment when the row changed since it was read
+	ExpectedVersion *int64
+
+	Reason    StockMovementReason
+	Reference string
+	ChangedBy string
+}
+
+// ProductStockLocation is the quantity of a product (variant) held in one warehouse
+type ProductStockLocation struct {
+	WarehouseID      int64 `db:"warehouse_id"`
+	VariantID        int64 `db:"variant_id"`
+	StockQuantity    int64 `db:"stock_quantity"`
+	ReservedQuantity int64 `db:"reserved_quantity"`
+}
+
+// ProductStockLevel is the per-location and aggregated stock of a product
+type ProductStockLevel struct {
+	ProductID        int64
+	StockQuantity    int64
+	ReservedQuantity int64
+	Locations        []ProductStockLocation
 }
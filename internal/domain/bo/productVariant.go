@@ -0,0 +1,37 @@
+package bo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrProductVariantNotFound = errors.New("the product variant was not found")
+)
+
+// ProductVariant is a sellable SKU of a product, e.g. a color/storage combination.
+// Its prices are in the currency of the product.
+type ProductVariant struct {
+	ID            int64             `db:"id"`
+	ProductID     int64             `db:"product_id"`
+	SKU           string            `db:"sku"`
+	Options       map[string]string `db:"options"`
+	UnitPrice     decimal.Decimal   `db:"unit_price"`
+	DiscountPrice decimal.Decimal   `db:"discount_price"`
+	StatusID      int64             `db:"status_id"`
+	CreatedAt     time.Time         `db:"created_at"`
+}
+
+type ProductVariantCollection []ProductVariant
+
+type ProductVariantUpdate struct {
+	ID            int64
+	ProductID     int64
+	SKU           *string
+	Options       map[string]string
+	UnitPrice     *decimal.Decimal
+	DiscountPrice *decimal.Decimal
+	StatusID      *int64
+}
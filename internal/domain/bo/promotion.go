@@ -0,0 +1,217 @@
+package bo
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrPromotionNotFound      = errors.New("the promotion was not found")
+	ErrInvalidPromotion       = errors.New("the promotion rule is not valid")
+	ErrCouponNotFound         = errors.New("the coupon code is unknown, expired or used up")
+	ErrPromotionUsageExceeded = errors.New("the promotion usage limit was reached")
+)
+
+// PromotionKind is how a promotion lowers the price of the items it covers
+type PromotionKind string
+
+const (
+	// PromotionPercentage takes Value percent off the unit price
+	PromotionPercentage PromotionKind = "percentage"
+	// PromotionFixedAmount takes Value in Currency off the unit price
+	PromotionFixedAmount PromotionKind = "fixed_amount"
+	// PromotionBuyXGetY makes GetQuantity units free for every BuyQuantity units bought
+	PromotionBuyXGetY PromotionKind = "buy_x_get_y"
+)
+
+// PromotionScope is what part of the catalog a promotion covers
+type PromotionScope string
+
+const (
+	PromotionScopeAll      PromotionScope = "all"
+	PromotionScopeBrand    PromotionScope = "brand"
+	PromotionScopeCategory PromotionScope = "category"
+	PromotionScopeSupplier PromotionScope = "supplier"
+	PromotionScopeProduct  PromotionScope = "product"
+)
+
+// PromotionQuery represent promotion model query parameter
+type PromotionQuery struct {
+	Limit  int
+	Offset int
+}
+
+type Promotion struct {
+	ID          int64           `db:"id"`
+	Name        string          `db:"name"`
+	Kind        PromotionKind   `db:"kind"`
+	Value       decimal.Decimal `db:"value"`
+	BuyQuantity int64           `db:"buy_quantity"`
+	GetQuantity int64           `db:"get_quantity"`
+	Scope       PromotionScope  `db:"scope"`
+	ScopeID     int64           `db:"scope_id"`
+	CouponCode  string          `db:"coupon_code"`
+	StartsAt    time.Time       `db:"starts_at"`
+	EndsAt      time.Time       `db:"ends_at"`
+	UsageLimit  int64           `db:"usage_limit"`
+	UsageCount  int64           `db:"usage_count"`
+	StatusID    int64           `db:"status_id"`
+	CreatedAt   time.Time       `db:"created_at"`
+	// Currency is the ISO 4217 currency of a fixed amount Value
+	Currency string `db:"currency"`
+
+	// CategoryIDs is the scope category with all of its descendants, only
+	// loaded with the promotions applicable to a price quote
+	CategoryIDs []int64 `db:"-"`
+}
+
+type PromotionCollection []Promotion
+
+// PaginatedPromotionCollection model array with total record
+type PaginatedPromotionCollection struct {
+	Data PromotionCollection
+
+	// This will always return the total of all records
+	Total int64
+}
+
+type PromotionUpdate struct {
+	ID         int64
+	Name       *string
+	Value      *decimal.Decimal
+	StartsAt   *time.Time
+	EndsAt     *time.Time
+	UsageLimit *int64
+	StatusID   *int64
+}
+
+// Apply returns the promotion with the update applied, so the result can be
+// validated before it is stored
+func (p Promotion) Apply(update PromotionUpdate) Promotion {
+	if update.Name != nil {
+		p.Name = *update.Name
+	}
+	if update.Value != nil {
+		p.Value = *update.Value
+	}
+	if update.StartsAt != nil {
+		p.StartsAt = *update.StartsAt
+	}
+	if update.EndsAt != nil {
+		p.EndsAt = *update.EndsAt
+	}
+	if update.UsageLimit != nil {
+		p.UsageLimit = *update.UsageLimit
+	}
+	if update.StatusID != nil {
+		p.StatusID = *update.StatusID
+	}
+	return p
+}
+
+// Validate reports ErrInvalidPromotion when the rule cannot be applied
+func (p Promotion) Validate() error {
+	switch p.Kind {
+	case PromotionPercentage:
+		if !p.Value.IsPositive() || p.Value.GreaterThanOrEqual(hundred) {
+			return ErrInvalidPromotion
+		}
+	case PromotionFixedAmount:
+		if !p.Value.IsPositive() {
+			return ErrInvalidPromotion
+		}
+	case PromotionBuyXGetY:
+		if p.BuyQuantity < 1 || p.GetQuantity < 1 {
+			return ErrInvalidPromotion
+		}
+	default:
+		return ErrInvalidPromotion
+	}
+
+	switch p.Scope {
+	case PromotionScopeAll:
+		if p.ScopeID != 0 {
+			return ErrInvalidPromotion
+		}
+	case PromotionScopeBrand, PromotionScopeCategory, PromotionScopeSupplier, PromotionScopeProduct:
+		if p.ScopeID < 1 {
+			return ErrInvalidPromotion
+		}
+	default:
+		return ErrInvalidPromotion
+	}
+
+	if p.Currency != "" && !ValidCurrency(p.Currency) {
+		return ErrInvalidPromotion
+	}
+
+	if p.UsageLimit < 0 || (!p.StartsAt.IsZero() && !p.EndsAt.IsZero() && !p.EndsAt.After(p.StartsAt)) {
+		return ErrInvalidPromotion
+	}
+	return nil
+}
+
+// ActiveAt reports whether the promotion can be applied at t, a zero StartsAt
+// or EndsAt leaves the window open on that side and a zero UsageLimit is unlimited
+func (p Promotion) ActiveAt(t time.Time) bool {
+	if p.StatusID != 1 {
+		return false
+	}
+	if !p.StartsAt.IsZero() && t.Before(p.StartsAt) {
+		return false
+	}
+	if !p.EndsAt.IsZero() && !t.Before(p.EndsAt) {
+		return false
+	}
+	return p.UsageLimit == 0 || p.UsageCount < p.UsageLimit
+}
+
+// InCurrency returns the promotion applied to prices in currency, a fixed amount is
+// converted at the rates and is false when they have no rate for its currency
+func (p Promotion) InCurrency(currency string, rates ExchangeRateCollection) (Promotion, bool) {
+	if p.Kind != PromotionFixedAmount || p.Currency == currency {
+		return p, true
+	}
+	rate, ok := rates.Rate(p.Currency, currency)
+	if !ok {
+		return p, false
+	}
+	p.Value = NewMoney(p.Value, p.Currency).Convert(rate, currency).Amount
+	p.Currency = currency
+	return p, true
+}
+
+// Covers reports whether the priced line falls in the promotion scope
+func (p Promotion) Covers(line PriceLine) bool {
+	switch p.Scope {
+	case PromotionScopeAll:
+		return true
+	case PromotionScopeBrand:
+		return line.BrandID == p.ScopeID
+	case PromotionScopeCategory:
+		return line.CategoryID == p.ScopeID || slices.Contains(p.CategoryIDs, line.CategoryID)
+	case PromotionScopeSupplier:
+		return line.SupplierID == p.ScopeID
+	case PromotionScopeProduct:
+		return line.ProductID == p.ScopeID
+	}
+	return false
+}
+
+// Discount is the amount the promotion takes off the list price of the whole line
+func (p Promotion) Discount(line PriceLine) decimal.Decimal {
+	quantity := decimal.NewFromInt(line.Quantity)
+	switch p.Kind {
+	case PromotionPercentage:
+		return line.UnitPrice.Mul(quantity).Mul(p.Value).Div(hundred)
+	case PromotionFixedAmount:
+		return decimal.Min(p.Value, line.UnitPrice).Mul(quantity)
+	case PromotionBuyXGetY:
+		free := line.Quantity / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+		return line.UnitPrice.Mul(decimal.NewFromInt(free))
+	}
+	return decimal.Zero
+}
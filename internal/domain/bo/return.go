@@ -0,0 +1,180 @@
+package bo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrReturnNotFound          = errors.New("the return was not found")
+	ErrReturnItemNotFound      = errors.New("the item is not part of the order or return")
+	ErrReturnEmpty             = errors.New("the return has no items")
+	ErrInvalidReturnTransition = errors.New("the return status does not allow this operation")
+	ErrReturnStatusConflict    = errors.New("the return status was changed concurrently")
+	ErrInvalidReturnCondition  = errors.New("the returned item condition is not valid")
+	ErrReturnQuantityExceeded  = errors.New("the returned quantity exceeds the ordered quantity")
+	ErrOrderNotReturnable      = errors.New("the order was not shipped or delivered")
+	ErrNoRefundablePayment     = errors.New("the order has no captured payment covering the refund")
+)
+
+// ReturnStatus is the lifecycle state of a return
+type ReturnStatus string
+
+const (
+	ReturnRequested ReturnStatus = "requested"
+	ReturnApproved  ReturnStatus = "approved"
+	ReturnRejected  ReturnStatus = "rejected"
+	ReturnReceived  ReturnStatus = "received"
+	// ReturnRefunding claims a received return while its refund is with the
+	// payment provider, so a concurrent refund cannot pay it out twice
+	ReturnRefunding ReturnStatus = "refunding"
+	ReturnRefunded  ReturnStatus = "refunded"
+)
+
+// returnTransitions lists every status a return may move to from its current one,
+// rejected and refunded are final. A refund the provider failed goes back to received.
+var returnTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnRequested: {ReturnApproved, ReturnRejected},
+	ReturnApproved:  {ReturnReceived},
+	ReturnReceived:  {ReturnRefunding},
+	ReturnRefunding: {ReturnRefunded, ReturnReceived},
+}
+
+func (s ReturnStatus) Valid() bool {
+	switch s {
+	case ReturnRequested, ReturnApproved, ReturnRejected, ReturnReceived, ReturnRefunding, ReturnRefunded:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a return in status s may move to next
+func (s ReturnStatus) CanTransitionTo(next ReturnStatus) bool {
+	for _, allowed := range returnTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ReturnCondition is the state a returned item arrived in, sellable items go
+// back on hand while damaged ones are written off
+type ReturnCondition string
+
+const (
+	ReturnSellable ReturnCondition = "sellable"
+	ReturnDamaged  ReturnCondition = "damaged"
+)
+
+func (c ReturnCondition) Valid() bool {
+	return c == ReturnSellable || c == ReturnDamaged
+}
+
+type Return struct {
+	ID           int64           `db:"id"`
+	OrderID      int64           `db:"order_id"`
+	Status       ReturnStatus    `db:"status"`
+	Reason       string          `db:"reason"`
+	RefundAmount decimal.Decimal `db:"refund_amount"`
+	PaymentID    int64           `db:"payment_id"`
+	CreatedAt    time.Time       `db:"created_at"`
+	UpdatedAt    time.Time       `db:"updated_at"`
+	Items        []ReturnItem
+	Events       []ReturnEvent
+
+	// Currency is the currency of the order
+	Currency string `db:"-"`
+}
+
+type ReturnItem struct {
+	ID               int64           `db:"id"`
+	ReturnID         int64           `db:"return_id"`
+	OrderItemID      int64           `db:"order_item_id"`
+	ProductID        int64           `db:"product_id"`
+	VariantID        int64           `db:"variant_id"`
+	Quantity         int64           `db:"quantity"`
+	ReceivedQuantity int64           `db:"received_quantity"`
+	Condition        ReturnCondition `db:"condition"`
+
+	// UnitPrice is the price charged per unit on the order line
+	UnitPrice decimal.Decimal `db:"-"`
+	// Tax is the tax charged on top of the order line with exclusive pricing,
+	// over its whole OrderedQuantity
+	Tax             decimal.Decimal `db:"-"`
+	OrderedQuantity int64           `db:"-"`
+}
+
+// ReturnEvent is one step of the return audit trail
+type ReturnEvent struct {
+	ID         int64        `db:"id"`
+	ReturnID   int64        `db:"return_id"`
+	FromStatus ReturnStatus `db:"from_status"`
+	ToStatus   ReturnStatus `db:"to_status"`
+	Note       string       `db:"note"`
+	CreatedBy  string       `db:"created_by"`
+	CreatedAt  time.Time    `db:"created_at"`
+}
+
+type ReturnCollection []Return
+
+// RefundableAmount is what was charged for the items which came back,
+// whatever condition they arrived in. Their share of the tax of the order
+// line is rounded to the minor units of the currency.
+func (r Return) RefundableAmount() decimal.Decimal {
+	places := CurrencyMinorUnits(r.Currency)
+	amount := decimal.Zero
+	for _, item := range r.Items {
+		received := decimal.NewFromInt(item.ReceivedQuantity)
+		amount = amount.Add(item.UnitPrice.Mul(received))
+		if item.OrderedQuantity > 0 {
+			amount = amount.Add(item.Tax.Mul(received).DivRound(decimal.NewFromInt(item.OrderedQuantity), places))
+		}
+	}
+	return amount
+}
+
+// ReturnRequest asks to send back part of the lines of an order
+type ReturnRequest struct {
+	OrderID     int64
+	Reason      string
+	Items       []ReturnRequestItem
+	RequestedBy string
+}
+
+type ReturnRequestItem struct {
+	OrderItemID int64
+	Quantity    int64
+}
+
+// ReturnStatusChange moves a return from one status to the next, From guards
+// against a concurrent change. RefundAmount and PaymentID are only set when
+// the return is refunded.
+type ReturnStatusChange struct {
+	ReturnID     int64
+	From         ReturnStatus
+	To           ReturnStatus
+	Note         string
+	ChangedBy    string
+	RefundAmount decimal.Decimal
+	PaymentID    int64
+}
+
+// ReturnReceipt records the items of an approved return arriving back,
+// items which are not listed did not come back
+type ReturnReceipt struct {
+	ReturnID  int64
+	Items     []ReturnItemReceipt
+	Note      string
+	ChangedBy string
+}
+
+// ReturnItemReceipt is the quantity of a return item which came back, the whole
+// requested quantity when Quantity is 0
+type ReturnItemReceipt struct {
+	ReturnItemID int64
+	Quantity     int64
+	Condition    ReturnCondition
+}
@@ -0,0 +1,49 @@
+package bo
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSearchSynonymNotFound = errors.New("the search synonym was not found")
+	ErrInvalidSearchSynonym  = errors.New("a search synonym needs a term and a different synonym")
+	ErrSearchSynonymExists   = errors.New("the search synonym already exists")
+)
+
+// SuggestionKind is what a suggestion points to
+type SuggestionKind string
+
+const (
+	SuggestionProduct  SuggestionKind = "product"
+	SuggestionBrand    SuggestionKind = "brand"
+	SuggestionCategory SuggestionKind = "category"
+)
+
+// SuggestQuery asks for the names starting with or resembling Prefix,
+// Prefix is expected in lower case with single spaces
+type SuggestQuery struct {
+	Prefix string
+	Limit  int
+}
+
+// Suggestion is a name matching a typed prefix, ranked by whether it starts
+// with the prefix, then by trigram similarity and then by Popularity, the units sold
+type Suggestion struct {
+	Kind       SuggestionKind `db:"kind"`
+	ID         int64          `db:"id"`
+	Name       string         `db:"name"`
+	Popularity int64          `db:"popularity"`
+}
+
+type SuggestionCollection []Suggestion
+
+// SearchSynonym makes the suggestions for Term include the ones for Synonym
+type SearchSynonym struct {
+	ID        int64     `db:"id"`
+	Term      string    `db:"term"`
+	Synonym   string    `db:"synonym"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type SearchSynonymCollection []SearchSynonym
@@ -0,0 +1,71 @@
+package bo
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidStockMovementReason = errors.New("the stock movement reason is invalid")
+)
+
+// StockMovementReason explains why the stock of a product changed
+type StockMovementReason string
+
+const (
+	StockMovementReceipt    StockMovementReason = "receipt"
+	StockMovementSale       StockMovementReason = "sale"
+	StockMovementReturn     StockMovementReason = "return"
+	StockMovementAdjustment StockMovementReason = "adjustment"
+	StockMovementDamage     StockMovementReason = "damage"
+	StockMovementTransfer   StockMovementReason = "transfer"
+)
+
+// Valid reports whether r is one of the known movement reasons
+func (r StockMovementReason) Valid() bool {
+	switch r {
+	case StockMovementReceipt, StockMovementSale, StockMovementReturn,
+		StockMovementAdjustment, StockMovementDamage, StockMovementTransfer:
+		return true
+	}
+	return false
+}
+
+// StockMovementQuery represent stock movement model query parameter
+type StockMovementQuery struct {
+	ProductID int64
+	Limit     int
+	Offset    int
+}
+
+// StockMovement is a single signed change of a stock row, kept in an append-only ledger
+type StockMovement struct {
+	ID            int64               `db:"id"`
+	ProductID     int64               `db:"product_id"`
+	VariantID     int64               `db:"variant_id"`
+	WarehouseID   int64               `db:"warehouse_id"`
+	QuantityDelta int64               `db:"quantity_delta"`
+	BalanceAfter  int64               `db:"balance_after"`
+	Reason        StockMovementReason `db:"reason"`
+	Reference     string              `db:"reference"`
+	CreatedBy     string              `db:"created_by"`
+	CreatedAt     time.Time           `db:"created_at"`
+}
+
+type StockMovementCollection []StockMovement
+
+// PaginatedStockMovementCollection model array with total record
+type PaginatedStockMovementCollection struct {
+	Data StockMovementCollection
+
+	// This will always return the total of all records
+	Total int64
+}
+
+// StockDiscrepancy is a stock row whose quantity does not match the sum of its ledger
+type StockDiscrepancy struct {
+	WarehouseID    int64
+	VariantID      int64
+	StockQuantity  int64
+	LedgerQuantity int64
+}
@@ -0,0 +1,43 @@
+package bo
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrStockReservationNotFound  = errors.New("the stock reservation was not found")
+	ErrStockReservationNotActive = errors.New("the stock reservation is no longer active")
+)
+
+// DefaultStockReservationTTL is how long a reservation holds stock when no TTL is given
+const DefaultStockReservationTTL = 15 * time.Minute
+
+// StockReservationStatus is the lifecycle state of a reservation
+type StockReservationStatus string
+
+const (
+	StockReservationActive    StockReservationStatus = "active"
+	StockReservationConfirmed StockReservationStatus = "confirmed"
+	StockReservationReleased  StockReservationStatus = "released"
+	StockReservationExpired   StockReservationStatus = "expired"
+)
+
+// StockReservation holds a quantity of a stock row without taking it off hand,
+// only active reservations which are not past ExpiresAt reduce the available stock
+type StockReservation struct {
+	ID             int64                  `db:"id"`
+	ProductStockID int64                  `db:"product_stock_id"`
+	ProductID      int64                  `db:"product_id"`
+	VariantID      int64                  `db:"variant_id"`
+	WarehouseID    int64                  `db:"warehouse_id"`
+	Quantity       int64                  `db:"quantity"`
+	Status         StockReservationStatus `db:"status"`
+	Reference      string                 `db:"reference"`
+	ExpiresAt      time.Time              `db:"expires_at"`
+	CreatedAt      time.Time              `db:"created_at"`
+	UpdatedAt      time.Time              `db:"updated_at"`
+
+	// TTL is only used on creation to compute ExpiresAt
+	TTL time.Duration `db:"-"`
+}
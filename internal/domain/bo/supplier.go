@@ -13,6 +13,7 @@ var (
 type SupplierQuery struct {
 	Limit  int
 	Offset int
+	PageCursors
 }
 
 type Supplier struct {
@@ -33,6 +34,7 @@ type PaginatedSupplierCollection struct {
 
 	// This will always return the total of all records
 	Total int64
+	PageLinks
 }
 
 type SupplierUpdate struct {
@@ -0,0 +1,255 @@
+package bo
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrTaxClassNotFound    = errors.New("the tax class was not found")
+	ErrTaxClassExists      = errors.New("a tax class with that code already exists")
+	ErrInvalidTaxClass     = errors.New("the tax class is invalid")
+	ErrTaxRateNotFound     = errors.New("the tax rate was not found")
+	ErrInvalidTaxRate      = errors.New("the tax rate is invalid")
+	ErrInvalidJurisdiction = errors.New("the jurisdiction is not an ISO 3166 country or subdivision code")
+)
+
+// TaxPricing tells whether the prices include the tax
+type TaxPricing string
+
+const (
+	// TaxInclusive prices include the tax, it is taken out of them
+	TaxInclusive TaxPricing = "inclusive"
+	// TaxExclusive prices exclude the tax, it is added on top of them
+	TaxExclusive TaxPricing = "exclusive"
+)
+
+func (p TaxPricing) Valid() bool {
+	return p == TaxInclusive || p == TaxExclusive
+}
+
+var jurisdictionPattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+
+// ValidJurisdiction reports whether code is an ISO 3166-1 country code such as BD,
+// or an ISO 3166-2 subdivision code such as US-CA
+func ValidJurisdiction(code string) bool {
+	return jurisdictionPattern.MatchString(code)
+}
+
+// TaxSettings are the pricing of the store and the jurisdiction taxed by default
+type TaxSettings struct {
+	Pricing      TaxPricing
+	Jurisdiction string
+}
+
+// TaxClass groups the products taxed alike, such as standard, reduced or exempt goods.
+// A product takes the class assigned to it, else the one of its closest category
+// having one, else the default class.
+type TaxClass struct {
+	ID        int64
+	Code      string
+	Name      string
+	Default   bool
+	CreatedAt time.Time
+}
+
+type TaxClassCollection []TaxClass
+
+type TaxClassUpdate struct {
+	ID      int64
+	Name    *string
+	Default *bool
+}
+
+// TaxRate is a percentage levied on the products of a class in a jurisdiction. The rates of
+// the most specific jurisdiction apply, those of US-CA rather than those of US, and several
+// rates of a jurisdiction add up, such as a VAT and a supplementary duty.
+type TaxRate struct {
+	ID           int64
+	TaxClassID   int64
+	Jurisdiction string
+	Name         string
+	// Rate is a percentage, 15 for 15%
+	Rate      decimal.Decimal
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type TaxRateCollection []TaxRate
+
+type TaxRateUpdate struct {
+	ID         int64
+	TaxClassID int64
+	Name       *string
+	Rate       *decimal.Decimal
+}
+
+// ProductTaxRates are the tax class of a product and the rates of that class in a jurisdiction,
+// TaxClassID is 0 when the product has no class and there is no default class
+type ProductTaxRates struct {
+	ProductID  int64
+	TaxClassID int64
+	Rates      TaxRateCollection
+}
+
+// TaxRequest asks for the tax of priced lines in a jurisdiction, the default one when empty.
+// The tax is rounded to the minor units of Currency, the currency of the amounts.
+type TaxRequest struct {
+	Jurisdiction string
+	Currency     string
+	Lines        []TaxableLine
+}
+
+// TaxableLine is the amount charged for a line, with or without the tax depending on the pricing
+type TaxableLine struct {
+	CartItemID int64
+	ProductID  int64
+	Amount     decimal.Decimal
+}
+
+// TaxLine is the tax of a line: Net excludes it, Gross includes it
+type TaxLine struct {
+	CartItemID int64
+	ProductID  int64
+	TaxClassID int64
+	Amount     decimal.Decimal
+	Net        decimal.Decimal
+	Tax        decimal.Decimal
+	Gross      decimal.Decimal
+	Rates      TaxRateCollection
+}
+
+// TaxTotal is what a rate levied over all the lines, on their Taxable net amount
+type TaxTotal struct {
+	TaxRateID    int64
+	Name         string
+	Jurisdiction string
+	Rate         decimal.Decimal
+	Taxable      decimal.Decimal
+	Tax          decimal.Decimal
+}
+
+// TaxBreakdown is the tax of a set of lines per line and per rate. Gross is what is charged:
+// the amounts themselves with inclusive pricing, the amounts and the tax with exclusive pricing.
+type TaxBreakdown struct {
+	Pricing      TaxPricing
+	Jurisdiction string
+	Currency     string
+	Lines        []TaxLine
+	Totals       []TaxTotal
+	Net          decimal.Decimal
+	Tax          decimal.Decimal
+	Gross        decimal.Decimal
+}
+
+var hundred = decimal.NewFromInt(100)
+
+// CalculateTax computes the tax of every line at its rates. Each rate is rounded per line to
+// the minor units of currency, halves away from zero. With inclusive pricing the net amount is the amount divided by one
+// plus the sum of the rates and the rounded tax is taken out of the amount; with exclusive
+// pricing the amount is the net amount and the rounded tax is added to it.
+func CalculateTax(pricing TaxPricing, jurisdiction, currency string, lines []TaxLine) TaxBreakdown {
+	breakdown := TaxBreakdown{Pricing: pricing, Jurisdiction: jurisdiction, Currency: currency, Lines: []TaxLine{}, Totals: []TaxTotal{}}
+	places := CurrencyMinorUnits(currency)
+	totals := map[int64]int{}
+	var taxable, taxed []decimal.Decimal
+	var net, tax, gross decimal.Decimal
+
+	for _, line := range lines {
+		amount := line.Amount
+
+		sum := decimal.Zero
+		for _, rate := range line.Rates {
+			sum = sum.Add(rate.Rate)
+		}
+		base := amount
+		if pricing == TaxInclusive {
+			base = amount.Mul(hundred).Div(hundred.Add(sum))
+		}
+
+		lineTax := decimal.Zero
+		rateTaxes := make([]decimal.Decimal, len(line.Rates))
+		for i, rate := range line.Rates {
+			rateTaxes[i] = base.Mul(rate.Rate).Div(hundred).Round(places)
+			lineTax = lineTax.Add(rateTaxes[i])
+		}
+
+		lineNet, lineGross := amount, amount.Add(lineTax)
+		if pricing == TaxInclusive {
+			lineNet, lineGross = amount.Sub(lineTax), amount
+		}
+
+		for i, rate := range line.Rates {
+			j, ok := totals[rate.ID]
+			if !ok {
+				j = len(breakdown.Totals)
+				totals[rate.ID] = j
+				breakdown.Totals = append(breakdown.Totals, TaxTotal{
+					TaxRateID:    rate.ID,
+					Name:         rate.Name,
+					Jurisdiction: rate.Jurisdiction,
+					Rate:         rate.Rate,
+				})
+				taxable = append(taxable, decimal.Zero)
+				taxed = append(taxed, decimal.Zero)
+			}
+			taxable[j] = taxable[j].Add(lineNet)
+			taxed[j] = taxed[j].Add(rateTaxes[i])
+		}
+
+		line.Net = lineNet
+		line.Tax = lineTax
+		line.Gross = lineGross
+		breakdown.Lines = append(breakdown.Lines, line)
+
+		net = net.Add(lineNet)
+		tax = tax.Add(lineTax)
+		gross = gross.Add(lineGross)
+	}
+
+	for j := range breakdown.Totals {
+		breakdown.Totals[j].Taxable = taxable[j]
+		breakdown.Totals[j].Tax = taxed[j]
+	}
+	breakdown.Net = net
+	breakdown.Tax = tax
+	breakdown.Gross = gross
+	return breakdown
+}
+
+// Recalculate computes the tax again for other amounts of the same products, at the rates
+// the breakdown found for them. A product the breakdown does not have is not taxed.
+func (b TaxBreakdown) Recalculate(lines []TaxableLine) TaxBreakdown {
+	rates := map[int64]TaxLine{}
+	for _, line := range b.Lines {
+		rates[line.ProductID] = line
+	}
+
+	taxLines := make([]TaxLine, 0, len(lines))
+	for _, line := range lines {
+		taxLines = append(taxLines, TaxLine{
+			CartItemID: line.CartItemID,
+			ProductID:  line.ProductID,
+			TaxClassID: rates[line.ProductID].TaxClassID,
+			Amount:     line.Amount,
+			Rates:      rates[line.ProductID].Rates,
+		})
+	}
+	return CalculateTax(b.Pricing, b.Jurisdiction, b.Currency, taxLines)
+}
+
+// TaxableLines are the lines of a quote at their effective price
+func (q PriceQuote) TaxableLines() []TaxableLine {
+	lines := make([]TaxableLine, 0, len(q.Lines))
+	for _, line := range q.Lines {
+		lines = append(lines, TaxableLine{
+			CartItemID: line.CartItemID,
+			ProductID:  line.ProductID,
+			Amount:     line.LineTotal,
+		})
+	}
+	return lines
+}
@@ -0,0 +1,43 @@
+package bo
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrWarehouseNotFound = errors.New("the warehouse was not found")
+)
+
+// WarehouseQuery represent warehouse model query parameter
+type WarehouseQuery struct {
+	Limit  int
+	Offset int
+}
+
+type Warehouse struct {
+	ID        int64     `db:"id"`
+	Code      string    `db:"code"`
+	Name      string    `db:"name"`
+	Address   string    `db:"address"`
+	StatusID  int64     `db:"status_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type WarehouseCollection []Warehouse
+
+// PaginatedWarehouseCollection model array with total record
+type PaginatedWarehouseCollection struct {
+	Data WarehouseCollection
+
+	// This will always return the total of all records
+	Total int64
+}
+
+type WarehouseUpdate struct {
+	ID       int64
+	Code     *string
+	Name     *string
+	Address  *string
+	StatusID *int64
+}
@@ -0,0 +1,23 @@
+package definition
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore is the interface that wraps the operations of a file storage backend
+// defines the rules around what a storage backend has to be able to perform,
+// keys are slash separated paths such as products/12/3f2a.jpg
+// For blob store implementations, see internal/infrastructure/blobstores
+type BlobStore interface {
+	// Name identifies the backend in logs
+	Name() string
+	// Put stores size bytes of body under key, replacing any file stored there
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get opens the file stored under key, bo.ErrBlobNotFound when there is none
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the file stored under key, a missing file is not an error
+	Delete(ctx context.Context, key string) error
+	// URL is where clients download the file stored under key
+	URL(key string) string
+}
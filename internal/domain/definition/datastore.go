@@ -2,16 +2,32 @@ package definition
 
 import (
 	"context"
+	"time"
 
 	"techno-store/internal/domain/bo"
 )
 
 type DataStore struct {
-	Brand        BrandRepository
-	Category     CategoryRepository
-	Supplier     SupplierRepository
-	Product      ProductRepository
-	ProductStock ProductStockRepository
+	Brand            BrandRepository
+	Category         CategoryRepository
+	Supplier         SupplierRepository
+	Product          ProductRepository
+	ProductVariant   ProductVariantRepository
+	ProductMedia     ProductMediaRepository
+	ProductImport    ProductImportRepository
+	ProductPrice     ProductPriceRepository
+	Currency         CurrencyRepository
+	Tax              TaxRepository
+	ProductStock     ProductStockRepository
+	Warehouse        WarehouseRepository
+	StockMovement    StockMovementRepository
+	StockReservation StockReservationRepository
+	Cart             CartRepository
+	Order            OrderRepository
+	Payment          PaymentRepository
+	Return           ReturnRepository
+	Promotion        PromotionRepository
+	Search           SearchRepository
 }
 
 // BrandRepository is the interface that wraps the basic CRUD operations
@@ -34,6 +50,11 @@ type CategoryRepository interface {
 	UpdateCategory(ctx context.Context, updateCategory bo.CategoryUpdate) error
 	DeleteCategory(ctx context.Context, categoryID int64) error
 	ListCategories(ctx context.Context) (bo.PaginatedCategoryCollection, error)
+	ListCategoryAncestors(ctx context.Context, categoryID int64) (bo.CategoryCollection, error)
+	ListCategorySubtree(ctx context.Context, categoryID int64) (bo.CategoryCollection, error)
+	ListCategoryAttributes(ctx context.Context, categoryID int64) (bo.CategoryAttributeCollection, error)
+	CreateCategoryAttribute(ctx context.Context, attribute *bo.CategoryAttribute) error
+	DeleteCategoryAttribute(ctx context.Context, categoryID, attributeID int64) error
 }
 
 // SupplierRepository is the interface that wraps the basic CRUD operations
@@ -56,6 +77,74 @@ type ProductRepository interface {
 	UpdateProduct(ctx context.Context, updateProduct bo.ProductUpdate) error
 	DeleteProduct(ctx context.Context, productID int64) error
 	ListProducts(ctx context.Context, productQuery bo.ProductSearchQuery) (bo.PaginatedProductCollection, error)
+	ProductFacets(ctx context.Context, filter bo.ProductFilter, attributes bo.CategoryAttributeCollection) (bo.ProductFacets, error)
+	// ExportProducts calls yield with every product matching the filter, by id, as they are
+	// read from the database; an error returned by yield stops the export
+	ExportProducts(ctx context.Context, filter bo.ProductFilter, yield func(bo.ProductExportRow) error) error
+}
+
+// ProductVariantRepository is the interface that wraps the basic CRUD operations
+// defines the rules around what a ProductVariant repository has to be able to perform
+// For datastore implementations, see internal/infrastructure/datastores
+type ProductVariantRepository interface {
+	GetProductVariantByID(ctx context.Context, productID, variantID int64) (bo.ProductVariant, error)
+	CreateProductVariant(ctx context.Context, productVariant *bo.ProductVariant) error
+	UpdateProductVariant(ctx context.Context, updateProductVariant bo.ProductVariantUpdate) error
+	DeleteProductVariant(ctx context.Context, productID, variantID int64) error
+	ListProductVariants(ctx context.Context, productID int64) (bo.ProductVariantCollection, error)
+}
+
+// ProductMediaRepository is the interface that wraps the product media operations
+// defines the rules around what a ProductMedia repository has to be able to perform,
+// the files themselves are kept in a BlobStore
+// For datastore implementations, see internal/infrastructure/datastores
+type ProductMediaRepository interface {
+	GetProductMediaByID(ctx context.Context, productID, mediaID int64) (bo.ProductMedia, error)
+	// CreateProductMedia makes the first image of a product its primary image
+	CreateProductMedia(ctx context.Context, media *bo.ProductMedia) error
+	UpdateProductMedia(ctx context.Context, updateMedia bo.ProductMediaUpdate) error
+	// DeleteProductMedia returns the deleted media so its file can be removed from the blob store
+	DeleteProductMedia(ctx context.Context, productID, mediaID int64) (bo.ProductMedia, error)
+	ListProductMedia(ctx context.Context, productID int64) (bo.ProductMediaCollection, error)
+	// ClaimPendingRenditions hands up to limit images waiting for their renditions to one
+	// worker, including the ones whose worker gave up on them for longer than staleAfter
+	ClaimPendingRenditions(ctx context.Context, limit int, staleAfter time.Duration) (bo.ProductMediaCollection, error)
+	// SaveProductMediaRenditions replaces the renditions of an image and returns the
+	// storage keys of its previous renditions which are not among them
+	SaveProductMediaRenditions(ctx context.Context, mediaID int64, renditions []bo.ProductMediaRendition) ([]string, error)
+	FailProductMediaRenditions(ctx context.Context, mediaID int64, reason string) error
+	// RequeueProductMediaRenditions sets an image back to pending, its renditions are generated again
+	RequeueProductMediaRenditions(ctx context.Context, productID, mediaID int64) error
+}
+
+// ProductPriceRepository is the interface that wraps the price history and schedule operations
+// defines the rules around what a ProductPrice repository has to be able to perform,
+// the history records every write of the prices of a product, whatever its origin
+// For datastore implementations, see internal/infrastructure/datastores
+type ProductPriceRepository interface {
+	GetProductPriceTimeline(ctx context.Context, productID int64) (bo.ProductPriceTimeline, error)
+	// CreateProductPriceSchedule refuses a schedule overlapping a pending or active one of the product
+	CreateProductPriceSchedule(ctx context.Context, schedule *bo.ProductPriceSchedule) error
+	// CancelProductPriceSchedule drops a pending schedule, an active one is reverted right away
+	CancelProductPriceSchedule(ctx context.Context, productID, scheduleID int64) (bo.ProductPriceSchedule, error)
+	// ApplyDueProductPriceSchedules applies the schedules past their start and reverts the ones
+	// past their end, in the order of these times, and returns how many it went through
+	ApplyDueProductPriceSchedules(ctx context.Context) (int64, error)
+}
+
+// CurrencyRepository is the interface that wraps the exchange rate and currency price operations
+// defines the rules around what a Currency repository has to be able to perform
+// For datastore implementations, see internal/infrastructure/datastores
+type CurrencyRepository interface {
+	ListExchangeRates(ctx context.Context) (bo.ExchangeRateCollection, error)
+	// UpsertExchangeRates writes every rate or none of them
+	UpsertExchangeRates(ctx context.Context, rates bo.ExchangeRateCollection) error
+	DeleteExchangeRate(ctx context.Context, base, quote string) error
+	ListProductCurrencyPrices(ctx context.Context, productID int64) (bo.ProductCurrencyPriceCollection, error)
+	// FindProductCurrencyPrices returns the prices in currency of the products which have some, by product id
+	FindProductCurrencyPrices(ctx context.Context, productIDs []int64, currency string) (map[int64]bo.ProductCurrencyPrice, error)
+	SetProductCurrencyPrice(ctx context.Context, price *bo.ProductCurrencyPrice) error
+	DeleteProductCurrencyPrice(ctx context.Context, productID int64, currency string) error
 }
 
 // StockRepository is the interface that wraps the basic CRUD operations
@@ -65,6 +154,140 @@ type ProductStockRepository interface {
 	GetProductStockByID(ctx context.Context, productStockID int64) (bo.ProductStock, error)
 	CreateProductStock(ctx context.Context, productStock *bo.ProductStock) error
 	UpdateProductStock(ctx context.Context, updateProductStock bo.ProductStockUpdate) error
+	AdjustProductStock(ctx context.Context, adjustment bo.ProductStockAdjustment) (bo.ProductStock, error)
 	DeleteProductStock(ctx context.Context, productStockID int64) error
 	ListProductStocks(ctx context.Context, productStockQuery bo.ProductStockQuery) (bo.PaginatedProductStockCollection, error)
+	GetProductStockLevel(ctx context.Context, productID int64) (bo.ProductStockLevel, error)
+}
+
+// WarehouseRepository is the interface that wraps the basic CRUD operations
+// defines the rules around what a Warehouse repository has to be able to perform
+// For datastore implementations, see internal/infrastructure/datastores
+type WarehouseRepository interface {
+	GetWarehouseByID(ctx context.Context, warehouseID int64) (bo.Warehouse, error)
+	CreateWarehouse(ctx context.Context, warehouse *bo.Warehouse) error
+	UpdateWarehouse(ctx context.Context, updateWarehouse bo.WarehouseUpdate) error
+	DeleteWarehouse(ctx context.Context, warehouseID int64) error
+	ListWarehouses(ctx context.Context, warehouseQuery bo.WarehouseQuery) (bo.PaginatedWarehouseCollection, error)
+}
+
+// ProductImportRepository resolves the references of imported products and writes them in bulk
+type ProductImportRepository interface {
+	// FindProductReferences returns the brands, categories or suppliers whose id is one
+	// of ids or whose name is one of names, the names are compared case insensitively
+	FindProductReferences(ctx context.Context, kind bo.ProductReferenceKind, ids []int64, names []string) ([]bo.ProductReference, error)
+	// FindExistingProducts returns the ids of the existing products among keys
+	FindExistingProducts(ctx context.Context, keys []bo.ProductKey) (map[bo.ProductKey]int64, error)
+	// ImportProducts writes all the products or none, upsert updates the existing products
+	// of the same supplier and name. It returns the number of created and updated products.
+	ImportProducts(ctx context.Context, products []bo.Product, upsert bool) (created, updated int, err error)
+}
+
+// StockMovementRepository is the interface that wraps the read operations
+// of the append-only stock movement ledger, movements are written by the
+// ProductStockRepository together with the stock change they record
+// For datastore implementations, see internal/infrastructure/datastores
+type StockMovementRepository interface {
+	ListStockMovements(ctx context.Context, stockMovementQuery bo.StockMovementQuery) (bo.PaginatedStockMovementCollection, error)
+	ReconcileProductStock(ctx context.Context, productID int64) ([]bo.StockDiscrepancy, error)
+}
+
+// StockReservationRepository is the interface that wraps the reservation lifecycle
+// defines the rules around holding stock for a checkout until it is confirmed,
+// released or expired
+// For datastore implementations, see internal/infrastructure/datastores
+type StockReservationRepository interface {
+	GetStockReservationByID(ctx context.Context, reservationID int64) (bo.StockReservation, error)
+	CreateStockReservation(ctx context.Context, reservation *bo.StockReservation) error
+	ConfirmStockReservation(ctx context.Context, reservationID int64) (bo.StockReservation, error)
+	ReleaseStockReservation(ctx context.Context, reservationID int64) error
+	ExpireStockReservations(ctx context.Context) (int64, error)
+}
+
+// TaxRepository is the interface that wraps the tax class and rate table operations
+// defines the rules around what a Tax repository has to be able to perform
+// For datastore implementations, see internal/infrastructure/datastores
+type TaxRepository interface {
+	ListTaxClasses(ctx context.Context) (bo.TaxClassCollection, error)
+	// CreateTaxClass makes the class the only default one when it is the default
+	CreateTaxClass(ctx context.Context, class *bo.TaxClass) error
+	UpdateTaxClass(ctx context.Context, update bo.TaxClassUpdate) (bo.TaxClass, error)
+	DeleteTaxClass(ctx context.Context, classID int64) error
+	ListTaxRates(ctx context.Context, classID int64) (bo.TaxRateCollection, error)
+	CreateTaxRate(ctx context.Context, rate *bo.TaxRate) error
+	UpdateTaxRate(ctx context.Context, update bo.TaxRateUpdate) (bo.TaxRate, error)
+	DeleteTaxRate(ctx context.Context, classID, rateID int64) error
+	// ResolveProductTaxRates returns the tax class of every product found and its rates in the
+	// most specific jurisdiction having some, the country ones for a subdivision without any
+	ResolveProductTaxRates(ctx context.Context, productIDs []int64, jurisdiction string) (map[int64]bo.ProductTaxRates, error)
+}
+
+// CartRepository is the interface that wraps the cart and line item operations
+// defines the rules around what a Cart repository has to be able to perform
+// For datastore implementations, see internal/infrastructure/datastores
+type CartRepository interface {
+	GetCartByID(ctx context.Context, cartID int64) (bo.Cart, error)
+	CreateCart(ctx context.Context, cart *bo.Cart) error
+	DeleteCart(ctx context.Context, cartID int64) error
+	AddCartItem(ctx context.Context, item *bo.CartItem) (bo.Cart, error)
+	UpdateCartItem(ctx context.Context, update bo.CartItemUpdate) (bo.Cart, error)
+	RemoveCartItem(ctx context.Context, cartID, itemID int64) (bo.Cart, error)
+}
+
+// OrderRepository is the interface that wraps the order placement and status operations
+// defines the rules around what an Order repository has to be able to perform,
+// the allowed status transitions are enforced by the order service
+// For datastore implementations, see internal/infrastructure/datastores
+type OrderRepository interface {
+	GetOrderByID(ctx context.Context, orderID int64) (bo.Order, error)
+	PlaceOrder(ctx context.Context, placement bo.OrderPlacement) (bo.Order, error)
+	UpdateOrderStatus(ctx context.Context, change bo.OrderStatusChange) (bo.Order, error)
+	ListOrders(ctx context.Context, orderQuery bo.OrderQuery) (bo.PaginatedOrderCollection, error)
+}
+
+// PaymentRepository is the interface that wraps the payment operations
+// defines the rules around what a Payment repository has to be able to perform,
+// provider events are applied idempotently
+// For datastore implementations, see internal/infrastructure/datastores
+type PaymentRepository interface {
+	GetPaymentByID(ctx context.Context, paymentID int64) (bo.Payment, error)
+	ListOrderPayments(ctx context.Context, orderID int64) (bo.PaymentCollection, error)
+	CreatePayment(ctx context.Context, payment *bo.Payment) error
+	ApplyPaymentEvent(ctx context.Context, event bo.PaymentEvent) (bo.Payment, bool, error)
+}
+
+// ReturnRepository is the interface that wraps the return (RMA) operations
+// defines the rules around what a Return repository has to be able to perform,
+// every status change is recorded in the return audit trail
+// For datastore implementations, see internal/infrastructure/datastores
+type ReturnRepository interface {
+	GetReturnByID(ctx context.Context, returnID int64) (bo.Return, error)
+	ListOrderReturns(ctx context.Context, orderID int64) (bo.ReturnCollection, error)
+	CreateReturn(ctx context.Context, request bo.ReturnRequest) (bo.Return, error)
+	UpdateReturnStatus(ctx context.Context, change bo.ReturnStatusChange) (bo.Return, error)
+	ReceiveReturn(ctx context.Context, receipt bo.ReturnReceipt) (bo.Return, error)
+}
+
+// PromotionRepository is the interface that wraps the basic CRUD operations
+// defines the rules around what a Promotion repository has to be able to perform,
+// usage is counted when an order redeems a promotion
+// For datastore implementations, see internal/infrastructure/datastores
+type PromotionRepository interface {
+	GetPromotionByID(ctx context.Context, promotionID int64) (bo.Promotion, error)
+	CreatePromotion(ctx context.Context, promotion *bo.Promotion) error
+	UpdatePromotion(ctx context.Context, updatePromotion bo.PromotionUpdate) error
+	DeletePromotion(ctx context.Context, promotionID int64) error
+	ListPromotions(ctx context.Context, promotionQuery bo.PromotionQuery) (bo.PaginatedPromotionCollection, error)
+	ListApplicablePromotions(ctx context.Context, couponCode string) (bo.PromotionCollection, error)
+}
+
+// SearchRepository is the interface that wraps the search suggestion operations
+// defines the rules around what a Search repository has to be able to perform,
+// including the synonyms a suggestion is also looked up as
+// For datastore implementations, see internal/infrastructure/datastores
+type SearchRepository interface {
+	Suggest(ctx context.Context, query bo.SuggestQuery) (bo.SuggestionCollection, error)
+	ListSearchSynonyms(ctx context.Context) (bo.SearchSynonymCollection, error)
+	CreateSearchSynonym(ctx context.Context, synonym *bo.SearchSynonym) error
+	DeleteSearchSynonym(ctx context.Context, synonymID int64) error
 }
@@ -0,0 +1,26 @@
+package definition
+
+import (
+	"context"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+// PaymentGateway is the interface that wraps the operations of a payment provider
+// defines the rules around what a provider integration has to be able to perform,
+// no provider SDK type may leak through it
+// For gateway implementations, see internal/infrastructure/payments
+type PaymentGateway interface {
+	// Name identifies the provider on stored payments and webhook events
+	Name() string
+	// Authorize holds the amount on the payment method, a declined payment
+	// is reported through a failed event rather than an error
+	Authorize(ctx context.Context, request bo.PaymentRequest) (bo.PaymentEvent, error)
+	Capture(ctx context.Context, providerReference string, amount decimal.Decimal) (bo.PaymentEvent, error)
+	Refund(ctx context.Context, providerReference string, amount decimal.Decimal) (bo.PaymentEvent, error)
+	Void(ctx context.Context, providerReference string) (bo.PaymentEvent, error)
+	// ParseWebhook verifies and decodes a provider callback
+	ParseWebhook(payload []byte, signature string) (bo.PaymentEvent, error)
+}
@@ -0,0 +1,17 @@
+package definition
+
+import (
+	"context"
+
+	"techno-store/internal/domain/bo"
+)
+
+// TaxCalculator is the interface that wraps the tax computation of priced lines
+// defines the rules around what a tax engine has to be able to perform, the
+// pricing of the store tells whether the amounts include the tax
+// For the rate table implementation, see internal/domain/services
+type TaxCalculator interface {
+	// Calculate taxes every line at the rates of its product class in the jurisdiction
+	// of the request, a product without a class or rates there is not taxed
+	Calculate(ctx context.Context, request bo.TaxRequest) (bo.TaxBreakdown, error)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitCartService sync.Once
+var cartServiceInstance *cartService
+
+type cartService struct {
+	repo definition.CartRepository
+}
+
+func Cart(cartRepo definition.CartRepository) *cartService {
+	onceInitCartService.Do(func() {
+		cartServiceInstance = &cartService{
+			repo: cartRepo,
+		}
+	})
+
+	return cartServiceInstance
+}
+
+func (s *cartService) GetByID(ctx context.Context, cartID int64) (bo.Cart, error) {
+	return s.repo.GetCartByID(ctx, cartID)
+}
+
+func (s *cartService) Create(ctx context.Context, cart *bo.Cart) error {
+	return s.repo.CreateCart(ctx, cart)
+}
+
+func (s *cartService) Delete(ctx context.Context, cartID int64) error {
+	return s.repo.DeleteCart(ctx, cartID)
+}
+
+func (s *cartService) AddItem(ctx context.Context, item *bo.CartItem) (bo.Cart, error) {
+	return s.repo.AddCartItem(ctx, item)
+}
+
+func (s *cartService) UpdateItem(ctx context.Context, update bo.CartItemUpdate) (bo.Cart, error) {
+	return s.repo.UpdateCartItem(ctx, update)
+}
+
+func (s *cartService) RemoveItem(ctx context.Context, cartID, itemID int64) (bo.Cart, error) {
+	return s.repo.RemoveCartItem(ctx, cartID, itemID)
+}
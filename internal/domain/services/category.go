@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
 	"sync"
 
 	"techno-store/internal/domain/bo"
@@ -45,10 +49,87 @@ func (s *categoryService) CreateCategory(ctx context.Context, category bo.Catego
 	return category.ID, nil
 }
 
+// UpdateCategory moves the subtree of the category along with it when its parent changes
 func (s *categoryService) UpdateCategory(ctx context.Context, updateCategory bo.CategoryUpdate) error {
+	if updateCategory.ParentID != nil && *updateCategory.ParentID == updateCategory.ID {
+		return bo.ErrCategoryCycle
+	}
 	return s.repo.UpdateCategory(ctx, updateCategory)
 }
 
+// Ancestors returns the breadcrumb of a category, from its root down to the category itself
+func (s *categoryService) Ancestors(ctx context.Context, categoryID int64) (bo.CategoryCollection, error) {
+	return s.repo.ListCategoryAncestors(ctx, categoryID)
+}
+
+// Subtree returns a category and all of its descendants
+func (s *categoryService) Subtree(ctx context.Context, categoryID int64) (bo.CategoryCollection, error) {
+	return s.repo.ListCategorySubtree(ctx, categoryID)
+}
+
 func (s *categoryService) DeleteCategory(ctx context.Context, categoryID int64) error {
 	return s.repo.DeleteCategory(ctx, categoryID)
 }
+
+// Attributes returns the attribute template of a category, the inherited attributes first
+func (s *categoryService) Attributes(ctx context.Context, categoryID int64) (bo.CategoryAttributeCollection, error) {
+	return s.repo.ListCategoryAttributes(ctx, categoryID)
+}
+
+var attributeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// CreateAttribute adds an attribute to the template of a category and its subcategories
+func (s *categoryService) CreateAttribute(ctx context.Context, attribute bo.CategoryAttribute) (bo.CategoryAttribute, error) {
+	if err := validateCategoryAttribute(&attribute); err != nil {
+		return bo.CategoryAttribute{}, err
+	}
+	if err := s.repo.CreateCategoryAttribute(ctx, &attribute); err != nil {
+		return bo.CategoryAttribute{}, err
+	}
+	return attribute, nil
+}
+
+func validateCategoryAttribute(attribute *bo.CategoryAttribute) error {
+	attribute.Code = strings.TrimSpace(attribute.Code)
+	attribute.Name = strings.TrimSpace(attribute.Name)
+	if !attributeCodePattern.MatchString(attribute.Code) {
+		return fmt.Errorf("%w: the code must be lower case letters, digits and underscores", bo.ErrInvalidCategoryAttribute)
+	}
+	if attribute.Name == "" {
+		return fmt.Errorf("%w: the name is required", bo.ErrInvalidCategoryAttribute)
+	}
+
+	switch attribute.Type {
+	case bo.AttributeEnum:
+		if len(attribute.Options) == 0 {
+			return fmt.Errorf("%w: an enum needs options", bo.ErrInvalidCategoryAttribute)
+		}
+		for i, option := range attribute.Options {
+			if option == "" || slices.Contains(attribute.Options[:i], option) {
+				return fmt.Errorf("%w: the options must be distinct and not empty", bo.ErrInvalidCategoryAttribute)
+			}
+		}
+	case bo.AttributeText:
+		if attribute.Filterable {
+			return fmt.Errorf("%w: a text cannot be filterable", bo.ErrInvalidCategoryAttribute)
+		}
+		fallthrough
+	case bo.AttributeNumber, bo.AttributeBoolean:
+		if len(attribute.Options) > 0 {
+			return fmt.Errorf("%w: only an enum has options", bo.ErrInvalidCategoryAttribute)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", bo.ErrInvalidCategoryAttribute, attribute.Type)
+	}
+
+	if attribute.Unit != "" && attribute.Type != bo.AttributeNumber {
+		return fmt.Errorf("%w: only a number has a unit", bo.ErrInvalidCategoryAttribute)
+	}
+
+	return nil
+}
+
+// DeleteAttribute removes an attribute of a category, along with the values of its products
+func (s *categoryService) DeleteAttribute(ctx context.Context, categoryID, attributeID int64) error {
+	return s.repo.DeleteCategoryAttribute(ctx, categoryID, attributeID)
+}
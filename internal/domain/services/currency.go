@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxExchangeRateImportRows is the largest number of rates in an import file
+const MaxExchangeRateImportRows = 1000
+
+var onceInitCurrencyService sync.Once
+var currencyServiceInstance *currencyService
+
+type currencyService struct {
+	repo definition.CurrencyRepository
+}
+
+func Currency(currencyRepo definition.CurrencyRepository) *currencyService {
+	onceInitCurrencyService.Do(func() {
+		currencyServiceInstance = &currencyService{
+			repo: currencyRepo,
+		}
+	})
+
+	return currencyServiceInstance
+}
+
+func (s *currencyService) ExchangeRates(ctx context.Context) (bo.ExchangeRateCollection, error) {
+	return s.repo.ListExchangeRates(ctx)
+}
+
+// SetExchangeRates validates every rate before any is written, then adds or replaces them all
+func (s *currencyService) SetExchangeRates(ctx context.Context, rates bo.ExchangeRateCollection) (bo.ExchangeRateCollection, error) {
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("%w: no rate", bo.ErrInvalidExchangeRate)
+	}
+	seen := map[[2]string]bool{}
+	for i, rate := range rates {
+		if err := validateExchangeRate(rate); err != nil {
+			return nil, fmt.Errorf("rate %d: %w", i+1, err)
+		}
+		pair := [2]string{rate.Base, rate.Quote}
+		if seen[pair] {
+			return nil, fmt.Errorf("%w: %s/%s is repeated", bo.ErrInvalidExchangeRate, rate.Base, rate.Quote)
+		}
+		seen[pair] = true
+	}
+
+	if err := s.repo.UpsertExchangeRates(ctx, rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func validateExchangeRate(rate bo.ExchangeRate) error {
+	if !bo.ValidCurrency(rate.Base) || !bo.ValidCurrency(rate.Quote) {
+		return fmt.Errorf("%w: %s/%s", bo.ErrInvalidCurrency, rate.Base, rate.Quote)
+	}
+	if rate.Base == rate.Quote {
+		return fmt.Errorf("%w: the base and quote currencies are the same", bo.ErrInvalidExchangeRate)
+	}
+	if !rate.Rate.IsPositive() {
+		return fmt.Errorf("%w: the rate must be positive", bo.ErrInvalidExchangeRate)
+	}
+	return nil
+}
+
+// ImportExchangeRates sets the rates of a CSV file with the base, quote and rate columns
+func (s *currencyService) ImportExchangeRates(ctx context.Context, file io.Reader) (bo.ExchangeRateCollection, error) {
+	rates, err := readExchangeRates(file)
+	if err != nil {
+		return nil, err
+	}
+	return s.SetExchangeRates(ctx, rates)
+}
+
+func readExchangeRates(file io.Reader) (bo.ExchangeRateCollection, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = 3
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("%w: the file is empty", bo.ErrInvalidExchangeRate)
+		}
+		return nil, fmt.Errorf("%w: %s", bo.ErrInvalidExchangeRate, err)
+	}
+	columns := map[string]int{}
+	for i, column := range header {
+		if i == 0 {
+			// spreadsheets often save a CSV with a byte order mark
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range []string{"base", "quote", "rate"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%w: the column %s is missing", bo.ErrInvalidExchangeRate, column)
+		}
+	}
+
+	var rates bo.ExchangeRateCollection
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("%w: line %d: %s", bo.ErrInvalidExchangeRate, parseErr.Line, parseErr.Err)
+			}
+			return nil, fmt.Errorf("%w: %s", bo.ErrInvalidExchangeRate, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(rates) == MaxExchangeRateImportRows {
+			return nil, fmt.Errorf("%w: the file has more than %d rates", bo.ErrInvalidExchangeRate, MaxExchangeRateImportRows)
+		}
+
+		rate, err := decimal.NewFromString(strings.TrimSpace(record[columns["rate"]]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: the rate is not a number", bo.ErrInvalidExchangeRate, line)
+		}
+		rates = append(rates, bo.ExchangeRate{
+			Base:  strings.ToUpper(strings.TrimSpace(record[columns["base"]])),
+			Quote: strings.ToUpper(strings.TrimSpace(record[columns["quote"]])),
+			Rate:  rate,
+		})
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("%w: the file has no rate", bo.ErrInvalidExchangeRate)
+	}
+
+	return rates, nil
+}
+
+func (s *currencyService) DeleteExchangeRate(ctx context.Context, base, quote string) error {
+	return s.repo.DeleteExchangeRate(ctx, base, quote)
+}
+
+func (s *currencyService) ProductPrices(ctx context.Context, productID int64) (bo.ProductCurrencyPriceCollection, error) {
+	return s.repo.ListProductCurrencyPrices(ctx, productID)
+}
+
+// SetProductPrice overrides the converted prices of a product in a currency, the prices
+// cannot have more decimal places than the minor units of the currency
+func (s *currencyService) SetProductPrice(ctx context.Context, price bo.ProductCurrencyPrice) (bo.ProductCurrencyPrice, error) {
+	if !bo.ValidCurrency(price.Currency) {
+		return bo.ProductCurrencyPrice{}, fmt.Errorf("%w: %s", bo.ErrInvalidCurrency, price.Currency)
+	}
+	if !price.UnitPrice.IsPositive() {
+		return bo.ProductCurrencyPrice{}, fmt.Errorf("%w: the unit price must be positive", bo.ErrInvalidCurrencyPrice)
+	}
+	if price.DiscountPrice.IsNegative() {
+		return bo.ProductCurrencyPrice{}, fmt.Errorf("%w: the discount price must not be negative", bo.ErrInvalidCurrencyPrice)
+	}
+	if price.DiscountPrice.GreaterThan(price.UnitPrice) {
+		return bo.ProductCurrencyPrice{}, fmt.Errorf("%w: the discount price is more than the unit price", bo.ErrInvalidCurrencyPrice)
+	}
+	places := bo.CurrencyMinorUnits(price.Currency)
+	if !price.UnitPrice.Equal(price.UnitPrice.Round(places)) || !price.DiscountPrice.Equal(price.DiscountPrice.Round(places)) {
+		return bo.ProductCurrencyPrice{}, fmt.Errorf("%w: %s prices have at most %d decimal places", bo.ErrInvalidCurrencyPrice, price.Currency, places)
+	}
+
+	if err := s.repo.SetProductCurrencyPrice(ctx, &price); err != nil {
+		return bo.ProductCurrencyPrice{}, err
+	}
+	return price, nil
+}
+
+func (s *currencyService) DeleteProductPrice(ctx context.Context, productID int64, currency string) error {
+	return s.repo.DeleteProductCurrencyPrice(ctx, productID, currency)
+}
+
+// ConvertProducts puts the prices of the products in currency: the prices set for the
+// product in that currency when there are some, else its prices converted at the exchange
+// rate and rounded to the minor units of the currency, see bo.Money.Convert.
+func (s *currencyService) ConvertProducts(ctx context.Context, products bo.ProductCollection, currency string) error {
+	if currency == "" || len(products) == 0 {
+		return nil
+	}
+
+	rates, err := s.repo.ListExchangeRates(ctx)
+	if err != nil {
+		return err
+	}
+	productIDs := make([]int64, len(products))
+	for i, product := range products {
+		productIDs[i] = product.ID
+	}
+	overrides, err := s.repo.FindProductCurrencyPrices(ctx, productIDs, currency)
+	if err != nil {
+		return err
+	}
+
+	for i := range products {
+		if err := convertProduct(&products[i], rates, overrides, currency); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// convertProduct puts the prices of a product in currency, overrides are the prices
+// set in that currency by product id
+func convertProduct(product *bo.Product, rates bo.ExchangeRateCollection, overrides map[int64]bo.ProductCurrencyPrice, currency string) error {
+	if override, ok := overrides[product.ID]; ok {
+		product.SetPrices(override.UnitPrice, override.DiscountPrice, currency)
+		return nil
+	}
+
+	rate, ok := rates.Rate(product.Currency(), currency)
+	if !ok {
+		return fmt.Errorf("%w: %s to %s", bo.ErrExchangeRateNotFound, product.Currency(), currency)
+	}
+	product.UnitPrice = product.UnitPrice.Convert(rate, currency)
+	product.DiscountPrice = product.DiscountPrice.Convert(rate, currency)
+	return nil
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestConvertProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	currencyStore := mockdb.NewMockCurrencyRepository(ctrl)
+	service := &currencyService{repo: currencyStore}
+
+	rates := bo.ExchangeRateCollection{
+		{Base: "USD", Quote: "BDT", Rate: decimal.RequireFromString("117.25")},
+		{Base: "BDT", Quote: "JPY", Rate: decimal.RequireFromString("1.2345")},
+	}
+
+	product := func(unit, discount, currency string) bo.Product {
+		product := bo.Product{ID: 1}
+		product.SetPrices(decimal.RequireFromString(unit), decimal.RequireFromString(discount), currency)
+		return product
+	}
+
+	testCases := []struct {
+		name      string
+		product   bo.Product
+		currency  string
+		override  *bo.ProductCurrencyPrice
+		unitPrice string
+		discount  string
+		err       error
+	}{
+		// 10.05 * 117.25 = 1178.3625, rounded half away from zero to the poisha
+		{name: "Rate", product: product("10.05", "0", "USD"), currency: "BDT", unitPrice: "1178.36", discount: "0"},
+		// 1000 / 117.25 = 8.5287..., the rate of the opposite pair inverted
+		{name: "InverseRate", product: product("1000", "900", "BDT"), currency: "USD", unitPrice: "8.53", discount: "7.68"},
+		// 999.5 * 1.2345 = 1233.88275, yen have no minor unit
+		{name: "NoMinorUnit", product: product("999.5", "0", "BDT"), currency: "JPY", unitPrice: "1234", discount: "0"},
+		{name: "SameCurrency", product: product("499.5", "0", "BDT"), currency: "BDT", unitPrice: "499.5", discount: "0"},
+		{
+			name:      "Override",
+			product:   product("1000", "0", "BDT"),
+			currency:  "USD",
+			override:  &bo.ProductCurrencyPrice{ProductID: 1, Currency: "USD", UnitPrice: decimal.RequireFromString("8.99"), DiscountPrice: decimal.RequireFromString("7.99")},
+			unitPrice: "8.99",
+			discount:  "7.99",
+		},
+		{name: "NoRate", product: product("10", "0", "USD"), currency: "EUR", err: bo.ErrExchangeRateNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			overrides := map[int64]bo.ProductCurrencyPrice{}
+			if tc.override != nil {
+				overrides[tc.override.ProductID] = *tc.override
+			}
+			currencyStore.EXPECT().ListExchangeRates(gomock.Any()).Times(1).Return(rates, nil)
+			currencyStore.EXPECT().
+				FindProductCurrencyPrices(gomock.Any(), gomock.Eq([]int64{tc.product.ID}), gomock.Eq(tc.currency)).
+				Times(1).
+				Return(overrides, nil)
+
+			products := bo.ProductCollection{tc.product}
+			err := service.ConvertProducts(context.Background(), products, tc.currency)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.unitPrice, products[0].UnitPrice.Amount.String())
+			require.Equal(t, tc.discount, products[0].DiscountPrice.Amount.String())
+			require.Equal(t, tc.currency, products[0].Currency())
+			require.Equal(t, tc.currency, products[0].DiscountPrice.Currency)
+		})
+	}
+}
+
+func TestSetProductCurrencyPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	currencyStore := mockdb.NewMockCurrencyRepository(ctrl)
+	service := &currencyService{repo: currencyStore}
+
+	price := func(currency, unit, discount string) bo.ProductCurrencyPrice {
+		return bo.ProductCurrencyPrice{
+			ProductID:     1,
+			Currency:      currency,
+			UnitPrice:     decimal.RequireFromString(unit),
+			DiscountPrice: decimal.RequireFromString(discount),
+		}
+	}
+
+	testCases := []struct {
+		name  string
+		price bo.ProductCurrencyPrice
+		err   error
+	}{
+		{name: "Valid", price: price("USD", "8.99", "7.5")},
+		{name: "Mills", price: price("KWD", "2.755", "0")},
+		{name: "TooPrecise", price: price("JPY", "1200.5", "0"), err: bo.ErrInvalidCurrencyPrice},
+		{name: "DiscountAboveUnit", price: price("USD", "8.99", "9"), err: bo.ErrInvalidCurrencyPrice},
+		{name: "NoUnitPrice", price: price("USD", "0", "0"), err: bo.ErrInvalidCurrencyPrice},
+		{name: "Currency", price: price("usd", "8.99", "0"), err: bo.ErrInvalidCurrency},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.err == nil {
+				currencyStore.EXPECT().SetProductCurrencyPrice(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			}
+
+			_, err := service.SetProductPrice(context.Background(), tc.price)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
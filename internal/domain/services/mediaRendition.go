@@ -0,0 +1,240 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+)
+
+var onceInitMediaRenditionService sync.Once
+var mediaRenditionServiceInstance *mediaRenditionService
+
+// renditionClaimTimeout is how long an image claimed by a worker waits before another
+// worker takes it over, the first one having stopped or failed to reach the blob store
+const renditionClaimTimeout = 10 * time.Minute
+
+// renditionRequests wakes up an idle worker when an image needs its renditions,
+// the buffer of one is enough as a worker processes all the pending images
+var renditionRequests = make(chan struct{}, 1)
+
+func requestRenditions() {
+	select {
+	case renditionRequests <- struct{}{}:
+	default:
+	}
+}
+
+// renditionExtensions are the extensions of the rendition storage keys by format
+var renditionExtensions = map[string]string{
+	bo.RenditionJPEG: ".jpg",
+	bo.RenditionPNG:  ".png",
+	bo.RenditionWebP: ".webp",
+}
+
+type mediaRenditionService struct {
+	repo  definition.ProductMediaRepository
+	blobs definition.BlobStore
+	specs []bo.RenditionSpec
+}
+
+// MediaRendition generates the renditions in specs for every product image
+func MediaRendition(productMediaRepo definition.ProductMediaRepository, blobs definition.BlobStore, specs []bo.RenditionSpec) *mediaRenditionService {
+	onceInitMediaRenditionService.Do(func() {
+		mediaRenditionServiceInstance = &mediaRenditionService{
+			repo:  productMediaRepo,
+			blobs: blobs,
+			specs: specs,
+		}
+	})
+
+	return mediaRenditionServiceInstance
+}
+
+// StartWorkers generates the pending renditions until ctx is done. A worker is
+// woken up by an upload, and looks for pending images every interval in case
+// they were left by another instance or by a worker which stopped.
+func (s *mediaRenditionService) StartWorkers(ctx context.Context, workers int, interval time.Duration) {
+	for i := 0; i < workers; i++ {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				s.ProcessPending(ctx)
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				case <-renditionRequests:
+				}
+			}
+		}()
+	}
+}
+
+// ProcessPending generates the renditions of the pending images one at a time until none is left
+func (s *mediaRenditionService) ProcessPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := s.repo.ClaimPendingRenditions(ctx, 1, renditionClaimTimeout)
+		if err != nil {
+			slog.Error("failed to claim pending product media renditions", "cause", err)
+			return
+		}
+		if len(claimed) == 0 {
+			return
+		}
+
+		for _, media := range claimed {
+			if err := s.Process(ctx, media); err != nil {
+				slog.Error("failed to generate product media renditions", slog.Int64("mediaID", media.ID), "cause", err)
+			}
+		}
+	}
+}
+
+// Process generates the renditions of an image in the format of the original, PNG for
+// a GIF, and in WebP. The storage key of a rendition only depends on the original and on
+// the rendition spec, so processing an image again overwrites its renditions with the same
+// files, and the files of the renditions no longer configured are deleted.
+// An image which cannot be decoded is marked as failed, the other errors leave the image
+// claimed until renditionClaimTimeout so that another worker tries again.
+func (s *mediaRenditionService) Process(ctx context.Context, media bo.ProductMedia) error {
+	original, err := s.blobs.Get(ctx, media.StorageKey)
+	if err != nil {
+		if errors.Is(err, bo.ErrBlobNotFound) {
+			return s.repo.FailProductMediaRenditions(ctx, media.ID, "the original file is missing")
+		}
+		return err
+	}
+	src, _, err := image.Decode(original)
+	original.Close()
+	if err != nil {
+		return s.repo.FailProductMediaRenditions(ctx, media.ID, fmt.Sprintf("the image cannot be decoded: %s", err))
+	}
+
+	format := bo.RenditionPNG
+	if media.MimeType == "image/jpeg" {
+		format = bo.RenditionJPEG
+	}
+
+	var renditions []bo.ProductMediaRendition
+	for _, spec := range s.specs {
+		scaled := scaleImage(src, spec.MaxSide)
+		for _, f := range []string{format, bo.RenditionWebP} {
+			rendition, err := s.putRendition(ctx, media, spec, f, scaled)
+			if err != nil {
+				return err
+			}
+			renditions = append(renditions, rendition)
+		}
+	}
+
+	staleKeys, err := s.repo.SaveProductMediaRenditions(ctx, media.ID, renditions)
+	if err != nil {
+		if errors.Is(err, bo.ErrProductMediaNotFound) {
+			// the image was deleted meanwhile, its renditions would be left behind
+			for _, rendition := range renditions {
+				s.deleteBlob(rendition.StorageKey)
+			}
+			return nil
+		}
+		return err
+	}
+	for _, key := range staleKeys {
+		s.deleteBlob(key)
+	}
+
+	return nil
+}
+
+func (s *mediaRenditionService) putRendition(ctx context.Context, media bo.ProductMedia, spec bo.RenditionSpec, format string, img image.Image) (bo.ProductMediaRendition, error) {
+	var (
+		buf         bytes.Buffer
+		err         error
+		contentType string
+	)
+	switch format {
+	case bo.RenditionJPEG:
+		contentType = "image/jpeg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	case bo.RenditionPNG:
+		contentType = "image/png"
+		err = png.Encode(&buf, img)
+	case bo.RenditionWebP:
+		contentType = "image/webp"
+		err = nativewebp.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		return bo.ProductMediaRendition{}, fmt.Errorf("failed to encode the %s %s rendition: %w", spec.Name, format, err)
+	}
+
+	rendition := bo.ProductMediaRendition{
+		Name:       spec.Name,
+		Format:     format,
+		StorageKey: renditionKey(media.StorageKey, spec, format),
+		Width:      int64(img.Bounds().Dx()),
+		Height:     int64(img.Bounds().Dy()),
+		SizeBytes:  int64(buf.Len()),
+	}
+	if err := s.blobs.Put(ctx, rendition.StorageKey, &buf, rendition.SizeBytes, contentType); err != nil {
+		slog.Error("failed to store product media rendition", slog.String("blobStore", s.blobs.Name()), slog.String("key", rendition.StorageKey), "cause", err)
+		return bo.ProductMediaRendition{}, err
+	}
+
+	return rendition, nil
+}
+
+func (s *mediaRenditionService) deleteBlob(key string) {
+	if err := s.blobs.Delete(context.Background(), key); err != nil {
+		slog.Error("failed to delete product media rendition", slog.String("blobStore", s.blobs.Name()), slog.String("key", key), "cause", err)
+	}
+}
+
+// renditionKey stores a rendition next to its original, e.g. products/7/ab12.png has its
+// 160 pixels thumbnail in WebP at products/7/ab12/thumbnail-160.webp. The size is part of
+// the key so that the file behind a key never changes, even when a rendition is resized.
+func renditionKey(originalKey string, spec bo.RenditionSpec, format string) string {
+	return path.Join(strings.TrimSuffix(originalKey, path.Ext(originalKey)),
+		fmt.Sprintf("%s-%d%s", spec.Name, spec.MaxSide, renditionExtensions[format]))
+}
+
+// scaleImage fits an image in maxSide x maxSide pixels, a smaller image keeps its size
+func scaleImage(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if longest := max(width, height); longest > maxSide {
+		width = max(1, (width*maxSide+longest/2)/longest)
+		height = max(1, (height*maxSide+longest/2)/longest)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if width == bounds.Dx() && height == bounds.Dy() {
+		draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	} else {
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	}
+	return dst
+}
+
+// setRenditionURLs sets the urls of renditions stored in blobs
+func setRenditionURLs(blobs definition.BlobStore, renditions []bo.ProductMediaRendition) {
+	for i := range renditions {
+		renditions[i].URL = blobs.URL(renditions[i].StorageKey)
+	}
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/blobstores"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessRenditions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mediaStore := mockdb.NewMockProductMediaRepository(ctrl)
+	blobs := blobstores.NewLocalStore(t.TempDir(), "/v1/media")
+	service := &mediaRenditionService{
+		repo:  mediaStore,
+		blobs: blobs,
+		specs: []bo.RenditionSpec{{Name: "thumbnail", MaxSide: 160}, {Name: "large", MaxSide: 1280}},
+	}
+
+	original := image.NewNRGBA(image.Rect(0, 0, 300, 200))
+	for x := 0; x < 300; x++ {
+		for y := 0; y < 200; y++ {
+			original.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var content bytes.Buffer
+	require.NoError(t, png.Encode(&content, original))
+	media := bo.ProductMedia{ID: 3, ProductID: 7, Kind: bo.MediaImage, StorageKey: "products/7/ab12.png", MimeType: "image/png"}
+	require.NoError(t, blobs.Put(ctx, media.StorageKey, bytes.NewReader(content.Bytes()), int64(content.Len()), media.MimeType))
+
+	var saved []bo.ProductMediaRendition
+	mediaStore.EXPECT().SaveProductMediaRenditions(gomock.Any(), int64(3), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _ int64, renditions []bo.ProductMediaRendition) ([]string, error) {
+			saved = renditions
+			return nil, nil
+		})
+	require.NoError(t, service.Process(ctx, media))
+
+	want := map[string][2]int64{
+		"products/7/ab12/thumbnail-160.png":  {160, 107},
+		"products/7/ab12/thumbnail-160.webp": {160, 107},
+		// a smaller image keeps its size
+		"products/7/ab12/large-1280.png":  {300, 200},
+		"products/7/ab12/large-1280.webp": {300, 200},
+	}
+	require.Len(t, saved, len(want))
+	for _, rendition := range saved {
+		size, ok := want[rendition.StorageKey]
+		require.True(t, ok, rendition.StorageKey)
+		require.Equal(t, size, [2]int64{rendition.Width, rendition.Height}, rendition.StorageKey)
+
+		file, err := blobs.Get(ctx, rendition.StorageKey)
+		require.NoError(t, err)
+		var decoded image.Image
+		if rendition.Format == bo.RenditionWebP {
+			decoded, err = nativewebp.Decode(file)
+		} else {
+			decoded, err = png.Decode(file)
+		}
+		file.Close()
+		require.NoError(t, err, rendition.StorageKey)
+		require.Equal(t, int(rendition.Width), decoded.Bounds().Dx())
+	}
+
+	t.Run("Reprocess", func(t *testing.T) {
+		// the thumbnail was resized from 120 pixels, its previous files are deleted
+		staleKey := "products/7/ab12/thumbnail-120.webp"
+		require.NoError(t, blobs.Put(ctx, staleKey, strings.NewReader("stale"), 5, "image/webp"))
+		mediaStore.EXPECT().SaveProductMediaRenditions(gomock.Any(), int64(3), gomock.Eq(saved)).Times(1).Return([]string{staleKey}, nil)
+
+		require.NoError(t, service.Process(ctx, media))
+		_, err := blobs.Get(ctx, staleKey)
+		require.ErrorIs(t, err, bo.ErrBlobNotFound)
+	})
+
+	t.Run("Undecodable", func(t *testing.T) {
+		broken := bo.ProductMedia{ID: 4, ProductID: 7, Kind: bo.MediaImage, StorageKey: "products/7/cd34.png", MimeType: "image/png"}
+		require.NoError(t, blobs.Put(ctx, broken.StorageKey, bytes.NewReader(content.Bytes()[:64]), 64, broken.MimeType))
+		mediaStore.EXPECT().FailProductMediaRenditions(gomock.Any(), int64(4), gomock.Any()).Times(1).Return(nil)
+
+		require.NoError(t, service.Process(ctx, broken))
+	})
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitOrderService sync.Once
+var orderServiceInstance *orderService
+
+type orderService struct {
+	repo definition.OrderRepository
+}
+
+func Order(orderRepo definition.OrderRepository) *orderService {
+	onceInitOrderService.Do(func() {
+		orderServiceInstance = &orderService{
+			repo: orderRepo,
+		}
+	})
+
+	return orderServiceInstance
+}
+
+func (s *orderService) GetByID(ctx context.Context, orderID int64) (bo.Order, error) {
+	return s.repo.GetOrderByID(ctx, orderID)
+}
+
+func (s *orderService) List(ctx context.Context, query bo.OrderQuery) (bo.PaginatedOrderCollection, error) {
+	return s.repo.ListOrders(ctx, query)
+}
+
+func (s *orderService) Place(ctx context.Context, placement bo.OrderPlacement) (bo.Order, error) {
+	return s.repo.PlaceOrder(ctx, placement)
+}
+
+// Transition moves an order to the next status when the lifecycle allows it,
+// ending an order before it was shipped puts its stock back on hand.
+func (s *orderService) Transition(ctx context.Context, orderID int64, next bo.OrderStatus, changedBy string) (bo.Order, error) {
+	if !next.Valid() {
+		return bo.Order{}, bo.ErrInvalidOrderStatus
+	}
+
+	order, err := s.repo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return bo.Order{}, err
+	}
+	if !order.Status.CanTransitionTo(next) {
+		return bo.Order{}, bo.ErrInvalidOrderTransition
+	}
+
+	return s.repo.UpdateOrderStatus(ctx, bo.OrderStatusChange{
+		OrderID:   orderID,
+		From:      order.Status,
+		To:        next,
+		Restock:   order.Status.Restocks(next),
+		ChangedBy: changedBy,
+	})
+}
+
+func (s *orderService) Cancel(ctx context.Context, orderID int64, changedBy string) (bo.Order, error) {
+	return s.Transition(ctx, orderID, bo.OrderCancelled, changedBy)
+}
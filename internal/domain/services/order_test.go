@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The service is a singleton, so every case shares one mock repository
+	orderStore := mockdb.NewMockOrderRepository(ctrl)
+	service := Order(orderStore)
+
+	testCases := []struct {
+		name    string
+		from    bo.OrderStatus
+		to      bo.OrderStatus
+		restock bool
+		err     error
+	}{
+		{name: "Pay", from: bo.OrderPending, to: bo.OrderPaid},
+		{name: "Pack", from: bo.OrderPaid, to: bo.OrderPacked},
+		{name: "Ship", from: bo.OrderPacked, to: bo.OrderShipped},
+		{name: "Deliver", from: bo.OrderShipped, to: bo.OrderDelivered},
+		{name: "CancelPending", from: bo.OrderPending, to: bo.OrderCancelled, restock: true},
+		{name: "CancelPacked", from: bo.OrderPacked, to: bo.OrderCancelled, restock: true},
+		{name: "RefundPaid", from: bo.OrderPaid, to: bo.OrderRefunded, restock: true},
+		{name: "RefundDelivered", from: bo.OrderDelivered, to: bo.OrderRefunded},
+		{name: "SkipPayment", from: bo.OrderPending, to: bo.OrderShipped, err: bo.ErrInvalidOrderTransition},
+		{name: "CancelShipped", from: bo.OrderShipped, to: bo.OrderCancelled, err: bo.ErrInvalidOrderTransition},
+		{name: "ReopenCancelled", from: bo.OrderCancelled, to: bo.OrderPending, err: bo.ErrInvalidOrderTransition},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			orderStore.EXPECT().
+				GetOrderByID(gomock.Any(), gomock.Eq(int64(1))).
+				Times(1).
+				Return(bo.Order{ID: 1, Status: tc.from}, nil)
+
+			if tc.err == nil {
+				orderStore.EXPECT().
+					UpdateOrderStatus(gomock.Any(), gomock.Eq(bo.OrderStatusChange{
+						OrderID: 1,
+						From:    tc.from,
+						To:      tc.to,
+						Restock: tc.restock,
+					})).
+					Times(1).
+					Return(bo.Order{ID: 1, Status: tc.to}, nil)
+			}
+
+			order, err := service.Transition(context.Background(), 1, tc.to, "")
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.to, order.Status)
+		})
+	}
+
+	t.Run("UnknownStatus", func(t *testing.T) {
+		_, err := service.Transition(context.Background(), 1, bo.OrderStatus("lost"), "")
+		require.ErrorIs(t, err, bo.ErrInvalidOrderStatus)
+	})
+}
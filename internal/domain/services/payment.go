@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+
+	"github.com/shopspring/decimal"
+)
+
+var onceInitPaymentService sync.Once
+var paymentServiceInstance *paymentService
+
+type paymentService struct {
+	repo      definition.PaymentRepository
+	orderRepo definition.OrderRepository
+	gateway   definition.PaymentGateway
+}
+
+func Payment(paymentRepo definition.PaymentRepository, orderRepo definition.OrderRepository, gateway definition.PaymentGateway) *paymentService {
+	onceInitPaymentService.Do(func() {
+		paymentServiceInstance = &paymentService{
+			repo:      paymentRepo,
+			orderRepo: orderRepo,
+			gateway:   gateway,
+		}
+	})
+
+	return paymentServiceInstance
+}
+
+func (s *paymentService) GetByID(ctx context.Context, paymentID int64) (bo.Payment, error) {
+	return s.repo.GetPaymentByID(ctx, paymentID)
+}
+
+func (s *paymentService) ListByOrder(ctx context.Context, orderID int64) (bo.PaymentCollection, error) {
+	if _, err := s.orderRepo.GetOrderByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListOrderPayments(ctx, orderID)
+}
+
+// Authorize holds the order total on the payment method. A declined payment is
+// still stored, with the failed status, and reported as ErrPaymentDeclined.
+func (s *paymentService) Authorize(ctx context.Context, orderID int64, token string) (bo.Payment, error) {
+	order, err := s.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return bo.Payment{}, err
+	}
+	if order.Status != bo.OrderPending {
+		return bo.Payment{}, bo.ErrOrderNotPayable
+	}
+
+	event, err := s.gateway.Authorize(ctx, bo.PaymentRequest{OrderID: orderID, Amount: order.Total, Token: token})
+	if err != nil {
+		return bo.Payment{}, err
+	}
+
+	payment := bo.Payment{
+		OrderID:           orderID,
+		Provider:          event.Provider,
+		ProviderReference: event.ProviderReference,
+		Status:            event.Status,
+		Amount:            order.Total.Amount,
+	}
+	if err := s.repo.CreatePayment(ctx, &payment); err != nil {
+		return bo.Payment{}, err
+	}
+
+	if payment.Status == bo.PaymentFailed {
+		return payment, bo.ErrPaymentDeclined
+	}
+	return payment, nil
+}
+
+// Capture collects the authorized amount and marks the order as paid.
+func (s *paymentService) Capture(ctx context.Context, paymentID int64) (bo.Payment, error) {
+	payment, err := s.repo.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return bo.Payment{}, err
+	}
+	if !payment.Status.CanTransitionTo(bo.PaymentCaptured) {
+		return bo.Payment{}, bo.ErrInvalidPaymentTransition
+	}
+
+	event, err := s.gateway.Capture(ctx, payment.ProviderReference, payment.Amount)
+	if err != nil {
+		return bo.Payment{}, err
+	}
+	return s.HandleEvent(ctx, event)
+}
+
+// Refund gives back part of the captured amount, the whole refundable amount
+// when amount is 0. A full refund also refunds the order.
+func (s *paymentService) Refund(ctx context.Context, paymentID int64, amount decimal.Decimal) (bo.Payment, error) {
+	payment, err := s.repo.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return bo.Payment{}, err
+	}
+	if payment.Status != bo.PaymentCaptured {
+		return bo.Payment{}, bo.ErrInvalidPaymentTransition
+	}
+	if !amount.IsPositive() {
+		amount = payment.RefundableAmount()
+	}
+	if amount.GreaterThan(payment.RefundableAmount()) {
+		return bo.Payment{}, bo.ErrInvalidRefundAmount
+	}
+
+	event, err := s.gateway.Refund(ctx, payment.ProviderReference, amount)
+	if err != nil {
+		return bo.Payment{}, err
+	}
+	return s.HandleEvent(ctx, event)
+}
+
+// Void releases an authorization which was not captured.
+func (s *paymentService) Void(ctx context.Context, paymentID int64) (bo.Payment, error) {
+	payment, err := s.repo.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return bo.Payment{}, err
+	}
+	if !payment.Status.CanTransitionTo(bo.PaymentVoided) {
+		return bo.Payment{}, bo.ErrInvalidPaymentTransition
+	}
+
+	event, err := s.gateway.Void(ctx, payment.ProviderReference)
+	if err != nil {
+		return bo.Payment{}, err
+	}
+	return s.HandleEvent(ctx, event)
+}
+
+// HandleWebhook verifies a provider callback and applies it.
+func (s *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) (bo.Payment, error) {
+	event, err := s.gateway.ParseWebhook(payload, signature)
+	if err != nil {
+		return bo.Payment{}, err
+	}
+	return s.HandleEvent(ctx, event)
+}
+
+// HandleEvent applies a provider event once and moves the order along with
+// its payment, a repeated event returns the payment unchanged.
+func (s *paymentService) HandleEvent(ctx context.Context, event bo.PaymentEvent) (bo.Payment, error) {
+	payment, applied, err := s.repo.ApplyPaymentEvent(ctx, event)
+	if err != nil || !applied {
+		return payment, err
+	}
+
+	// a partial refund leaves the payment captured and the order unchanged
+	var next bo.OrderStatus
+	switch {
+	case event.Status == bo.PaymentCaptured:
+		next = bo.OrderPaid
+	case payment.Status == bo.PaymentRefunded:
+		next = bo.OrderRefunded
+	default:
+		return payment, nil
+	}
+
+	// The payment is already settled, an order which moved on in the meantime
+	// is left for an operator rather than failing the provider callback
+	if _, err := Order(s.orderRepo).Transition(ctx, payment.OrderID, next, event.Provider); err != nil {
+		slog.Warn("payment settled but the order was not updated",
+			slog.Int64("orderID", payment.OrderID), slog.String("status", string(next)), "cause", err)
+	}
+	return payment, nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitPricingService sync.Once
+var pricingServiceInstance *pricingService
+
+type pricingService struct {
+	promotionRepo definition.PromotionRepository
+	productRepo   definition.ProductRepository
+	cartRepo      definition.CartRepository
+	currencyRepo  definition.CurrencyRepository
+}
+
+func Pricing(promotionRepo definition.PromotionRepository, productRepo definition.ProductRepository, cartRepo definition.CartRepository,
+	currencyRepo definition.CurrencyRepository) *pricingService {
+	onceInitPricingService.Do(func() {
+		pricingServiceInstance = &pricingService{
+			promotionRepo: promotionRepo,
+			productRepo:   productRepo,
+			cartRepo:      cartRepo,
+			currencyRepo:  currencyRepo,
+		}
+	})
+
+	return pricingServiceInstance
+}
+
+// QuoteProduct is the effective price of one unit of a product
+func (s *pricingService) QuoteProduct(ctx context.Context, productID int64, couponCode string) (bo.PriceQuote, error) {
+	product, err := s.productRepo.GetProductByID(ctx, productID)
+	if err != nil {
+		return bo.PriceQuote{}, err
+	}
+
+	return s.quote(ctx, product.Currency(), []bo.PriceLine{{
+		ProductID:     product.ID,
+		BrandID:       product.BrandID,
+		CategoryID:    product.CategoryID,
+		SupplierID:    product.SupplierID,
+		Quantity:      1,
+		UnitPrice:     product.UnitPrice.Amount,
+		DiscountPrice: product.DiscountPrice.Amount,
+	}}, couponCode)
+}
+
+// QuoteCart is the effective price of the items of a cart, at the prices they
+// were added to the cart with
+func (s *pricingService) QuoteCart(ctx context.Context, cartID int64, couponCode string) (bo.PriceQuote, error) {
+	cart, err := s.cartRepo.GetCartByID(ctx, cartID)
+	if err != nil {
+		return bo.PriceQuote{}, err
+	}
+
+	products := map[int64]bo.Product{}
+	lines := make([]bo.PriceLine, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		product, ok := products[item.ProductID]
+		if !ok {
+			if product, err = s.productRepo.GetProductByID(ctx, item.ProductID); err != nil {
+				return bo.PriceQuote{}, err
+			}
+			products[item.ProductID] = product
+		}
+
+		lines = append(lines, bo.PriceLine{
+			CartItemID:    item.ID,
+			ProductID:     item.ProductID,
+			VariantID:     item.VariantID,
+			BrandID:       product.BrandID,
+			CategoryID:    product.CategoryID,
+			SupplierID:    product.SupplierID,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice.Amount,
+			DiscountPrice: item.DiscountPrice.Amount,
+		})
+	}
+
+	return s.quote(ctx, cart.Currency, lines, couponCode)
+}
+
+// quote prices the lines in currency with the promotions which apply now, an
+// unknown or used up coupon code is reported rather than silently ignored
+func (s *pricingService) quote(ctx context.Context, currency string, lines []bo.PriceLine, couponCode string) (bo.PriceQuote, error) {
+	couponCode = strings.TrimSpace(couponCode)
+	promotions, err := s.promotionRepo.ListApplicablePromotions(ctx, couponCode)
+	if err != nil {
+		return bo.PriceQuote{}, err
+	}
+
+	if couponCode != "" {
+		found := false
+		for _, promotion := range promotions {
+			if strings.EqualFold(promotion.CouponCode, couponCode) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return bo.PriceQuote{}, bo.ErrCouponNotFound
+		}
+	}
+
+	if promotions, err = s.inCurrency(ctx, currency, promotions); err != nil {
+		return bo.PriceQuote{}, err
+	}
+
+	return bo.QuotePrices(currency, lines, promotions), nil
+}
+
+// inCurrency converts the fixed amount promotions into currency, the rates are only
+// loaded when one is in another currency. A promotion without a rate is left out.
+func (s *pricingService) inCurrency(ctx context.Context, currency string, promotions bo.PromotionCollection) (bo.PromotionCollection, error) {
+	var rates bo.ExchangeRateCollection
+	loaded := false
+	converted := make(bo.PromotionCollection, 0, len(promotions))
+	for _, promotion := range promotions {
+		if promotion.Kind == bo.PromotionFixedAmount && promotion.Currency != currency && !loaded {
+			var err error
+			if rates, err = s.currencyRepo.ListExchangeRates(ctx); err != nil {
+				return nil, err
+			}
+			loaded = true
+		}
+
+		promotion, ok := promotion.InCurrency(currency, rates)
+		if !ok {
+			slog.Warn("promotion left out of the quote", slog.Int64("promotionID", promotion.ID),
+				slog.String("from", promotion.Currency), slog.String("to", currency))
+			continue
+		}
+		converted = append(converted, promotion)
+	}
+
+	return converted, nil
+}
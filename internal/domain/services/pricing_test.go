@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuoteCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The service is a singleton, so every case shares one set of mock repositories
+	promotionStore := mockdb.NewMockPromotionRepository(ctrl)
+	productStore := mockdb.NewMockProductRepository(ctrl)
+	cartStore := mockdb.NewMockCartRepository(ctrl)
+	currencyStore := mockdb.NewMockCurrencyRepository(ctrl)
+	service := Pricing(promotionStore, productStore, cartStore, currencyStore)
+
+	bdt := func(amount int64) bo.Money {
+		return bo.NewMoney(decimal.NewFromInt(amount), "BDT")
+	}
+	cart := bo.Cart{
+		ID:       1,
+		Currency: "BDT",
+		Items: []bo.CartItem{
+			{ID: 10, ProductID: 100, Quantity: 3, UnitPrice: bdt(50), DiscountPrice: bdt(0)},
+			{ID: 11, ProductID: 200, Quantity: 1, UnitPrice: bdt(80), DiscountPrice: bdt(60)},
+		},
+	}
+	// the same prices in dollars
+	usdCart := bo.Cart{ID: 1, Currency: "USD"}
+	for _, item := range cart.Items {
+		item.UnitPrice = bo.NewMoney(item.UnitPrice.Amount, "USD")
+		item.DiscountPrice = bo.NewMoney(item.DiscountPrice.Amount, "USD")
+		usdCart.Items = append(usdCart.Items, item)
+	}
+	products := map[int64]bo.Product{
+		100: {ID: 100, BrandID: 1, CategoryID: 7, SupplierID: 3},
+		200: {ID: 200, BrandID: 2, CategoryID: 9, SupplierID: 3},
+	}
+
+	// category 5 is the parent of category 7
+	electronics := bo.Promotion{ID: 1, Name: "Electronics week", Kind: bo.PromotionPercentage, Value: decimal.NewFromInt(10),
+		Scope: bo.PromotionScopeCategory, ScopeID: 5, CategoryIDs: []int64{5, 7}}
+	buyTwo := bo.Promotion{ID: 2, Name: "Buy 2 get 1", Kind: bo.PromotionBuyXGetY, BuyQuantity: 2, GetQuantity: 1,
+		Scope: bo.PromotionScopeProduct, ScopeID: 100}
+	supplier := bo.Promotion{ID: 3, Name: "Supplier deal", Kind: bo.PromotionFixedAmount, Value: decimal.NewFromInt(15),
+		Scope: bo.PromotionScopeSupplier, ScopeID: 3, CouponCode: "SAVE15", Currency: "BDT"}
+	supplierTaka := bo.Promotion{ID: 4, Name: "Supplier taka deal", Kind: bo.PromotionFixedAmount, Value: decimal.NewFromInt(1200),
+		Scope: bo.PromotionScopeSupplier, ScopeID: 3, Currency: "BDT"}
+	rates := bo.ExchangeRateCollection{{Base: "USD", Quote: "BDT", Rate: decimal.NewFromInt(120)}}
+
+	testCases := []struct {
+		name       string
+		cart       bo.Cart
+		couponCode string
+		promotions bo.PromotionCollection
+		// rates are only loaded for the dollar cart
+		rates   bo.ExchangeRateCollection
+		total   string
+		applied []int64
+		err     error
+	}{
+		// 150 - 10% = 135 on the first line, the static 60 on the second
+		{name: "CategoryDescendant", promotions: bo.PromotionCollection{electronics}, total: "195", applied: []int64{1}},
+		// one of three units is free, which beats 10% off
+		{name: "BestPromotionWins", promotions: bo.PromotionCollection{electronics, buyTwo}, total: "160", applied: []int64{2}},
+		// 15 off each unit, 80 - 15 = 65 does not beat the static 60
+		{name: "Coupon", couponCode: "save15", promotions: bo.PromotionCollection{supplier}, total: "165", applied: []int64{3}},
+		{name: "UnknownCoupon", couponCode: "nope", promotions: bo.PromotionCollection{electronics}, err: bo.ErrCouponNotFound},
+		{name: "NoPromotion", promotions: bo.PromotionCollection{}, total: "210"},
+		// 1200 taka is 10 dollars off each unit, 80 - 10 = 70 does not beat the static 60
+		{name: "FixedAmountConverted", cart: usdCart, promotions: bo.PromotionCollection{supplierTaka}, rates: rates, total: "180", applied: []int64{4}},
+		{name: "FixedAmountWithoutRate", cart: usdCart, promotions: bo.PromotionCollection{supplierTaka}, rates: bo.ExchangeRateCollection{}, total: "210"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.cart.ID == 0 {
+				tc.cart = cart
+			}
+			cartStore.EXPECT().
+				GetCartByID(gomock.Any(), gomock.Eq(int64(1))).
+				Times(1).
+				Return(tc.cart, nil)
+			productStore.EXPECT().
+				GetProductByID(gomock.Any(), gomock.Any()).
+				Times(2).
+				DoAndReturn(func(_ context.Context, productID int64) (bo.Product, error) {
+					return products[productID], nil
+				})
+			promotionStore.EXPECT().
+				ListApplicablePromotions(gomock.Any(), gomock.Eq(tc.couponCode)).
+				Times(1).
+				Return(tc.promotions, nil)
+			if tc.rates != nil {
+				currencyStore.EXPECT().
+					ListExchangeRates(gomock.Any()).
+					Times(1).
+					Return(tc.rates, nil)
+			}
+
+			quote, err := service.QuoteCart(context.Background(), 1, tc.couponCode)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.cart.Currency, quote.Currency)
+			require.Equal(t, "230", quote.Subtotal.String())
+			require.Equal(t, tc.total, quote.Total.String())
+			require.True(t, quote.Subtotal.Sub(quote.Total).Equal(quote.DiscountTotal))
+
+			var applied []int64
+			for _, promotion := range quote.Promotions {
+				applied = append(applied, promotion.PromotionID)
+			}
+			require.Equal(t, tc.applied, applied)
+		})
+	}
+}
@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 
 	"techno-store/internal/domain/bo"
@@ -13,13 +17,18 @@ var onceInitProductService sync.Once
 var productServiceInstance *productService
 
 type productService struct {
-	repo definition.ProductRepository
+	repo         definition.ProductRepository
+	categoryRepo definition.CategoryRepository
+	blobs        definition.BlobStore
 }
 
-func Product(productRepo definition.ProductRepository) *productService {
+// Product sets the urls of the product images from blobs, they are left empty without a blob store
+func Product(productRepo definition.ProductRepository, categoryRepo definition.CategoryRepository, blobs definition.BlobStore) *productService {
 	onceInitProductService.Do(func() {
 		productServiceInstance = &productService{
-			repo: productRepo,
+			repo:         productRepo,
+			categoryRepo: categoryRepo,
+			blobs:        blobs,
 		}
 	})
 
@@ -27,14 +36,146 @@ func Product(productRepo definition.ProductRepository) *productService {
 }
 
 func (s *productService) List(ctx context.Context, query bo.ProductSearchQuery) (bo.PaginatedProductCollection, error) {
-	return s.repo.ListProducts(ctx, query)
+	filter, _, err := s.attributeFilter(ctx, query.Filter)
+	if err != nil {
+		return bo.PaginatedProductCollection{}, err
+	}
+	query.Filter = filter
+
+	products, err := s.repo.ListProducts(ctx, query)
+	if err != nil {
+		return products, err
+	}
+	for i := range products.Data {
+		s.setImageURLs(&products.Data[i])
+	}
+	return products, nil
+}
+
+func (s *productService) setImageURLs(product *bo.Product) {
+	if s.blobs == nil {
+		return
+	}
+	for i := range product.Images {
+		product.Images[i].URL = s.blobs.URL(product.Images[i].StorageKey)
+		setRenditionURLs(s.blobs, product.Images[i].Renditions)
+	}
+}
+
+// Facets counts the products matching the filter per brand, category, supplier, verified supplier and price bucket,
+// and per value of the filterable attributes of the filtered category
+func (s *productService) Facets(ctx context.Context, filter bo.ProductFilter) (bo.ProductFacets, error) {
+	filter, attributes, err := s.attributeFilter(ctx, filter)
+	if err != nil {
+		return bo.ProductFacets{}, err
+	}
+	return s.repo.ProductFacets(ctx, filter, attributes)
+}
+
+// attributeFilter types the requested attribute values of the filter against
+// the attributes of its category, returned along with the filter. Without a
+// category filter there are no attributes to filter on.
+func (s *productService) attributeFilter(ctx context.Context, filter bo.ProductFilter) (bo.ProductFilter, bo.CategoryAttributeCollection, error) {
+	if filter.CategoryFilter < 1 {
+		if len(filter.Attributes) > 0 {
+			return filter, nil, fmt.Errorf("%w: attributes need a category", bo.ErrInvalidAttributeFilter)
+		}
+		return filter, nil, nil
+	}
+
+	attributes, err := s.categoryRepo.ListCategoryAttributes(ctx, filter.CategoryFilter)
+	if err != nil {
+		return filter, nil, err
+	}
+
+	codes := make([]string, 0, len(filter.Attributes))
+	for code := range filter.Attributes {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+
+	filter.AttributeFilters = make([]bo.AttributeFilter, 0, len(codes))
+	for _, code := range codes {
+		attribute, ok := attributes.Find(code)
+		if !ok || !attribute.Filterable {
+			return filter, nil, fmt.Errorf("%w: %s", bo.ErrInvalidAttributeFilter, code)
+		}
+		attributeFilter, err := parseAttributeFilter(attribute, filter.Attributes[code])
+		if err != nil {
+			return filter, nil, fmt.Errorf("%w: %s", bo.ErrInvalidAttributeFilter, code)
+		}
+		filter.AttributeFilters = append(filter.AttributeFilters, attributeFilter)
+	}
+
+	return filter, attributes, nil
+}
+
+// parseAttributeFilter parses the requested value of an attribute: comma
+// separated options of an enum, true or false, and a number or a min..max
+// range of a number whose bounds may be left out, e.g. 8, 6.1..6.7 or 128..
+func parseAttributeFilter(attribute bo.CategoryAttribute, value string) (bo.AttributeFilter, error) {
+	filter := bo.AttributeFilter{Code: attribute.Code}
+	value = strings.TrimSpace(value)
+
+	switch attribute.Type {
+	case bo.AttributeEnum:
+		for _, option := range strings.Split(value, ",") {
+			option = strings.TrimSpace(option)
+			if !slices.Contains(attribute.Options, option) {
+				return filter, fmt.Errorf("unknown option %q", option)
+			}
+			filter.Values = append(filter.Values, option)
+		}
+	case bo.AttributeBoolean:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Values = []any{boolean}
+	case bo.AttributeNumber:
+		lower, upper, isRange := strings.Cut(value, "..")
+		if !isRange {
+			upper = lower
+		}
+		for _, bound := range []struct {
+			value string
+			to    **float64
+		}{{lower, &filter.Min}, {upper, &filter.Max}} {
+			if bound.value == "" {
+				continue
+			}
+			number, err := strconv.ParseFloat(strings.TrimSpace(bound.value), 64)
+			if err != nil {
+				return filter, err
+			}
+			*bound.to = &number
+		}
+		if filter.Min == nil && filter.Max == nil {
+			return filter, fmt.Errorf("empty range")
+		}
+	default:
+		return filter, fmt.Errorf("attribute type %q cannot be filtered", attribute.Type)
+	}
+
+	return filter, nil
 }
 
 func (s *productService) GetProductByID(ctx context.Context, productID int64) (bo.Product, error) {
-	return s.repo.GetProductByID(ctx, productID)
+	product, err := s.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		return product, err
+	}
+	s.setImageURLs(&product)
+	return product, nil
 }
 
 func (s *productService) CreateProduct(ctx context.Context, product bo.Product) (int64, error) {
+	attributes, err := s.productAttributes(ctx, product.CategoryID, product.Attributes)
+	if err != nil {
+		return -1, err
+	}
+	product.Attributes = attributes
+
 	if err := s.repo.CreateProduct(ctx, &product); err != nil {
 		return -1, err
 	}
@@ -45,10 +186,87 @@ func (s *productService) CreateProduct(ctx context.Context, product bo.Product)
 	return product.ID, nil
 }
 
+// UpdateProduct checks the attribute values against the template of the
+// product category, the current values too when only the category changes
 func (s *productService) UpdateProduct(ctx context.Context, updateProduct bo.ProductUpdate) error {
+	if updateProduct.Attributes != nil || updateProduct.CategoryID != nil {
+		product, err := s.repo.GetProductByID(ctx, updateProduct.ID)
+		if err != nil {
+			return err
+		}
+
+		categoryID, values := product.CategoryID, product.Attributes
+		if updateProduct.CategoryID != nil {
+			categoryID = *updateProduct.CategoryID
+		}
+		if updateProduct.Attributes != nil {
+			values = updateProduct.Attributes
+		}
+
+		attributes, err := s.productAttributes(ctx, categoryID, values)
+		if err != nil {
+			return err
+		}
+		updateProduct.Attributes = attributes
+	}
+
 	return s.repo.UpdateProduct(ctx, updateProduct)
 }
 
+// productAttributes validates the attribute values of a product against the
+// template of its category and returns them without the null ones
+func (s *productService) productAttributes(ctx context.Context, categoryID int64, values map[string]any) (map[string]any, error) {
+	attributes, err := s.categoryRepo.ListCategoryAttributes(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return validProductAttributes(attributes, values)
+}
+
+// validProductAttributes checks the attribute values of a product against the template
+// of its category and returns them without the null ones
+func validProductAttributes(attributes bo.CategoryAttributeCollection, values map[string]any) (map[string]any, error) {
+	valid := make(map[string]any, len(values))
+	for code, value := range values {
+		if value == nil {
+			continue
+		}
+		attribute, ok := attributes.Find(code)
+		if !ok || !validAttributeValue(attribute, value) {
+			return nil, fmt.Errorf("%w: %s", bo.ErrInvalidProductAttribute, code)
+		}
+		valid[code] = value
+	}
+
+	for _, attribute := range attributes {
+		if _, ok := valid[attribute.Code]; attribute.Required && !ok {
+			return nil, fmt.Errorf("%w: %s is required", bo.ErrInvalidProductAttribute, attribute.Code)
+		}
+	}
+
+	return valid, nil
+}
+
+// validAttributeValue reports whether the value, as decoded from JSON, has the type of the attribute
+func validAttributeValue(attribute bo.CategoryAttribute, value any) bool {
+	switch attribute.Type {
+	case bo.AttributeEnum:
+		option, ok := value.(string)
+		return ok && slices.Contains(attribute.Options, option)
+	case bo.AttributeNumber:
+		_, ok := value.(float64)
+		return ok
+	case bo.AttributeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case bo.AttributeText:
+		text, ok := value.(string)
+		return ok && text != ""
+	}
+	return false
+}
+
 func (s *productService) DeleteProduct(ctx context.Context, productID int64) error {
 	return s.repo.DeleteProduct(ctx, productID)
 }
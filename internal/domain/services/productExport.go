@@ -0,0 +1,223 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+
+	"github.com/shopspring/decimal"
+)
+
+// productExportColumns are the values of the exported columns besides the attr.<code> ones
+var productExportColumns = map[string]func(bo.ProductExportRow) any{
+	"id":             func(p bo.ProductExportRow) any { return p.ID },
+	"name":           func(p bo.ProductExportRow) any { return p.Name },
+	"description":    func(p bo.ProductExportRow) any { return p.Description },
+	"specifications": func(p bo.ProductExportRow) any { return p.Specifications },
+	"brand_id":       func(p bo.ProductExportRow) any { return p.BrandID },
+	"brand":          func(p bo.ProductExportRow) any { return p.BrandName },
+	"category_id":    func(p bo.ProductExportRow) any { return p.CategoryID },
+	"category_path":  func(p bo.ProductExportRow) any { return strings.Join(p.CategoryPath, " > ") },
+	"supplier_id":    func(p bo.ProductExportRow) any { return p.SupplierID },
+	"supplier":       func(p bo.ProductExportRow) any { return p.SupplierName },
+	"unit_price":     func(p bo.ProductExportRow) any { return p.UnitPrice.Amount },
+	"discount_price": func(p bo.ProductExportRow) any { return p.DiscountPrice.Amount },
+	"currency":       func(p bo.ProductExportRow) any { return p.Currency() },
+	"tags":           func(p bo.ProductExportRow) any { return p.Tags },
+	"status_id":      func(p bo.ProductExportRow) any { return p.StatusID },
+	"stock":          func(p bo.ProductExportRow) any { return p.Stock },
+	"attributes":     func(p bo.ProductExportRow) any { return p.Attributes },
+}
+
+// DefaultProductExportColumns are the columns of an export without a column selection
+var DefaultProductExportColumns = []string{
+	"id", "name", "description", "specifications", "brand_id", "brand", "category_id", "category_path",
+	"supplier_id", "supplier", "unit_price", "discount_price", "currency", "tags", "status_id", "stock",
+	"attributes",
+}
+
+// productExport is a validated export, ready to be written
+type productExport struct {
+	repo    definition.ProductRepository
+	filter  bo.ProductFilter
+	format  bo.ProductExportFormat
+	columns []string
+	values  []func(bo.ProductExportRow) any
+}
+
+// Export checks the format, the columns and the filter of an export before anything is
+// written, so that a bad query can still be answered with an error. The columns are the
+// product columns, see DefaultProductExportColumns, and attr.<code> for an attribute value.
+func (s *productService) Export(ctx context.Context, query bo.ProductExportQuery) (*productExport, error) {
+	switch query.Format {
+	case bo.ExportCSV, bo.ExportNDJSON, bo.ExportXLSX:
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", bo.ErrInvalidProductExport, query.Format)
+	}
+
+	columns := query.Columns
+	if len(columns) == 0 {
+		columns = DefaultProductExportColumns
+	}
+	values := make([]func(bo.ProductExportRow) any, len(columns))
+	seen := map[string]bool{}
+	for i, column := range columns {
+		if seen[column] {
+			return nil, fmt.Errorf("%w: the column %s is repeated", bo.ErrInvalidProductExport, column)
+		}
+		seen[column] = true
+
+		if code, ok := strings.CutPrefix(column, attributeColumnPrefix); ok && code != "" {
+			values[i] = func(p bo.ProductExportRow) any { return p.Attributes[code] }
+			continue
+		}
+		value, ok := productExportColumns[column]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", bo.ErrInvalidProductExport, column)
+		}
+		values[i] = value
+	}
+
+	filter, _, err := s.attributeFilter(ctx, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productExport{repo: s.repo, filter: filter, format: query.Format, columns: columns, values: values}, nil
+}
+
+// Write streams the products to w as they are read, a header row first for CSV and XLSX
+func (e *productExport) Write(ctx context.Context, w io.Writer) error {
+	var writer productExportWriter
+	switch e.format {
+	case bo.ExportNDJSON:
+		writer = newNDJSONExportWriter(w, e.columns)
+	case bo.ExportXLSX:
+		writer = newXLSXWriter(w, "Products")
+	default:
+		writer = &csvExportWriter{writer: csv.NewWriter(w)}
+	}
+
+	if e.format != bo.ExportNDJSON {
+		header := make([]any, len(e.columns))
+		for i, column := range e.columns {
+			header[i] = column
+		}
+		if err := writer.WriteRow(header); err != nil {
+			return err
+		}
+	}
+
+	row := make([]any, len(e.columns))
+	err := e.repo.ExportProducts(ctx, e.filter, func(product bo.ProductExportRow) error {
+		for i, value := range e.values {
+			row[i] = value(product)
+		}
+		return writer.WriteRow(row)
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// productExportWriter writes the rows of an export in a file format, Close completes the file
+type productExportWriter interface {
+	WriteRow(values []any) error
+	Close() error
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func (w *csvExportWriter) WriteRow(values []any) error {
+	w.record = w.record[:0]
+	for _, value := range values {
+		w.record = append(w.record, exportText(value))
+	}
+	return w.writer.Write(w.record)
+}
+
+func (w *csvExportWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// ndjsonExportWriter writes an object per row with the columns in order
+type ndjsonExportWriter struct {
+	writer  *bufio.Writer
+	keys    [][]byte
+	line    bytes.Buffer
+	encoder *json.Encoder
+}
+
+func newNDJSONExportWriter(w io.Writer, columns []string) *ndjsonExportWriter {
+	keys := make([][]byte, len(columns))
+	for i, column := range columns {
+		keys[i], _ = json.Marshal(column)
+	}
+	writer := &ndjsonExportWriter{writer: bufio.NewWriter(w), keys: keys}
+	writer.encoder = json.NewEncoder(&writer.line)
+	writer.encoder.SetEscapeHTML(false)
+	return writer
+}
+
+func (w *ndjsonExportWriter) WriteRow(values []any) error {
+	w.line.Reset()
+	w.line.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			w.line.WriteByte(',')
+		}
+		w.line.Write(w.keys[i])
+		w.line.WriteByte(':')
+		if err := w.encoder.Encode(value); err != nil {
+			return err
+		}
+		// the encoder ends every value with a newline
+		w.line.Truncate(w.line.Len() - 1)
+	}
+	w.line.WriteString("}\n")
+
+	_, err := w.writer.Write(w.line.Bytes())
+	return err
+}
+
+func (w *ndjsonExportWriter) Close() error {
+	return w.writer.Flush()
+}
+
+// exportText formats a value for a text cell, an attribute map as JSON
+func exportText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case decimal.Decimal:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	document, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(document)
+}
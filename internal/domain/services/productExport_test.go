@@ -0,0 +1,103 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productStore := mockdb.NewMockProductRepository(ctrl)
+	// the service is built directly, the singleton keeps the mocks of the first test
+	service := &productService{repo: productStore, categoryRepo: mockdb.NewMockCategoryRepository(ctrl)}
+
+	rows := []bo.ProductExportRow{
+		{
+			Product:      bo.Product{ID: 1, Name: "Phone, \"X\"", UnitPrice: bo.NewMoney(decimal.RequireFromString("499.5"), "BDT"), StatusID: 1, Attributes: map[string]any{"ram": 8.0}},
+			BrandName:    "Acme",
+			CategoryPath: []string{"Electronics", "Phones"},
+			Stock:        12,
+		},
+		{Product: bo.Product{ID: 2, Name: "Cable <1m>", UnitPrice: bo.NewMoney(decimal.NewFromInt(5), "BDT"), StatusID: 1}, BrandName: "Acme", CategoryPath: []string{"Accessories"}},
+	}
+	productStore.EXPECT().ExportProducts(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, _ bo.ProductFilter, yield func(bo.ProductExportRow) error) error {
+			for _, row := range rows {
+				if err := yield(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	export := func(t *testing.T, format bo.ProductExportFormat, columns ...string) []byte {
+		e, err := service.Export(context.Background(), bo.ProductExportQuery{Format: format, Columns: columns})
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, e.Write(context.Background(), &buf))
+		return buf.Bytes()
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		require.Equal(t, "id,name,category_path,stock,attr.ram\n"+
+			"1,\"Phone, \"\"X\"\"\",Electronics > Phones,12,8\n"+
+			"2,Cable <1m>,Accessories,0,\n",
+			string(export(t, bo.ExportCSV, "id", "name", "category_path", "stock", "attr.ram")))
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		require.Equal(t, `{"name":"Phone, \"X\"","unit_price":499.5,"attributes":{"ram":8}}`+"\n"+
+			`{"name":"Cable <1m>","unit_price":5,"attributes":null}`+"\n",
+			string(export(t, bo.ExportNDJSON, "name", "unit_price", "attributes")))
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		file := export(t, bo.ExportXLSX, "id", "name", "brand")
+		archive, err := zip.NewReader(bytes.NewReader(file), int64(len(file)))
+		require.NoError(t, err)
+
+		var names []string
+		var sheet []byte
+		for _, part := range archive.File {
+			names = append(names, part.Name)
+			if part.Name == "xl/worksheets/sheet1.xml" {
+				reader, err := part.Open()
+				require.NoError(t, err)
+				sheet, err = io.ReadAll(reader)
+				require.NoError(t, err)
+			}
+		}
+		require.Equal(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/workbook.xml", "xl/worksheets/sheet1.xml"}, names)
+		require.Contains(t, string(sheet), `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+		require.Contains(t, string(sheet), `<row r="3"><c r="A3"><v>2</v></c><c r="B3" t="inlineStr"><is><t xml:space="preserve">Cable &lt;1m&gt;</t></is></c>`)
+		require.Contains(t, string(sheet), `</sheetData></worksheet>`)
+	})
+
+	for name, query := range map[string]bo.ProductExportQuery{
+		"UnknownFormat":   {Format: "pdf"},
+		"UnknownColumn":   {Format: bo.ExportCSV, Columns: []string{"id", "price"}},
+		"RepeatedColumn":  {Format: bo.ExportCSV, Columns: []string{"id", "id"}},
+		"EmptyAttrColumn": {Format: bo.ExportCSV, Columns: []string{"attr."}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.Export(context.Background(), query)
+			require.ErrorIs(t, err, bo.ErrInvalidProductExport)
+		})
+	}
+
+	require.Equal(t, []string{"A", "Z", "AA", "AZ", "BA", "ZZ", "AAA"}, []string{
+		xlsxColumn(0), xlsxColumn(25), xlsxColumn(26), xlsxColumn(51), xlsxColumn(52), xlsxColumn(701), xlsxColumn(702),
+	})
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+// The limits of Merchant Center on the attributes of an item
+const (
+	feedMaxTitleLength       = 150
+	feedMaxDescriptionLength = 5000
+	feedMaxAdditionalImages  = 10
+)
+
+// feedColumns are the attributes of an item in order, the header row of a TSV feed
+var feedColumns = []string{
+	"id", "title", "description", "link", "image_link", "additional_image_link", "availability",
+	"price", "sale_price", "brand", "condition", "google_product_category", "product_type", "identifier_exists",
+}
+
+var onceInitProductFeedService sync.Once
+var productFeedServiceInstance *productFeedService
+
+type productFeedService struct {
+	repo         definition.ProductRepository
+	currencyRepo definition.CurrencyRepository
+	blobs        definition.BlobStore
+	settings     bo.ProductFeedSettings
+
+	// generating serializes the generations, interval is only set by the scheduler
+	generating sync.Mutex
+	interval   time.Duration
+	feed       atomic.Pointer[bo.ProductFeed]
+}
+
+// ProductFeed generates the Merchant Center feed of the active products in stock,
+// priced in the feed currency, the image links are the urls of blobs
+func ProductFeed(productRepo definition.ProductRepository, currencyRepo definition.CurrencyRepository, blobs definition.BlobStore, settings bo.ProductFeedSettings) *productFeedService {
+	onceInitProductFeedService.Do(func() {
+		productFeedServiceInstance = &productFeedService{
+			repo:         productRepo,
+			currencyRepo: currencyRepo,
+			blobs:        blobs,
+			settings:     settings,
+		}
+	})
+
+	return productFeedServiceInstance
+}
+
+// Get returns the last generated feed, the first call generates it when the scheduler has not yet
+func (s *productFeedService) Get(ctx context.Context) (*bo.ProductFeed, error) {
+	if feed := s.feed.Load(); feed != nil {
+		return feed, nil
+	}
+
+	s.generating.Lock()
+	defer s.generating.Unlock()
+	// another request may have generated it while this one waited
+	if feed := s.feed.Load(); feed != nil {
+		return feed, nil
+	}
+	return s.generate(ctx)
+}
+
+// Generate builds the feed again from the catalog, it is served from then on
+func (s *productFeedService) Generate(ctx context.Context) (*bo.ProductFeed, error) {
+	s.generating.Lock()
+	defer s.generating.Unlock()
+	return s.generate(ctx)
+}
+
+func (s *productFeedService) generate(ctx context.Context) (*bo.ProductFeed, error) {
+	feed := &bo.ProductFeed{GeneratedAt: time.Now().UTC()}
+	if s.interval > 0 {
+		feed.ExpiresAt = feed.GeneratedAt.Add(s.interval)
+	}
+
+	var products []bo.ProductExportRow
+	err := s.repo.ExportProducts(ctx, bo.ProductFilter{}, func(product bo.ProductExportRow) error {
+		products = append(products, product)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The prices are converted the way the products are listed in the feed currency
+	rates, err := s.currencyRepo.ListExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	productIDs := make([]int64, len(products))
+	for i, product := range products {
+		productIDs[i] = product.ID
+	}
+	overrides, err := s.currencyRepo.FindProductCurrencyPrices(ctx, productIDs, s.settings.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []bo.ProductFeedItem
+	for _, product := range products {
+		// a product without a rate to the feed currency cannot be priced
+		if err := convertProduct(&product.Product, rates, overrides, s.settings.Currency); err != nil {
+			slog.Warn("product left out of the feed", slog.Int64("productID", product.ID), "cause", err)
+			feed.Skipped++
+			continue
+		}
+		item, ok := s.feedItem(product)
+		if !ok {
+			feed.Skipped++
+			continue
+		}
+		items = append(items, item)
+	}
+	feed.Items = len(items)
+
+	feed.Files = map[bo.ProductFeedFormat]bo.ProductFeedFile{
+		bo.FeedXML: newFeedFile(s.feedXML(items)),
+		bo.FeedTSV: newFeedFile(feedTSV(items)),
+	}
+	s.feed.Store(feed)
+
+	return feed, nil
+}
+
+// StartScheduler generates the feed right away, then every interval until ctx is done
+func (s *productFeedService) StartScheduler(ctx context.Context, interval time.Duration) {
+	s.generating.Lock()
+	s.interval = interval
+	s.generating.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			feed, err := s.Generate(ctx)
+			if err != nil {
+				slog.Error("failed to generate the product feed", "cause", err)
+			} else {
+				slog.Info("generated the product feed", slog.Int("items", feed.Items), slog.Int("skipped", feed.Skipped))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// feedItem maps a product to a feed item, a product without an image cannot be listed
+func (s *productFeedService) feedItem(product bo.ProductExportRow) (bo.ProductFeedItem, bool) {
+	if s.blobs == nil || len(product.ImageKeys) == 0 {
+		return bo.ProductFeedItem{}, false
+	}
+
+	description := product.Description
+	if description == "" {
+		description = product.Specifications
+	}
+	if description == "" {
+		description = product.Name
+	}
+
+	item := bo.ProductFeedItem{
+		ID:                    strconv.FormatInt(product.ID, 10),
+		Title:                 truncateRunes(product.Name, feedMaxTitleLength),
+		Description:           truncateRunes(description, feedMaxDescriptionLength),
+		Link:                  strings.ReplaceAll(s.settings.ProductURL, "{id}", strconv.FormatInt(product.ID, 10)),
+		ImageLink:             s.absoluteURL(s.blobs.URL(product.ImageKeys[0])),
+		Availability:          feedAvailability(product.Stock),
+		Price:                 feedPrice(product.UnitPrice),
+		Brand:                 product.BrandName,
+		Condition:             "new",
+		GoogleProductCategory: product.GoogleProductCategory,
+		ProductType:           strings.Join(product.CategoryPath, " > "),
+	}
+	for _, key := range product.ImageKeys[1:min(len(product.ImageKeys), feedMaxAdditionalImages+1)] {
+		item.AdditionalImageLinks = append(item.AdditionalImageLinks, s.absoluteURL(s.blobs.URL(key)))
+	}
+	if sale := product.Price(); sale.Amount.LessThan(product.UnitPrice.Amount) {
+		item.SalePrice = feedPrice(sale)
+	}
+
+	return item, true
+}
+
+// feedAvailability tells whether a product with that much available stock can be ordered
+func feedAvailability(stock int64) bo.FeedAvailability {
+	if stock > 0 {
+		return bo.FeedInStock
+	}
+	return bo.FeedOutOfStock
+}
+
+// feedPrice formats a price converted to the feed currency
+func feedPrice(price bo.Money) string {
+	return price.Round().Amount.StringFixed(bo.CurrencyMinorUnits(price.Currency)) + " " + price.Currency
+}
+
+// absoluteURL resolves a url of the blob store, relative to the API by default, against the base url
+func (s *productFeedService) absoluteURL(link string) string {
+	base, err := url.Parse(s.settings.BaseURL + "/")
+	if err != nil {
+		return link
+	}
+	reference, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	return base.ResolveReference(reference).String()
+}
+
+func truncateRunes(text string, length int) string {
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	return string([]rune(text)[:length])
+}
+
+// feedXML writes the items as an RSS 2.0 channel, the Merchant Center attributes in the g: namespace
+func (s *productFeedService) feedXML(items []bo.ProductFeedItem) []byte {
+	var buf bytes.Buffer
+	element := func(name, value string) {
+		if value == "" {
+			return
+		}
+		buf.WriteString("<" + name + ">")
+		xml.EscapeText(&buf, []byte(value))
+		buf.WriteString("</" + name + ">")
+	}
+
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">` + "\n<channel>")
+	element("title", s.settings.Title)
+	element("link", s.settings.BaseURL)
+	element("description", "The products of "+s.settings.Title)
+	buf.WriteString("\n")
+	for _, item := range items {
+		buf.WriteString("<item>")
+		element("g:id", item.ID)
+		element("title", item.Title)
+		element("description", item.Description)
+		element("link", item.Link)
+		element("g:image_link", item.ImageLink)
+		for _, link := range item.AdditionalImageLinks {
+			element("g:additional_image_link", link)
+		}
+		element("g:availability", string(item.Availability))
+		element("g:price", item.Price)
+		element("g:sale_price", item.SalePrice)
+		element("g:brand", item.Brand)
+		element("g:condition", item.Condition)
+		element("g:google_product_category", item.GoogleProductCategory)
+		element("g:product_type", item.ProductType)
+		// the products have no GTIN nor MPN
+		element("g:identifier_exists", "no")
+		buf.WriteString("</item>\n")
+	}
+	buf.WriteString("</channel>\n</rss>\n")
+
+	return buf.Bytes()
+}
+
+// feedTSV writes the items under a header row, tabs and line breaks in the values become spaces
+func feedTSV(items []bo.ProductFeedItem) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(strings.Join(feedColumns, "\t") + "\n")
+
+	values := make([]string, len(feedColumns))
+	for _, item := range items {
+		values = append(values[:0], item.ID, item.Title, item.Description, item.Link, item.ImageLink,
+			strings.Join(item.AdditionalImageLinks, ","), string(item.Availability), item.Price, item.SalePrice,
+			item.Brand, item.Condition, item.GoogleProductCategory, item.ProductType, "no")
+		for i, value := range values {
+			values[i] = tsvReplacer.Replace(value)
+		}
+		buf.WriteString(strings.Join(values, "\t") + "\n")
+	}
+
+	return buf.Bytes()
+}
+
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+func newFeedFile(content []byte) bo.ProductFeedFile {
+	sum := sha256.Sum256(content)
+	return bo.ProductFeedFile{Content: content, ETag: fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:16]))}
+}
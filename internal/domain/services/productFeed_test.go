@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/blobstores"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGenerateProductFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productStore := mockdb.NewMockProductRepository(ctrl)
+	currencyStore := mockdb.NewMockCurrencyRepository(ctrl)
+	service := &productFeedService{
+		repo:         productStore,
+		currencyRepo: currencyStore,
+		blobs:        blobstores.NewLocalStore(t.TempDir(), "/v1/media"),
+		settings: bo.ProductFeedSettings{
+			Title:      "Shop & Co",
+			ProductURL: "https://shop.example/products/{id}",
+			BaseURL:    "https://api.shop.example",
+			Currency:   "EUR",
+		},
+	}
+
+	price := func(amount, currency string) bo.Money {
+		return bo.NewMoney(decimal.RequireFromString(amount), currency)
+	}
+	rows := []bo.ProductExportRow{
+		{
+			Product:               bo.Product{ID: 1, Name: "Phone <X>", Description: "A phone\twith\nlines", UnitPrice: price("499.5", "EUR"), DiscountPrice: price("449", "EUR")},
+			BrandName:             "Acme",
+			CategoryPath:          []string{"Electronics", "Phones"},
+			Stock:                 12,
+			GoogleProductCategory: "267",
+			ImageKeys:             []string{"products/1/a.jpg", "products/1/b.jpg"},
+		},
+		// no image, Merchant Center would reject it
+		{Product: bo.Product{ID: 2, Name: "Cable", UnitPrice: price("5", "EUR")}, BrandName: "Acme", Stock: 3},
+		// a discount above the unit price is no sale, the prices in taka are converted
+		{
+			Product:      bo.Product{ID: 3, Name: "Case", Specifications: "Silicone", UnitPrice: price("1000", "BDT"), DiscountPrice: price("1200", "BDT")},
+			BrandName:    "Acme",
+			CategoryPath: []string{"Accessories"},
+			Stock:        1,
+			ImageKeys:    []string{"products/3/a.png"},
+		},
+		// there is no rate from dollars to euros
+		{
+			Product:   bo.Product{ID: 4, Name: "Charger", UnitPrice: price("20", "USD"), DiscountPrice: price("0", "USD")},
+			BrandName: "Acme",
+			Stock:     2,
+			ImageKeys: []string{"products/4/a.png"},
+		},
+	}
+	currencyStore.EXPECT().ListExchangeRates(gomock.Any()).Times(1).
+		Return(bo.ExchangeRateCollection{{Base: "BDT", Quote: "EUR", Rate: decimal.RequireFromString("0.01")}}, nil)
+	currencyStore.EXPECT().FindProductCurrencyPrices(gomock.Any(), []int64{1, 2, 3, 4}, "EUR").Times(1).
+		Return(map[int64]bo.ProductCurrencyPrice{}, nil)
+	productStore.EXPECT().ExportProducts(gomock.Any(), bo.ProductFilter{}, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _ bo.ProductFilter, yield func(bo.ProductExportRow) error) error {
+			for _, row := range rows {
+				if err := yield(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	feed, err := service.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, feed.Items)
+	require.Equal(t, 2, feed.Skipped)
+	require.True(t, feed.ExpiresAt.IsZero())
+
+	// the feed is served from memory once generated
+	again, err := service.Get(context.Background())
+	require.NoError(t, err)
+	require.Same(t, feed, again)
+
+	feedXML := string(feed.Files[bo.FeedXML].Content)
+	require.Contains(t, feedXML, `<channel><title>Shop &amp; Co</title><link>https://api.shop.example</link>`)
+	require.Contains(t, feedXML, "<item><g:id>1</g:id><title>Phone &lt;X&gt;</title><description>A phone&#x9;with&#xA;lines</description>"+
+		"<link>https://shop.example/products/1</link>"+
+		"<g:image_link>https://api.shop.example/v1/media/products/1/a.jpg</g:image_link>"+
+		"<g:additional_image_link>https://api.shop.example/v1/media/products/1/b.jpg</g:additional_image_link>"+
+		"<g:availability>in_stock</g:availability><g:price>499.50 EUR</g:price><g:sale_price>449.00 EUR</g:sale_price>"+
+		"<g:brand>Acme</g:brand><g:condition>new</g:condition><g:google_product_category>267</g:google_product_category>"+
+		"<g:product_type>Electronics &gt; Phones</g:product_type><g:identifier_exists>no</g:identifier_exists></item>")
+	require.NotContains(t, feedXML, "<g:id>2</g:id>")
+	require.NotContains(t, feedXML, "<g:id>4</g:id>")
+
+	lines := strings.Split(string(feed.Files[bo.FeedTSV].Content), "\n")
+	require.Equal(t, []string{
+		strings.Join(feedColumns, "\t"),
+		"1\tPhone <X>\tA phone with lines\thttps://shop.example/products/1\thttps://api.shop.example/v1/media/products/1/a.jpg\t" +
+			"https://api.shop.example/v1/media/products/1/b.jpg\tin_stock\t499.50 EUR\t449.00 EUR\tAcme\tnew\t267\tElectronics > Phones\tno",
+		"3\tCase\tSilicone\thttps://shop.example/products/3\thttps://api.shop.example/v1/media/products/3/a.png\t" +
+			"\tin_stock\t10.00 EUR\t\tAcme\tnew\t\tAccessories\tno",
+		"",
+	}, lines)
+
+	require.NotEqual(t, feed.Files[bo.FeedXML].ETag, feed.Files[bo.FeedTSV].ETag)
+	require.Equal(t, bo.FeedOutOfStock, feedAvailability(0))
+}
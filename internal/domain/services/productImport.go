@@ -0,0 +1,401 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+
+	"github.com/shopspring/decimal"
+)
+
+var onceInitProductImportService sync.Once
+var productImportServiceInstance *productImportService
+
+const (
+	// MaxProductImportRows is the largest number of products in one import file
+	MaxProductImportRows = 10000
+	// defaultProductStatus is the status of the imported products without a status_id
+	defaultProductStatus = 1
+	// attributeColumnPrefix starts the columns of the attribute values, e.g. attr.color
+	attributeColumnPrefix = "attr."
+)
+
+// productImportColumns are the columns of an import file besides the attributes, by whether they are required
+var productImportColumns = map[string]bool{
+	"name":           true,
+	"description":    false,
+	"specifications": false,
+	"brand":          true,
+	"category":       true,
+	"supplier":       true,
+	"unit_price":     true,
+	"discount_price": false,
+	"currency":       false,
+	"tags":           false,
+	"status_id":      false,
+}
+
+type productImportService struct {
+	repo         definition.ProductImportRepository
+	categoryRepo definition.CategoryRepository
+}
+
+func ProductImport(productImportRepo definition.ProductImportRepository, categoryRepo definition.CategoryRepository) *productImportService {
+	onceInitProductImportService.Do(func() {
+		productImportServiceInstance = &productImportService{
+			repo:         productImportRepo,
+			categoryRepo: categoryRepo,
+		}
+	})
+
+	return productImportServiceInstance
+}
+
+// importRow is a row of an import file by column, invalid is set when the row
+// has not as many fields as the header
+type importRow struct {
+	line    int
+	values  map[string]string
+	invalid string
+}
+
+// Import reads a CSV file of products with a header row. The brand, category and supplier
+// columns take an id or a name, and the attr.<code> columns the attribute values.
+// Every row is validated first, the products are only written when all the rows are valid,
+// otherwise the report lists the errors of every invalid row.
+// A file which cannot be read as a product CSV is an ErrInvalidProductImport.
+func (s *productImportService) Import(ctx context.Context, file io.Reader, options bo.ProductImportOptions) (bo.ProductImportReport, error) {
+	rows, err := readImportRows(file)
+	if err != nil {
+		return bo.ProductImportReport{}, err
+	}
+
+	report := bo.ProductImportReport{DryRun: options.DryRun, Rows: len(rows)}
+	importRows, rowErrors, err := s.validateRows(ctx, rows, options.Upsert)
+	if err != nil {
+		return bo.ProductImportReport{}, err
+	}
+	report.Valid = len(importRows)
+	report.Errors = rowErrors
+	if len(report.Errors) > 0 || options.DryRun {
+		return report, nil
+	}
+
+	products := make([]bo.Product, len(importRows))
+	for i, row := range importRows {
+		products[i] = row.Product
+	}
+	report.Created, report.Updated, err = s.repo.ImportProducts(ctx, products, options.Upsert)
+	if err != nil {
+		return bo.ProductImportReport{}, err
+	}
+
+	return report, nil
+}
+
+// readImportRows reads the rows of a CSV file by the lower case names of its header
+func readImportRows(file io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("%w: the file is empty", bo.ErrInvalidProductImport)
+		}
+		return nil, fmt.Errorf("%w: %s", bo.ErrInvalidProductImport, err)
+	}
+
+	columns := make([]string, len(header))
+	seen := map[string]bool{}
+	for i, column := range header {
+		if i == 0 {
+			// spreadsheets often save a CSV with a byte order mark
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		column = strings.ToLower(strings.TrimSpace(column))
+		if _, ok := productImportColumns[column]; !ok && !strings.HasPrefix(column, attributeColumnPrefix) {
+			return nil, fmt.Errorf("%w: unknown column %q", bo.ErrInvalidProductImport, header[i])
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("%w: the column %s is repeated", bo.ErrInvalidProductImport, column)
+		}
+		seen[column] = true
+		columns[i] = column
+	}
+	for column, required := range productImportColumns {
+		if required && !seen[column] {
+			return nil, fmt.Errorf("%w: the column %s is missing", bo.ErrInvalidProductImport, column)
+		}
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %s", bo.ErrInvalidProductImport, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(rows) == MaxProductImportRows {
+			return nil, fmt.Errorf("%w: the file has more than %d products", bo.ErrInvalidProductImport, MaxProductImportRows)
+		}
+
+		row := importRow{line: line, values: make(map[string]string, len(columns))}
+		for i, value := range record {
+			if i < len(columns) {
+				row.values[columns[i]] = strings.TrimSpace(value)
+			}
+		}
+		if errors.Is(err, csv.ErrFieldCount) {
+			// reported along with the other errors of the row
+			row.invalid = fmt.Sprintf("the row has %d fields instead of %d", len(record), len(columns))
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: the file has no product", bo.ErrInvalidProductImport)
+	}
+
+	return rows, nil
+}
+
+// validateRows returns the valid rows as products, or the errors of the invalid rows
+func (s *productImportService) validateRows(ctx context.Context, rows []importRow, upsert bool) ([]bo.ProductImportRow, []bo.ProductImportError, error) {
+	references := map[bo.ProductReferenceKind]*referenceResolver{}
+	for _, kind := range []bo.ProductReferenceKind{bo.ReferenceBrand, bo.ReferenceCategory, bo.ReferenceSupplier} {
+		resolver, err := s.resolveReferences(ctx, kind, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		references[kind] = resolver
+	}
+
+	var (
+		importRows []bo.ProductImportRow
+		rowErrors  []bo.ProductImportError
+		templates  = map[int64]bo.CategoryAttributeCollection{}
+		lines      = map[bo.ProductKey]int{}
+	)
+	for _, row := range rows {
+		var errs []bo.ProductImportError
+		fail := func(column, message string, args ...any) {
+			errs = append(errs, bo.ProductImportError{Line: row.line, Column: column, Message: fmt.Sprintf(message, args...)})
+		}
+		if row.invalid != "" {
+			fail("", "%s", row.invalid)
+		}
+
+		product := bo.Product{
+			Name:           row.values["name"],
+			Description:    row.values["description"],
+			Specifications: row.values["specifications"],
+			Tags:           row.values["tags"],
+			StatusID:       defaultProductStatus,
+		}
+		if product.Name == "" {
+			fail("name", "the name is required")
+		} else if utf8.RuneCountInString(product.Name) > 255 {
+			fail("name", "the name is longer than 255 characters")
+		}
+
+		for _, reference := range []struct {
+			kind bo.ProductReferenceKind
+			id   *int64
+		}{
+			{bo.ReferenceBrand, &product.BrandID},
+			{bo.ReferenceCategory, &product.CategoryID},
+			{bo.ReferenceSupplier, &product.SupplierID},
+		} {
+			var err error
+			if *reference.id, err = references[reference.kind].resolve(row.values[string(reference.kind)]); err != nil {
+				fail(string(reference.kind), "%s", err)
+			}
+		}
+
+		unitPrice, err := decimal.NewFromString(row.values["unit_price"])
+		if err != nil || !unitPrice.IsPositive() {
+			fail("unit_price", "the unit price must be a positive number")
+		}
+		discountPrice := decimal.Zero
+		if value := row.values["discount_price"]; value != "" {
+			if discountPrice, err = decimal.NewFromString(value); err != nil || discountPrice.IsNegative() {
+				fail("discount_price", "the discount price must be a number from 0")
+			} else if unitPrice.IsPositive() && discountPrice.GreaterThan(unitPrice) {
+				fail("discount_price", "the discount price is more than the unit price")
+			}
+		}
+		// without a currency the products are priced in the default currency of the store
+		currency := row.values["currency"]
+		if currency == "" {
+			currency = bo.DefaultCurrency
+		} else if !bo.ValidCurrency(currency) {
+			fail("currency", "the currency must be an ISO 4217 code such as BDT")
+		}
+		product.SetPrices(unitPrice, discountPrice, currency)
+		if value := row.values["status_id"]; value != "" {
+			if product.StatusID, err = strconv.ParseInt(value, 10, 64); err != nil || product.StatusID < 1 {
+				fail("status_id", "the status id must be a positive integer")
+			}
+		}
+
+		if product.CategoryID > 0 {
+			attributes, ok := templates[product.CategoryID]
+			if !ok {
+				if attributes, err = s.categoryRepo.ListCategoryAttributes(ctx, product.CategoryID); err != nil {
+					return nil, nil, err
+				}
+				templates[product.CategoryID] = attributes
+			}
+			product.Attributes, err = importAttributes(attributes, row.values)
+			if err != nil {
+				fail("", "%s", err)
+			}
+		}
+
+		if product.Name != "" && product.SupplierID > 0 {
+			key := bo.ProductKey{SupplierID: product.SupplierID, Name: product.Name}
+			if first, ok := lines[key]; ok {
+				fail("name", "the supplier already has this product on line %d", first)
+			} else {
+				lines[key] = row.line
+			}
+		}
+
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+		importRows = append(importRows, bo.ProductImportRow{Line: row.line, Product: product})
+	}
+
+	if !upsert && len(importRows) > 0 {
+		keys := make([]bo.ProductKey, len(importRows))
+		for i, row := range importRows {
+			keys[i] = bo.ProductKey{SupplierID: row.Product.SupplierID, Name: row.Product.Name}
+		}
+		existing, err := s.repo.FindExistingProducts(ctx, keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		newRows := importRows[:0]
+		for i, row := range importRows {
+			if _, ok := existing[keys[i]]; ok {
+				rowErrors = append(rowErrors, bo.ProductImportError{Line: row.Line, Column: "name",
+					Message: "the supplier already has this product, import with upsert to update it"})
+				continue
+			}
+			newRows = append(newRows, row)
+		}
+		importRows = newRows
+	}
+	slices.SortStableFunc(rowErrors, func(a, b bo.ProductImportError) int {
+		return a.Line - b.Line
+	})
+
+	return importRows, rowErrors, nil
+}
+
+// importAttributes types the attr.<code> values of a row by the attributes of its category,
+// an empty value is no value
+func importAttributes(attributes bo.CategoryAttributeCollection, values map[string]string) (map[string]any, error) {
+	typed := map[string]any{}
+	for column, value := range values {
+		code, ok := strings.CutPrefix(column, attributeColumnPrefix)
+		if !ok || value == "" {
+			continue
+		}
+		attribute, ok := attributes.Find(code)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not an attribute of the category", bo.ErrInvalidProductAttribute, code)
+		}
+
+		switch attribute.Type {
+		case bo.AttributeNumber:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s must be a number", bo.ErrInvalidProductAttribute, code)
+			}
+			typed[code] = number
+		case bo.AttributeBoolean:
+			boolean, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s must be true or false", bo.ErrInvalidProductAttribute, code)
+			}
+			typed[code] = boolean
+		default:
+			typed[code] = value
+		}
+	}
+
+	return validProductAttributes(attributes, typed)
+}
+
+// referenceResolver finds the id of a brand, a category or a supplier by its id or its name
+type referenceResolver struct {
+	kind    bo.ProductReferenceKind
+	ids     map[int64]bool
+	byNames map[string][]int64
+}
+
+// resolveReferences looks up all the references of a kind in the rows at once
+func (s *productImportService) resolveReferences(ctx context.Context, kind bo.ProductReferenceKind, rows []importRow) (*referenceResolver, error) {
+	var (
+		ids   []int64
+		names []string
+	)
+	for _, row := range rows {
+		value := row.values[string(kind)]
+		if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+			ids = append(ids, id)
+		} else if value != "" {
+			names = append(names, value)
+		}
+	}
+
+	found, err := s.repo.FindProductReferences(ctx, kind, ids, names)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &referenceResolver{kind: kind, ids: map[int64]bool{}, byNames: map[string][]int64{}}
+	for _, reference := range found {
+		resolver.ids[reference.ID] = true
+		name := strings.ToLower(reference.Name)
+		resolver.byNames[name] = append(resolver.byNames[name], reference.ID)
+	}
+	return resolver, nil
+}
+
+func (r *referenceResolver) resolve(value string) (int64, error) {
+	if value == "" {
+		return 0, fmt.Errorf("the %s is required", r.kind)
+	}
+	if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if !r.ids[id] {
+			return 0, fmt.Errorf("no %s has the id %d", r.kind, id)
+		}
+		return id, nil
+	}
+
+	ids := r.byNames[strings.ToLower(value)]
+	switch len(ids) {
+	case 0:
+		return 0, fmt.Errorf("no %s is named %q", r.kind, value)
+	case 1:
+		return ids[0], nil
+	}
+	return 0, fmt.Errorf("%d %ss are named %q, use the id instead", len(ids), r.kind, value)
+}
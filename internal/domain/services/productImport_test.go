@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The service is a singleton, so every case shares one set of mock repositories
+	importStore := mockdb.NewMockProductImportRepository(ctrl)
+	categoryStore := mockdb.NewMockCategoryRepository(ctrl)
+	service := ProductImport(importStore, categoryStore)
+
+	importStore.EXPECT().FindProductReferences(gomock.Any(), bo.ReferenceBrand, gomock.Any(), gomock.Any()).AnyTimes().
+		Return([]bo.ProductReference{{ID: 1, Name: "Acme"}}, nil)
+	importStore.EXPECT().FindProductReferences(gomock.Any(), bo.ReferenceCategory, gomock.Any(), gomock.Any()).AnyTimes().
+		Return([]bo.ProductReference{{ID: 7, Name: "Phones"}}, nil)
+	// two suppliers share a name, they can only be told apart by id
+	importStore.EXPECT().FindProductReferences(gomock.Any(), bo.ReferenceSupplier, gomock.Any(), gomock.Any()).AnyTimes().
+		Return([]bo.ProductReference{{ID: 3, Name: "Gadgets"}, {ID: 4, Name: "Parts"}, {ID: 5, Name: "Parts"}}, nil)
+	categoryStore.EXPECT().ListCategoryAttributes(gomock.Any(), int64(7)).AnyTimes().Return(bo.CategoryAttributeCollection{
+		{Code: "ram", Type: bo.AttributeNumber, Required: true},
+		{Code: "nfc", Type: bo.AttributeBoolean},
+	}, nil)
+
+	phone := bo.Product{Name: "Phone X", BrandID: 1, CategoryID: 7, SupplierID: 3, StatusID: 1,
+		Attributes: map[string]any{"ram": 8.0, "nfc": true}}
+	phone.SetPrices(decimal.RequireFromString("499"), decimal.RequireFromString("449"), bo.DefaultCurrency)
+	validFile := "\ufeffName,Brand,Category,Supplier,Unit_Price,Discount_Price,attr.ram,attr.nfc\n" +
+		"Phone X,acme,Phones,3,499,449,8,true\n"
+
+	t.Run("Import", func(t *testing.T) {
+		importStore.EXPECT().FindExistingProducts(gomock.Any(), []bo.ProductKey{{SupplierID: 3, Name: "Phone X"}}).Times(1).
+			Return(map[bo.ProductKey]int64{}, nil)
+		importStore.EXPECT().ImportProducts(gomock.Any(), []bo.Product{phone}, false).Times(1).Return(1, 0, nil)
+
+		report, err := service.Import(context.Background(), strings.NewReader(validFile), bo.ProductImportOptions{})
+		require.NoError(t, err)
+		require.Equal(t, bo.ProductImportReport{Rows: 1, Valid: 1, Created: 1}, report)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		importStore.EXPECT().FindExistingProducts(gomock.Any(), gomock.Any()).Times(1).Return(map[bo.ProductKey]int64{}, nil)
+
+		report, err := service.Import(context.Background(), strings.NewReader(validFile), bo.ProductImportOptions{DryRun: true})
+		require.NoError(t, err)
+		require.Equal(t, bo.ProductImportReport{DryRun: true, Rows: 1, Valid: 1}, report)
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		// an exported file keeps the currency of the prices
+		usdPhone := phone
+		usdPhone.SetPrices(decimal.RequireFromString("4.99"), decimal.RequireFromString("4.49"), "USD")
+		file := "name,brand,category,supplier,unit_price,discount_price,currency,attr.ram,attr.nfc\n" +
+			"Phone X,acme,Phones,3,4.99,4.49,USD,8,true\n"
+		importStore.EXPECT().ImportProducts(gomock.Any(), []bo.Product{usdPhone}, true).Times(1).Return(0, 1, nil)
+
+		report, err := service.Import(context.Background(), strings.NewReader(file), bo.ProductImportOptions{Upsert: true})
+		require.NoError(t, err)
+		require.Equal(t, 1, report.Updated)
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		file := "name,brand,category,supplier,unit_price,discount_price,currency,attr.ram\n" +
+			"Phone X,Acme,Phones,Gadgets,499,,BDT,8\n" +
+			"Phone Y,Nope,Phones,Parts,-1,,taka,8\n" +
+			"Phone X,Acme,Phones,3,499,600,,\n" +
+			"\"Phone\nZ\",Acme,Phones,4,10,,,4,extra\n"
+		importStore.EXPECT().FindExistingProducts(gomock.Any(), []bo.ProductKey{{SupplierID: 3, Name: "Phone X"}}).Times(1).
+			Return(map[bo.ProductKey]int64{{SupplierID: 3, Name: "Phone X"}: 11}, nil)
+
+		report, err := service.Import(context.Background(), strings.NewReader(file), bo.ProductImportOptions{})
+		require.NoError(t, err)
+		require.Equal(t, 4, report.Rows)
+		require.Equal(t, 0, report.Valid)
+		require.Equal(t, []bo.ProductImportError{
+			{Line: 2, Column: "name", Message: "the supplier already has this product, import with upsert to update it"},
+			{Line: 3, Column: "brand", Message: `no brand is named "Nope"`},
+			{Line: 3, Column: "supplier", Message: `2 suppliers are named "Parts", use the id instead`},
+			{Line: 3, Column: "unit_price", Message: "the unit price must be a positive number"},
+			{Line: 3, Column: "currency", Message: "the currency must be an ISO 4217 code such as BDT"},
+			{Line: 4, Column: "discount_price", Message: "the discount price is more than the unit price"},
+			{Line: 4, Column: "", Message: "the product attribute is not valid for its category: ram is required"},
+			{Line: 4, Column: "name", Message: "the supplier already has this product on line 2"},
+			{Line: 5, Column: "", Message: "the row has 9 fields instead of 8"},
+		}, report.Errors)
+	})
+
+	for name, file := range map[string]string{
+		"Empty":          "",
+		"MissingColumn":  "name,brand,category,unit_price\nPhone,1,7,10\n",
+		"UnknownColumn":  "name,brand,category,supplier,unit_price,price\nPhone,1,7,3,10,10\n",
+		"NoRows":         "name,brand,category,supplier,unit_price\n",
+		"UnclosedQuote":  "name,brand,category,supplier,unit_price\n\"Phone,1,7,3,10\n",
+		"RepeatedColumn": "name,brand,category,supplier,unit_price,Name\nPhone,1,7,3,10,Phone\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.Import(context.Background(), strings.NewReader(file), bo.ProductImportOptions{})
+			require.ErrorIs(t, err, bo.ErrInvalidProductImport)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitProductMediaService sync.Once
+var productMediaServiceInstance *productMediaService
+
+type productMediaService struct {
+	repo          definition.ProductMediaRepository
+	blobs         definition.BlobStore
+	maxUploadSize int64
+}
+
+// mediaTypes are the accepted file types by MIME type, with the extension of their storage key
+var mediaTypes = map[string]struct {
+	kind      bo.MediaKind
+	extension string
+}{
+	"image/jpeg":      {kind: bo.MediaImage, extension: ".jpg"},
+	"image/png":       {kind: bo.MediaImage, extension: ".png"},
+	"image/gif":       {kind: bo.MediaImage, extension: ".gif"},
+	"application/pdf": {kind: bo.MediaDocument, extension: ".pdf"},
+}
+
+// ProductMedia keeps the files in blobs, an upload may be up to maxUploadSize bytes
+func ProductMedia(productMediaRepo definition.ProductMediaRepository, blobs definition.BlobStore, maxUploadSize int64) *productMediaService {
+	onceInitProductMediaService.Do(func() {
+		productMediaServiceInstance = &productMediaService{
+			repo:          productMediaRepo,
+			blobs:         blobs,
+			maxUploadSize: maxUploadSize,
+		}
+	})
+
+	return productMediaServiceInstance
+}
+
+func (s *productMediaService) List(ctx context.Context, productID int64) (bo.ProductMediaCollection, error) {
+	mediaCollection, err := s.repo.ListProductMedia(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range mediaCollection {
+		mediaCollection[i].URL = s.blobs.URL(mediaCollection[i].StorageKey)
+		setRenditionURLs(s.blobs, mediaCollection[i].Renditions)
+	}
+	return mediaCollection, nil
+}
+
+func (s *productMediaService) GetProductMediaByID(ctx context.Context, productID, mediaID int64) (bo.ProductMedia, error) {
+	media, err := s.repo.GetProductMediaByID(ctx, productID, mediaID)
+	if err != nil {
+		return bo.ProductMedia{}, err
+	}
+	media.URL = s.blobs.URL(media.StorageKey)
+	setRenditionURLs(s.blobs, media.Renditions)
+	return media, nil
+}
+
+// Upload stores an image or a document of a product. The type is told by the
+// content of the file, not by its name: JPEG, PNG and GIF images whose sides
+// are within bo.MinImageDimension and bo.MaxImageDimension, and PDF documents.
+// The first image of a product becomes its primary image, the renditions of an
+// image are generated in the background.
+func (s *productMediaService) Upload(ctx context.Context, productID int64, fileName string, file io.Reader, sequence int64) (bo.ProductMedia, error) {
+	content, err := io.ReadAll(io.LimitReader(file, s.maxUploadSize+1))
+	if err != nil {
+		return bo.ProductMedia{}, err
+	}
+	if int64(len(content)) > s.maxUploadSize {
+		return bo.ProductMedia{}, fmt.Errorf("%w of %d bytes", bo.ErrProductMediaTooLarge, s.maxUploadSize)
+	}
+
+	media, err := describeMedia(content)
+	if err != nil {
+		return bo.ProductMedia{}, err
+	}
+	media.ProductID = productID
+	media.FileName = mediaFileName(fileName, mediaTypes[media.MimeType].extension)
+	media.Sequence = sequence
+
+	suffix := make([]byte, 12)
+	if _, err := rand.Read(suffix); err != nil {
+		return bo.ProductMedia{}, err
+	}
+	media.StorageKey = fmt.Sprintf("products/%d/%s%s", productID, hex.EncodeToString(suffix), mediaTypes[media.MimeType].extension)
+
+	if err := s.blobs.Put(ctx, media.StorageKey, bytes.NewReader(content), media.SizeBytes, media.MimeType); err != nil {
+		slog.Error("failed to store product media file", slog.String("blobStore", s.blobs.Name()), slog.String("key", media.StorageKey), "cause", err)
+		return bo.ProductMedia{}, err
+	}
+
+	if err := s.repo.CreateProductMedia(ctx, &media); err != nil {
+		s.deleteBlob(media.StorageKey)
+		return bo.ProductMedia{}, err
+	}
+	if media.Kind == bo.MediaImage {
+		requestRenditions()
+	}
+
+	media.URL = s.blobs.URL(media.StorageKey)
+	return media, nil
+}
+
+// describeMedia tells the type of a file from its content, and the dimensions of an image
+func describeMedia(content []byte) (bo.ProductMedia, error) {
+	if len(content) == 0 {
+		return bo.ProductMedia{}, fmt.Errorf("%w: the file is empty", bo.ErrInvalidProductMedia)
+	}
+
+	mimeType, _, _ := strings.Cut(http.DetectContentType(content), ";")
+	mediaType, ok := mediaTypes[mimeType]
+	if !ok {
+		return bo.ProductMedia{}, fmt.Errorf("%w: %s is not accepted", bo.ErrInvalidProductMedia, mimeType)
+	}
+
+	media := bo.ProductMedia{Kind: mediaType.kind, MimeType: mimeType, SizeBytes: int64(len(content))}
+	if media.Kind == bo.MediaImage {
+		imageConfig, _, err := image.DecodeConfig(bytes.NewReader(content))
+		if err != nil {
+			return bo.ProductMedia{}, fmt.Errorf("%w: the image cannot be decoded", bo.ErrInvalidProductMedia)
+		}
+		for _, side := range []int{imageConfig.Width, imageConfig.Height} {
+			if side < bo.MinImageDimension || side > bo.MaxImageDimension {
+				return bo.ProductMedia{}, fmt.Errorf("%w: the image is %dx%d, its sides must be from %d to %d pixels",
+					bo.ErrInvalidProductMedia, imageConfig.Width, imageConfig.Height, bo.MinImageDimension, bo.MaxImageDimension)
+			}
+		}
+		media.Width, media.Height = int64(imageConfig.Width), int64(imageConfig.Height)
+	}
+
+	return media, nil
+}
+
+// mediaFileName keeps the base name of an uploaded file, which is only displayed
+func mediaFileName(fileName, extension string) string {
+	fileName = strings.TrimSpace(filepath.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if fileName == "." || fileName == "/" || fileName == "" {
+		return "file" + extension
+	}
+	fileName = strings.ToValidUTF8(fileName, "")
+	if len(fileName) > 255 {
+		fileName = strings.ToValidUTF8(fileName[:255], "")
+	}
+	return fileName
+}
+
+func (s *productMediaService) UpdateProductMedia(ctx context.Context, updateMedia bo.ProductMediaUpdate) error {
+	return s.repo.UpdateProductMedia(ctx, updateMedia)
+}
+
+// DeleteProductMedia deletes the file and its renditions once its record is gone,
+// a file left behind by a failed delete is only logged
+func (s *productMediaService) DeleteProductMedia(ctx context.Context, productID, mediaID int64) error {
+	media, err := s.repo.DeleteProductMedia(ctx, productID, mediaID)
+	if err != nil {
+		return err
+	}
+	s.deleteBlob(media.StorageKey)
+	for _, rendition := range media.Renditions {
+		s.deleteBlob(rendition.StorageKey)
+	}
+	return nil
+}
+
+// Reprocess generates the renditions of an image again, e.g. after the configured renditions changed
+func (s *productMediaService) Reprocess(ctx context.Context, productID, mediaID int64) error {
+	if err := s.repo.RequeueProductMediaRenditions(ctx, productID, mediaID); err != nil {
+		return err
+	}
+	requestRenditions()
+	return nil
+}
+
+func (s *productMediaService) deleteBlob(key string) {
+	if err := s.blobs.Delete(context.Background(), key); err != nil {
+		slog.Error("failed to delete product media file", slog.String("blobStore", s.blobs.Name()), slog.String("key", key), "cause", err)
+	}
+}
+
+// Open returns a stored file, for the blob stores which are served through the API
+func (s *productMediaService) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.blobs.Get(ctx, key)
+}
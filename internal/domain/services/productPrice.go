@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitProductPriceService sync.Once
+var productPriceServiceInstance *productPriceService
+
+type productPriceService struct {
+	repo definition.ProductPriceRepository
+	now  func() time.Time
+}
+
+func ProductPrice(productPriceRepo definition.ProductPriceRepository) *productPriceService {
+	onceInitProductPriceService.Do(func() {
+		productPriceServiceInstance = &productPriceService{
+			repo: productPriceRepo,
+			now:  time.Now,
+		}
+	})
+
+	return productPriceServiceInstance
+}
+
+// Timeline returns the current prices of a product, its price history and its schedules
+func (s *productPriceService) Timeline(ctx context.Context, productID int64) (bo.ProductPriceTimeline, error) {
+	return s.repo.GetProductPriceTimeline(ctx, productID)
+}
+
+// Schedule plans a price change of a product, to be applied by the scheduler at its start
+func (s *productPriceService) Schedule(ctx context.Context, schedule bo.ProductPriceSchedule) (bo.ProductPriceSchedule, error) {
+	if err := s.validatePriceSchedule(&schedule); err != nil {
+		return bo.ProductPriceSchedule{}, err
+	}
+	if err := s.repo.CreateProductPriceSchedule(ctx, &schedule); err != nil {
+		return bo.ProductPriceSchedule{}, err
+	}
+	return schedule, nil
+}
+
+func (s *productPriceService) validatePriceSchedule(schedule *bo.ProductPriceSchedule) error {
+	if schedule.UnitPrice == nil && schedule.DiscountPrice == nil {
+		return fmt.Errorf("%w: a unit price or a discount price is required", bo.ErrInvalidPriceSchedule)
+	}
+	if schedule.UnitPrice != nil && !schedule.UnitPrice.IsPositive() {
+		return fmt.Errorf("%w: the unit price must be positive", bo.ErrInvalidPriceSchedule)
+	}
+	if schedule.DiscountPrice != nil && schedule.DiscountPrice.IsNegative() {
+		return fmt.Errorf("%w: the discount price must not be negative", bo.ErrInvalidPriceSchedule)
+	}
+	if schedule.UnitPrice != nil && schedule.DiscountPrice != nil && schedule.DiscountPrice.GreaterThan(*schedule.UnitPrice) {
+		return fmt.Errorf("%w: the discount price is more than the unit price", bo.ErrInvalidPriceSchedule)
+	}
+
+	if schedule.StartsAt.IsZero() || !schedule.StartsAt.After(s.now()) {
+		return fmt.Errorf("%w: the start must be in the future", bo.ErrInvalidPriceSchedule)
+	}
+	if !schedule.EndsAt.IsZero() && !schedule.EndsAt.After(schedule.StartsAt) {
+		return fmt.Errorf("%w: the end must be after the start", bo.ErrInvalidPriceSchedule)
+	}
+
+	return nil
+}
+
+// CancelSchedule drops a pending schedule, or ends an active one right away putting the previous prices back
+func (s *productPriceService) CancelSchedule(ctx context.Context, productID, scheduleID int64) (bo.ProductPriceSchedule, error) {
+	return s.repo.CancelProductPriceSchedule(ctx, productID, scheduleID)
+}
+
+// StartScheduler applies and reverts the due price schedules every interval until ctx is done
+func (s *productPriceService) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				count, err := s.repo.ApplyDueProductPriceSchedules(ctx)
+				if err != nil {
+					slog.Error("failed to apply price schedules", "cause", err)
+				}
+				if count > 0 {
+					slog.Info("applied price schedules", slog.Int64("count", count))
+				}
+			}
+		}
+	}()
+}
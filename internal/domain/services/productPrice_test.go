@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduleProductPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	priceStore := mockdb.NewMockProductPriceRepository(ctrl)
+	service := &productPriceService{
+		repo: priceStore,
+		now:  func() time.Time { return now },
+	}
+
+	price := func(v int64) *decimal.Decimal { d := decimal.NewFromInt(v); return &d }
+
+	testCases := []struct {
+		name     string
+		schedule bo.ProductPriceSchedule
+		repoErr  error
+		err      error
+	}{
+		{
+			name:     "Permanent",
+			schedule: bo.ProductPriceSchedule{ProductID: 4, UnitPrice: price(90), StartsAt: now.Add(time.Hour)},
+		},
+		{
+			name: "Sale",
+			schedule: bo.ProductPriceSchedule{
+				ProductID:     4,
+				DiscountPrice: price(70),
+				StartsAt:      now.Add(time.Hour),
+				EndsAt:        now.Add(48 * time.Hour),
+			},
+		},
+		{
+			name:     "Overlap",
+			schedule: bo.ProductPriceSchedule{ProductID: 4, UnitPrice: price(90), StartsAt: now.Add(time.Hour)},
+			repoErr:  bo.ErrPriceScheduleOverlap,
+			err:      bo.ErrPriceScheduleOverlap,
+		},
+		{
+			name:     "NoPrice",
+			schedule: bo.ProductPriceSchedule{ProductID: 4, StartsAt: now.Add(time.Hour)},
+			err:      bo.ErrInvalidPriceSchedule,
+		},
+		{
+			name: "DiscountAboveUnit",
+			schedule: bo.ProductPriceSchedule{
+				ProductID:     4,
+				UnitPrice:     price(50),
+				DiscountPrice: price(60),
+				StartsAt:      now.Add(time.Hour),
+			},
+			err: bo.ErrInvalidPriceSchedule,
+		},
+		{
+			name:     "StartInThePast",
+			schedule: bo.ProductPriceSchedule{ProductID: 4, UnitPrice: price(90), StartsAt: now.Add(-time.Minute)},
+			err:      bo.ErrInvalidPriceSchedule,
+		},
+		{
+			name: "EndBeforeStart",
+			schedule: bo.ProductPriceSchedule{
+				ProductID: 4,
+				UnitPrice: price(90),
+				StartsAt:  now.Add(2 * time.Hour),
+				EndsAt:    now.Add(time.Hour),
+			},
+			err: bo.ErrInvalidPriceSchedule,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			valid := tc.err == nil || tc.repoErr != nil
+			if valid {
+				priceStore.EXPECT().
+					CreateProductPriceSchedule(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, schedule *bo.ProductPriceSchedule) error {
+						if tc.repoErr != nil {
+							return tc.repoErr
+						}
+						schedule.ID = 7
+						schedule.Status = bo.PriceSchedulePending
+						return nil
+					})
+			}
+
+			schedule, err := service.Schedule(context.Background(), tc.schedule)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(7), schedule.ID)
+			require.Equal(t, bo.PriceSchedulePending, schedule.Status)
+		})
+	}
+}
@@ -30,6 +30,10 @@ func (s *productStockService) List(ctx context.Context, query bo.ProductStockQue
 	return s.repo.ListProductStocks(ctx, query)
 }
 
+func (s *productStockService) GetProductStockLevel(ctx context.Context, productID int64) (bo.ProductStockLevel, error) {
+	return s.repo.GetProductStockLevel(ctx, productID)
+}
+
 func (s *productStockService) GetProductStockByID(ctx context.Context, productStockID int64) (bo.ProductStock, error) {
 	return s.repo.GetProductStockByID(ctx, productStockID)
 }
@@ -45,10 +49,17 @@ func (s *productStockService) CreateProductStock(ctx context.Context, productSto
 	return productStock.ID, nil
 }
 
+// UpdateProductStock sets the quantity of one stock row, it never spreads an
+// absolute quantity over every warehouse of the product. Without a warehouse the
+// row of the product's only warehouse, else of the main warehouse, is set.
 func (s *productStockService) UpdateProductStock(ctx context.Context, updateProductStock bo.ProductStockUpdate) error {
 	return s.repo.UpdateProductStock(ctx, updateProductStock)
 }
 
+func (s *productStockService) AdjustProductStock(ctx context.Context, adjustment bo.ProductStockAdjustment) (bo.ProductStock, error) {
+	return s.repo.AdjustProductStock(ctx, adjustment)
+}
+
 func (s *productStockService) DeleteProductStock(ctx context.Context, productStockID int64) error {
 	return s.repo.DeleteProductStock(ctx, productStockID)
 }
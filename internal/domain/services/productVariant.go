@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitProductVariantService sync.Once
+var productVariantServiceInstance *productVariantService
+
+type productVariantService struct {
+	repo definition.ProductVariantRepository
+}
+
+func ProductVariant(productVariantRepo definition.ProductVariantRepository) *productVariantService {
+	onceInitProductVariantService.Do(func() {
+		productVariantServiceInstance = &productVariantService{
+			repo: productVariantRepo,
+		}
+	})
+
+	return productVariantServiceInstance
+}
+
+func (s *productVariantService) List(ctx context.Context, productID int64) (bo.ProductVariantCollection, error) {
+	return s.repo.ListProductVariants(ctx, productID)
+}
+
+func (s *productVariantService) GetProductVariantByID(ctx context.Context, productID, variantID int64) (bo.ProductVariant, error) {
+	return s.repo.GetProductVariantByID(ctx, productID, variantID)
+}
+
+func (s *productVariantService) CreateProductVariant(ctx context.Context, productVariant bo.ProductVariant) (int64, error) {
+	if err := s.repo.CreateProductVariant(ctx, &productVariant); err != nil {
+		return -1, err
+	}
+
+	if productVariant.ID < 1 {
+		slog.Warn("inserted product variant has invalid id", slog.String("sku", productVariant.SKU))
+	}
+	return productVariant.ID, nil
+}
+
+func (s *productVariantService) UpdateProductVariant(ctx context.Context, updateProductVariant bo.ProductVariantUpdate) error {
+	return s.repo.UpdateProductVariant(ctx, updateProductVariant)
+}
+
+func (s *productVariantService) DeleteProductVariant(ctx context.Context, productID, variantID int64) error {
+	return s.repo.DeleteProductVariant(ctx, productID, variantID)
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProductAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The service is a singleton, so every case shares one set of mock repositories
+	productStore := mockdb.NewMockProductRepository(ctrl)
+	categoryStore := mockdb.NewMockCategoryRepository(ctrl)
+	service := Product(productStore, categoryStore, nil)
+
+	// category 7, phones, inherits color from category 5, electronics
+	color := bo.CategoryAttribute{ID: 1, CategoryID: 5, Code: "color", Type: bo.AttributeEnum, Options: []string{"black", "blue"}, Filterable: true}
+	ram := bo.CategoryAttribute{ID: 2, CategoryID: 7, Code: "ram", Type: bo.AttributeNumber, Unit: "GB", Required: true, Filterable: true}
+	nfc := bo.CategoryAttribute{ID: 3, CategoryID: 7, Code: "nfc", Type: bo.AttributeBoolean, Filterable: true}
+	notes := bo.CategoryAttribute{ID: 4, CategoryID: 7, Code: "notes", Type: bo.AttributeText}
+	phones := bo.CategoryAttributeCollection{color, ram, nfc, notes}
+	categoryStore.EXPECT().ListCategoryAttributes(gomock.Any(), int64(7)).AnyTimes().Return(phones, nil)
+
+	t.Run("Filters", func(t *testing.T) {
+		eight, sixteen := 8.0, 16.0
+		filter := bo.ProductFilter{CategoryFilter: 7, Attributes: map[string]string{"color": "black, blue", "ram": "8..16", "nfc": "true"}}
+		typed := filter
+		typed.AttributeFilters = []bo.AttributeFilter{
+			{Code: "color", Values: []any{"black", "blue"}},
+			{Code: "nfc", Values: []any{true}},
+			{Code: "ram", Min: &eight, Max: &sixteen},
+		}
+		productStore.EXPECT().ProductFacets(gomock.Any(), gomock.Eq(typed), gomock.Eq(phones)).Times(1).Return(bo.ProductFacets{}, nil)
+
+		_, err := service.Facets(context.Background(), filter)
+		require.NoError(t, err)
+	})
+
+	t.Run("NumberFilter", func(t *testing.T) {
+		eight := 8.0
+		for value, want := range map[string]bo.AttributeFilter{
+			"8":   {Code: "ram", Min: &eight, Max: &eight},
+			"8..": {Code: "ram", Min: &eight},
+			"..8": {Code: "ram", Max: &eight},
+		} {
+			filter, err := parseAttributeFilter(ram, value)
+			require.NoError(t, err)
+			require.Equal(t, want, filter, value)
+		}
+	})
+
+	for name, filter := range map[string]bo.ProductFilter{
+		"FilterWithoutCategory":  {Attributes: map[string]string{"color": "black"}},
+		"UnknownFilter":          {CategoryFilter: 7, Attributes: map[string]string{"weight": "1"}},
+		"TextFilter":             {CategoryFilter: 7, Attributes: map[string]string{"notes": "new"}},
+		"UnknownOptionFilter":    {CategoryFilter: 7, Attributes: map[string]string{"color": "red"}},
+		"InvalidNumberFilter":    {CategoryFilter: 7, Attributes: map[string]string{"ram": "eight"}},
+		"EmptyNumberRangeFilter": {CategoryFilter: 7, Attributes: map[string]string{"ram": ".."}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.List(context.Background(), bo.ProductSearchQuery{Filter: filter})
+			require.ErrorIs(t, err, bo.ErrInvalidAttributeFilter)
+		})
+	}
+
+	t.Run("CreateProduct", func(t *testing.T) {
+		product := bo.Product{Name: "phone", CategoryID: 7, Attributes: map[string]any{"color": "blue", "ram": 8.0, "nfc": nil}}
+		created := product
+		created.Attributes = map[string]any{"color": "blue", "ram": 8.0}
+		productStore.EXPECT().CreateProduct(gomock.Any(), gomock.Eq(&created)).Times(1).
+			DoAndReturn(func(_ context.Context, product *bo.Product) error {
+				product.ID = 1
+				return nil
+			})
+
+		id, err := service.CreateProduct(context.Background(), product)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), id)
+	})
+
+	for name, attributes := range map[string]map[string]any{
+		"MissingRequired": {"color": "blue"},
+		"UnknownOption":   {"ram": 8.0, "color": "red"},
+		"WrongType":       {"ram": "8"},
+		"UnknownCode":     {"ram": 8.0, "weight": 120.0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.CreateProduct(context.Background(), bo.Product{CategoryID: 7, Attributes: attributes})
+			require.ErrorIs(t, err, bo.ErrInvalidProductAttribute)
+		})
+	}
+
+	t.Run("MoveProduct", func(t *testing.T) {
+		// the current values are checked against the template of the new category
+		categoryID := int64(7)
+		productStore.EXPECT().GetProductByID(gomock.Any(), int64(2)).Times(1).
+			Return(bo.Product{ID: 2, CategoryID: 5, Attributes: map[string]any{"color": "black"}}, nil)
+
+		err := service.UpdateProduct(context.Background(), bo.ProductUpdate{ID: 2, CategoryID: &categoryID})
+		require.ErrorIs(t, err, bo.ErrInvalidProductAttribute)
+	})
+}
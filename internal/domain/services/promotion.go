@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitPromotionService sync.Once
+var promotionServiceInstance *promotionService
+
+type promotionService struct {
+	repo definition.PromotionRepository
+}
+
+func Promotion(promotionRepo definition.PromotionRepository) *promotionService {
+	onceInitPromotionService.Do(func() {
+		promotionServiceInstance = &promotionService{
+			repo: promotionRepo,
+		}
+	})
+
+	return promotionServiceInstance
+}
+
+func (s *promotionService) List(ctx context.Context, query bo.PromotionQuery) (bo.PaginatedPromotionCollection, error) {
+	return s.repo.ListPromotions(ctx, query)
+}
+
+func (s *promotionService) GetPromotionByID(ctx context.Context, promotionID int64) (bo.Promotion, error) {
+	return s.repo.GetPromotionByID(ctx, promotionID)
+}
+
+func (s *promotionService) CreatePromotion(ctx context.Context, promotion bo.Promotion) (int64, error) {
+	if err := promotion.Validate(); err != nil {
+		return -1, err
+	}
+	if err := s.repo.CreatePromotion(ctx, &promotion); err != nil {
+		return -1, err
+	}
+
+	if promotion.ID < 1 {
+		slog.Warn("inserted promotion has invalid id", slog.String("name", promotion.Name))
+	}
+	return promotion.ID, nil
+}
+
+// UpdatePromotion validates the promotion as it will be after the update
+func (s *promotionService) UpdatePromotion(ctx context.Context, updatePromotion bo.PromotionUpdate) error {
+	promotion, err := s.repo.GetPromotionByID(ctx, updatePromotion.ID)
+	if err != nil {
+		return err
+	}
+	if err := promotion.Apply(updatePromotion).Validate(); err != nil {
+		return err
+	}
+	return s.repo.UpdatePromotion(ctx, updatePromotion)
+}
+
+func (s *promotionService) DeletePromotion(ctx context.Context, promotionID int64) error {
+	return s.repo.DeletePromotion(ctx, promotionID)
+}
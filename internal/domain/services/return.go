@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+
+	"github.com/shopspring/decimal"
+)
+
+var onceInitReturnService sync.Once
+var returnServiceInstance *returnService
+
+type returnService struct {
+	repo        definition.ReturnRepository
+	orderRepo   definition.OrderRepository
+	paymentRepo definition.PaymentRepository
+	gateway     definition.PaymentGateway
+}
+
+func Return(returnRepo definition.ReturnRepository, orderRepo definition.OrderRepository, paymentRepo definition.PaymentRepository, gateway definition.PaymentGateway) *returnService {
+	onceInitReturnService.Do(func() {
+		returnServiceInstance = &returnService{
+			repo:        returnRepo,
+			orderRepo:   orderRepo,
+			paymentRepo: paymentRepo,
+			gateway:     gateway,
+		}
+	})
+
+	return returnServiceInstance
+}
+
+func (s *returnService) GetByID(ctx context.Context, returnID int64) (bo.Return, error) {
+	return s.repo.GetReturnByID(ctx, returnID)
+}
+
+func (s *returnService) ListByOrder(ctx context.Context, orderID int64) (bo.ReturnCollection, error) {
+	if _, err := s.orderRepo.GetOrderByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListOrderReturns(ctx, orderID)
+}
+
+// Request opens a return for lines of a shipped or delivered order, the same
+// order line listed twice is requested once for the combined quantity.
+func (s *returnService) Request(ctx context.Context, request bo.ReturnRequest) (bo.Return, error) {
+	if len(request.Items) == 0 {
+		return bo.Return{}, bo.ErrReturnEmpty
+	}
+
+	var items []bo.ReturnRequestItem
+	index := map[int64]int{}
+	for _, item := range request.Items {
+		if i, ok := index[item.OrderItemID]; ok {
+			items[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.OrderItemID] = len(items)
+		items = append(items, item)
+	}
+	request.Items = items
+
+	return s.repo.CreateReturn(ctx, request)
+}
+
+func (s *returnService) Approve(ctx context.Context, returnID int64, changedBy, note string) (bo.Return, error) {
+	return s.transition(ctx, returnID, bo.ReturnApproved, changedBy, note)
+}
+
+func (s *returnService) Reject(ctx context.Context, returnID int64, changedBy, note string) (bo.Return, error) {
+	return s.transition(ctx, returnID, bo.ReturnRejected, changedBy, note)
+}
+
+func (s *returnService) transition(ctx context.Context, returnID int64, next bo.ReturnStatus, changedBy, note string) (bo.Return, error) {
+	ret, err := s.repo.GetReturnByID(ctx, returnID)
+	if err != nil {
+		return bo.Return{}, err
+	}
+	if !ret.Status.CanTransitionTo(next) {
+		return bo.Return{}, bo.ErrInvalidReturnTransition
+	}
+
+	return s.repo.UpdateReturnStatus(ctx, bo.ReturnStatusChange{
+		ReturnID:  returnID,
+		From:      ret.Status,
+		To:        next,
+		Note:      note,
+		ChangedBy: changedBy,
+	})
+}
+
+// Receive records the items of an approved return arriving back, in the
+// condition they arrived in.
+func (s *returnService) Receive(ctx context.Context, receipt bo.ReturnReceipt) (bo.Return, error) {
+	ret, err := s.repo.GetReturnByID(ctx, receipt.ReturnID)
+	if err != nil {
+		return bo.Return{}, err
+	}
+	if !ret.Status.CanTransitionTo(bo.ReturnReceived) {
+		return bo.Return{}, bo.ErrInvalidReturnTransition
+	}
+
+	requested := map[int64]int64{}
+	for _, item := range ret.Items {
+		requested[item.ID] = item.Quantity
+	}
+	for _, item := range receipt.Items {
+		if !item.Condition.Valid() {
+			return bo.Return{}, bo.ErrInvalidReturnCondition
+		}
+		quantity, ok := requested[item.ReturnItemID]
+		if !ok {
+			return bo.Return{}, bo.ErrReturnItemNotFound
+		}
+		if item.Quantity < 0 || item.Quantity > quantity {
+			return bo.Return{}, bo.ErrReturnQuantityExceeded
+		}
+	}
+
+	return s.repo.ReceiveReturn(ctx, receipt)
+}
+
+// Refund gives back what was charged for the received items, or part of it,
+// through a captured payment of the order. Amount 0 refunds the whole
+// refundable amount. The return is claimed as refunding before the provider
+// is asked, so of two concurrent refunds only one reaches it. A refund the
+// provider fails puts the return back to received where it can be retried.
+func (s *returnService) Refund(ctx context.Context, returnID int64, amount decimal.Decimal, changedBy, note string) (bo.Return, error) {
+	ret, err := s.repo.GetReturnByID(ctx, returnID)
+	if err != nil {
+		return bo.Return{}, err
+	}
+	if !ret.Status.CanTransitionTo(bo.ReturnRefunding) {
+		return bo.Return{}, bo.ErrInvalidReturnTransition
+	}
+
+	refundable := ret.RefundableAmount()
+	if !amount.IsPositive() {
+		amount = refundable
+	}
+	if !amount.IsPositive() || amount.GreaterThan(refundable) {
+		return bo.Return{}, bo.ErrInvalidRefundAmount
+	}
+
+	payments, err := s.paymentRepo.ListOrderPayments(ctx, ret.OrderID)
+	if err != nil {
+		return bo.Return{}, err
+	}
+
+	var payment *bo.Payment
+	for i := range payments {
+		if payments[i].Status == bo.PaymentCaptured && payments[i].RefundableAmount().GreaterThanOrEqual(amount) {
+			payment = &payments[i]
+			break
+		}
+	}
+	if payment == nil {
+		return bo.Return{}, bo.ErrNoRefundablePayment
+	}
+
+	if _, err := s.repo.UpdateReturnStatus(ctx, bo.ReturnStatusChange{
+		ReturnID:  returnID,
+		From:      ret.Status,
+		To:        bo.ReturnRefunding,
+		ChangedBy: changedBy,
+	}); err != nil {
+		return bo.Return{}, err
+	}
+
+	if _, err := Payment(s.paymentRepo, s.orderRepo, s.gateway).Refund(ctx, payment.ID, amount); err != nil {
+		if _, releaseErr := s.repo.UpdateReturnStatus(ctx, bo.ReturnStatusChange{
+			ReturnID:  returnID,
+			From:      bo.ReturnRefunding,
+			To:        bo.ReturnReceived,
+			Note:      "refund failed: " + err.Error(),
+			ChangedBy: changedBy,
+		}); releaseErr != nil {
+			slog.Error("refund failed and the return was left refunding",
+				slog.Int64("returnID", returnID), "cause", releaseErr)
+		}
+		return bo.Return{}, err
+	}
+
+	return s.repo.UpdateReturnStatus(ctx, bo.ReturnStatusChange{
+		ReturnID:     returnID,
+		From:         bo.ReturnRefunding,
+		To:           bo.ReturnRefunded,
+		Note:         note,
+		ChangedBy:    changedBy,
+		RefundAmount: amount,
+		PaymentID:    payment.ID,
+	})
+}
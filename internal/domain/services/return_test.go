@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+	"techno-store/internal/infrastructure/payments"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReturnRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The services are singletons, so every case shares one set of mock repositories
+	returnStore := mockdb.NewMockReturnRepository(ctrl)
+	orderStore := mockdb.NewMockOrderRepository(ctrl)
+	paymentStore := mockdb.NewMockPaymentRepository(ctrl)
+	service := Return(returnStore, orderStore, paymentStore, payments.NewFakeGateway("", 0))
+
+	amount := decimal.RequireFromString
+
+	// two units at 40 came back, one at 15 was requested but never arrived
+	received := bo.Return{
+		ID:      3,
+		OrderID: 9,
+		Status:  bo.ReturnReceived,
+		Items: []bo.ReturnItem{
+			{ID: 1, Quantity: 2, ReceivedQuantity: 2, Condition: bo.ReturnSellable, UnitPrice: amount("40")},
+			{ID: 2, Quantity: 1, UnitPrice: amount("15")},
+		},
+	}
+	// with exclusive pricing the line of three units at 40 was charged a tax of 10 on top,
+	// two of them came back with 80 + 6.67 of it
+	taxed := bo.Return{
+		ID:       3,
+		OrderID:  9,
+		Status:   bo.ReturnReceived,
+		Currency: "BDT",
+		Items: []bo.ReturnItem{
+			{ID: 1, Quantity: 2, ReceivedQuantity: 2, Condition: bo.ReturnSellable, UnitPrice: amount("40"), Tax: amount("10"), OrderedQuantity: 3},
+		},
+	}
+	captured := bo.Payment{
+		ID:                5,
+		OrderID:           9,
+		Provider:          payments.FakeProvider,
+		ProviderReference: "fake_pay_test_1",
+		Status:            bo.PaymentCaptured,
+		Amount:            amount("100"),
+		CapturedAmount:    amount("100"),
+	}
+
+	// the fake gateway refuses to refund a reference it did not issue
+	foreign := captured
+	foreign.ProviderReference = "other_pay_1"
+
+	testCases := []struct {
+		name     string
+		ret      bo.Return
+		payments bo.PaymentCollection
+		amount   decimal.Decimal
+		claimErr error
+		// gatewayFails leaves the refund with the provider failing
+		gatewayFails bool
+		refunded     decimal.Decimal
+		err          error
+	}{
+		{name: "Full", ret: received, payments: bo.PaymentCollection{captured}, refunded: amount("80")},
+		{name: "Partial", ret: received, payments: bo.PaymentCollection{captured}, amount: amount("25.5"), refunded: amount("25.5")},
+		{name: "ExclusiveTax", ret: taxed, payments: bo.PaymentCollection{captured}, refunded: amount("86.67")},
+		{name: "ExceedsReceived", ret: received, amount: amount("95"), err: bo.ErrInvalidRefundAmount},
+		{
+			name:     "NoCapturedPayment",
+			ret:      received,
+			payments: bo.PaymentCollection{{ID: 6, Status: bo.PaymentVoided, Amount: amount("100")}},
+			err:      bo.ErrNoRefundablePayment,
+		},
+		{name: "NotReceived", ret: bo.Return{ID: 3, OrderID: 9, Status: bo.ReturnApproved}, err: bo.ErrInvalidReturnTransition},
+		{
+			name:     "ClaimedConcurrently",
+			ret:      received,
+			payments: bo.PaymentCollection{captured},
+			claimErr: bo.ErrReturnStatusConflict,
+			err:      bo.ErrReturnStatusConflict,
+		},
+		{name: "GatewayFails", ret: received, payments: bo.PaymentCollection{foreign}, gatewayFails: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			returnStore.EXPECT().
+				GetReturnByID(gomock.Any(), gomock.Eq(int64(3))).
+				Times(1).
+				Return(tc.ret, nil)
+
+			if tc.payments != nil {
+				paymentStore.EXPECT().
+					ListOrderPayments(gomock.Any(), gomock.Eq(int64(9))).
+					Times(1).
+					Return(tc.payments, nil)
+			}
+
+			claimed := tc.err == nil || tc.claimErr != nil
+			if claimed {
+				returnStore.EXPECT().
+					UpdateReturnStatus(gomock.Any(), gomock.Eq(bo.ReturnStatusChange{
+						ReturnID:  3,
+						From:      bo.ReturnReceived,
+						To:        bo.ReturnRefunding,
+						ChangedBy: "clerk",
+					})).
+					Times(1).
+					Return(bo.Return{ID: 3, Status: bo.ReturnRefunding}, tc.claimErr)
+			}
+
+			refunding := claimed && tc.claimErr == nil
+			switch {
+			case refunding && tc.gatewayFails:
+				paymentStore.EXPECT().
+					GetPaymentByID(gomock.Any(), gomock.Eq(foreign.ID)).
+					Times(1).
+					Return(foreign, nil)
+				returnStore.EXPECT().
+					UpdateReturnStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, change bo.ReturnStatusChange) (bo.Return, error) {
+						require.Equal(t, bo.ReturnRefunding, change.From)
+						require.Equal(t, bo.ReturnReceived, change.To)
+						require.True(t, change.RefundAmount.IsZero())
+						return bo.Return{ID: 3, Status: bo.ReturnReceived}, nil
+					})
+			case refunding:
+				paymentStore.EXPECT().
+					GetPaymentByID(gomock.Any(), gomock.Eq(captured.ID)).
+					Times(1).
+					Return(captured, nil)
+				paymentStore.EXPECT().
+					ApplyPaymentEvent(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, event bo.PaymentEvent) (bo.Payment, bool, error) {
+						require.True(t, tc.refunded.Equal(event.Amount), event.Amount.String())
+						payment, applied := captured.Apply(event)
+						return payment, applied, nil
+					})
+				returnStore.EXPECT().
+					UpdateReturnStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, change bo.ReturnStatusChange) (bo.Return, error) {
+						require.True(t, tc.refunded.Equal(change.RefundAmount), change.RefundAmount.String())
+						change.RefundAmount = decimal.Decimal{}
+						require.Equal(t, bo.ReturnStatusChange{
+							ReturnID:  3,
+							From:      bo.ReturnRefunding,
+							To:        bo.ReturnRefunded,
+							ChangedBy: "clerk",
+							PaymentID: captured.ID,
+						}, change)
+						return bo.Return{ID: 3, Status: bo.ReturnRefunded, RefundAmount: tc.refunded}, nil
+					})
+			}
+
+			ret, err := service.Refund(context.Background(), 3, tc.amount, "clerk", "")
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			if tc.gatewayFails {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, bo.ReturnRefunded, ret.Status)
+			require.True(t, tc.refunded.Equal(ret.RefundAmount))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"techno-store/internal/domain/algo"
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitSearchService sync.Once
+var searchServiceInstance *searchService
+
+type searchService struct {
+	repo definition.SearchRepository
+
+	// The suggestions of hot prefixes are cached, the LRU cache is not safe
+	// for concurrent use so every access holds mu
+	mu        sync.Mutex
+	cache     *algo.LRUCache
+	cacheSize int
+	cacheTTL  time.Duration
+	now       func() time.Time
+}
+
+// cachedSuggestions is the cached value of a prefix
+type cachedSuggestions struct {
+	ExpiresAt   time.Time
+	Suggestions bo.SuggestionCollection
+}
+
+// Search caches the suggestions of up to cacheSize prefixes for cacheTTL, a zero cacheSize disables the cache
+func Search(searchRepo definition.SearchRepository, cacheSize int, cacheTTL time.Duration) *searchService {
+	onceInitSearchService.Do(func() {
+		searchServiceInstance = &searchService{
+			repo:      searchRepo,
+			cacheSize: cacheSize,
+			cacheTTL:  cacheTTL,
+			now:       time.Now,
+		}
+		if cacheSize > 0 {
+			searchServiceInstance.cache = algo.NewLRUCache(cacheSize)
+		}
+	})
+
+	return searchServiceInstance
+}
+
+// normalizeSearchTerm lower cases the term and collapses its spaces, so
+// "  IPhone   15" and "iphone 15" share their suggestions and synonyms
+func normalizeSearchTerm(term string) string {
+	return strings.Join(strings.Fields(strings.ToLower(term)), " ")
+}
+
+// Suggest returns the product, brand and category names matching what was typed so far
+func (s *searchService) Suggest(ctx context.Context, query bo.SuggestQuery) (bo.SuggestionCollection, error) {
+	query.Prefix = normalizeSearchTerm(query.Prefix)
+	if query.Prefix == "" {
+		return bo.SuggestionCollection{}, nil
+	}
+
+	key := strconv.Itoa(query.Limit) + ":" + query.Prefix
+	if suggestions, ok := s.cached(key); ok {
+		return suggestions, nil
+	}
+
+	suggestions, err := s.repo.Suggest(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	s.store(key, suggestions)
+	return suggestions, nil
+}
+
+func (s *searchService) cached(key string) (bo.SuggestionCollection, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cache == nil {
+		return nil, false
+	}
+	value, ok := s.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	var entry cachedSuggestions
+	if err := json.Unmarshal([]byte(value), &entry); err != nil {
+		slog.Error("unable to decode cached suggestions", "cause", err)
+		return nil, false
+	}
+	if !s.now().Before(entry.ExpiresAt) {
+		return nil, false
+	}
+	return entry.Suggestions, true
+}
+
+func (s *searchService) store(key string, suggestions bo.SuggestionCollection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cache == nil {
+		return
+	}
+	value, err := json.Marshal(cachedSuggestions{ExpiresAt: s.now().Add(s.cacheTTL), Suggestions: suggestions})
+	if err != nil {
+		slog.Error("unable to encode suggestions", "cause", err)
+		return
+	}
+	s.cache.Put(key, string(value))
+}
+
+// clearCache drops every cached suggestion, synonyms change what a prefix suggests
+func (s *searchService) clearCache() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cache != nil {
+		s.cache = algo.NewLRUCache(s.cacheSize)
+	}
+}
+
+func (s *searchService) ListSynonyms(ctx context.Context) (bo.SearchSynonymCollection, error) {
+	return s.repo.ListSearchSynonyms(ctx)
+}
+
+func (s *searchService) CreateSynonym(ctx context.Context, synonym bo.SearchSynonym) (bo.SearchSynonym, error) {
+	synonym.Term = normalizeSearchTerm(synonym.Term)
+	synonym.Synonym = normalizeSearchTerm(synonym.Synonym)
+	if synonym.Term == "" || synonym.Synonym == "" || synonym.Term == synonym.Synonym {
+		return bo.SearchSynonym{}, bo.ErrInvalidSearchSynonym
+	}
+
+	if err := s.repo.CreateSearchSynonym(ctx, &synonym); err != nil {
+		return bo.SearchSynonym{}, err
+	}
+
+	s.clearCache()
+	return synonym, nil
+}
+
+func (s *searchService) DeleteSynonym(ctx context.Context, synonymID int64) error {
+	if err := s.repo.DeleteSearchSynonym(ctx, synonymID); err != nil {
+		return err
+	}
+
+	s.clearCache()
+	return nil
+}
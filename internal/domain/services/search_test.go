@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSuggest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The service is a singleton, so the cases run in order against one cache
+	searchStore := mockdb.NewMockSearchRepository(ctrl)
+	service := Search(searchStore, 2, time.Minute)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	iphone := bo.SuggestionCollection{
+		{Kind: bo.SuggestionProduct, ID: 1, Name: "iPhone 15", Popularity: 12},
+		{Kind: bo.SuggestionCategory, ID: 4, Name: "iOS accessories"},
+	}
+
+	testCases := []struct {
+		name      string
+		prefix    string
+		advance   time.Duration
+		synonym   bool
+		lookup    string
+		looked    bool
+		suggested bo.SuggestionCollection
+	}{
+		{name: "Miss", prefix: "  IPhone ", lookup: "iphone", looked: true, suggested: iphone},
+		{name: "Hit", prefix: "iphone", suggested: iphone},
+		{name: "Expired", prefix: "iphone", advance: time.Minute, lookup: "iphone", looked: true, suggested: iphone},
+		{name: "OtherPrefix", prefix: "sam", lookup: "sam", looked: true, suggested: bo.SuggestionCollection{}},
+		{name: "SynonymClearsCache", prefix: "iphone", synonym: true, lookup: "iphone", looked: true, suggested: iphone},
+		{name: "Blank", prefix: "   ", suggested: bo.SuggestionCollection{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now = now.Add(tc.advance)
+			if tc.synonym {
+				searchStore.EXPECT().
+					CreateSearchSynonym(gomock.Any(), gomock.Eq(&bo.SearchSynonym{Term: "iphone", Synonym: "ios"})).
+					Times(1).
+					Return(nil)
+				_, err := service.CreateSynonym(context.Background(), bo.SearchSynonym{Term: "iPhone", Synonym: " iOS"})
+				require.NoError(t, err)
+			}
+			if tc.looked {
+				searchStore.EXPECT().
+					Suggest(gomock.Any(), gomock.Eq(bo.SuggestQuery{Prefix: tc.lookup, Limit: 10})).
+					Times(1).
+					Return(tc.suggested, nil)
+			}
+
+			suggestions, err := service.Suggest(context.Background(), bo.SuggestQuery{Prefix: tc.prefix, Limit: 10})
+			require.NoError(t, err)
+			require.Equal(t, tc.suggested, suggestions)
+		})
+	}
+
+	t.Run("InvalidSynonym", func(t *testing.T) {
+		_, err := service.CreateSynonym(context.Background(), bo.SearchSynonym{Term: "Phone", Synonym: "phone "})
+		require.ErrorIs(t, err, bo.ErrInvalidSearchSynonym)
+	})
+}
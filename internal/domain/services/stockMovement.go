@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitStockMovementService sync.Once
+var stockMovementServiceInstance *stockMovementService
+
+type stockMovementService struct {
+	repo definition.StockMovementRepository
+}
+
+func StockMovement(stockMovementRepo definition.StockMovementRepository) *stockMovementService {
+	onceInitStockMovementService.Do(func() {
+		stockMovementServiceInstance = &stockMovementService{
+			repo: stockMovementRepo,
+		}
+	})
+
+	return stockMovementServiceInstance
+}
+
+func (s *stockMovementService) List(ctx context.Context, query bo.StockMovementQuery) (bo.PaginatedStockMovementCollection, error) {
+	return s.repo.ListStockMovements(ctx, query)
+}
+
+func (s *stockMovementService) Reconcile(ctx context.Context, productID int64) ([]bo.StockDiscrepancy, error) {
+	return s.repo.ReconcileProductStock(ctx, productID)
+}
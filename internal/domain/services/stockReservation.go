@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitStockReservationService sync.Once
+var stockReservationServiceInstance *stockReservationService
+
+type stockReservationService struct {
+	repo definition.StockReservationRepository
+}
+
+func StockReservation(stockReservationRepo definition.StockReservationRepository) *stockReservationService {
+	onceInitStockReservationService.Do(func() {
+		stockReservationServiceInstance = &stockReservationService{
+			repo: stockReservationRepo,
+		}
+	})
+
+	return stockReservationServiceInstance
+}
+
+func (s *stockReservationService) GetByID(ctx context.Context, reservationID int64) (bo.StockReservation, error) {
+	return s.repo.GetStockReservationByID(ctx, reservationID)
+}
+
+func (s *stockReservationService) Create(ctx context.Context, reservation *bo.StockReservation) error {
+	return s.repo.CreateStockReservation(ctx, reservation)
+}
+
+func (s *stockReservationService) Confirm(ctx context.Context, reservationID int64) (bo.StockReservation, error) {
+	return s.repo.ConfirmStockReservation(ctx, reservationID)
+}
+
+func (s *stockReservationService) Release(ctx context.Context, reservationID int64) error {
+	return s.repo.ReleaseStockReservation(ctx, reservationID)
+}
+
+// StartSweeper expires stale reservations every interval until ctx is done.
+// Reads already ignore reservations past their expiry, the sweep only moves
+// them to the expired status so they can no longer be confirmed or released.
+func (s *stockReservationService) StartSweeper(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				expired, err := s.repo.ExpireStockReservations(ctx)
+				if err != nil {
+					slog.Error("failed to expire stock reservations", "cause", err)
+					continue
+				}
+				if expired > 0 {
+					slog.Info("expired stock reservations", slog.Int64("count", expired))
+				}
+			}
+		}
+	}()
+}
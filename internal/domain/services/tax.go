@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+
+	"github.com/shopspring/decimal"
+)
+
+// taxRatePlaces are the decimal places a rate may have, those of the rate column
+const taxRatePlaces = 4
+
+var taxClassCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+var onceInitTaxService sync.Once
+var taxServiceInstance *taxService
+
+// taxService is the TaxCalculator of the store, it taxes the products at the rates of its rate tables
+type taxService struct {
+	repo     definition.TaxRepository
+	settings bo.TaxSettings
+}
+
+func Tax(taxRepo definition.TaxRepository, settings bo.TaxSettings) *taxService {
+	onceInitTaxService.Do(func() {
+		taxServiceInstance = &taxService{
+			repo:     taxRepo,
+			settings: settings,
+		}
+	})
+
+	return taxServiceInstance
+}
+
+// Calculate taxes the lines in the jurisdiction of the request, the default one when it has none
+func (s *taxService) Calculate(ctx context.Context, request bo.TaxRequest) (bo.TaxBreakdown, error) {
+	jurisdiction := strings.ToUpper(strings.TrimSpace(request.Jurisdiction))
+	if jurisdiction == "" {
+		jurisdiction = s.settings.Jurisdiction
+	}
+	if !bo.ValidJurisdiction(jurisdiction) {
+		return bo.TaxBreakdown{}, fmt.Errorf("%w: %s", bo.ErrInvalidJurisdiction, request.Jurisdiction)
+	}
+	if len(request.Lines) == 0 {
+		return bo.CalculateTax(s.settings.Pricing, jurisdiction, request.Currency, nil), nil
+	}
+
+	seen := map[int64]bool{}
+	var productIDs []int64
+	for _, line := range request.Lines {
+		if !seen[line.ProductID] {
+			seen[line.ProductID] = true
+			productIDs = append(productIDs, line.ProductID)
+		}
+	}
+
+	resolved, err := s.repo.ResolveProductTaxRates(ctx, productIDs, jurisdiction)
+	if err != nil {
+		return bo.TaxBreakdown{}, err
+	}
+
+	lines := make([]bo.TaxLine, 0, len(request.Lines))
+	for _, line := range request.Lines {
+		product := resolved[line.ProductID]
+		lines = append(lines, bo.TaxLine{
+			CartItemID: line.CartItemID,
+			ProductID:  line.ProductID,
+			TaxClassID: product.TaxClassID,
+			Amount:     line.Amount,
+			Rates:      product.Rates,
+		})
+	}
+
+	return bo.CalculateTax(s.settings.Pricing, jurisdiction, request.Currency, lines), nil
+}
+
+func (s *taxService) Classes(ctx context.Context) (bo.TaxClassCollection, error) {
+	return s.repo.ListTaxClasses(ctx)
+}
+
+// CreateClass adds a class, a default class replaces the previous default
+func (s *taxService) CreateClass(ctx context.Context, class bo.TaxClass) (bo.TaxClass, error) {
+	class.Code = strings.ToLower(strings.TrimSpace(class.Code))
+	class.Name = strings.TrimSpace(class.Name)
+	if !taxClassCodePattern.MatchString(class.Code) {
+		return bo.TaxClass{}, fmt.Errorf("%w: the code has lowercase letters, digits, dashes and underscores", bo.ErrInvalidTaxClass)
+	}
+	if class.Name == "" {
+		return bo.TaxClass{}, fmt.Errorf("%w: the name is empty", bo.ErrInvalidTaxClass)
+	}
+
+	if err := s.repo.CreateTaxClass(ctx, &class); err != nil {
+		return bo.TaxClass{}, err
+	}
+	return class, nil
+}
+
+func (s *taxService) UpdateClass(ctx context.Context, update bo.TaxClassUpdate) (bo.TaxClass, error) {
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return bo.TaxClass{}, fmt.Errorf("%w: the name is empty", bo.ErrInvalidTaxClass)
+		}
+		update.Name = &name
+	}
+	return s.repo.UpdateTaxClass(ctx, update)
+}
+
+// DeleteClass drops a class and its rates, its products and categories fall back to the default class
+func (s *taxService) DeleteClass(ctx context.Context, classID int64) error {
+	return s.repo.DeleteTaxClass(ctx, classID)
+}
+
+func (s *taxService) Rates(ctx context.Context, classID int64) (bo.TaxRateCollection, error) {
+	return s.repo.ListTaxRates(ctx, classID)
+}
+
+func (s *taxService) CreateRate(ctx context.Context, rate bo.TaxRate) (bo.TaxRate, error) {
+	rate.Jurisdiction = strings.ToUpper(strings.TrimSpace(rate.Jurisdiction))
+	rate.Name = strings.TrimSpace(rate.Name)
+	if !bo.ValidJurisdiction(rate.Jurisdiction) {
+		return bo.TaxRate{}, fmt.Errorf("%w: %s", bo.ErrInvalidJurisdiction, rate.Jurisdiction)
+	}
+	if rate.Name == "" {
+		return bo.TaxRate{}, fmt.Errorf("%w: the name is empty", bo.ErrInvalidTaxRate)
+	}
+	if err := validateTaxRate(rate.Rate); err != nil {
+		return bo.TaxRate{}, err
+	}
+
+	if err := s.repo.CreateTaxRate(ctx, &rate); err != nil {
+		return bo.TaxRate{}, err
+	}
+	return rate, nil
+}
+
+func (s *taxService) UpdateRate(ctx context.Context, update bo.TaxRateUpdate) (bo.TaxRate, error) {
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return bo.TaxRate{}, fmt.Errorf("%w: the name is empty", bo.ErrInvalidTaxRate)
+		}
+		update.Name = &name
+	}
+	if update.Rate != nil {
+		if err := validateTaxRate(*update.Rate); err != nil {
+			return bo.TaxRate{}, err
+		}
+	}
+	return s.repo.UpdateTaxRate(ctx, update)
+}
+
+func (s *taxService) DeleteRate(ctx context.Context, classID, rateID int64) error {
+	return s.repo.DeleteTaxRate(ctx, classID, rateID)
+}
+
+// validateTaxRate accepts a percentage from 0 to 100 with at most four decimal places
+func validateTaxRate(rate decimal.Decimal) error {
+	if rate.IsNegative() || rate.GreaterThan(decimal.NewFromInt(100)) {
+		return fmt.Errorf("%w: the rate is a percentage from 0 to 100", bo.ErrInvalidTaxRate)
+	}
+	if !rate.Equal(rate.Round(taxRatePlaces)) {
+		return fmt.Errorf("%w: the rate has at most %d decimal places", bo.ErrInvalidTaxRate, taxRatePlaces)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/mockdb"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalculateTax(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taxStore := mockdb.NewMockTaxRepository(ctrl)
+	amount := decimal.RequireFromString
+
+	vat := bo.TaxRate{ID: 1, TaxClassID: 1, Jurisdiction: "BD", Name: "VAT", Rate: decimal.NewFromInt(15)}
+	duty := bo.TaxRate{ID: 2, TaxClassID: 2, Jurisdiction: "BD", Name: "Supplementary duty", Rate: decimal.NewFromInt(10)}
+	standard := bo.ProductTaxRates{ProductID: 1, TaxClassID: 1, Rates: bo.TaxRateCollection{vat}}
+	luxury := bo.ProductTaxRates{ProductID: 2, TaxClassID: 2, Rates: bo.TaxRateCollection{{ID: 3, TaxClassID: 2, Jurisdiction: "BD", Name: "VAT", Rate: decimal.NewFromInt(15)}, duty}}
+
+	testCases := []struct {
+		name         string
+		pricing      bo.TaxPricing
+		jurisdiction string
+		currency     string
+		lines        []bo.TaxableLine
+		resolved     map[int64]bo.ProductTaxRates
+		lineTaxes    []string
+		net          string
+		tax          string
+		gross        string
+		totals       []string
+		err          error
+	}{
+		{
+			name:      "Inclusive",
+			pricing:   bo.TaxInclusive,
+			lines:     []bo.TaxableLine{{CartItemID: 1, ProductID: 1, Amount: amount("115")}},
+			resolved:  map[int64]bo.ProductTaxRates{1: standard},
+			lineTaxes: []string{"15"},
+			net:       "100", tax: "15", gross: "115",
+			totals: []string{"15"},
+		},
+		{
+			name:      "Exclusive",
+			pricing:   bo.TaxExclusive,
+			lines:     []bo.TaxableLine{{CartItemID: 1, ProductID: 1, Amount: amount("100")}},
+			resolved:  map[int64]bo.ProductTaxRates{1: standard},
+			lineTaxes: []string{"15"},
+			net:       "100", tax: "15", gross: "115",
+			totals: []string{"15"},
+		},
+		{
+			// 99.99 / 1.15 = 86.947..., the tax of 13.0421... is rounded to the poisha
+			name:      "InclusiveRounding",
+			pricing:   bo.TaxInclusive,
+			lines:     []bo.TaxableLine{{CartItemID: 1, ProductID: 1, Amount: amount("99.99")}},
+			resolved:  map[int64]bo.ProductTaxRates{1: standard},
+			lineTaxes: []string{"13.04"},
+			net:       "86.95", tax: "13.04", gross: "99.99",
+			totals: []string{"13.04"},
+		},
+		{
+			// the rates of a line add up, each is levied on the same net amount
+			name:      "SeveralRates",
+			pricing:   bo.TaxInclusive,
+			lines:     []bo.TaxableLine{{CartItemID: 1, ProductID: 2, Amount: amount("125")}},
+			resolved:  map[int64]bo.ProductTaxRates{2: luxury},
+			lineTaxes: []string{"25"},
+			net:       "100", tax: "25", gross: "125",
+			totals: []string{"15", "10"},
+		},
+		{
+			name:    "SameRateOverLines",
+			pricing: bo.TaxExclusive,
+			lines: []bo.TaxableLine{
+				{CartItemID: 1, ProductID: 1, Amount: amount("10.05")},
+				{CartItemID: 2, ProductID: 1, Amount: amount("20.05")},
+			},
+			resolved:  map[int64]bo.ProductTaxRates{1: standard},
+			lineTaxes: []string{"1.51", "3.01"},
+			net:       "30.1", tax: "4.52", gross: "34.62",
+			totals: []string{"4.52"},
+		},
+		{
+			// 999 * 15% = 149.85, the yen has no minor units
+			name:      "CurrencyMinorUnits",
+			pricing:   bo.TaxExclusive,
+			currency:  "JPY",
+			lines:     []bo.TaxableLine{{CartItemID: 1, ProductID: 1, Amount: amount("999")}},
+			resolved:  map[int64]bo.ProductTaxRates{1: standard},
+			lineTaxes: []string{"150"},
+			net:       "999", tax: "150", gross: "1149",
+			totals: []string{"150"},
+		},
+		{
+			name:      "NoClass",
+			pricing:   bo.TaxExclusive,
+			lines:     []bo.TaxableLine{{CartItemID: 1, ProductID: 3, Amount: amount("50")}},
+			resolved:  map[int64]bo.ProductTaxRates{},
+			lineTaxes: []string{"0"},
+			net:       "50", tax: "0", gross: "50",
+			totals: []string{},
+		},
+		{
+			name:         "Jurisdiction",
+			pricing:      bo.TaxInclusive,
+			jurisdiction: "Bangladesh",
+			lines:        []bo.TaxableLine{{CartItemID: 1, ProductID: 1, Amount: amount("115")}},
+			err:          bo.ErrInvalidJurisdiction,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.currency == "" {
+				tc.currency = "BDT"
+			}
+			service := &taxService{repo: taxStore, settings: bo.TaxSettings{Pricing: tc.pricing, Jurisdiction: "BD"}}
+			if tc.err == nil {
+				// the request without a jurisdiction is taxed in the default one
+				taxStore.EXPECT().
+					ResolveProductTaxRates(gomock.Any(), gomock.Any(), gomock.Eq("BD")).
+					Times(1).
+					Return(tc.resolved, nil)
+			}
+
+			breakdown, err := service.Calculate(context.Background(), bo.TaxRequest{Jurisdiction: tc.jurisdiction, Currency: tc.currency, Lines: tc.lines})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.pricing, breakdown.Pricing)
+			require.Equal(t, "BD", breakdown.Jurisdiction)
+			require.Equal(t, tc.currency, breakdown.Currency)
+			require.Len(t, breakdown.Lines, len(tc.lines))
+			for i, line := range breakdown.Lines {
+				require.Equal(t, tc.lineTaxes[i], line.Tax.String())
+			}
+			require.Equal(t, tc.net, breakdown.Net.String())
+			require.Equal(t, tc.tax, breakdown.Tax.String())
+			require.Equal(t, tc.gross, breakdown.Gross.String())
+			require.Len(t, breakdown.Totals, len(tc.totals))
+			for i, total := range breakdown.Totals {
+				require.Equal(t, tc.totals[i], total.Tax.String())
+			}
+		})
+	}
+}
+
+func TestRecalculateTax(t *testing.T) {
+	amount := decimal.RequireFromString
+	vat := bo.TaxRate{ID: 1, TaxClassID: 1, Jurisdiction: "BD", Name: "VAT", Rate: decimal.NewFromInt(15)}
+	quoted := bo.CalculateTax(bo.TaxExclusive, "BD", "BDT", []bo.TaxLine{
+		{CartItemID: 1, ProductID: 1, TaxClassID: 1, Amount: amount("200"), Rates: bo.TaxRateCollection{vat}},
+	})
+
+	// the item was charged its cart price and a product was added since the quote
+	charged := quoted.Recalculate([]bo.TaxableLine{
+		{CartItemID: 1, ProductID: 1, Amount: amount("220")},
+		{CartItemID: 2, ProductID: 2, Amount: amount("50")},
+	})
+
+	require.Equal(t, "30", quoted.Tax.String())
+	require.Equal(t, "33", charged.Tax.String())
+	require.Equal(t, "303", charged.Gross.String())
+	require.Equal(t, int64(1), charged.Lines[0].TaxClassID)
+	require.True(t, charged.Lines[1].Tax.IsZero())
+}
+
+func TestCreateTaxRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taxStore := mockdb.NewMockTaxRepository(ctrl)
+	service := &taxService{repo: taxStore, settings: bo.TaxSettings{Pricing: bo.TaxInclusive, Jurisdiction: "BD"}}
+
+	testCases := []struct {
+		name         string
+		jurisdiction string
+		rate         string
+		err          error
+	}{
+		{name: "Country", jurisdiction: "BD", rate: "15"},
+		{name: "Subdivision", jurisdiction: "us-ca", rate: "7.25"},
+		{name: "Exempt", jurisdiction: "BD", rate: "0"},
+		{name: "Negative", jurisdiction: "BD", rate: "-1", err: bo.ErrInvalidTaxRate},
+		{name: "AboveHundred", jurisdiction: "BD", rate: "100.5", err: bo.ErrInvalidTaxRate},
+		{name: "TooPrecise", jurisdiction: "BD", rate: "7.12345", err: bo.ErrInvalidTaxRate},
+		{name: "Jurisdiction", jurisdiction: "BGD", rate: "15", err: bo.ErrInvalidJurisdiction},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.err == nil {
+				taxStore.EXPECT().CreateTaxRate(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			}
+
+			rate, err := service.CreateRate(context.Background(), bo.TaxRate{
+				TaxClassID:   1,
+				Jurisdiction: tc.jurisdiction,
+				Name:         "VAT",
+				Rate:         decimal.RequireFromString(tc.rate),
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, bo.ValidJurisdiction(rate.Jurisdiction))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/domain/definition"
+)
+
+var onceInitWarehouseService sync.Once
+var warehouseServiceInstance *warehouseService
+
+type warehouseService struct {
+	repo definition.WarehouseRepository
+}
+
+func Warehouse(warehouseRepo definition.WarehouseRepository) *warehouseService {
+	onceInitWarehouseService.Do(func() {
+		warehouseServiceInstance = &warehouseService{
+			repo: warehouseRepo,
+		}
+	})
+
+	return warehouseServiceInstance
+}
+
+func (s *warehouseService) List(ctx context.Context, query bo.WarehouseQuery) (bo.PaginatedWarehouseCollection, error) {
+	return s.repo.ListWarehouses(ctx, query)
+}
+
+func (s *warehouseService) GetWarehouseByID(ctx context.Context, warehouseID int64) (bo.Warehouse, error) {
+	return s.repo.GetWarehouseByID(ctx, warehouseID)
+}
+
+func (s *warehouseService) CreateWarehouse(ctx context.Context, warehouse bo.Warehouse) (int64, error) {
+	if err := s.repo.CreateWarehouse(ctx, &warehouse); err != nil {
+		return -1, err
+	}
+
+	if warehouse.ID < 1 {
+		slog.Warn("inserted warehouse has invalid id", slog.String("name", warehouse.Name))
+	}
+	return warehouse.ID, nil
+}
+
+func (s *warehouseService) UpdateWarehouse(ctx context.Context, updateWarehouse bo.WarehouseUpdate) error {
+	return s.repo.UpdateWarehouse(ctx, updateWarehouse)
+}
+
+func (s *warehouseService) DeleteWarehouse(ctx context.Context, warehouseID int64) error {
+	return s.repo.DeleteWarehouse(ctx, warehouseID)
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// xlsxMaxCellLength is the most characters a spreadsheet cell holds
+const xlsxMaxCellLength = 32767
+
+// xlsxParts are the parts of a workbook with a single sheet, besides the sheet itself
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxWriter streams the rows of a single sheet workbook. The cells are written inline,
+// without a shared string table, so that no row is kept in memory.
+type xlsxWriter struct {
+	zip    *zip.Writer
+	sheet  *bufio.Writer
+	name   string
+	rows   int
+	err    error
+	opened bool
+}
+
+func newXLSXWriter(w io.Writer, sheetName string) *xlsxWriter {
+	return &xlsxWriter{zip: zip.NewWriter(w), name: sheetName}
+}
+
+// open writes the parts before the sheet, the sheet being the last part of the archive
+func (w *xlsxWriter) open() error {
+	w.opened = true
+	for _, part := range xlsxParts {
+		if err := w.writePart(part.name, part.content); err != nil {
+			return err
+		}
+	}
+
+	var workbook strings.Builder
+	workbook.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	workbook.WriteString(`<sheets><sheet name="`)
+	xml.EscapeText(&workbook, []byte(w.name))
+	workbook.WriteString(`" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+	if err := w.writePart("xl/workbook.xml", workbook.String()); err != nil {
+		return err
+	}
+
+	sheet, err := w.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	w.sheet = bufio.NewWriter(sheet)
+	w.sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return nil
+}
+
+func (w *xlsxWriter) writePart(name, content string) error {
+	part, err := w.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(part, content)
+	return err
+}
+
+// WriteRow writes numbers and booleans as such, and any other value as text
+func (w *xlsxWriter) WriteRow(values []any) error {
+	if w.err != nil {
+		return w.err
+	}
+	if !w.opened {
+		if w.err = w.open(); w.err != nil {
+			return w.err
+		}
+	}
+
+	w.rows++
+	row := strconv.Itoa(w.rows)
+	w.sheet.WriteString(`<row r="` + row + `">`)
+	for i, value := range values {
+		ref := xlsxColumn(i) + row
+		switch v := value.(type) {
+		case nil:
+			continue
+		case int64:
+			w.sheet.WriteString(`<c r="` + ref + `"><v>` + strconv.FormatInt(v, 10) + `</v></c>`)
+		case float64:
+			w.sheet.WriteString(`<c r="` + ref + `"><v>` + strconv.FormatFloat(v, 'g', -1, 64) + `</v></c>`)
+		case decimal.Decimal:
+			w.sheet.WriteString(`<c r="` + ref + `"><v>` + v.String() + `</v></c>`)
+		case bool:
+			boolean := "0"
+			if v {
+				boolean = "1"
+			}
+			w.sheet.WriteString(`<c r="` + ref + `" t="b"><v>` + boolean + `</v></c>`)
+		default:
+			text := exportText(value)
+			if text == "" {
+				continue
+			}
+			text = truncateRunes(text, xlsxMaxCellLength)
+			w.sheet.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(w.sheet, []byte(text))
+			w.sheet.WriteString(`</t></is></c>`)
+		}
+	}
+	// a failed write is kept by the buffered writer and returned by every later write
+	_, w.err = w.sheet.WriteString(`</row>`)
+	return w.err
+}
+
+// Close completes the sheet and the archive, an export without rows is an empty sheet
+func (w *xlsxWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if !w.opened {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+	w.sheet.WriteString(`</sheetData></worksheet>`)
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+// xlsxColumn is the letter name of a zero based column: A, B, ..., Z, AA, AB...
+func xlsxColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,96 @@
+package blobstores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"techno-store/internal/domain/bo"
+)
+
+// LocalStorage is the name of the local filesystem backend
+const LocalStorage = "local"
+
+// LocalStore keeps the files in a directory of the local filesystem, a key is
+// their path relative to it. It suits a single server and local runs.
+type LocalStore struct {
+	dir       string
+	publicURL string
+}
+
+// NewLocalStore builds a store of the files under dir, served from publicURL
+func NewLocalStore(dir, publicURL string) *LocalStore {
+	return &LocalStore{dir: dir, publicURL: publicURL}
+}
+
+func (s *LocalStore) Name() string {
+	return LocalStorage
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes the file next to its final path first, so a reader never opens a partial file
+func (s *LocalStore) Put(_ context.Context, key string, body io.Reader, size int64, _ string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if written != size {
+		return fmt.Errorf("wrote %d bytes of blob %q, expected %d", written, key, size)
+	}
+
+	return os.Rename(file.Name(), name)
+}
+
+func (s *LocalStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, bo.ErrBlobNotFound
+	}
+	return file, err
+}
+
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) URL(key string) string {
+	return publicURL(s.publicURL, key)
+}
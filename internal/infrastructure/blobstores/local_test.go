@@ -0,0 +1,44 @@
+package blobstores
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir(), "/v1/media")
+	key := "products/1/front.png"
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("image"), 5, "image/png"))
+	require.Equal(t, "/v1/media/products/1/front.png", store.URL(key))
+
+	file, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Equal(t, "image", string(content))
+
+	require.Error(t, store.Put(ctx, key, strings.NewReader("cut"), 5, "image/png"), "a short body keeps the stored file")
+	file, err = store.Get(ctx, key)
+	require.NoError(t, err)
+	content, _ = io.ReadAll(file)
+	file.Close()
+	require.Equal(t, "image", string(content))
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.NoError(t, store.Delete(ctx, key), "a missing file is already deleted")
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, bo.ErrBlobNotFound)
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "products/../../secret", "products//1"} {
+		require.Error(t, store.Put(ctx, key, strings.NewReader("x"), 1, ""), key)
+	}
+}
@@ -0,0 +1,35 @@
+package blobstores
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"techno-store/config"
+	"techno-store/internal/domain/definition"
+)
+
+// GetInstance returns the blob store of the configured media storage, the
+// config rejects any storage other than local and s3.
+func GetInstance(config *config.MediaConfig) definition.BlobStore {
+	if config.Storage == S3Storage {
+		return NewS3Store(config.S3, config.PublicURL, &http.Client{Timeout: 30 * time.Second})
+	}
+	return NewLocalStore(config.LocalDir, config.PublicURL)
+}
+
+// checkKey rejects the keys which are not a clean relative path, so a key
+// cannot escape the local directory nor the bucket
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	return nil
+}
+
+// publicURL joins the public url of the media and a key
+func publicURL(base, key string) string {
+	return base + "/" + key
+}
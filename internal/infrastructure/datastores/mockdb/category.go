@@ -53,6 +53,20 @@ func (mr *MockCategoryRepositoryMockRecorder) CreateCategory(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryRepository)(nil).CreateCategory), arg0, arg1)
 }
 
+// CreateCategoryAttribute mocks base method.
+func (m *MockCategoryRepository) CreateCategoryAttribute(arg0 context.Context, arg1 *bo.CategoryAttribute) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategoryAttribute", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategoryAttribute indicates an expected call of CreateCategoryAttribute.
+func (mr *MockCategoryRepositoryMockRecorder) CreateCategoryAttribute(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryAttribute", reflect.TypeOf((*MockCategoryRepository)(nil).CreateCategoryAttribute), arg0, arg1)
+}
+
 // DeleteCategory mocks base method.
 func (m *MockCategoryRepository) DeleteCategory(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -67,6 +81,20 @@ func (mr *MockCategoryRepositoryMockRecorder) DeleteCategory(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryRepository)(nil).DeleteCategory), arg0, arg1)
 }
 
+// DeleteCategoryAttribute mocks base method.
+func (m *MockCategoryRepository) DeleteCategoryAttribute(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategoryAttribute", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategoryAttribute indicates an expected call of DeleteCategoryAttribute.
+func (mr *MockCategoryRepositoryMockRecorder) DeleteCategoryAttribute(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategoryAttribute", reflect.TypeOf((*MockCategoryRepository)(nil).DeleteCategoryAttribute), arg0, arg1, arg2)
+}
+
 // GetCategoryByID mocks base method.
 func (m *MockCategoryRepository) GetCategoryByID(arg0 context.Context, arg1 int64) (bo.Category, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +125,51 @@ func (mr *MockCategoryRepositoryMockRecorder) ListCategories(arg0 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCategoryRepository)(nil).ListCategories), arg0)
 }
 
+// ListCategoryAncestors mocks base method.
+func (m *MockCategoryRepository) ListCategoryAncestors(arg0 context.Context, arg1 int64) (bo.CategoryCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryAncestors", arg0, arg1)
+	ret0, _ := ret[0].(bo.CategoryCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryAncestors indicates an expected call of ListCategoryAncestors.
+func (mr *MockCategoryRepositoryMockRecorder) ListCategoryAncestors(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryAncestors", reflect.TypeOf((*MockCategoryRepository)(nil).ListCategoryAncestors), arg0, arg1)
+}
+
+// ListCategoryAttributes mocks base method.
+func (m *MockCategoryRepository) ListCategoryAttributes(arg0 context.Context, arg1 int64) (bo.CategoryAttributeCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryAttributes", arg0, arg1)
+	ret0, _ := ret[0].(bo.CategoryAttributeCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryAttributes indicates an expected call of ListCategoryAttributes.
+func (mr *MockCategoryRepositoryMockRecorder) ListCategoryAttributes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryAttributes", reflect.TypeOf((*MockCategoryRepository)(nil).ListCategoryAttributes), arg0, arg1)
+}
+
+// ListCategorySubtree mocks base method.
+func (m *MockCategoryRepository) ListCategorySubtree(arg0 context.Context, arg1 int64) (bo.CategoryCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategorySubtree", arg0, arg1)
+	ret0, _ := ret[0].(bo.CategoryCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategorySubtree indicates an expected call of ListCategorySubtree.
+func (mr *MockCategoryRepositoryMockRecorder) ListCategorySubtree(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategorySubtree", reflect.TypeOf((*MockCategoryRepository)(nil).ListCategorySubtree), arg0, arg1)
+}
+
 // UpdateCategory mocks base method.
 func (m *MockCategoryRepository) UpdateCategory(arg0 context.Context, arg1 bo.CategoryUpdate) error {
 	m.ctrl.T.Helper()
@@ -67,6 +67,20 @@ func (mr *MockProductRepositoryMockRecorder) DeleteProduct(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductRepository)(nil).DeleteProduct), arg0, arg1)
 }
 
+// ExportProducts mocks base method.
+func (m *MockProductRepository) ExportProducts(arg0 context.Context, arg1 bo.ProductFilter, arg2 func(bo.ProductExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProducts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockProductRepositoryMockRecorder) ExportProducts(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockProductRepository)(nil).ExportProducts), arg0, arg1, arg2)
+}
+
 // GetProductByID mocks base method.
 func (m *MockProductRepository) GetProductByID(arg0 context.Context, arg1 int64) (bo.Product, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +111,21 @@ func (mr *MockProductRepositoryMockRecorder) ListProducts(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockProductRepository)(nil).ListProducts), arg0, arg1)
 }
 
+// ProductFacets mocks base method.
+func (m *MockProductRepository) ProductFacets(arg0 context.Context, arg1 bo.ProductFilter, arg2 bo.CategoryAttributeCollection) (bo.ProductFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductFacets", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bo.ProductFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductFacets indicates an expected call of ProductFacets.
+func (mr *MockProductRepositoryMockRecorder) ProductFacets(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductFacets", reflect.TypeOf((*MockProductRepository)(nil).ProductFacets), arg0, arg1, arg2)
+}
+
 // UpdateProduct mocks base method.
 func (m *MockProductRepository) UpdateProduct(arg0 context.Context, arg1 bo.ProductUpdate) error {
 	m.ctrl.T.Helper()
@@ -39,6 +39,21 @@ func (m *MockProductStockRepository) EXPECT() *MockProductStockRepositoryMockRec
 	return m.recorder
 }
 
+// AdjustProductStock mocks base method.
+func (m *MockProductStockRepository) AdjustProductStock(arg0 context.Context, arg1 bo.ProductStockAdjustment) (bo.ProductStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustProductStock", arg0, arg1)
+	ret0, _ := ret[0].(bo.ProductStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustProductStock indicates an expected call of AdjustProductStock.
+func (mr *MockProductStockRepositoryMockRecorder) AdjustProductStock(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustProductStock", reflect.TypeOf((*MockProductStockRepository)(nil).AdjustProductStock), arg0, arg1)
+}
+
 // CreateProductStock mocks base method.
 func (m *MockProductStockRepository) CreateProductStock(arg0 context.Context, arg1 *bo.ProductStock) error {
 	m.ctrl.T.Helper()
@@ -82,6 +97,21 @@ func (mr *MockProductStockRepositoryMockRecorder) GetProductStockByID(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductStockByID", reflect.TypeOf((*MockProductStockRepository)(nil).GetProductStockByID), arg0, arg1)
 }
 
+// GetProductStockLevel mocks base method.
+func (m *MockProductStockRepository) GetProductStockLevel(arg0 context.Context, arg1 int64) (bo.ProductStockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductStockLevel", arg0, arg1)
+	ret0, _ := ret[0].(bo.ProductStockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductStockLevel indicates an expected call of GetProductStockLevel.
+func (mr *MockProductStockRepositoryMockRecorder) GetProductStockLevel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductStockLevel", reflect.TypeOf((*MockProductStockRepository)(nil).GetProductStockLevel), arg0, arg1)
+}
+
 // ListProductStocks mocks base method.
 func (m *MockProductStockRepository) ListProductStocks(arg0 context.Context, arg1 bo.ProductStockQuery) (bo.PaginatedProductStockCollection, error) {
 	m.ctrl.T.Helper()
@@ -8,10 +8,25 @@ import (
 
 func GetInstance(ctrl *gomock.Controller) definition.DataStore {
 	return definition.DataStore{
-		Brand:        NewMockBrandRepository(ctrl),
-		Category:     NewMockCategoryRepository(ctrl),
-		Supplier:     NewMockSupplierRepository(ctrl),
-		Product:      NewMockProductRepository(ctrl),
-		ProductStock: NewMockProductStockRepository(ctrl),
+		Brand:            NewMockBrandRepository(ctrl),
+		Category:         NewMockCategoryRepository(ctrl),
+		Supplier:         NewMockSupplierRepository(ctrl),
+		Product:          NewMockProductRepository(ctrl),
+		ProductVariant:   NewMockProductVariantRepository(ctrl),
+		ProductMedia:     NewMockProductMediaRepository(ctrl),
+		ProductImport:    NewMockProductImportRepository(ctrl),
+		ProductPrice:     NewMockProductPriceRepository(ctrl),
+		Currency:         NewMockCurrencyRepository(ctrl),
+		Tax:              NewMockTaxRepository(ctrl),
+		ProductStock:     NewMockProductStockRepository(ctrl),
+		Warehouse:        NewMockWarehouseRepository(ctrl),
+		StockMovement:    NewMockStockMovementRepository(ctrl),
+		StockReservation: NewMockStockReservationRepository(ctrl),
+		Cart:             NewMockCartRepository(ctrl),
+		Order:            NewMockOrderRepository(ctrl),
+		Payment:          NewMockPaymentRepository(ctrl),
+		Return:           NewMockReturnRepository(ctrl),
+		Promotion:        NewMockPromotionRepository(ctrl),
+		Search:           NewMockSearchRepository(ctrl),
 	}
 }
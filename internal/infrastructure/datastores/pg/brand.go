@@ -6,10 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"strconv"
 	"strings"
 
 	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -67,19 +67,7 @@ func (s *brandStore) CreateBrand(ctx context.Context, brand *bo.Brand) error {
 		return fmt.Errorf("empty core insert for brand")
 	}
 
-	start := 1
-	arguments := make([]interface{}, 0, len(insertMap))
-	fields := []string{}
-	placeholders := []string{}
-
-	for field, v := range insertMap {
-		fields = append(fields, field)
-		arguments = append(arguments, v)
-		placeholders = append(placeholders, "$"+strconv.Itoa(start))
-		start++
-	}
-
-	sqlQuery := fmt.Sprintf("INSERT INTO brands(%s)VALUES (%s) RETURNING id", strings.Join(fields, ","), strings.Join(placeholders, ","))
+	sqlQuery, arguments := sqlbuilder.Insert("brands").Values(insertMap).Returning("id").Build()
 
 	conn, err := s.dbPool.Acquire(ctx)
 	if err != nil {
@@ -121,22 +109,7 @@ func (s *brandStore) UpdateBrand(ctx context.Context, updateBrand bo.BrandUpdate
 			return errors.New("empty core update for brand")
 		}
 
-		sqlQuery := "UPDATE brands SET "
-		start := 1
-		arguments := make([]interface{}, 0, len(updateMap)+1)
-
-		for k, v := range updateMap {
-			sqlQuery = sqlQuery + k + "=$" + strconv.Itoa(start)
-			arguments = append(arguments, v)
-			if start < len(updateMap) {
-				sqlQuery = sqlQuery + ", "
-			}
-
-			start++
-		}
-
-		sqlQuery = sqlQuery + fmt.Sprintf(" WHERE id = $%d", start)
-		arguments = append(arguments, updateBrand.ID)
+		sqlQuery, arguments := sqlbuilder.Update("brands").Set(updateMap).Where("id = ?", updateBrand.ID).Build()
 
 		commandTag, err := tx.Exec(ctx, sqlQuery, arguments...)
 		if err != nil {
@@ -187,17 +160,32 @@ func (s *brandStore) DeleteBrand(ctx context.Context, brandID int64) error {
 	})
 }
 
+// brandListSort names the only order brands are listed in, by name
+const brandListSort = "name"
+
+func brandCursor(brand bo.Brand) *bo.PageCursor {
+	return &bo.PageCursor{Sort: brandListSort, Key: brand.Name, ID: brand.ID}
+}
+
 func (s *brandStore) ListBrands(ctx context.Context, brandQuery bo.BrandQuery) (bo.PaginatedBrandCollection, error) {
 	pagingCollection := bo.PaginatedBrandCollection{}
 
+	cursor, backward, err := pageCursor(brandQuery.PageCursors, brandListSort)
+	if err != nil || !cursorKeyIs[string](cursor) {
+		return pagingCollection, bo.ErrInvalidCursor
+	}
+
 	conn, err := s.dbPool.Acquire(ctx)
 	if err != nil {
 		return pagingCollection, err
 	}
 	defer conn.Release()
 
-	dbQuery := fmt.Sprintf("SELECT %s FROM brands ORDER BY name ASC LIMIT $1 OFFSET $2", strings.Join(brandFields, ","))
-	rows, err := conn.Query(ctx, dbQuery, brandQuery.Limit, brandQuery.Offset)
+	query := sqlbuilder.Select(brandFields...).From("brands")
+	countQuery, countArgs := query.BuildCount()
+	keysetQuery(query, []string{"name", "id"}, false, brandQuery.Limit, brandQuery.Offset, cursor, backward)
+	dbQuery, args := query.Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
 	if err != nil {
 		slog.Error("failed to list brands", "cause", err)
 		return pagingCollection, err
@@ -229,11 +217,11 @@ func (s *brandStore) ListBrands(ctx context.Context, brandQuery bo.BrandQuery) (
 		return pagingCollection, err
 	}
 
-	pagingCollection.Data = brands
+	pagingCollection.Data, pagingCollection.PageLinks = keysetPage(brands, brandQuery.Limit, brandQuery.Offset, brandQuery.PageCursors, brandCursor)
 	var (
 		totalRecord sql.NullInt64
 	)
-	if err = conn.QueryRow(ctx, `SELECT COUNT(*) FROM brands`).Scan(&totalRecord); err != nil {
+	if err = conn.QueryRow(ctx, countQuery, countArgs...).Scan(&totalRecord); err != nil {
 		slog.Error("error scanning COUNT brands row", "cause", err)
 		return pagingCollection, err
 	}
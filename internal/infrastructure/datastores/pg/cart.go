@@ -0,0 +1,350 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type cartStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// cartItemAvailability is the available stock, on hand minus live reservations,
+// of every product (variant) in the cart $1 over all warehouses
+const cartItemAvailability = `(SELECT ps.product_id, ps.variant_id, SUM(COALESCE(ps.stock_quantity, 0) - COALESCE(rs.reserved_quantity, 0)) AS available_quantity
+	FROM product_stocks ps LEFT JOIN ` + reservedQuantityAggregate + ` rs ON rs.product_stock_id = ps.id
+	WHERE ps.product_id IN (SELECT product_id FROM cart_items WHERE cart_id = $1)
+	GROUP BY ps.product_id, ps.variant_id)`
+
+func (s *cartStore) GetCartByID(ctx context.Context, cartID int64) (bo.Cart, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return bo.Cart{}, err
+	}
+	defer conn.Release()
+
+	return getCart(ctx, conn, cartID)
+}
+
+// getCart reads a cart with its items and their current availability.
+func getCart(ctx context.Context, q querier, cartID int64) (bo.Cart, error) {
+	var (
+		id            sql.NullInt64
+		reference     sql.NullString
+		status        sql.NullString
+		currency      sql.NullString
+		subtotal      decimal.NullDecimal
+		discountTotal decimal.NullDecimal
+		total         decimal.NullDecimal
+		createdAt     sql.NullTime
+		updatedAt     sql.NullTime
+	)
+
+	row := q.QueryRow(ctx, `SELECT id, reference, status, currency, subtotal, discount_total, total, created_at, updated_at
+		FROM carts WHERE id = $1`, cartID)
+	if err := row.Scan(&id, &reference, &status, &currency, &subtotal, &discountTotal, &total, &createdAt, &updatedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			slog.Error("cart id does not exist", slog.Int64("id", cartID))
+			return bo.Cart{}, bo.ErrCartNotFound
+		}
+		slog.Error("failed to scan cart table row", "cause", err)
+		return bo.Cart{}, err
+	}
+
+	cart := bo.Cart{
+		ID:            id.Int64,
+		Reference:     reference.String,
+		Status:        bo.CartStatus(status.String),
+		Currency:      currency.String,
+		Subtotal:      bo.NewMoney(subtotal.Decimal, currency.String),
+		DiscountTotal: bo.NewMoney(discountTotal.Decimal, currency.String),
+		Total:         bo.NewMoney(total.Decimal, currency.String),
+		CreatedAt:     createdAt.Time,
+		UpdatedAt:     updatedAt.Time,
+		Items:         []bo.CartItem{},
+	}
+
+	itemQuery := `SELECT ci.id, ci.cart_id, ci.product_id, ci.variant_id, ci.quantity, ci.unit_price, ci.discount_price,
+		COALESCE(a.available_quantity, 0)
+		FROM cart_items ci
+		LEFT JOIN ` + cartItemAvailability + ` a ON a.product_id = ci.product_id AND a.variant_id IS NOT DISTINCT FROM ci.variant_id
+		WHERE ci.cart_id = $1 ORDER BY ci.id ASC`
+	rows, err := q.Query(ctx, itemQuery, cartID)
+	if err != nil {
+		slog.Error("failed to list cart items", "cause", err)
+		return bo.Cart{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			itemID            sql.NullInt64
+			itemCartID        sql.NullInt64
+			productID         sql.NullInt64
+			variantID         sql.NullInt64
+			quantity          sql.NullInt64
+			unitPrice         decimal.NullDecimal
+			discountPrice     decimal.NullDecimal
+			availableQuantity sql.NullInt64
+		)
+		if err := rows.Scan(&itemID, &itemCartID, &productID, &variantID, &quantity, &unitPrice, &discountPrice, &availableQuantity); err != nil {
+			slog.Error("failed to scan cart item row", "cause", err)
+			return bo.Cart{}, err
+		}
+		cart.Items = append(cart.Items, bo.CartItem{
+			ID:                itemID.Int64,
+			CartID:            itemCartID.Int64,
+			ProductID:         productID.Int64,
+			VariantID:         variantID.Int64,
+			Quantity:          quantity.Int64,
+			UnitPrice:         bo.NewMoney(unitPrice.Decimal, cart.Currency),
+			DiscountPrice:     bo.NewMoney(discountPrice.Decimal, cart.Currency),
+			AvailableQuantity: availableQuantity.Int64,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return bo.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+func (s *cartStore) CreateCart(ctx context.Context, cart *bo.Cart) error {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	var (
+		id        sql.NullInt64
+		status    sql.NullString
+		createdAt sql.NullTime
+		updatedAt sql.NullTime
+	)
+	err = conn.QueryRow(ctx, `INSERT INTO carts(reference) VALUES ($1) RETURNING id, status, created_at, updated_at`,
+		sql.NullString{String: cart.Reference, Valid: cart.Reference != ""},
+	).Scan(&id, &status, &createdAt, &updatedAt)
+	if err != nil {
+		slog.Error("failed to insert cart", "cause", err)
+		return fmt.Errorf("failed to insert cart: %w", err)
+	}
+
+	cart.ID = id.Int64
+	cart.Status = bo.CartStatus(status.String)
+	cart.CreatedAt = createdAt.Time
+	cart.UpdatedAt = updatedAt.Time
+	cart.Items = []bo.CartItem{}
+	return nil
+}
+
+func (s *cartStore) DeleteCart(ctx context.Context, cartID int64) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		sqlQuery := `DELETE FROM carts WHERE id = $1`
+		if commandTag, err := tx.Exec(ctx, sqlQuery, cartID); err != nil {
+			slog.Error("failed to delete cart", slog.Int64("cartID", cartID), "cause", err)
+			return err
+		} else if commandTag.RowsAffected() == 0 {
+			return bo.ErrCartNotFound
+		}
+
+		return nil
+	})
+}
+
+// AddCartItem adds a product (variant) to the cart at its current price, adding
+// one which is already in the cart raises its quantity and refreshes the price.
+// The first item sets the currency of the cart, the next ones must be priced in it.
+func (s *cartStore) AddCartItem(ctx context.Context, item *bo.CartItem) (bo.Cart, error) {
+	var cart bo.Cart
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		currency, err := lockOpenCart(ctx, tx, item.CartID)
+		if err != nil {
+			return err
+		}
+		if err := snapshotCartItemPrice(ctx, tx, item); err != nil {
+			return err
+		}
+		if currency != "" && currency != item.UnitPrice.Currency {
+			return bo.ErrCartCurrencyMismatch
+		}
+		if currency == "" {
+			_, err := tx.Exec(ctx, `UPDATE carts SET currency = $1 WHERE id = $2`, item.UnitPrice.Currency, item.CartID)
+			if err != nil {
+				slog.Error("failed to set cart currency", slog.Int64("cartID", item.CartID), "cause", err)
+				return err
+			}
+		}
+
+		var id, quantity sql.NullInt64
+		sqlQuery := `INSERT INTO cart_items(cart_id, product_id, variant_id, quantity, unit_price, discount_price)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (cart_id, product_id, COALESCE(variant_id, 0)) DO UPDATE
+			SET quantity = cart_items.quantity + EXCLUDED.quantity, unit_price = EXCLUDED.unit_price,
+				discount_price = EXCLUDED.discount_price, updated_at = CURRENT_TIMESTAMP
+			RETURNING id, quantity`
+		err = tx.QueryRow(ctx, sqlQuery, item.CartID, item.ProductID,
+			sql.NullInt64{Int64: item.VariantID, Valid: item.VariantID != 0},
+			item.Quantity, item.UnitPrice.Amount, item.DiscountPrice.Amount,
+		).Scan(&id, &quantity)
+		if err != nil {
+			slog.Error("failed to insert cart item", "cause", err)
+			return fmt.Errorf("failed to insert cart item: %w", err)
+		}
+
+		item.ID = id.Int64
+		item.Quantity = quantity.Int64
+
+		cart, err = recalculateCart(ctx, tx, item.CartID, item.ID)
+		return err
+	})
+
+	return cart, err
+}
+
+func (s *cartStore) UpdateCartItem(ctx context.Context, update bo.CartItemUpdate) (bo.Cart, error) {
+	var cart bo.Cart
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		if _, err := lockOpenCart(ctx, tx, update.CartID); err != nil {
+			return err
+		}
+
+		commandTag, err := tx.Exec(ctx, `UPDATE cart_items SET quantity = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2 AND cart_id = $3`, update.Quantity, update.ID, update.CartID)
+		if err != nil {
+			slog.Error("failed to update cart item in database", "cause", err)
+			return fmt.Errorf("failed to update cart item in database: %w", err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			return bo.ErrCartItemNotFound
+		}
+
+		cart, err = recalculateCart(ctx, tx, update.CartID, update.ID)
+		return err
+	})
+
+	return cart, err
+}
+
+func (s *cartStore) RemoveCartItem(ctx context.Context, cartID, itemID int64) (bo.Cart, error) {
+	var cart bo.Cart
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		if _, err := lockOpenCart(ctx, tx, cartID); err != nil {
+			return err
+		}
+
+		commandTag, err := tx.Exec(ctx, `DELETE FROM cart_items WHERE id = $1 AND cart_id = $2`, itemID, cartID)
+		if err != nil {
+			slog.Error("failed to delete cart item", slog.Int64("itemID", itemID), "cause", err)
+			return err
+		}
+		if commandTag.RowsAffected() == 0 {
+			return bo.ErrCartItemNotFound
+		}
+
+		cart, err = recalculateCart(ctx, tx, cartID, 0)
+		return err
+	})
+
+	return cart, err
+}
+
+// lockOpenCart serializes changes to a cart and rejects carts which were checked out,
+// it returns the currency of the cart, empty while the cart has no item.
+func lockOpenCart(ctx context.Context, tx pgx.Tx, cartID int64) (string, error) {
+	var status, currency sql.NullString
+	if err := tx.QueryRow(ctx, `SELECT status, currency FROM carts WHERE id = $1 FOR UPDATE`, cartID).Scan(&status, &currency); err != nil {
+		if err == pgx.ErrNoRows {
+			return "", bo.ErrCartNotFound
+		}
+		slog.Error("failed to lock cart", "cause", err)
+		return "", err
+	}
+
+	if bo.CartStatus(status.String) != bo.CartOpen {
+		return "", bo.ErrCartNotOpen
+	}
+	return currency.String, nil
+}
+
+// snapshotCartItemPrice copies the current price of an active product (variant) to the item,
+// in the currency of the product.
+func snapshotCartItemPrice(ctx context.Context, tx pgx.Tx, item *bo.CartItem) error {
+	var unitPrice, discountPrice decimal.NullDecimal
+	var currency sql.NullString
+
+	if item.VariantID != 0 {
+		err := tx.QueryRow(ctx, `SELECT v.unit_price, v.discount_price, p.currency FROM product_variants v
+			INNER JOIN products p ON p.id = v.product_id
+			WHERE v.id = $1 AND v.product_id = $2 AND v.status_id = 1 AND p.status_id = 1`, item.VariantID, item.ProductID).Scan(&unitPrice, &discountPrice, &currency)
+		if err == pgx.ErrNoRows {
+			return bo.ErrProductVariantNotFound
+		}
+		if err != nil {
+			slog.Error("failed to scan product variant price", "cause", err)
+			return err
+		}
+	} else {
+		err := tx.QueryRow(ctx, `SELECT unit_price, discount_price, currency FROM products WHERE id = $1 AND status_id = 1`, item.ProductID).Scan(&unitPrice, &discountPrice, &currency)
+		if err == pgx.ErrNoRows {
+			return bo.ErrProductNotFound
+		}
+		if err != nil {
+			slog.Error("failed to scan product price", "cause", err)
+			return err
+		}
+	}
+
+	item.UnitPrice = bo.NewMoney(unitPrice.Decimal, currency.String)
+	item.DiscountPrice = bo.NewMoney(discountPrice.Decimal, currency.String)
+	return nil
+}
+
+// recalculateCart checks the changed item against the available stock and
+// stores the new cart totals, changedItemID is 0 when an item was removed.
+// An emptied cart drops its currency, the next item added sets it again.
+func recalculateCart(ctx context.Context, tx pgx.Tx, cartID, changedItemID int64) (bo.Cart, error) {
+	cart, err := getCart(ctx, tx, cartID)
+	if err != nil {
+		return bo.Cart{}, err
+	}
+	if len(cart.Items) == 0 {
+		cart.Currency = ""
+	}
+
+	subtotal, total := bo.NewMoney(decimal.Zero, cart.Currency), bo.NewMoney(decimal.Zero, cart.Currency)
+	for _, item := range cart.Items {
+		if item.ID == changedItemID && item.Quantity > item.AvailableQuantity {
+			return bo.Cart{}, bo.ErrInsufficientStock
+		}
+		subtotal.Amount = subtotal.Amount.Add(item.UnitPrice.Mul(item.Quantity).Amount)
+		total.Amount = total.Amount.Add(item.LineTotal().Amount)
+	}
+	subtotal, total = subtotal.Round(), total.Round()
+	discountTotal := bo.NewMoney(subtotal.Amount.Sub(total.Amount), cart.Currency)
+
+	var updatedAt sql.NullTime
+	err = tx.QueryRow(ctx, `UPDATE carts SET subtotal = $1, discount_total = $2, total = $3, currency = NULLIF($4, ''),
+		updated_at = CURRENT_TIMESTAMP WHERE id = $5 RETURNING updated_at`,
+		subtotal.Amount, discountTotal.Amount, total.Amount, cart.Currency, cartID).Scan(&updatedAt)
+	if err != nil {
+		slog.Error("failed to update cart totals", slog.Int64("cartID", cartID), "cause", err)
+		return bo.Cart{}, fmt.Errorf("failed to update cart totals: %w", err)
+	}
+
+	cart.Subtotal = subtotal
+	cart.DiscountTotal = discountTotal
+	cart.Total = total
+	cart.UpdatedAt = updatedAt.Time
+	return cart, nil
+}
@@ -6,12 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"strconv"
 	"strings"
 
 	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -25,17 +26,21 @@ var categoryFields = []string{
 	"parent_id",
 	"sequence",
 	"status_id",
+	"google_product_category",
+	"tax_class_id",
 	"created_at",
 }
 
 func (s *categoryStore) GetCategoryByID(ctx context.Context, categoryID int64) (bo.Category, error) {
 	var (
-		id        sql.NullInt64
-		name      sql.NullString
-		parentID  sql.NullInt64
-		sequence  sql.NullInt64
-		statusID  sql.NullInt64
-		createdAt sql.NullTime
+		id             sql.NullInt64
+		name           sql.NullString
+		parentID       sql.NullInt64
+		sequence       sql.NullInt64
+		statusID       sql.NullInt64
+		googleCategory sql.NullString
+		taxClassID     sql.NullInt64
+		createdAt      sql.NullTime
 	)
 
 	conn, err := s.dbPool.Acquire(ctx)
@@ -47,7 +52,7 @@ func (s *categoryStore) GetCategoryByID(ctx context.Context, categoryID int64) (
 	dbQuery := fmt.Sprintf("SELECT %s FROM categories WHERE id = $1", strings.Join(categoryFields, ","))
 	row := conn.QueryRow(ctx, dbQuery, categoryID)
 
-	if err = row.Scan(&id, &name, &parentID, &sequence, &statusID, &createdAt); err != nil {
+	if err = row.Scan(&id, &name, &parentID, &sequence, &statusID, &googleCategory, &taxClassID, &createdAt); err != nil {
 		if err == pgx.ErrNoRows {
 			slog.Error("category id does not exist", slog.Int64("id", categoryID))
 			return bo.Category{}, bo.ErrCategoryNotFound
@@ -57,12 +62,14 @@ func (s *categoryStore) GetCategoryByID(ctx context.Context, categoryID int64) (
 	}
 
 	return bo.Category{
-		ID:        id.Int64,
-		Name:      name.String,
-		ParentID:  parentID.Int64,
-		Sequence:  sequence.Int64,
-		StatusID:  statusID.Int64,
-		CreatedAt: createdAt.Time,
+		ID:                    id.Int64,
+		Name:                  name.String,
+		ParentID:              parentID.Int64,
+		Sequence:              sequence.Int64,
+		StatusID:              statusID.Int64,
+		GoogleProductCategory: googleCategory.String,
+		TaxClassID:            taxClassID.Int64,
+		CreatedAt:             createdAt.Time,
 	}, nil
 }
 
@@ -73,19 +80,7 @@ func (s *categoryStore) CreateCategory(ctx context.Context, category *bo.Categor
 		return fmt.Errorf("empty insert for category")
 	}
 
-	start := 1
-	arguments := make([]interface{}, 0, len(insertMap))
-	fields := []string{}
-	placeholders := []string{}
-
-	for field, v := range insertMap {
-		fields = append(fields, field)
-		arguments = append(arguments, v)
-		placeholders = append(placeholders, "$"+strconv.Itoa(start))
-		start++
-	}
-
-	sqlQuery := fmt.Sprintf("INSERT INTO categories(%s) VALUES (%s) RETURNING id", strings.Join(fields, ","), strings.Join(placeholders, ","))
+	sqlQuery, arguments := sqlbuilder.Insert("categories").Values(insertMap).Returning("id").Build()
 
 	conn, err := s.dbPool.Acquire(ctx)
 	if err != nil {
@@ -95,7 +90,7 @@ func (s *categoryStore) CreateCategory(ctx context.Context, category *bo.Categor
 
 	var id sql.NullInt64
 	if err := conn.QueryRow(ctx, sqlQuery, arguments...).Scan(&id); err != nil {
-		return err
+		return categoryMoveError(err)
 	}
 
 	category.ID = id.Int64
@@ -112,17 +107,52 @@ func buildCategoryInsertMap(i bo.Category) map[string]interface{} {
 				insertedFields[value] = strings.ToLower(i.Name)
 			}
 		case "parent_id":
-			insertedFields[value] = i.ParentID
+			insertedFields[value] = categoryParentID(i.ParentID)
 		case "sequence":
 			insertedFields[value] = i.Sequence
 		case "status_id":
 			insertedFields[value] = i.StatusID
+		case "google_product_category":
+			if i.GoogleProductCategory != "" {
+				insertedFields[value] = i.GoogleProductCategory
+			}
+		case "tax_class_id":
+			if i.TaxClassID != 0 {
+				insertedFields[value] = i.TaxClassID
+			}
 		}
 	}
 
 	return insertedFields
 }
 
+// categoryParentID is the parent_id of a category, NULL for a root category
+func categoryParentID(parentID int64) any {
+	if parentID == 0 {
+		return nil
+	}
+	return parentID
+}
+
+// categoryMoveError maps the errors of placing a category in the tree, a
+// missing parent or a cycle caught by the category_closure triggers, and
+// the error of a missing tax class
+func categoryMoveError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch {
+	case pgErr.Code == "23503" && pgErr.ConstraintName == "categories_parent_id_fkey":
+		return bo.ErrCategoryParentNotFound
+	case pgErr.Code == "23514" && pgErr.ConstraintName == "categories_acyclic":
+		return bo.ErrCategoryCycle
+	case pgErr.Code == "23503" && pgErr.ConstraintName == "categories_tax_class_id_fkey":
+		return bo.ErrTaxClassNotFound
+	}
+	return err
+}
+
 func (s *categoryStore) UpdateCategory(ctx context.Context, updateCategory bo.CategoryUpdate) error {
 	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
 		updateMap := buildCategoryUpdateMap(updateCategory)
@@ -131,24 +161,13 @@ func (s *categoryStore) UpdateCategory(ctx context.Context, updateCategory bo.Ca
 			return errors.New("empty update for category")
 		}
 
-		sqlQuery := "UPDATE categories SET "
-		start := 1
-		arguments := make([]interface{}, 0, len(updateMap)+1)
-
-		for k, v := range updateMap {
-			sqlQuery += fmt.Sprintf("%s = $%d", k, start)
-			arguments = append(arguments, v)
-			if start < len(updateMap) {
-				sqlQuery += ", "
-			}
-			start++
-		}
-
-		sqlQuery += fmt.Sprintf(" WHERE id = $%d", start)
-		arguments = append(arguments, updateCategory.ID)
+		sqlQuery, arguments := sqlbuilder.Update("categories").Set(updateMap).Where("id = ?", updateCategory.ID).Build()
 
 		commandTag, err := tx.Exec(ctx, sqlQuery, arguments...)
 		if err != nil {
+			if moveErr := categoryMoveError(err); moveErr != err {
+				return moveErr
+			}
 			slog.Error("failed to update category in database", "cause", err)
 			return fmt.Errorf("failed to update category in database: %w", err)
 		}
@@ -173,6 +192,20 @@ func buildCategoryUpdateMap(u bo.CategoryUpdate) map[string]interface{} {
 	if u.Sequence != nil {
 		updateFields["sequence"] = *u.Sequence
 	}
+	if u.ParentID != nil {
+		updateFields["parent_id"] = categoryParentID(*u.ParentID)
+	}
+	if u.GoogleProductCategory != nil {
+		// an empty mapping lets the category inherit the one of its parent again
+		var googleCategory any
+		if *u.GoogleProductCategory != "" {
+			googleCategory = *u.GoogleProductCategory
+		}
+		updateFields["google_product_category"] = googleCategory
+	}
+	if u.TaxClassID != nil {
+		updateFields["tax_class_id"] = taxClassID(*u.TaxClassID)
+	}
 
 	return updateFields
 }
@@ -197,45 +230,106 @@ func (s *categoryStore) ListCategories(ctx context.Context) (bo.PaginatedCategor
 	}
 	defer conn.Release()
 
-	dbQuery := fmt.Sprintf("SELECT %s FROM categories ORDER BY sequence ASC", strings.Join(categoryFields, ","))
-	rows, err := conn.Query(ctx, dbQuery)
+	dbQuery, args := sqlbuilder.Select(categoryFields...).From("categories").OrderBy("sequence ASC").Build()
+	categories, err := queryCategories(ctx, conn, dbQuery, args...)
 	if err != nil {
 		slog.Error("failed to list categories", "cause", err)
 		return pagingCollection, err
 	}
+
+	pagingCollection.Data = categories
+
+	return pagingCollection, nil
+}
+
+// ListCategoryAncestors returns the breadcrumb of a category, from its root down to the category itself
+func (s *categoryStore) ListCategoryAncestors(ctx context.Context, categoryID int64) (bo.CategoryCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	dbQuery, args := categoryTreeQuery("cc.ancestor_id", "cc.descendant_id = ?", categoryID).OrderBy("cc.depth DESC").Build()
+	categories, err := queryCategories(ctx, conn, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to list category ancestors", slog.Int64("categoryID", categoryID), "cause", err)
+		return nil, err
+	}
+	if len(categories) == 0 {
+		return nil, bo.ErrCategoryNotFound
+	}
+
+	return categories, nil
+}
+
+// ListCategorySubtree returns a category and all of its descendants, level by level
+func (s *categoryStore) ListCategorySubtree(ctx context.Context, categoryID int64) (bo.CategoryCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	dbQuery, args := categoryTreeQuery("cc.descendant_id", "cc.ancestor_id = ?", categoryID).OrderBy("cc.depth ASC", "c.sequence ASC").Build()
+	categories, err := queryCategories(ctx, conn, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to list category subtree", slog.Int64("categoryID", categoryID), "cause", err)
+		return nil, err
+	}
+	if len(categories) == 0 {
+		return nil, bo.ErrCategoryNotFound
+	}
+
+	return categories, nil
+}
+
+// categoryTreeQuery selects the categories linked to another one in category_closure
+func categoryTreeQuery(linked, where string, categoryID int64) *sqlbuilder.SelectBuilder {
+	columns := make([]string, len(categoryFields))
+	for i, field := range categoryFields {
+		columns[i] = "c." + field
+	}
+	return sqlbuilder.Select(columns...).
+		From("category_closure cc").
+		Join("INNER JOIN categories c ON c.id = "+linked).
+		Where(where, categoryID)
+}
+
+func queryCategories(ctx context.Context, conn *pgxpool.Conn, dbQuery string, args ...any) (bo.CategoryCollection, error) {
+	rows, err := conn.Query(ctx, dbQuery, args...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	var categories bo.CategoryCollection
 	for rows.Next() {
 		var (
-			id        sql.NullInt64
-			name      sql.NullString
-			parentID  sql.NullInt64
-			sequence  sql.NullInt64
-			statusID  sql.NullInt64
-			createdAt sql.NullTime
+			id             sql.NullInt64
+			name           sql.NullString
+			parentID       sql.NullInt64
+			sequence       sql.NullInt64
+			statusID       sql.NullInt64
+			googleCategory sql.NullString
+			taxClassID     sql.NullInt64
+			createdAt      sql.NullTime
 		)
-		if err := rows.Scan(&id, &name, &parentID, &sequence, &statusID, &createdAt); err != nil {
-			slog.Error("failed to scan category row", "cause", err)
-			return pagingCollection, err
+		if err := rows.Scan(&id, &name, &parentID, &sequence, &statusID, &googleCategory, &taxClassID, &createdAt); err != nil {
+			return nil, err
 		}
 
 		categories = append(categories, bo.Category{
-			ID:        id.Int64,
-			Name:      name.String,
-			ParentID:  parentID.Int64,
-			Sequence:  sequence.Int64,
-			StatusID:  statusID.Int64,
-			CreatedAt: createdAt.Time,
+			ID:                    id.Int64,
+			Name:                  name.String,
+			ParentID:              parentID.Int64,
+			Sequence:              sequence.Int64,
+			StatusID:              statusID.Int64,
+			GoogleProductCategory: googleCategory.String,
+			TaxClassID:            taxClassID.Int64,
+			CreatedAt:             createdAt.Time,
 		})
 	}
 
-	if err = rows.Err(); err != nil {
-		slog.Error("failed during rows iteration", "cause", err)
-		return pagingCollection, err
-	}
-
-	pagingCollection.Data = categories
-
-	return pagingCollection, nil
+	return categories, rows.Err()
 }
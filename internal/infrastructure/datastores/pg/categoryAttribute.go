@@ -0,0 +1,158 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var categoryAttributeFields = []string{
+	"a.id",
+	"a.category_id",
+	"a.code",
+	"a.name",
+	"a.type",
+	"a.unit",
+	"a.options",
+	"a.required",
+	"a.filterable",
+	"a.sequence",
+	"a.created_at",
+}
+
+// ListCategoryAttributes returns the attribute template of a category, the
+// attributes inherited from its root first
+func (s *categoryStore) ListCategoryAttributes(ctx context.Context, categoryID int64) (bo.CategoryAttributeCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	dbQuery, args := sqlbuilder.Select(categoryAttributeFields...).
+		From("category_attributes a").
+		Join("INNER JOIN category_closure cc ON cc.ancestor_id = a.category_id").
+		Where("cc.descendant_id = ?", categoryID).
+		OrderBy("cc.depth DESC", "a.sequence ASC", "a.id ASC").
+		Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to list category attributes", slog.Int64("categoryID", categoryID), "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	attributes := bo.CategoryAttributeCollection{}
+	for rows.Next() {
+		var (
+			attribute     bo.CategoryAttribute
+			attributeType sql.NullString
+			unit          sql.NullString
+		)
+		if err := rows.Scan(&attribute.ID, &attribute.CategoryID, &attribute.Code, &attribute.Name, &attributeType, &unit,
+			&attribute.Options, &attribute.Required, &attribute.Filterable, &attribute.Sequence, &attribute.CreatedAt); err != nil {
+			slog.Error("failed to scan category attribute row", "cause", err)
+			return nil, err
+		}
+		attribute.Type = bo.AttributeType(attributeType.String)
+		attribute.Unit = unit.String
+		attributes = append(attributes, attribute)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return attributes, nil
+}
+
+// CreateCategoryAttribute adds an attribute to a category unless its code is
+// already defined along a path of the tree through the category
+func (s *categoryStore) CreateCategoryAttribute(ctx context.Context, attribute *bo.CategoryAttribute) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		// attributes are added one at a time, so a parent and a subcategory cannot take a code together
+		if _, err := tx.Exec(ctx, `LOCK TABLE category_attributes IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			slog.Error("failed to lock category attributes", "cause", err)
+			return err
+		}
+
+		var taken bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (
+				SELECT 1 FROM category_attributes a INNER JOIN category_closure cc
+					ON (cc.ancestor_id = a.category_id AND cc.descendant_id = $1) OR (cc.descendant_id = a.category_id AND cc.ancestor_id = $1)
+				WHERE a.code = $2
+			)`, attribute.CategoryID, attribute.Code).Scan(&taken); err != nil {
+			slog.Error("failed to look up the category attribute code", "cause", err)
+			return err
+		}
+		if taken {
+			return bo.ErrCategoryAttributeExists
+		}
+
+		var unit any
+		if attribute.Unit != "" {
+			unit = attribute.Unit
+		}
+		options := attribute.Options
+		if options == nil {
+			options = []string{}
+		}
+		sqlQuery, arguments := sqlbuilder.Insert("category_attributes").
+			Values(map[string]any{
+				"category_id": attribute.CategoryID,
+				"code":        attribute.Code,
+				"name":        attribute.Name,
+				"type":        string(attribute.Type),
+				"unit":        unit,
+				"options":     options,
+				"required":    attribute.Required,
+				"filterable":  attribute.Filterable,
+				"sequence":    attribute.Sequence,
+			}).
+			Returning("id", "created_at").
+			Build()
+
+		if err := tx.QueryRow(ctx, sqlQuery, arguments...).Scan(&attribute.ID, &attribute.CreatedAt); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				return bo.ErrCategoryNotFound
+			}
+			slog.Error("failed to insert category attribute", "cause", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// DeleteCategoryAttribute removes an attribute along with its values from the
+// products of the category and of its subcategories
+func (s *categoryStore) DeleteCategoryAttribute(ctx context.Context, categoryID, attributeID int64) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		var code string
+		err := tx.QueryRow(ctx, `DELETE FROM category_attributes WHERE id = $1 AND category_id = $2 RETURNING code`, attributeID, categoryID).Scan(&code)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrCategoryAttributeNotFound
+			}
+			slog.Error("failed to delete category attribute", slog.Int64("attributeID", attributeID), "cause", err)
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE products SET attributes = attributes - $1::text
+			WHERE category_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = $2) AND attributes ? $1::text`, code, categoryID); err != nil {
+			slog.Error("failed to remove the attribute values of products", slog.String("code", code), "cause", err)
+			return err
+		}
+
+		return nil
+	})
+}
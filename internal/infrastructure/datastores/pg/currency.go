@@ -0,0 +1,210 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+// currencyStore reads and writes the numeric columns as text so that the amounts stay exact
+type currencyStore struct {
+	dbPool *pgxpool.Pool
+}
+
+func (s *currencyStore) ListExchangeRates(ctx context.Context) (bo.ExchangeRateCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `SELECT base_currency, quote_currency, rate::text, updated_at
+		FROM exchange_rates ORDER BY base_currency, quote_currency`)
+	if err != nil {
+		slog.Error("failed to list exchange rates", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := bo.ExchangeRateCollection{}
+	for rows.Next() {
+		var (
+			rate     bo.ExchangeRate
+			rateText string
+		)
+		if err := rows.Scan(&rate.Base, &rate.Quote, &rateText, &rate.UpdatedAt); err != nil {
+			slog.Error("failed to scan exchange rate row", "cause", err)
+			return nil, err
+		}
+		if rate.Rate, err = decimal.NewFromString(rateText); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (s *currencyStore) UpsertExchangeRates(ctx context.Context, rates bo.ExchangeRateCollection) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		for i := range rates {
+			err := tx.QueryRow(ctx, `INSERT INTO exchange_rates (base_currency, quote_currency, rate)
+				VALUES ($1, $2, $3::numeric)
+				ON CONFLICT (base_currency, quote_currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = CURRENT_TIMESTAMP
+				RETURNING updated_at`, rates[i].Base, rates[i].Quote, rates[i].Rate.String()).Scan(&rates[i].UpdatedAt)
+			if err != nil {
+				slog.Error("failed to upsert exchange rate", "base", rates[i].Base, "quote", rates[i].Quote, "cause", err)
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *currencyStore) DeleteExchangeRate(ctx context.Context, base, quote string) error {
+	commandTag, err := s.dbPool.Exec(ctx, `DELETE FROM exchange_rates WHERE base_currency = $1 AND quote_currency = $2`, base, quote)
+	if err != nil {
+		slog.Error("failed to delete exchange rate", "base", base, "quote", quote, "cause", err)
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return bo.ErrExchangeRateNotFound
+	}
+	return nil
+}
+
+func scanProductCurrencyPrice(row pgx.Row) (bo.ProductCurrencyPrice, error) {
+	var (
+		price                    bo.ProductCurrencyPrice
+		unitPrice, discountPrice string
+	)
+	if err := row.Scan(&price.ProductID, &price.Currency, &unitPrice, &discountPrice, &price.UpdatedAt); err != nil {
+		return bo.ProductCurrencyPrice{}, err
+	}
+	var err error
+	if price.UnitPrice, err = decimal.NewFromString(unitPrice); err != nil {
+		return bo.ProductCurrencyPrice{}, err
+	}
+	if price.DiscountPrice, err = decimal.NewFromString(discountPrice); err != nil {
+		return bo.ProductCurrencyPrice{}, err
+	}
+	return price, nil
+}
+
+func (s *currencyStore) ListProductCurrencyPrices(ctx context.Context, productID int64) (bo.ProductCurrencyPriceCollection, error) {
+	prices := bo.ProductCurrencyPriceCollection{}
+
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, productID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return bo.ErrProductNotFound
+		}
+
+		rows, err := tx.Query(ctx, `SELECT product_id, currency, unit_price::text, discount_price::text, updated_at
+			FROM product_currency_prices WHERE product_id = $1 ORDER BY currency`, productID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			price, err := scanProductCurrencyPrice(rows)
+			if err != nil {
+				slog.Error("failed to scan product currency price row", "cause", err)
+				return err
+			}
+			prices = append(prices, price)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		if err != bo.ErrProductNotFound {
+			slog.Error("failed to list product currency prices", slog.Int64("productID", productID), "cause", err)
+		}
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+func (s *currencyStore) FindProductCurrencyPrices(ctx context.Context, productIDs []int64, currency string) (map[int64]bo.ProductCurrencyPrice, error) {
+	prices := map[int64]bo.ProductCurrencyPrice{}
+	if len(productIDs) == 0 {
+		return prices, nil
+	}
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `SELECT product_id, currency, unit_price::text, discount_price::text, updated_at
+		FROM product_currency_prices WHERE product_id = ANY($1) AND currency = $2`, productIDs, currency)
+	if err != nil {
+		slog.Error("failed to find product currency prices", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		price, err := scanProductCurrencyPrice(rows)
+		if err != nil {
+			slog.Error("failed to scan product currency price row", "cause", err)
+			return nil, err
+		}
+		prices[price.ProductID] = price
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+func (s *currencyStore) SetProductCurrencyPrice(ctx context.Context, price *bo.ProductCurrencyPrice) error {
+	err := s.dbPool.QueryRow(ctx, `INSERT INTO product_currency_prices (product_id, currency, unit_price, discount_price)
+		VALUES ($1, $2, $3::numeric, $4::numeric)
+		ON CONFLICT (product_id, currency) DO UPDATE
+			SET unit_price = EXCLUDED.unit_price, discount_price = EXCLUDED.discount_price, updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`, price.ProductID, price.Currency, price.UnitPrice.String(), price.DiscountPrice.String()).
+		Scan(&price.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return bo.ErrProductNotFound
+		}
+		slog.Error("failed to set product currency price", slog.Int64("productID", price.ProductID), "cause", err)
+		return err
+	}
+	return nil
+}
+
+func (s *currencyStore) DeleteProductCurrencyPrice(ctx context.Context, productID int64, currency string) error {
+	commandTag, err := s.dbPool.Exec(ctx, `DELETE FROM product_currency_prices WHERE product_id = $1 AND currency = $2`, productID, currency)
+	if err != nil {
+		slog.Error("failed to delete product currency price", slog.Int64("productID", productID), "cause", err)
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return bo.ErrProductCurrencyPriceNotFound
+	}
+	return nil
+}
@@ -0,0 +1,537 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type orderStore struct {
+	dbPool *pgxpool.Pool
+}
+
+var orderFields = []string{
+	"id", "cart_id", "reference", "status", "currency", "subtotal", "discount_total", "total",
+	"tax_total", "tax_pricing", "tax_jurisdiction", "created_at", "updated_at",
+}
+
+// orderAllocation is the quantity of an order item taken from one stock row
+type orderAllocation struct {
+	productStockID int64
+	quantity       int64
+}
+
+func (s *orderStore) GetOrderByID(ctx context.Context, orderID int64) (bo.Order, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return bo.Order{}, err
+	}
+	defer conn.Release()
+
+	return getOrder(ctx, conn, orderID)
+}
+
+// getOrder reads an order with its items through a pool connection or a transaction.
+func getOrder(ctx context.Context, q querier, orderID int64) (bo.Order, error) {
+	dbQuery, args := sqlbuilder.Select(orderFields...).From("orders").Where("id = ?", orderID).Build()
+	order, err := scanOrder(q.QueryRow(ctx, dbQuery, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			slog.Error("order id does not exist", slog.Int64("id", orderID))
+			return bo.Order{}, bo.ErrOrderNotFound
+		}
+		slog.Error("failed to scan order table row", "cause", err)
+		return bo.Order{}, err
+	}
+
+	rows, err := q.Query(ctx, `SELECT id, order_id, product_id, variant_id, quantity, unit_price, discount_price
+		FROM order_items WHERE order_id = $1 ORDER BY id ASC`, orderID)
+	if err != nil {
+		slog.Error("failed to list order items", "cause", err)
+		return bo.Order{}, err
+	}
+	defer rows.Close()
+
+	order.Items = []bo.OrderItem{}
+	for rows.Next() {
+		var (
+			id            sql.NullInt64
+			itemOrderID   sql.NullInt64
+			productID     sql.NullInt64
+			variantID     sql.NullInt64
+			quantity      sql.NullInt64
+			unitPrice     decimal.NullDecimal
+			discountPrice decimal.NullDecimal
+		)
+		if err := rows.Scan(&id, &itemOrderID, &productID, &variantID, &quantity, &unitPrice, &discountPrice); err != nil {
+			slog.Error("failed to scan order item row", "cause", err)
+			return bo.Order{}, err
+		}
+		order.Items = append(order.Items, bo.OrderItem{
+			ID:            id.Int64,
+			OrderID:       itemOrderID.Int64,
+			ProductID:     productID.Int64,
+			VariantID:     variantID.Int64,
+			Quantity:      quantity.Int64,
+			UnitPrice:     bo.NewMoney(unitPrice.Decimal, order.Currency),
+			DiscountPrice: bo.NewMoney(discountPrice.Decimal, order.Currency),
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return bo.Order{}, err
+	}
+
+	if order.Taxes, err = getOrderTaxes(ctx, q, orderID); err != nil {
+		return bo.Order{}, err
+	}
+
+	return order, nil
+}
+
+// getOrderTaxes reads what every rate levied over an order, the rate id is 0 once the rate is deleted
+func getOrderTaxes(ctx context.Context, q querier, orderID int64) ([]bo.TaxTotal, error) {
+	rows, err := q.Query(ctx, `SELECT tax_rate_id, name, jurisdiction, rate::text, taxable, tax
+		FROM order_taxes WHERE order_id = $1 ORDER BY id ASC`, orderID)
+	if err != nil {
+		slog.Error("failed to list order taxes", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	taxes := []bo.TaxTotal{}
+	for rows.Next() {
+		var (
+			rateID       sql.NullInt64
+			name         sql.NullString
+			jurisdiction sql.NullString
+			rate         sql.NullString
+			taxable      decimal.NullDecimal
+			tax          decimal.NullDecimal
+		)
+		if err := rows.Scan(&rateID, &name, &jurisdiction, &rate, &taxable, &tax); err != nil {
+			slog.Error("failed to scan order tax row", "cause", err)
+			return nil, err
+		}
+		value, err := decimal.NewFromString(rate.String)
+		if err != nil {
+			return nil, err
+		}
+		taxes = append(taxes, bo.TaxTotal{
+			TaxRateID:    rateID.Int64,
+			Name:         name.String,
+			Jurisdiction: jurisdiction.String,
+			Rate:         value,
+			Taxable:      taxable.Decimal,
+			Tax:          tax.Decimal,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return taxes, nil
+}
+
+func scanOrder(row pgx.Row) (bo.Order, error) {
+	var (
+		id            sql.NullInt64
+		cartID        sql.NullInt64
+		reference     sql.NullString
+		status        sql.NullString
+		currency      sql.NullString
+		subtotal      decimal.NullDecimal
+		discountTotal decimal.NullDecimal
+		total         decimal.NullDecimal
+		taxTotal      decimal.NullDecimal
+		taxPricing    sql.NullString
+		jurisdiction  sql.NullString
+		createdAt     sql.NullTime
+		updatedAt     sql.NullTime
+	)
+	if err := row.Scan(&id, &cartID, &reference, &status, &currency, &subtotal, &discountTotal, &total,
+		&taxTotal, &taxPricing, &jurisdiction, &createdAt, &updatedAt); err != nil {
+		return bo.Order{}, err
+	}
+
+	return bo.Order{
+		ID:              id.Int64,
+		CartID:          cartID.Int64,
+		Reference:       reference.String,
+		Status:          bo.OrderStatus(status.String),
+		Currency:        currency.String,
+		Subtotal:        bo.NewMoney(subtotal.Decimal, currency.String),
+		DiscountTotal:   bo.NewMoney(discountTotal.Decimal, currency.String),
+		Total:           bo.NewMoney(total.Decimal, currency.String),
+		TaxTotal:        bo.NewMoney(taxTotal.Decimal, currency.String),
+		TaxPricing:      bo.TaxPricing(taxPricing.String),
+		TaxJurisdiction: jurisdiction.String,
+		CreatedAt:       createdAt.Time,
+		UpdatedAt:       updatedAt.Time,
+	}, nil
+}
+
+// PlaceOrder turns an open cart into a pending order. The ordered quantity is
+// taken off hand in the same transaction, so the order is only placed when
+// every item could be allocated. A taxed quote is taxed again on the charged
+// prices, the tax is added to the total with exclusive pricing and kept per line.
+func (s *orderStore) PlaceOrder(ctx context.Context, placement bo.OrderPlacement) (bo.Order, error) {
+	var order bo.Order
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		if _, err := lockOpenCart(ctx, tx, placement.CartID); err != nil {
+			return err
+		}
+
+		cart, err := getCart(ctx, tx, placement.CartID)
+		if err != nil {
+			return err
+		}
+		if len(cart.Items) == 0 {
+			return bo.ErrCartEmpty
+		}
+
+		items, promotions := applyOrderQuote(cart.Items, placement.Quote)
+
+		subtotal, total := bo.NewMoney(decimal.Zero, cart.Currency), bo.NewMoney(decimal.Zero, cart.Currency)
+		for _, item := range items {
+			subtotal.Amount = subtotal.Amount.Add(item.UnitPrice.Mul(item.Quantity).Amount)
+			total.Amount = total.Amount.Add(item.LineTotal().Amount)
+		}
+		subtotal, total = subtotal.Round(), total.Round()
+		discountTotal := subtotal.Amount.Sub(total.Amount)
+
+		var tax *bo.TaxBreakdown
+		if placement.Quote.Tax != nil {
+			breakdown := placement.Quote.Tax.Recalculate(orderTaxableLines(items))
+			tax = &breakdown
+			if breakdown.Pricing == bo.TaxExclusive {
+				total.Amount = total.Amount.Add(breakdown.Tax)
+				total = total.Round()
+			}
+		}
+
+		var (
+			orderID      sql.NullInt64
+			taxTotal     decimal.Decimal
+			taxPricing   sql.NullString
+			jurisdiction sql.NullString
+		)
+		if tax != nil {
+			taxTotal = tax.Tax
+			taxPricing = sql.NullString{String: string(tax.Pricing), Valid: true}
+			jurisdiction = sql.NullString{String: tax.Jurisdiction, Valid: true}
+		}
+		err = tx.QueryRow(ctx, `INSERT INTO orders(cart_id, reference, status, currency, subtotal, discount_total, total, tax_total, tax_pricing, tax_jurisdiction)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
+			placement.CartID, sql.NullString{String: placement.Reference, Valid: placement.Reference != ""},
+			string(bo.OrderPending), cart.Currency, subtotal.Amount, discountTotal, total.Amount, taxTotal, taxPricing, jurisdiction,
+		).Scan(&orderID)
+		if err != nil {
+			slog.Error("failed to insert order", "cause", err)
+			return fmt.Errorf("failed to insert order: %w", err)
+		}
+
+		lineTaxes := map[int64]decimal.Decimal{}
+		if tax != nil {
+			for _, line := range tax.Lines {
+				lineTaxes[line.CartItemID] = line.Tax
+			}
+		}
+
+		reference := fmt.Sprintf("order:%d", orderID.Int64)
+		for _, item := range items {
+			var itemID sql.NullInt64
+			err := tx.QueryRow(ctx, `INSERT INTO order_items(order_id, product_id, variant_id, quantity, unit_price, discount_price, tax)
+				VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+				orderID.Int64, item.ProductID, sql.NullInt64{Int64: item.VariantID, Valid: item.VariantID != 0},
+				item.Quantity, item.UnitPrice.Amount, item.DiscountPrice.Amount, lineTaxes[item.ID],
+			).Scan(&itemID)
+			if err != nil {
+				slog.Error("failed to insert order item", "cause", err)
+				return fmt.Errorf("failed to insert order item: %w", err)
+			}
+
+			if err := allocateOrderItem(ctx, tx, itemID.Int64, item, reference, placement.ChangedBy); err != nil {
+				return err
+			}
+		}
+
+		if tax != nil {
+			if err := insertOrderTaxes(ctx, tx, orderID.Int64, tax.Totals); err != nil {
+				return err
+			}
+		}
+
+		if err := redeemPromotions(ctx, tx, orderID.Int64, promotions); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE carts SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`,
+			string(bo.CartCheckedOut), placement.CartID); err != nil {
+			slog.Error("failed to check out cart", slog.Int64("cartID", placement.CartID), "cause", err)
+			return fmt.Errorf("failed to check out cart: %w", err)
+		}
+
+		order, err = getOrder(ctx, tx, orderID.Int64)
+		return err
+	})
+
+	return order, err
+}
+
+// applyOrderQuote charges the promotional price of every cart item which is
+// still as it was quoted and returns the promotions which were honoured.
+func applyOrderQuote(items []bo.CartItem, quote bo.PriceQuote) ([]bo.CartItem, []bo.AppliedPromotion) {
+	lines := map[int64]bo.PriceQuoteLine{}
+	for _, line := range quote.Lines {
+		lines[line.CartItemID] = line
+	}
+
+	honoured := map[int64]bool{}
+	priced := make([]bo.CartItem, 0, len(items))
+	for _, item := range items {
+		line, ok := lines[item.ID]
+		if ok && line.PromotionID != 0 && line.Quantity == item.Quantity && line.UnitPrice.Equal(item.UnitPrice.Amount) && line.Price.LessThan(item.Price().Amount) {
+			item.DiscountPrice = bo.NewMoney(line.Price, item.UnitPrice.Currency)
+			honoured[line.PromotionID] = true
+		}
+		priced = append(priced, item)
+	}
+
+	var promotions []bo.AppliedPromotion
+	for _, promotion := range quote.Promotions {
+		if honoured[promotion.PromotionID] {
+			promotions = append(promotions, promotion)
+		}
+	}
+	return priced, promotions
+}
+
+// orderTaxableLines are the items of an order at the price they are charged
+func orderTaxableLines(items []bo.CartItem) []bo.TaxableLine {
+	lines := make([]bo.TaxableLine, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, bo.TaxableLine{
+			CartItemID: item.ID,
+			ProductID:  item.ProductID,
+			Amount:     item.LineTotal().Round().Amount,
+		})
+	}
+	return lines
+}
+
+// insertOrderTaxes copies what every rate levied, so that editing a rate keeps the placed orders
+func insertOrderTaxes(ctx context.Context, tx pgx.Tx, orderID int64, totals []bo.TaxTotal) error {
+	for _, total := range totals {
+		if _, err := tx.Exec(ctx, `INSERT INTO order_taxes(order_id, tax_rate_id, name, jurisdiction, rate, taxable, tax)
+			VALUES ($1, $2, $3, $4, $5::numeric, $6, $7)`,
+			orderID, sql.NullInt64{Int64: total.TaxRateID, Valid: total.TaxRateID != 0},
+			total.Name, total.Jurisdiction, total.Rate.String(), total.Taxable, total.Tax); err != nil {
+			slog.Error("failed to insert order tax", "cause", err)
+			return fmt.Errorf("failed to insert order tax: %w", err)
+		}
+	}
+	return nil
+}
+
+// allocateOrderItem takes the item quantity off the stock rows with the most
+// available stock first and records where it was taken from.
+func allocateOrderItem(ctx context.Context, tx pgx.Tx, orderItemID int64, item bo.CartItem, reference, changedBy string) error {
+	stockIDs, err := lockProductStockRows(ctx, tx, item.ProductID, item.VariantID, 0)
+	if err == bo.ErrProductStockNotFound {
+		return bo.ErrInsufficientStock
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT ps.id, COALESCE(ps.stock_quantity, 0) - COALESCE(rs.reserved_quantity, 0)
+		FROM product_stocks ps LEFT JOIN `+reservedQuantityAggregate+` rs ON rs.product_stock_id = ps.id
+		WHERE ps.id = ANY($1) ORDER BY 2 DESC, ps.id ASC`, stockIDs)
+	if err != nil {
+		slog.Error("failed to list available product stock", "cause", err)
+		return err
+	}
+
+	var allocations []orderAllocation
+	remaining := item.Quantity
+	for remaining > 0 && rows.Next() {
+		var id, available sql.NullInt64
+		if err := rows.Scan(&id, &available); err != nil {
+			rows.Close()
+			slog.Error("failed to scan available product stock row", "cause", err)
+			return err
+		}
+		if available.Int64 <= 0 {
+			continue
+		}
+		quantity := min(remaining, available.Int64)
+		allocations = append(allocations, orderAllocation{productStockID: id.Int64, quantity: quantity})
+		remaining -= quantity
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return err
+	}
+	if remaining > 0 {
+		return bo.ErrInsufficientStock
+	}
+
+	for _, allocation := range allocations {
+		if _, err := adjustStockInTx(ctx, tx, bo.ProductStockAdjustment{
+			ProductStockID: allocation.productStockID,
+			Delta:          -allocation.quantity,
+			Reason:         bo.StockMovementSale,
+			Reference:      reference,
+			ChangedBy:      changedBy,
+		}); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, `INSERT INTO order_item_allocations(order_item_id, product_stock_id, quantity) VALUES ($1, $2, $3)`,
+			orderItemID, allocation.productStockID, allocation.quantity); err != nil {
+			slog.Error("failed to insert order item allocation", "cause", err)
+			return fmt.Errorf("failed to insert order item allocation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateOrderStatus moves an order to its next status when it is still in
+// change.From, restocking the allocated quantity in the same transaction
+// when requested. The transition rules are enforced by the order service.
+func (s *orderStore) UpdateOrderStatus(ctx context.Context, change bo.OrderStatusChange) (bo.Order, error) {
+	var order bo.Order
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		commandTag, err := tx.Exec(ctx, `UPDATE orders SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 AND status = $3`,
+			string(change.To), change.OrderID, string(change.From))
+		if err != nil {
+			slog.Error("failed to update order status", slog.Int64("orderID", change.OrderID), "cause", err)
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			if _, err := getOrder(ctx, tx, change.OrderID); err != nil {
+				return err
+			}
+			return bo.ErrOrderStatusConflict
+		}
+
+		if change.Restock {
+			if err := restockOrder(ctx, tx, change); err != nil {
+				return err
+			}
+		}
+
+		order, err = getOrder(ctx, tx, change.OrderID)
+		return err
+	})
+
+	return order, err
+}
+
+// restockOrder puts every allocation of an order back on the stock row it was
+// taken from, allocations of deleted stock rows are skipped.
+func restockOrder(ctx context.Context, tx pgx.Tx, change bo.OrderStatusChange) error {
+	rows, err := tx.Query(ctx, `SELECT a.product_stock_id, a.quantity FROM order_item_allocations a
+		INNER JOIN order_items i ON i.id = a.order_item_id
+		WHERE i.order_id = $1 AND a.product_stock_id IS NOT NULL
+		ORDER BY a.product_stock_id ASC`, change.OrderID)
+	if err != nil {
+		slog.Error("failed to list order item allocations", "cause", err)
+		return err
+	}
+
+	var allocations []orderAllocation
+	for rows.Next() {
+		var productStockID, quantity sql.NullInt64
+		if err := rows.Scan(&productStockID, &quantity); err != nil {
+			rows.Close()
+			slog.Error("failed to scan order item allocation row", "cause", err)
+			return err
+		}
+		allocations = append(allocations, orderAllocation{productStockID: productStockID.Int64, quantity: quantity.Int64})
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return err
+	}
+
+	reference := fmt.Sprintf("order:%d:%s", change.OrderID, change.To)
+	for _, allocation := range allocations {
+		if _, err := adjustStockInTx(ctx, tx, bo.ProductStockAdjustment{
+			ProductStockID: allocation.productStockID,
+			Delta:          allocation.quantity,
+			Reason:         bo.StockMovementReturn,
+			Reference:      reference,
+			ChangedBy:      change.ChangedBy,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *orderStore) ListOrders(ctx context.Context, orderQuery bo.OrderQuery) (bo.PaginatedOrderCollection, error) {
+	pagingCollection := bo.PaginatedOrderCollection{}
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return pagingCollection, err
+	}
+	defer conn.Release()
+
+	query := sqlbuilder.Select(orderFields...).From("orders")
+	if orderQuery.Status != "" {
+		query.Where("status = ?", string(orderQuery.Status))
+	}
+	query.OrderBy("created_at DESC", "id DESC").Page(orderQuery.Limit, orderQuery.Offset)
+
+	dbQuery, args := query.Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to list orders", "cause", err)
+		return pagingCollection, err
+	}
+	defer rows.Close()
+
+	var orders bo.OrderCollection
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			slog.Error("failed to scan order row", "cause", err)
+			return pagingCollection, err
+		}
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return pagingCollection, err
+	}
+
+	pagingCollection.Data = orders
+	var totalRecord sql.NullInt64
+	countQuery, countArgs := query.BuildCount()
+	if err = conn.QueryRow(ctx, countQuery, countArgs...).Scan(&totalRecord); err != nil {
+		slog.Error("error scanning COUNT orders row", "cause", err)
+		return pagingCollection, err
+	}
+
+	pagingCollection.Total = totalRecord.Int64
+	return pagingCollection, nil
+}
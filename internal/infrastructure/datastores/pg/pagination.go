@@ -0,0 +1,80 @@
+package pg
+
+import (
+	"slices"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
+)
+
+// pageCursor is the cursor a keyset page starts from, and whether it goes
+// backward from it. A cursor taken from another order is ErrInvalidCursor.
+func pageCursor(cursors bo.PageCursors, sort string) (*bo.PageCursor, bool, error) {
+	cursor, backward := cursors.After, false
+	if cursor == nil && cursors.Before != nil {
+		cursor, backward = cursors.Before, true
+	}
+	if cursor != nil && cursor.Sort != sort {
+		return nil, false, bo.ErrInvalidCursor
+	}
+	return cursor, backward, nil
+}
+
+// keysetQuery orders the query by its sort keys, the last one being the unique
+// id, and pages it from the cursor. One row more than the limit is fetched to
+// know whether there is another page, the offset only applies without a cursor.
+// Count the query before paging it, the cursor is not part of the total.
+func keysetQuery(query *sqlbuilder.SelectBuilder, keys []string, desc bool, limit, offset int, cursor *bo.PageCursor, backward bool) *sqlbuilder.SelectBuilder {
+	if cursor == nil {
+		return query.Keyset(keys, desc, false).Page(limit+1, offset)
+	}
+
+	values := []any{cursor.ID}
+	if len(keys) > 1 {
+		values = []any{cursor.Key, cursor.ID}
+	}
+	return query.Keyset(keys, desc, backward, values...).Page(limit+1, 0)
+}
+
+// cursorKeyIs tells whether the sort value of a cursor has the type of the
+// sorted column, a cursor whose value has not was not taken from this list
+func cursorKeyIs[T any](cursor *bo.PageCursor) bool {
+	if cursor == nil {
+		return true
+	}
+	_, ok := cursor.Key.(T)
+	return ok
+}
+
+// keysetPage trims the extra row fetched to know whether there is another
+// page, puts a backward page back in list order and links its neighbours.
+// A page only links back when it did not start at the top of the list.
+func keysetPage[T any](rows []T, limit, offset int, cursors bo.PageCursors, cursorOf func(T) *bo.PageCursor) ([]T, bo.PageLinks) {
+	var links bo.PageLinks
+	backward := cursors.After == nil && cursors.Before != nil
+
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+	if len(rows) == 0 {
+		return rows, links
+	}
+
+	if backward {
+		slices.Reverse(rows)
+		if more {
+			links.Prev = cursorOf(rows[0])
+		}
+		links.Next = cursorOf(rows[len(rows)-1])
+		return rows, links
+	}
+
+	if more {
+		links.Next = cursorOf(rows[len(rows)-1])
+	}
+	if cursors.After != nil || offset > 0 {
+		links.Prev = cursorOf(rows[0])
+	}
+	return rows, links
+}
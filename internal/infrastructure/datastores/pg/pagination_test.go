@@ -0,0 +1,41 @@
+package pg
+
+import (
+	"testing"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeysetPage(t *testing.T) {
+	cursorOf := func(id int64) *bo.PageCursor { return &bo.PageCursor{Sort: "id", ID: id} }
+	after := bo.PageCursors{After: cursorOf(2)}
+	before := bo.PageCursors{Before: cursorOf(9)}
+
+	testCases := []struct {
+		name    string
+		rows    []int64
+		offset  int
+		cursors bo.PageCursors
+		page    []int64
+		links   bo.PageLinks
+	}{
+		{name: "FirstPage", rows: []int64{1, 2, 3, 4}, page: []int64{1, 2, 3}, links: bo.PageLinks{Next: cursorOf(3)}},
+		{name: "OnlyPage", rows: []int64{1, 2}, page: []int64{1, 2}},
+		{name: "OffsetPage", rows: []int64{4, 5}, offset: 3, page: []int64{4, 5}, links: bo.PageLinks{Prev: cursorOf(4)}},
+		{name: "AfterCursor", rows: []int64{3, 4, 5, 6}, cursors: after, page: []int64{3, 4, 5}, links: bo.PageLinks{Next: cursorOf(5), Prev: cursorOf(3)}},
+		{name: "LastPageAfterCursor", rows: []int64{3}, cursors: after, page: []int64{3}, links: bo.PageLinks{Prev: cursorOf(3)}},
+		{name: "EmptyPageAfterCursor", rows: []int64{}, cursors: after, page: []int64{}},
+		{name: "BeforeCursor", rows: []int64{8, 7, 6, 5}, cursors: before, page: []int64{6, 7, 8}, links: bo.PageLinks{Next: cursorOf(8), Prev: cursorOf(6)}},
+		{name: "FirstPageBeforeCursor", rows: []int64{8, 7}, cursors: before, page: []int64{7, 8}, links: bo.PageLinks{Next: cursorOf(8)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, links := keysetPage(tc.rows, 3, tc.offset, tc.cursors, cursorOf)
+			require.Equal(t, tc.page, page)
+			require.Equal(t, tc.links, links)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type paymentStore struct {
+	dbPool *pgxpool.Pool
+}
+
+const paymentSelect = `SELECT id, order_id, provider, provider_reference, status, amount, captured_amount, refunded_amount,
+	created_at, updated_at FROM payments`
+
+func scanPayment(row pgx.Row) (bo.Payment, error) {
+	var (
+		id                sql.NullInt64
+		orderID           sql.NullInt64
+		provider          sql.NullString
+		providerReference sql.NullString
+		status            sql.NullString
+		amount            decimal.NullDecimal
+		capturedAmount    decimal.NullDecimal
+		refundedAmount    decimal.NullDecimal
+		createdAt         sql.NullTime
+		updatedAt         sql.NullTime
+	)
+	if err := row.Scan(&id, &orderID, &provider, &providerReference, &status, &amount, &capturedAmount, &refundedAmount, &createdAt, &updatedAt); err != nil {
+		return bo.Payment{}, err
+	}
+
+	return bo.Payment{
+		ID:                id.Int64,
+		OrderID:           orderID.Int64,
+		Provider:          provider.String,
+		ProviderReference: providerReference.String,
+		Status:            bo.PaymentStatus(status.String),
+		Amount:            amount.Decimal,
+		CapturedAmount:    capturedAmount.Decimal,
+		RefundedAmount:    refundedAmount.Decimal,
+		CreatedAt:         createdAt.Time,
+		UpdatedAt:         updatedAt.Time,
+	}, nil
+}
+
+func (s *paymentStore) GetPaymentByID(ctx context.Context, paymentID int64) (bo.Payment, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return bo.Payment{}, err
+	}
+	defer conn.Release()
+
+	payment, err := scanPayment(conn.QueryRow(ctx, paymentSelect+" WHERE id = $1", paymentID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			slog.Error("payment id does not exist", slog.Int64("id", paymentID))
+			return bo.Payment{}, bo.ErrPaymentNotFound
+		}
+		slog.Error("failed to scan payment table row", "cause", err)
+		return bo.Payment{}, err
+	}
+
+	return payment, nil
+}
+
+func (s *paymentStore) ListOrderPayments(ctx context.Context, orderID int64) (bo.PaymentCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, paymentSelect+" WHERE order_id = $1 ORDER BY id ASC", orderID)
+	if err != nil {
+		slog.Error("failed to list payments", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := bo.PaymentCollection{}
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			slog.Error("failed to scan payment row", "cause", err)
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+func (s *paymentStore) CreatePayment(ctx context.Context, payment *bo.Payment) error {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	var (
+		id        sql.NullInt64
+		createdAt sql.NullTime
+		updatedAt sql.NullTime
+	)
+	err = conn.QueryRow(ctx, `INSERT INTO payments(order_id, provider, provider_reference, status, amount)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at, updated_at`,
+		payment.OrderID, payment.Provider, payment.ProviderReference, string(payment.Status), payment.Amount,
+	).Scan(&id, &createdAt, &updatedAt)
+	if err != nil {
+		slog.Error("failed to insert payment", "cause", err)
+		return fmt.Errorf("failed to insert payment: %w", err)
+	}
+
+	payment.ID = id.Int64
+	payment.CreatedAt = createdAt.Time
+	payment.UpdatedAt = updatedAt.Time
+	return nil
+}
+
+// ApplyPaymentEvent records a provider event and applies it to its payment.
+// An event which was already received is skipped, so the provider may deliver
+// it any number of times; applied is false when the payment did not change.
+func (s *paymentStore) ApplyPaymentEvent(ctx context.Context, event bo.PaymentEvent) (payment bo.Payment, applied bool, err error) {
+	err = WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		commandTag, err := tx.Exec(ctx, `INSERT INTO payment_events(provider, event_id, provider_reference, status, amount)
+			VALUES ($1, $2, $3, $4, $5) ON CONFLICT (provider, event_id) DO NOTHING`,
+			event.Provider, event.EventID, event.ProviderReference, string(event.Status), event.Amount)
+		if err != nil {
+			slog.Error("failed to insert payment event", "cause", err)
+			return fmt.Errorf("failed to insert payment event: %w", err)
+		}
+
+		payment, err = scanPayment(tx.QueryRow(ctx, paymentSelect+" WHERE provider = $1 AND provider_reference = $2 FOR UPDATE",
+			event.Provider, event.ProviderReference))
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrPaymentNotFound
+			}
+			slog.Error("failed to lock payment", "cause", err)
+			return err
+		}
+		if commandTag.RowsAffected() == 0 {
+			slog.Info("payment event already applied", slog.String("eventID", event.EventID))
+			return nil
+		}
+
+		next, changed := payment.Apply(event)
+		if !changed {
+			return nil
+		}
+
+		var updatedAt sql.NullTime
+		err = tx.QueryRow(ctx, `UPDATE payments SET status = $1, captured_amount = $2, refunded_amount = $3, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $4 RETURNING updated_at`, string(next.Status), next.CapturedAmount, next.RefundedAmount, payment.ID).Scan(&updatedAt)
+		if err != nil {
+			slog.Error("failed to update payment", slog.Int64("paymentID", payment.ID), "cause", err)
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+
+		next.UpdatedAt = updatedAt.Time
+		payment, applied = next, true
+		return nil
+	})
+
+	return payment, applied, err
+}
@@ -3,16 +3,18 @@ package pg
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
-	"strconv"
 	"strings"
 
 	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 )
 
 type productStore struct {
@@ -22,7 +24,7 @@ type productStore struct {
 var productFields = []string{
 	"id", "name", "description", "specifications", "brand_id",
 	"category_id", "supplier_id", "unit_price", "discount_price",
-	"tags", "status_id",
+	"tags", "status_id", "attributes", "currency", "tax_class_id",
 }
 
 func (s *productStore) GetProductByID(ctx context.Context, productID int64) (bo.Product, error) {
@@ -34,10 +36,13 @@ func (s *productStore) GetProductByID(ctx context.Context, productID int64) (bo.
 		brandID        sql.NullInt64
 		categoryID     sql.NullInt64
 		supplierID     sql.NullInt64
-		unitPrice      sql.NullFloat64
-		discountPrice  sql.NullFloat64
+		unitPrice      decimal.NullDecimal
+		discountPrice  decimal.NullDecimal
 		tags           sql.NullString
 		statusID       sql.NullInt64
+		attributes     map[string]any
+		currency       sql.NullString
+		taxClassID     sql.NullInt64
 	)
 
 	conn, err := s.dbPool.Acquire(ctx)
@@ -49,7 +54,7 @@ func (s *productStore) GetProductByID(ctx context.Context, productID int64) (bo.
 	dbQuery := fmt.Sprintf("SELECT %s FROM products WHERE id = $1", strings.Join(productFields, ","))
 	row := conn.QueryRow(ctx, dbQuery, productID)
 
-	if err = row.Scan(&id, &name, &description, &specifications, &brandID, &categoryID, &supplierID, &unitPrice, &discountPrice, &tags, &statusID); err != nil {
+	if err = row.Scan(&id, &name, &description, &specifications, &brandID, &categoryID, &supplierID, &unitPrice, &discountPrice, &tags, &statusID, &attributes, &currency, &taxClassID); err != nil {
 		if err == pgx.ErrNoRows {
 			slog.Error("product id does not exist", slog.Int64("id", productID))
 			return bo.Product{}, bo.ErrProductNotFound
@@ -58,7 +63,13 @@ func (s *productStore) GetProductByID(ctx context.Context, productID int64) (bo.
 		return bo.Product{}, err
 	}
 
-	return bo.Product{
+	images, err := queryProductImages(ctx, conn, id.Int64)
+	if err != nil {
+		slog.Error("failed to list product images", slog.Int64("id", productID), "cause", err)
+		return bo.Product{}, err
+	}
+
+	product := bo.Product{
 		ID:             id.Int64,
 		Name:           name.String,
 		Description:    description.String,
@@ -66,11 +77,14 @@ func (s *productStore) GetProductByID(ctx context.Context, productID int64) (bo.
 		BrandID:        brandID.Int64,
 		CategoryID:     categoryID.Int64,
 		SupplierID:     supplierID.Int64,
-		UnitPrice:      unitPrice.Float64,
-		DiscountPrice:  discountPrice.Float64,
 		Tags:           tags.String,
 		StatusID:       statusID.Int64,
-	}, nil
+		TaxClassID:     taxClassID.Int64,
+		Attributes:     attributes,
+		Images:         images[id.Int64],
+	}
+	product.SetPrices(unitPrice.Decimal, discountPrice.Decimal, currency.String)
+	return product, nil
 }
 
 func (s *productStore) CreateProduct(ctx context.Context, product *bo.Product) error {
@@ -80,19 +94,7 @@ func (s *productStore) CreateProduct(ctx context.Context, product *bo.Product) e
 		return fmt.Errorf("empty core insert for product")
 	}
 
-	start := 1
-	arguments := make([]interface{}, 0, len(insertMap))
-	fields := []string{}
-	placeholders := []string{}
-
-	for field, v := range insertMap {
-		fields = append(fields, field)
-		arguments = append(arguments, v)
-		placeholders = append(placeholders, "$"+strconv.Itoa(start))
-		start++
-	}
-
-	sqlQuery := fmt.Sprintf("INSERT INTO products(%s) VALUES (%s) RETURNING id", strings.Join(fields, ","), strings.Join(placeholders, ","))
+	sqlQuery, arguments := sqlbuilder.Insert("products").Values(insertMap).Returning("id").Build()
 
 	conn, err := s.dbPool.Acquire(ctx)
 	if err != nil {
@@ -102,7 +104,7 @@ func (s *productStore) CreateProduct(ctx context.Context, product *bo.Product) e
 
 	var id sql.NullInt64
 	if err := conn.QueryRow(ctx, sqlQuery, arguments...).Scan(&id); err != nil {
-		return err
+		return taxClassError(err)
 	}
 
 	product.ID = id.Int64
@@ -117,8 +119,8 @@ func buildProductInsertMap(p bo.Product) map[string]interface{} {
 	insertedFields["brand_id"] = p.BrandID
 	insertedFields["category_id"] = p.CategoryID
 	insertedFields["supplier_id"] = p.SupplierID
-	insertedFields["unit_price"] = p.UnitPrice
-	insertedFields["discount_price"] = p.DiscountPrice
+	insertedFields["unit_price"] = p.UnitPrice.Amount
+	insertedFields["discount_price"] = p.DiscountPrice.Amount
 	insertedFields["status_id"] = p.StatusID
 
 	// Optional fields
@@ -131,6 +133,16 @@ func buildProductInsertMap(p bo.Product) map[string]interface{} {
 	if p.Tags != "" {
 		insertedFields["tags"] = p.Tags
 	}
+	if p.Attributes != nil {
+		insertedFields["attributes"] = p.Attributes
+	}
+	// Without a currency the column default applies
+	if p.Currency() != "" {
+		insertedFields["currency"] = p.Currency()
+	}
+	if p.TaxClassID != 0 {
+		insertedFields["tax_class_id"] = p.TaxClassID
+	}
 
 	return insertedFields
 }
@@ -143,24 +155,13 @@ func (s *productStore) UpdateProduct(ctx context.Context, updateProduct bo.Produ
 			return errors.New("empty core update for product")
 		}
 
-		sqlQuery := "UPDATE products SET "
-		start := 1
-		arguments := make([]interface{}, 0, len(updateMap)+1)
-
-		for k, v := range updateMap {
-			sqlQuery += k + "=$" + strconv.Itoa(start)
-			arguments = append(arguments, v)
-			if start < len(updateMap) {
-				sqlQuery += ", "
-			}
-			start++
-		}
-
-		sqlQuery += fmt.Sprintf(" WHERE id = $%d", start)
-		arguments = append(arguments, updateProduct.ID)
+		sqlQuery, arguments := sqlbuilder.Update("products").Set(updateMap).Where("id = ?", updateProduct.ID).Build()
 
 		commandTag, err := tx.Exec(ctx, sqlQuery, arguments...)
 		if err != nil {
+			if classErr := taxClassError(err); classErr != err {
+				return classErr
+			}
 			slog.Error("failed to update product in database", "cause", err)
 			return fmt.Errorf("failed to update product in database: %w", err)
 		}
@@ -206,6 +207,15 @@ func buildProductUpdateMap(u bo.ProductUpdate) map[string]interface{} {
 	if u.StatusID != nil {
 		updateFields["status_id"] = *u.StatusID
 	}
+	if u.Attributes != nil {
+		updateFields["attributes"] = u.Attributes
+	}
+	if u.Currency != nil {
+		updateFields["currency"] = *u.Currency
+	}
+	if u.TaxClassID != nil {
+		updateFields["tax_class_id"] = taxClassID(*u.TaxClassID)
+	}
 
 	return updateFields
 }
@@ -225,7 +235,14 @@ func (s *productStore) DeleteProduct(ctx context.Context, productID int64) error
 func (s *productStore) ListProducts(ctx context.Context, productQuery bo.ProductSearchQuery) (bo.PaginatedProductCollection, error) {
 	pagingCollection := bo.PaginatedProductCollection{}
 
-	dbQuery, countQuery := buildQuery(productQuery)
+	query, order, err := buildProductQuery(productQuery)
+	if err != nil {
+		return pagingCollection, err
+	}
+	countQuery, countArgs := query.BuildCount()
+	if err := pageProductQuery(query, order, productQuery.Paging); err != nil {
+		return pagingCollection, err
+	}
 
 	conn, err := s.dbPool.Acquire(ctx)
 	if err != nil {
@@ -233,14 +250,15 @@ func (s *productStore) ListProducts(ctx context.Context, productQuery bo.Product
 	}
 	defer conn.Release()
 
-	rows, err := conn.Query(ctx, dbQuery)
+	dbQuery, args := query.Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
 	if err != nil {
 		slog.Error("failed to list products", "cause", err)
 		return pagingCollection, err
 	}
 	defer rows.Close()
 
-	var products bo.ProductCollection
+	var products []rankedProduct
 	for rows.Next() {
 		var (
 			id             sql.NullInt64
@@ -250,18 +268,29 @@ func (s *productStore) ListProducts(ctx context.Context, productQuery bo.Product
 			brandID        sql.NullInt64
 			categoryID     sql.NullInt64
 			supplierID     sql.NullInt64
-			unitPrice      sql.NullFloat64
-			discountPrice  sql.NullFloat64
+			unitPrice      decimal.NullDecimal
+			discountPrice  decimal.NullDecimal
 			tags           sql.NullString
 			statusID       sql.NullInt64
+			attributes     map[string]any
+			currency       sql.NullString
+			taxClassID     sql.NullInt64
 		)
 
-		if err := rows.Scan(&id, &name, &description, &specifications, &brandID, &categoryID, &supplierID, &unitPrice, &discountPrice, &tags, &statusID); err != nil {
+		var (
+			highlight bo.ProductHighlight
+			rank      float32
+		)
+		dest := []any{&id, &name, &description, &specifications, &brandID, &categoryID, &supplierID, &unitPrice, &discountPrice, &tags, &statusID, &attributes, &currency, &taxClassID}
+		if productQuery.Filter.Query != "" {
+			dest = append(dest, &highlight.Name, &highlight.Description, &rank)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			slog.Error("failed to scan product row", "cause", err)
 			return pagingCollection, err
 		}
 
-		products = append(products, bo.Product{
+		product := bo.Product{
 			ID:             id.Int64,
 			Name:           name.String,
 			Description:    description.String,
@@ -269,11 +298,14 @@ func (s *productStore) ListProducts(ctx context.Context, productQuery bo.Product
 			BrandID:        brandID.Int64,
 			CategoryID:     categoryID.Int64,
 			SupplierID:     supplierID.Int64,
-			UnitPrice:      unitPrice.Float64,
-			DiscountPrice:  discountPrice.Float64,
 			Tags:           tags.String,
 			StatusID:       statusID.Int64,
-		})
+			TaxClassID:     taxClassID.Int64,
+			Attributes:     attributes,
+			Highlight:      highlight,
+		}
+		product.SetPrices(unitPrice.Decimal, discountPrice.Decimal, currency.String)
+		products = append(products, rankedProduct{Product: product, rank: rank})
 	}
 
 	if err = rows.Err(); err != nil {
@@ -281,9 +313,23 @@ func (s *productStore) ListProducts(ctx context.Context, productQuery bo.Product
 		return pagingCollection, err
 	}
 
-	pagingCollection.Data = products
+	products, pagingCollection.PageLinks = keysetPage(products, productQuery.Paging.Limit, productQuery.Paging.Offset, productQuery.Paging.PageCursors, order.cursor)
+	productIDs := make([]int64, len(products))
+	for i, product := range products {
+		productIDs[i] = product.ID
+	}
+	images, err := queryProductImages(ctx, conn, productIDs...)
+	if err != nil {
+		slog.Error("failed to list product images", "cause", err)
+		return pagingCollection, err
+	}
+	for _, product := range products {
+		product.Images = images[product.ID]
+		pagingCollection.Data = append(pagingCollection.Data, product.Product)
+	}
+
 	var totalRecord sql.NullInt64
-	if err = conn.QueryRow(ctx, countQuery).Scan(&totalRecord); err != nil {
+	if err = conn.QueryRow(ctx, countQuery, countArgs...).Scan(&totalRecord); err != nil {
 		slog.Error("error scanning COUNT products row", "cause", err)
 		return pagingCollection, err
 	}
@@ -292,48 +338,455 @@ func (s *productStore) ListProducts(ctx context.Context, productQuery bo.Product
 	return pagingCollection, nil
 }
 
-func buildQuery(productQuery bo.ProductSearchQuery) (string, string) {
-	var pFields = []string{
+// rankedProduct is a listed product with its relevance to the search, if any
+type rankedProduct struct {
+	bo.Product
+	rank float32
+}
+
+// productSortable is every field a product list can be sorted on, a missing
+// discount sorts as no discount
+var productSortable = sqlbuilder.Sortable{
+	"id":             "p.id",
+	"name":           "p.name",
+	"unit_price":     "p.unit_price",
+	"discount_price": "COALESCE(p.discount_price, 0)",
+}
+
+// productHighlightOptions wraps the matched words of a search in <mark> tags,
+// descriptions are cut to the fragments around them
+const (
+	productNameHighlightOptions        = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	productDescriptionHighlightOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+)
+
+// productOrder is the order of a product list. Its keys are the sorted column
+// and the id breaking ties, sort names the order in the cursors of the list.
+type productOrder struct {
+	sort  string
+	field string
+	keys  []string
+	desc  bool
+}
+
+// relevanceProductSort names the order of a search without a sort field
+const relevanceProductSort = "relevance"
+
+// cursor is the position of a product in the list
+func (o productOrder) cursor(product rankedProduct) *bo.PageCursor {
+	cursor := &bo.PageCursor{Sort: o.sort, ID: product.ID}
+	switch o.field {
+	case "name":
+		cursor.Key = product.Name
+	case "unit_price":
+		cursor.Key = product.UnitPrice.Amount.String()
+	case "discount_price":
+		cursor.Key = product.DiscountPrice.Amount.String()
+	case relevanceProductSort:
+		cursor.Key = float64(product.rank)
+	}
+	return cursor
+}
+
+// validKey tells whether the sort value of a cursor has the type of the sorted column,
+// a price is kept as its exact decimal text
+func (o productOrder) validKey(cursor *bo.PageCursor) bool {
+	switch o.field {
+	case "id":
+		return true
+	case "name":
+		return cursorKeyIs[string](cursor)
+	case "unit_price", "discount_price":
+		if cursor == nil {
+			return true
+		}
+		key, ok := cursor.Key.(string)
+		if !ok {
+			return false
+		}
+		_, err := decimal.NewFromString(key)
+		return err == nil
+	}
+	return cursorKeyIs[float64](cursor)
+}
+
+// buildProductQuery selects the products matching the filter and decides their
+// order: the sort field, else relevance for a search and price for a listing
+func buildProductQuery(productQuery bo.ProductSearchQuery) (*sqlbuilder.SelectBuilder, productOrder, error) {
+	columns := []string{
 		"p.id", "p.name", "p.description", "p.specifications", "p.brand_id",
 		"p.category_id", "p.supplier_id", "p.unit_price", "p.discount_price",
-		"p.tags", "p.status_id",
+		"p.tags", "p.status_id", "p.attributes", "p.currency", "p.tax_class_id",
+	}
+	search := productQuery.Filter.Query != ""
+	if search {
+		columns = append(columns,
+			"ts_headline('english', p.name, query, '"+productNameHighlightOptions+"')",
+			"ts_headline('english', COALESCE(p.description, ''), query, '"+productDescriptionHighlightOptions+"')",
+			"ts_rank(p.search_vector, query)",
+		)
+	}
+
+	var order productOrder
+	switch {
+	case productQuery.Sort.Field != "":
+		column, desc, err := productSortable.Key(productQuery.Sort.Field, productQuery.Sort.Order)
+		if err != nil {
+			return nil, order, bo.ErrInvalidProductSort
+		}
+		order = productOrder{field: productQuery.Sort.Field, keys: []string{column, "p.id"}, desc: desc}
+		if column == "p.id" {
+			order.keys = []string{"p.id"}
+		}
+	case search:
+		order = productOrder{field: relevanceProductSort, keys: []string{"ts_rank(p.search_vector, query)", "p.id"}, desc: true}
+	default:
+		order = productOrder{field: "unit_price", keys: []string{"p.unit_price", "p.id"}}
+	}
+	order.sort = order.field + ":asc"
+	if order.desc {
+		order.sort = order.field + ":desc"
+	}
+
+	return filterProducts(sqlbuilder.Select(columns...), productQuery.Filter, noProductFacet), order, nil
+}
+
+// pageProductQuery orders the products and pages them from the cursor of the paging, if any
+func pageProductQuery(query *sqlbuilder.SelectBuilder, order productOrder, paging bo.ProductPaging) error {
+	cursor, backward, err := pageCursor(paging.PageCursors, order.sort)
+	if err != nil || !order.validKey(cursor) {
+		return bo.ErrInvalidCursor
+	}
+
+	keysetQuery(query, order.keys, order.desc, paging.Limit, paging.Offset, cursor, backward)
+	return nil
+}
+
+// productFacet is a filter dimension, a facet is counted without its own filter
+type productFacet int
+
+const (
+	noProductFacet productFacet = iota
+	brandProductFacet
+	categoryProductFacet
+	supplierProductFacet
+	verifiedSupplierProductFacet
+	priceProductFacet
+)
+
+// filterProducts selects the available products matching the filter, leaving out the filter of the skipped facet
+func filterProducts(query *sqlbuilder.SelectBuilder, filter bo.ProductFilter, skip productFacet) *sqlbuilder.SelectBuilder {
+	query.From("products p").
+		Join("INNER JOIN brands b ON p.brand_id = b.id").
+		Join("INNER JOIN categories c ON p.category_id = c.id").
+		Join("INNER JOIN suppliers s ON p.supplier_id = s.id").
+		Join("INNER JOIN " + productStockAggregate + " ps ON p.id = ps.product_id").
+		Where("p.status_id = 1").
+		Where("ps.available_quantity > 0")
+	if filter.Query != "" {
+		query.Join("CROSS JOIN websearch_to_tsquery('english', ?) query", filter.Query).
+			Where("p.search_vector @@ query")
+	}
+
+	if skip != priceProductFacet {
+		if filter.PriceRangeFilter.Min > 0 {
+			query.Where("p.unit_price >= ?", filter.PriceRangeFilter.Min)
+		}
+		if filter.PriceRangeFilter.Max > 0 {
+			query.Where("p.unit_price <= ?", filter.PriceRangeFilter.Max)
+		}
+	}
+	if len(filter.BrandFilter) > 0 && skip != brandProductFacet {
+		query.Where("p.brand_id = ANY(?)", filter.BrandFilter)
+	}
+	if filter.CategoryFilter != 0 && skip != categoryProductFacet {
+		query.Where("p.category_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = ?)", filter.CategoryFilter)
+	}
+	if filter.SupplierFilter != 0 && skip != supplierProductFacet {
+		query.Where("p.supplier_id = ?", filter.SupplierFilter)
+	}
+	if filter.VerifiedSupplierFilter && skip != verifiedSupplierProductFacet {
+		query.Where("s.is_verified_supplier = true")
+	}
+	for _, attributeFilter := range filter.AttributeFilters {
+		filterProductAttribute(query, attributeFilter)
+	}
+
+	return query
+}
+
+// filterProductAttribute matches an attribute against any of the values, each
+// one as a JSON containment, or a number against its range with a jsonpath.
+// Codes are checked against the category attributes, so they are safe in the path.
+func filterProductAttribute(query *sqlbuilder.SelectBuilder, filter bo.AttributeFilter) {
+	if len(filter.Values) > 0 {
+		values := make([]string, 0, len(filter.Values))
+		for _, value := range filter.Values {
+			document, err := json.Marshal(map[string]any{filter.Code: value})
+			if err != nil {
+				continue
+			}
+			values = append(values, string(document))
+		}
+		query.Where("p.attributes @> ANY(?::jsonb[])", values)
+		return
+	}
+
+	var (
+		bounds []string
+		vars   = map[string]float64{}
+	)
+	if filter.Min != nil {
+		bounds = append(bounds, "@ >= $min")
+		vars["min"] = *filter.Min
 	}
+	if filter.Max != nil {
+		bounds = append(bounds, "@ <= $max")
+		vars["max"] = *filter.Max
+	}
+	if len(bounds) == 0 {
+		return
+	}
+	path := `$."` + filter.Code + `" ? (` + strings.Join(bounds, " && ") + ")"
+	query.Where("jsonb_path_exists(p.attributes, ?::jsonpath, ?::jsonb)", path, vars)
+}
+
+// buildProductFacetQueries counts the products per brand, category and supplier, in that order
+func buildProductFacetQueries(filter bo.ProductFilter) []*sqlbuilder.SelectBuilder {
+	return []*sqlbuilder.SelectBuilder{
+		filterProducts(sqlbuilder.Select("b.id", "b.name", "COUNT(*)"), filter, brandProductFacet).
+			GroupBy("b.id", "b.name").OrderBy("COUNT(*) DESC", "b.name ASC"),
+		filterProducts(sqlbuilder.Select("c.id", "c.name", "COUNT(*)"), filter, categoryProductFacet).
+			GroupBy("c.id", "c.name").OrderBy("COUNT(*) DESC", "c.name ASC"),
+		filterProducts(sqlbuilder.Select("s.id", "s.name", "COUNT(*)"), filter, supplierProductFacet).
+			GroupBy("s.id", "s.name").OrderBy("COUNT(*) DESC", "s.name ASC"),
+	}
+}
+
+func buildVerifiedSupplierFacetQuery(filter bo.ProductFilter) *sqlbuilder.SelectBuilder {
+	return filterProducts(sqlbuilder.Select("s.is_verified_supplier", "COUNT(*)"), filter, verifiedSupplierProductFacet).
+		GroupBy("s.is_verified_supplier").OrderBy("s.is_verified_supplier DESC")
+}
+
+// buildPriceFacetQuery counts the products per price bucket, width_bucket
+// numbers the bucket starting at ProductPriceBuckets[i] as i+1
+func buildPriceFacetQuery(filter bo.ProductFilter) *sqlbuilder.SelectBuilder {
+	query := sqlbuilder.Select("width_bucket(p.unit_price, price.bounds) AS bucket", "COUNT(*)")
+	return filterProducts(query, filter, priceProductFacet).
+		Join("CROSS JOIN (SELECT ?::numeric[] AS bounds) price", bo.ProductPriceBuckets).
+		GroupBy("bucket").
+		OrderBy("bucket ASC")
+}
+
+// buildAttributeFacetQuery counts the products per value of an attribute, under
+// every attribute filter but its own. The code comes from the category attributes.
+func buildAttributeFacetQuery(filter bo.ProductFilter, code string) *sqlbuilder.SelectBuilder {
+	others := make([]bo.AttributeFilter, 0, len(filter.AttributeFilters))
+	for _, attributeFilter := range filter.AttributeFilters {
+		if attributeFilter.Code != code {
+			others = append(others, attributeFilter)
+		}
+	}
+	filter.AttributeFilters = others
+
+	value := "p.attributes ->> '" + code + "'"
+	return filterProducts(sqlbuilder.Select(value, "COUNT(*)"), filter, noProductFacet).
+		Where(value+" IS NOT NULL").
+		GroupBy(value).
+		OrderBy("COUNT(*) DESC", value+" ASC")
+}
 
-	sqlStatement := `SELECT %s FROM products p
-		INNER JOIN brands b ON p.brand_id = b.id
-		INNER JOIN categories c ON p.category_id = c.id
-		INNER JOIN suppliers s ON p.supplier_id = s.id
-		INNER JOIN product_stocks ps ON p.id = ps.product_id
-		WHERE p.status_id = 1 AND ps.stock_quantity > 0`
+func (s *productStore) ProductFacets(ctx context.Context, filter bo.ProductFilter, attributes bo.CategoryAttributeCollection) (bo.ProductFacets, error) {
+	facets := bo.ProductFacets{}
 
-	if productQuery.Filter.PriceRangeFilter.Min > 0 {
-		sqlStatement += fmt.Sprintf(" AND p.unit_price >= %f", productQuery.Filter.PriceRangeFilter.Min)
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return facets, err
 	}
-	if productQuery.Filter.PriceRangeFilter.Max > 0 {
-		sqlStatement += fmt.Sprintf(" AND p.unit_price <= %f", productQuery.Filter.PriceRangeFilter.Max)
+	defer conn.Release()
+
+	counts := make([][]bo.FacetCount, 0, 3)
+	for _, query := range buildProductFacetQueries(filter) {
+		dbQuery, args := query.Build()
+		rows, err := conn.Query(ctx, dbQuery, args...)
+		if err != nil {
+			slog.Error("failed to count product facet", "cause", err)
+			return facets, err
+		}
+
+		facet := []bo.FacetCount{}
+		for rows.Next() {
+			var count bo.FacetCount
+			if err := rows.Scan(&count.ID, &count.Name, &count.Count); err != nil {
+				rows.Close()
+				slog.Error("failed to scan product facet row", "cause", err)
+				return facets, err
+			}
+			facet = append(facet, count)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			slog.Error("failed during rows iteration", "cause", err)
+			return facets, err
+		}
+		counts = append(counts, facet)
+	}
+	facets.Brands, facets.Categories, facets.Suppliers = counts[0], counts[1], counts[2]
+
+	dbQuery, args := buildVerifiedSupplierFacetQuery(filter).Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to count verified supplier facet", "cause", err)
+		return facets, err
+	}
+	facets.VerifiedSupplier = []bo.VerifiedSupplierFacetCount{}
+	for rows.Next() {
+		var count bo.VerifiedSupplierFacetCount
+		if err := rows.Scan(&count.Verified, &count.Count); err != nil {
+			rows.Close()
+			slog.Error("failed to scan verified supplier facet row", "cause", err)
+			return facets, err
+		}
+		facets.VerifiedSupplier = append(facets.VerifiedSupplier, count)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return facets, err
+	}
+
+	// Every bucket is listed, the empty ones with a zero count
+	facets.PriceBuckets = make([]bo.PriceBucketFacetCount, len(bo.ProductPriceBuckets))
+	for i, lower := range bo.ProductPriceBuckets {
+		facets.PriceBuckets[i].Min = lower
+		if i+1 < len(bo.ProductPriceBuckets) {
+			facets.PriceBuckets[i].Max = bo.ProductPriceBuckets[i+1]
+		}
+	}
+
+	dbQuery, args = buildPriceFacetQuery(filter).Build()
+	rows, err = conn.Query(ctx, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to count price facet", "cause", err)
+		return facets, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bucket, count int64
+		if err := rows.Scan(&bucket, &count); err != nil {
+			slog.Error("failed to scan price facet row", "cause", err)
+			return facets, err
+		}
+		if bucket >= 1 && int(bucket) <= len(facets.PriceBuckets) {
+			facets.PriceBuckets[bucket-1].Count = count
+		}
 	}
-	if len(productQuery.Filter.BrandFilter) > 0 {
-		sqlStatement += fmt.Sprintf(" AND p.brand_id IN (%s)", strings.Trim(strings.Replace(fmt.Sprint(productQuery.Filter.BrandFilter), " ", ",", -1), "[]"))
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return facets, err
 	}
-	if productQuery.Filter.CategoryFilter != 0 {
-		sqlStatement += fmt.Sprintf(" AND p.category_id = %d", productQuery.Filter.CategoryFilter)
+
+	facets.Attributes = []bo.AttributeFacet{}
+	for _, attribute := range attributes {
+		if !attribute.Filterable {
+			continue
+		}
+
+		facet := bo.AttributeFacet{Code: attribute.Code, Name: attribute.Name, Type: attribute.Type, Unit: attribute.Unit, Values: []bo.AttributeFacetCount{}}
+		dbQuery, args := buildAttributeFacetQuery(filter, attribute.Code).Build()
+		rows, err := conn.Query(ctx, dbQuery, args...)
+		if err != nil {
+			slog.Error("failed to count attribute facet", slog.String("code", attribute.Code), "cause", err)
+			return facets, err
+		}
+		for rows.Next() {
+			var count bo.AttributeFacetCount
+			if err := rows.Scan(&count.Value, &count.Count); err != nil {
+				rows.Close()
+				slog.Error("failed to scan attribute facet row", "cause", err)
+				return facets, err
+			}
+			facet.Values = append(facet.Values, count)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			slog.Error("failed during rows iteration", "cause", err)
+			return facets, err
+		}
+		facets.Attributes = append(facets.Attributes, facet)
 	}
-	if productQuery.Filter.SupplierFilter != 0 {
-		sqlStatement += fmt.Sprintf(" AND p.supplier_id = %d", productQuery.Filter.SupplierFilter)
+
+	return facets, nil
+}
+
+// buildProductExportQuery selects the products of a list with their brand, category path,
+// supplier, available stock, Google category mapping and images, by id so that an export is stable
+func buildProductExportQuery(filter bo.ProductFilter) *sqlbuilder.SelectBuilder {
+	columns := []string{
+		"p.id", "p.name", "p.description", "p.specifications", "p.brand_id",
+		"p.category_id", "p.supplier_id", "p.unit_price", "p.discount_price",
+		"p.tags", "p.status_id", "p.attributes", "p.currency",
+		"b.name",
+		`ARRAY(SELECT a.name FROM category_closure cc INNER JOIN categories a ON a.id = cc.ancestor_id
+			WHERE cc.descendant_id = p.category_id ORDER BY cc.depth DESC)`,
+		"s.name", "ps.available_quantity::bigint",
+		`(SELECT a.google_product_category FROM category_closure cc INNER JOIN categories a ON a.id = cc.ancestor_id
+			WHERE cc.descendant_id = p.category_id AND a.google_product_category IS NOT NULL
+			ORDER BY cc.depth ASC LIMIT 1)`,
+		`ARRAY(SELECT storage_key FROM product_media
+			WHERE product_id = p.id AND kind = 'image' ORDER BY ` + productMediaOrder + `)`,
+	}
+	return filterProducts(sqlbuilder.Select(columns...), filter, noProductFacet).OrderBy("p.id ASC")
+}
+
+// ExportProducts reads the products one row at a time, the connection is held until the last one is yielded
+func (s *productStore) ExportProducts(ctx context.Context, filter bo.ProductFilter, yield func(bo.ProductExportRow) error) error {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return err
 	}
-	if productQuery.Filter.VerifiedSupplierFilter {
-		sqlStatement += " AND s.verified = true"
+	defer conn.Release()
+
+	dbQuery, args := buildProductExportQuery(filter).Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to export products", "cause", err)
+		return err
 	}
+	defer rows.Close()
 
-	countQuery := fmt.Sprintf(sqlStatement, `COUNT(*)`)
-	sqlStatement = fmt.Sprintf(sqlStatement, strings.Join(pFields, ","))
-	if productQuery.Filter.Query != "" {
-		sqlStatement += fmt.Sprintf(` AND p.name LIKE '%s'`, `%`+productQuery.Filter.Query+`%`)
-		countQuery += fmt.Sprintf(` AND p.name LIKE '%s'`, `%`+productQuery.Filter.Query+`%`)
+	for rows.Next() {
+		var (
+			row            bo.ProductExportRow
+			description    sql.NullString
+			specifications sql.NullString
+			unitPrice      decimal.NullDecimal
+			discountPrice  decimal.NullDecimal
+			currency       sql.NullString
+			tags           sql.NullString
+			googleCategory sql.NullString
+		)
+		if err := rows.Scan(&row.ID, &row.Name, &description, &specifications, &row.BrandID, &row.CategoryID, &row.SupplierID,
+			&unitPrice, &discountPrice, &tags, &row.StatusID, &row.Attributes, &currency,
+			&row.BrandName, &row.CategoryPath, &row.SupplierName, &row.Stock, &googleCategory, &row.ImageKeys); err != nil {
+			slog.Error("failed to scan exported product row", "cause", err)
+			return err
+		}
+		row.Description = description.String
+		row.Specifications = specifications.String
+		row.SetPrices(unitPrice.Decimal, discountPrice.Decimal, currency.String)
+		row.Tags = tags.String
+		row.GoogleProductCategory = googleCategory.String
+
+		if err := yield(row); err != nil {
+			return err
+		}
 	}
 
-	countQuery += ` LIMIT ` + strconv.Itoa(productQuery.Paging.Limit) + ` OFFSET ` + strconv.Itoa(productQuery.Paging.Offset)
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return err
+	}
 
-	sqlStatement += ` ORDER BY ` + productQuery.Sort.Field + ` ` + productQuery.Sort.Order + ` LIMIT ` + strconv.Itoa(productQuery.Paging.Limit) + ` OFFSET ` + strconv.Itoa(productQuery.Paging.Offset)
-	return sqlStatement, countQuery
+	return nil
 }
@@ -0,0 +1,181 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// productImportBatchSize is the number of products sent to the database at once
+const productImportBatchSize = 500
+
+// productReferenceTables are the tables of the product references by kind
+var productReferenceTables = map[bo.ProductReferenceKind]string{
+	bo.ReferenceBrand:    "brands",
+	bo.ReferenceCategory: "categories",
+	bo.ReferenceSupplier: "suppliers",
+}
+
+type productImportStore struct {
+	dbPool *pgxpool.Pool
+}
+
+func (s *productImportStore) FindProductReferences(ctx context.Context, kind bo.ProductReferenceKind, ids []int64, names []string) ([]bo.ProductReference, error) {
+	table, ok := productReferenceTables[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown product reference %s", kind)
+	}
+	references := []bo.ProductReference{}
+	if len(ids) == 0 && len(names) == 0 {
+		return references, nil
+	}
+
+	lowerNames := make([]string, len(names))
+	for i, name := range names {
+		lowerNames[i] = strings.ToLower(name)
+	}
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, fmt.Sprintf("SELECT id, name FROM %s WHERE id = ANY($1) OR LOWER(name) = ANY($2) ORDER BY id", table),
+		ids, lowerNames)
+	if err != nil {
+		slog.Error("failed to find product references", slog.String("kind", string(kind)), "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reference bo.ProductReference
+		if err := rows.Scan(&reference.ID, &reference.Name); err != nil {
+			slog.Error("failed to scan product reference row", "cause", err)
+			return nil, err
+		}
+		references = append(references, reference)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return references, nil
+}
+
+func (s *productImportStore) FindExistingProducts(ctx context.Context, keys []bo.ProductKey) (map[bo.ProductKey]int64, error) {
+	existing := make(map[bo.ProductKey]int64)
+	if len(keys) == 0 {
+		return existing, nil
+	}
+
+	supplierIDs := make([]int64, len(keys))
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		supplierIDs[i], names[i] = key.SupplierID, key.Name
+	}
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `SELECT p.id, p.supplier_id, p.name FROM products p
+		JOIN UNNEST($1::int[], $2::text[]) AS k(supplier_id, name) ON p.supplier_id = k.supplier_id AND p.name = k.name`,
+		supplierIDs, names)
+	if err != nil {
+		slog.Error("failed to find existing products", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id  int64
+			key bo.ProductKey
+		)
+		if err := rows.Scan(&id, &key.SupplierID, &key.Name); err != nil {
+			slog.Error("failed to scan existing product row", "cause", err)
+			return nil, err
+		}
+		existing[key] = id
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// ImportProducts sends the products in batches of productImportBatchSize within one transaction,
+// an upsert tells a created product from an updated one by xmax, which is only set on an update
+func (s *productImportStore) ImportProducts(ctx context.Context, products []bo.Product, upsert bool) (int, int, error) {
+	sqlQuery := `INSERT INTO products (name, description, specifications, brand_id, category_id, supplier_id,
+			unit_price, discount_price, currency, tags, status_id, attributes)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, $12)`
+	if upsert {
+		sqlQuery += `
+		ON CONFLICT (supplier_id, name) DO UPDATE SET
+			description = EXCLUDED.description, specifications = EXCLUDED.specifications,
+			brand_id = EXCLUDED.brand_id, category_id = EXCLUDED.category_id,
+			unit_price = EXCLUDED.unit_price, discount_price = EXCLUDED.discount_price, currency = EXCLUDED.currency,
+			tags = EXCLUDED.tags, status_id = EXCLUDED.status_id, attributes = EXCLUDED.attributes`
+	}
+	sqlQuery += `
+		RETURNING xmax = 0`
+
+	var created, updated int
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		for start := 0; start < len(products); start += productImportBatchSize {
+			end := min(start+productImportBatchSize, len(products))
+
+			batch := &pgx.Batch{}
+			for _, p := range products[start:end] {
+				attributes := p.Attributes
+				if attributes == nil {
+					attributes = map[string]any{}
+				}
+				batch.Queue(sqlQuery, p.Name, p.Description, p.Specifications, p.BrandID, p.CategoryID, p.SupplierID,
+					p.UnitPrice.Amount, p.DiscountPrice.Amount, p.Currency(), p.Tags, p.StatusID, attributes)
+			}
+
+			results := tx.SendBatch(ctx, batch)
+			for i := start; i < end; i++ {
+				var inserted bool
+				if err := results.QueryRow().Scan(&inserted); err != nil {
+					results.Close()
+					slog.Error("failed to import product", slog.String("name", products[i].Name), "cause", err)
+					return fmt.Errorf("failed to import product %q: %w", products[i].Name, err)
+				}
+				if inserted {
+					created++
+				} else {
+					updated++
+				}
+			}
+			if err := results.Close(); err != nil {
+				slog.Error("failed to import products", "cause", err)
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return created, updated, nil
+}
@@ -0,0 +1,464 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type productMediaStore struct {
+	dbPool *pgxpool.Pool
+}
+
+var productMediaFields = []string{
+	"id",
+	"product_id",
+	"kind",
+	"storage_key",
+	"file_name",
+	"mime_type",
+	"size_bytes",
+	"width",
+	"height",
+	"sequence",
+	"is_primary",
+	"created_at",
+	"rendition_status",
+	"rendition_error",
+}
+
+// productMediaOrder lists the primary image first, then by sequence
+const productMediaOrder = "is_primary DESC, sequence ASC, id ASC"
+
+func scanProductMedia(row pgx.Row) (bo.ProductMedia, error) {
+	var (
+		media           bo.ProductMedia
+		kind            string
+		width           sql.NullInt64
+		height          sql.NullInt64
+		renditionStatus string
+		renditionError  sql.NullString
+	)
+	if err := row.Scan(&media.ID, &media.ProductID, &kind, &media.StorageKey, &media.FileName, &media.MimeType,
+		&media.SizeBytes, &width, &height, &media.Sequence, &media.Primary, &media.CreatedAt, &renditionStatus, &renditionError); err != nil {
+		return bo.ProductMedia{}, err
+	}
+	media.Kind = bo.MediaKind(kind)
+	media.Width = width.Int64
+	media.Height = height.Int64
+	media.RenditionStatus = bo.RenditionStatus(renditionStatus)
+	media.RenditionError = renditionError.String
+	return media, nil
+}
+
+func (s *productMediaStore) GetProductMediaByID(ctx context.Context, productID, mediaID int64) (bo.ProductMedia, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return bo.ProductMedia{}, err
+	}
+	defer conn.Release()
+
+	dbQuery := fmt.Sprintf("SELECT %s FROM product_media WHERE id = $1 AND product_id = $2", strings.Join(productMediaFields, ","))
+	media, err := scanProductMedia(conn.QueryRow(ctx, dbQuery, mediaID, productID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return bo.ProductMedia{}, bo.ErrProductMediaNotFound
+		}
+		slog.Error("failed to scan product media table row", "cause", err)
+		return bo.ProductMedia{}, err
+	}
+
+	renditions, err := queryProductMediaRenditions(ctx, conn, media.ID)
+	if err != nil {
+		slog.Error("failed to list product media renditions", slog.Int64("mediaID", mediaID), "cause", err)
+		return bo.ProductMedia{}, err
+	}
+	media.Renditions = renditions[media.ID]
+
+	return media, nil
+}
+
+func (s *productMediaStore) CreateProductMedia(ctx context.Context, media *bo.ProductMedia) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		// the product row lock keeps two first images from both becoming primary
+		var productID int64
+		if err := tx.QueryRow(ctx, `SELECT id FROM products WHERE id = $1 FOR UPDATE`, media.ProductID).Scan(&productID); err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrProductNotFound
+			}
+			slog.Error("failed to lock the product of the media", slog.Int64("productID", media.ProductID), "cause", err)
+			return err
+		}
+
+		var width, height any
+		if media.Kind == bo.MediaImage {
+			width, height = media.Width, media.Height
+		}
+		sqlQuery := `INSERT INTO product_media (product_id, kind, storage_key, file_name, mime_type, size_bytes, width, height, sequence, is_primary, rendition_status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9,
+				$2 = 'image' AND NOT EXISTS (SELECT 1 FROM product_media WHERE product_id = $1 AND is_primary),
+				CASE WHEN $2 = 'image' THEN 'pending' ELSE 'ready' END)
+			RETURNING id, is_primary, created_at, rendition_status`
+		var renditionStatus string
+		if err := tx.QueryRow(ctx, sqlQuery, media.ProductID, string(media.Kind), media.StorageKey, media.FileName, media.MimeType,
+			media.SizeBytes, width, height, media.Sequence).Scan(&media.ID, &media.Primary, &media.CreatedAt, &renditionStatus); err != nil {
+			slog.Error("failed to insert product media", "cause", err)
+			return err
+		}
+		media.RenditionStatus = bo.RenditionStatus(renditionStatus)
+
+		return nil
+	})
+}
+
+func (s *productMediaStore) UpdateProductMedia(ctx context.Context, updateMedia bo.ProductMediaUpdate) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		updateMap := map[string]interface{}{}
+		if updateMedia.Sequence != nil {
+			updateMap["sequence"] = *updateMedia.Sequence
+		}
+		if updateMedia.Primary != nil {
+			updateMap["is_primary"] = *updateMedia.Primary
+		}
+		if len(updateMap) < 1 {
+			slog.Debug("empty update for product media", slog.Int64("id", updateMedia.ID))
+			return errors.New("empty update for product media")
+		}
+
+		var kind string
+		if err := tx.QueryRow(ctx, `SELECT kind FROM product_media WHERE id = $1 AND product_id = $2 FOR UPDATE`,
+			updateMedia.ID, updateMedia.ProductID).Scan(&kind); err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrProductMediaNotFound
+			}
+			slog.Error("failed to look up product media", slog.Int64("id", updateMedia.ID), "cause", err)
+			return err
+		}
+
+		if updateMedia.Primary != nil && *updateMedia.Primary {
+			if bo.MediaKind(kind) != bo.MediaImage {
+				return fmt.Errorf("%w: only an image can be the primary image", bo.ErrInvalidProductMedia)
+			}
+			if _, err := tx.Exec(ctx, `UPDATE product_media SET is_primary = FALSE WHERE product_id = $1 AND is_primary AND id <> $2`,
+				updateMedia.ProductID, updateMedia.ID); err != nil {
+				slog.Error("failed to unset the primary image", slog.Int64("productID", updateMedia.ProductID), "cause", err)
+				return err
+			}
+		}
+
+		sqlQuery, arguments := sqlbuilder.Update("product_media").
+			Set(updateMap).
+			Where("id = ?", updateMedia.ID).
+			Build()
+		if _, err := tx.Exec(ctx, sqlQuery, arguments...); err != nil {
+			slog.Error("failed to update product media in database", "cause", err)
+			return fmt.Errorf("failed to update product media in database: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteProductMedia makes the next image the primary one when the primary image is deleted,
+// the deleted media has its renditions so their files can be removed too
+func (s *productMediaStore) DeleteProductMedia(ctx context.Context, productID, mediaID int64) (bo.ProductMedia, error) {
+	var media bo.ProductMedia
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		renditions, err := queryProductMediaRenditions(ctx, tx, mediaID)
+		if err != nil {
+			slog.Error("failed to list product media renditions", slog.Int64("mediaID", mediaID), "cause", err)
+			return err
+		}
+
+		sqlQuery := fmt.Sprintf("DELETE FROM product_media WHERE id = $1 AND product_id = $2 RETURNING %s", strings.Join(productMediaFields, ","))
+		deleted, err := scanProductMedia(tx.QueryRow(ctx, sqlQuery, mediaID, productID))
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrProductMediaNotFound
+			}
+			slog.Error("failed to delete product media", slog.Int64("mediaID", mediaID), "cause", err)
+			return err
+		}
+		media = deleted
+		media.Renditions = renditions[media.ID]
+
+		if media.Primary {
+			if _, err := tx.Exec(ctx, `UPDATE product_media SET is_primary = TRUE WHERE id = (
+					SELECT id FROM product_media WHERE product_id = $1 AND kind = 'image' ORDER BY sequence ASC, id ASC LIMIT 1
+				)`, productID); err != nil {
+				slog.Error("failed to promote the next primary image", slog.Int64("productID", productID), "cause", err)
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return bo.ProductMedia{}, err
+	}
+
+	return media, nil
+}
+
+func (s *productMediaStore) ListProductMedia(ctx context.Context, productID int64) (bo.ProductMediaCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	dbQuery := fmt.Sprintf("SELECT %s FROM product_media WHERE product_id = $1 ORDER BY %s", strings.Join(productMediaFields, ","), productMediaOrder)
+	rows, err := conn.Query(ctx, dbQuery, productID)
+	if err != nil {
+		slog.Error("failed to list product media", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	mediaCollection := bo.ProductMediaCollection{}
+	for rows.Next() {
+		media, err := scanProductMedia(rows)
+		if err != nil {
+			slog.Error("failed to scan product media row", "cause", err)
+			return nil, err
+		}
+		mediaCollection = append(mediaCollection, media)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+	rows.Close()
+
+	mediaIDs := make([]int64, len(mediaCollection))
+	for i, media := range mediaCollection {
+		mediaIDs[i] = media.ID
+	}
+	renditions, err := queryProductMediaRenditions(ctx, conn, mediaIDs...)
+	if err != nil {
+		slog.Error("failed to list product media renditions", slog.Int64("productID", productID), "cause", err)
+		return nil, err
+	}
+	for i := range mediaCollection {
+		mediaCollection[i].Renditions = renditions[mediaCollection[i].ID]
+	}
+
+	return mediaCollection, nil
+}
+
+// ClaimPendingRenditions skips the images claimed by other workers, a claim older than staleAfter
+// is from a worker which stopped before finishing the renditions
+func (s *productMediaStore) ClaimPendingRenditions(ctx context.Context, limit int, staleAfter time.Duration) (bo.ProductMediaCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	dbQuery := fmt.Sprintf(`UPDATE product_media SET rendition_status = 'processing', rendition_claimed_at = NOW()
+		WHERE id IN (
+			SELECT id FROM product_media
+			WHERE kind = 'image' AND (rendition_status = 'pending'
+				OR (rendition_status = 'processing' AND rendition_claimed_at < NOW() - $2 * INTERVAL '1 second'))
+			ORDER BY id ASC LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s`, strings.Join(productMediaFields, ","))
+	rows, err := conn.Query(ctx, dbQuery, limit, staleAfter.Seconds())
+	if err != nil {
+		slog.Error("failed to claim pending product media renditions", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	mediaCollection := bo.ProductMediaCollection{}
+	for rows.Next() {
+		media, err := scanProductMedia(rows)
+		if err != nil {
+			slog.Error("failed to scan product media row", "cause", err)
+			return nil, err
+		}
+		mediaCollection = append(mediaCollection, media)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return mediaCollection, nil
+}
+
+func (s *productMediaStore) SaveProductMediaRenditions(ctx context.Context, mediaID int64, renditions []bo.ProductMediaRendition) ([]string, error) {
+	var staleKeys []string
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		// the media may have been deleted while its renditions were generated
+		var id int64
+		if err := tx.QueryRow(ctx, `SELECT id FROM product_media WHERE id = $1 FOR UPDATE`, mediaID).Scan(&id); err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrProductMediaNotFound
+			}
+			slog.Error("failed to lock product media", slog.Int64("mediaID", mediaID), "cause", err)
+			return err
+		}
+
+		rows, err := tx.Query(ctx, `DELETE FROM product_media_renditions WHERE media_id = $1 RETURNING storage_key`, mediaID)
+		if err != nil {
+			slog.Error("failed to delete previous product media renditions", slog.Int64("mediaID", mediaID), "cause", err)
+			return err
+		}
+		previousKeys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			slog.Error("failed to delete previous product media renditions", slog.Int64("mediaID", mediaID), "cause", err)
+			return err
+		}
+
+		keys := make(map[string]bool, len(renditions))
+		for _, rendition := range renditions {
+			keys[rendition.StorageKey] = true
+			if _, err := tx.Exec(ctx, `INSERT INTO product_media_renditions (media_id, name, format, storage_key, width, height, size_bytes)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+				mediaID, rendition.Name, rendition.Format, rendition.StorageKey, rendition.Width, rendition.Height, rendition.SizeBytes); err != nil {
+				slog.Error("failed to save product media rendition", slog.Int64("mediaID", mediaID), "cause", err)
+				return err
+			}
+		}
+		for _, key := range previousKeys {
+			if !keys[key] {
+				staleKeys = append(staleKeys, key)
+			}
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE product_media SET rendition_status = 'ready', rendition_error = NULL, rendition_claimed_at = NULL
+			WHERE id = $1`, mediaID); err != nil {
+			slog.Error("failed to update product media rendition status", slog.Int64("mediaID", mediaID), "cause", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return staleKeys, nil
+}
+
+func (s *productMediaStore) FailProductMediaRenditions(ctx context.Context, mediaID int64, reason string) error {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `UPDATE product_media SET rendition_status = 'failed', rendition_error = $2, rendition_claimed_at = NULL
+		WHERE id = $1`, mediaID, reason); err != nil {
+		slog.Error("failed to update product media rendition status", slog.Int64("mediaID", mediaID), "cause", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *productMediaStore) RequeueProductMediaRenditions(ctx context.Context, productID, mediaID int64) error {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	tag, err := conn.Exec(ctx, `UPDATE product_media SET rendition_status = 'pending', rendition_error = NULL, rendition_claimed_at = NULL
+		WHERE id = $1 AND product_id = $2 AND kind = 'image'`, mediaID, productID)
+	if err != nil {
+		slog.Error("failed to requeue product media renditions", slog.Int64("mediaID", mediaID), "cause", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return bo.ErrProductMediaNotFound
+	}
+
+	return nil
+}
+
+// queryProductImages returns the images of the products by product id, the primary image first
+func queryProductImages(ctx context.Context, q querier, productIDs ...int64) (map[int64][]bo.ProductImage, error) {
+	images := make(map[int64][]bo.ProductImage, len(productIDs))
+	if len(productIDs) == 0 {
+		return images, nil
+	}
+
+	rows, err := q.Query(ctx, `SELECT product_id, id, storage_key, is_primary FROM product_media
+		WHERE product_id = ANY($1) AND kind = 'image' ORDER BY product_id, `+productMediaOrder, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mediaIDs []int64
+	for rows.Next() {
+		var (
+			productID int64
+			image     bo.ProductImage
+		)
+		if err := rows.Scan(&productID, &image.ID, &image.StorageKey, &image.Primary); err != nil {
+			return nil, err
+		}
+		images[productID] = append(images[productID], image)
+		mediaIDs = append(mediaIDs, image.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	renditions, err := queryProductMediaRenditions(ctx, q, mediaIDs...)
+	if err != nil {
+		return nil, err
+	}
+	for _, productImages := range images {
+		for i := range productImages {
+			productImages[i].Renditions = renditions[productImages[i].ID]
+		}
+	}
+
+	return images, nil
+}
+
+// queryProductMediaRenditions returns the renditions of the media by media id
+func queryProductMediaRenditions(ctx context.Context, q querier, mediaIDs ...int64) (map[int64][]bo.ProductMediaRendition, error) {
+	renditions := make(map[int64][]bo.ProductMediaRendition, len(mediaIDs))
+	if len(mediaIDs) == 0 {
+		return renditions, nil
+	}
+
+	rows, err := q.Query(ctx, `SELECT media_id, name, format, storage_key, width, height, size_bytes FROM product_media_renditions
+		WHERE media_id = ANY($1) ORDER BY media_id, width ASC, name ASC, format ASC`, mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			mediaID   int64
+			rendition bo.ProductMediaRendition
+		)
+		if err := rows.Scan(&mediaID, &rendition.Name, &rendition.Format, &rendition.StorageKey, &rendition.Width, &rendition.Height, &rendition.SizeBytes); err != nil {
+			return nil, err
+		}
+		renditions[mediaID] = append(renditions[mediaID], rendition)
+	}
+
+	return renditions, rows.Err()
+}
@@ -0,0 +1,344 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type productPriceStore struct {
+	dbPool *pgxpool.Pool
+}
+
+var productPriceScheduleFields = []string{
+	"id",
+	"product_id",
+	"unit_price",
+	"discount_price",
+	"starts_at",
+	"ends_at",
+	"status",
+	"previous_unit_price",
+	"previous_discount_price",
+	"applied_at",
+	"reverted_at",
+	"created_at",
+}
+
+func scanProductPriceSchedule(row pgx.Row) (bo.ProductPriceSchedule, error) {
+	var (
+		schedule              bo.ProductPriceSchedule
+		unitPrice             decimal.NullDecimal
+		discountPrice         decimal.NullDecimal
+		endsAt                sql.NullTime
+		status                string
+		previousUnitPrice     decimal.NullDecimal
+		previousDiscountPrice decimal.NullDecimal
+		appliedAt             sql.NullTime
+		revertedAt            sql.NullTime
+	)
+	if err := row.Scan(&schedule.ID, &schedule.ProductID, &unitPrice, &discountPrice, &schedule.StartsAt, &endsAt, &status,
+		&previousUnitPrice, &previousDiscountPrice, &appliedAt, &revertedAt, &schedule.CreatedAt); err != nil {
+		return bo.ProductPriceSchedule{}, err
+	}
+	if unitPrice.Valid {
+		schedule.UnitPrice = &unitPrice.Decimal
+	}
+	if discountPrice.Valid {
+		schedule.DiscountPrice = &discountPrice.Decimal
+	}
+	schedule.EndsAt = endsAt.Time
+	schedule.Status = bo.PriceScheduleStatus(status)
+	schedule.PreviousUnitPrice = previousUnitPrice.Decimal
+	schedule.PreviousDiscountPrice = previousDiscountPrice.Decimal
+	schedule.AppliedAt = appliedAt.Time
+	schedule.RevertedAt = revertedAt.Time
+	return schedule, nil
+}
+
+func getProductPriceSchedule(ctx context.Context, q querier, productID, scheduleID int64) (bo.ProductPriceSchedule, error) {
+	dbQuery := fmt.Sprintf("SELECT %s FROM product_price_schedules WHERE id = $1 AND product_id = $2", strings.Join(productPriceScheduleFields, ","))
+	schedule, err := scanProductPriceSchedule(q.QueryRow(ctx, dbQuery, scheduleID, productID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return bo.ProductPriceSchedule{}, bo.ErrPriceScheduleNotFound
+		}
+		slog.Error("failed to scan product price schedule table row", "cause", err)
+		return bo.ProductPriceSchedule{}, err
+	}
+	return schedule, nil
+}
+
+func (s *productPriceStore) GetProductPriceTimeline(ctx context.Context, productID int64) (bo.ProductPriceTimeline, error) {
+	timeline := bo.ProductPriceTimeline{ProductID: productID}
+
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		var discountPrice decimal.NullDecimal
+		err := tx.QueryRow(ctx, `SELECT unit_price, discount_price FROM products WHERE id = $1`, productID).
+			Scan(&timeline.UnitPrice, &discountPrice)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrProductNotFound
+			}
+			return err
+		}
+		timeline.DiscountPrice = discountPrice.Decimal
+
+		rows, err := tx.Query(ctx, `SELECT id, product_id, unit_price, discount_price, previous_unit_price, previous_discount_price,
+			reason, price_schedule_id, changed_at
+			FROM product_price_history WHERE product_id = $1 ORDER BY changed_at ASC, id ASC`, productID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				change                bo.ProductPriceChange
+				discountPrice         decimal.NullDecimal
+				previousUnitPrice     decimal.NullDecimal
+				previousDiscountPrice decimal.NullDecimal
+				reason                string
+				scheduleID            sql.NullInt64
+			)
+			if err := rows.Scan(&change.ID, &change.ProductID, &change.UnitPrice, &discountPrice, &previousUnitPrice,
+				&previousDiscountPrice, &reason, &scheduleID, &change.ChangedAt); err != nil {
+				return err
+			}
+			change.DiscountPrice = discountPrice.Decimal
+			change.PreviousUnitPrice = previousUnitPrice.Decimal
+			change.PreviousDiscountPrice = previousDiscountPrice.Decimal
+			change.Reason = bo.PriceChangeReason(reason)
+			change.PriceScheduleID = scheduleID.Int64
+			timeline.History = append(timeline.History, change)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		rows.Close()
+
+		dbQuery := fmt.Sprintf("SELECT %s FROM product_price_schedules WHERE product_id = $1 ORDER BY starts_at ASC, id ASC",
+			strings.Join(productPriceScheduleFields, ","))
+		rows, err = tx.Query(ctx, dbQuery, productID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			schedule, err := scanProductPriceSchedule(rows)
+			if err != nil {
+				return err
+			}
+			timeline.Schedules = append(timeline.Schedules, schedule)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		if err != bo.ErrProductNotFound {
+			slog.Error("failed to read product price timeline", slog.Int64("productID", productID), "cause", err)
+		}
+		return bo.ProductPriceTimeline{}, err
+	}
+
+	return timeline, nil
+}
+
+func (s *productPriceStore) CreateProductPriceSchedule(ctx context.Context, schedule *bo.ProductPriceSchedule) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		// the product row serializes the schedules of a product, so two of them cannot overlap
+		var productID int64
+		if err := tx.QueryRow(ctx, `SELECT id FROM products WHERE id = $1 FOR UPDATE`, schedule.ProductID).Scan(&productID); err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrProductNotFound
+			}
+			return err
+		}
+
+		endsAt := sql.NullTime{Time: schedule.EndsAt, Valid: !schedule.EndsAt.IsZero()}
+		// a schedule with an end covers [starts_at, ends_at), a permanent one only its start
+		var overlap bool
+		err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM product_price_schedules
+			WHERE product_id = $1 AND status IN ('pending', 'active')
+			AND tstzrange(starts_at, COALESCE(ends_at, starts_at), CASE WHEN ends_at IS NULL THEN '[]' ELSE '[)' END)
+				&& tstzrange($2::TIMESTAMPTZ, COALESCE($3::TIMESTAMPTZ, $2::TIMESTAMPTZ), CASE WHEN $3::TIMESTAMPTZ IS NULL THEN '[]' ELSE '[)' END))`,
+			schedule.ProductID, schedule.StartsAt, endsAt).Scan(&overlap)
+		if err != nil {
+			slog.Error("failed to look for overlapping price schedules", "cause", err)
+			return err
+		}
+		if overlap {
+			return bo.ErrPriceScheduleOverlap
+		}
+
+		var status string
+		err = tx.QueryRow(ctx, `INSERT INTO product_price_schedules (product_id, unit_price, discount_price, starts_at, ends_at)
+			VALUES ($1, $2, $3, $4, $5) RETURNING id, status, created_at`,
+			schedule.ProductID, schedule.UnitPrice, schedule.DiscountPrice, schedule.StartsAt, endsAt,
+		).Scan(&schedule.ID, &status, &schedule.CreatedAt)
+		if err != nil {
+			slog.Error("failed to insert product price schedule", "cause", err)
+			return fmt.Errorf("failed to insert product price schedule: %w", err)
+		}
+		schedule.Status = bo.PriceScheduleStatus(status)
+
+		return nil
+	})
+}
+
+func (s *productPriceStore) CancelProductPriceSchedule(ctx context.Context, productID, scheduleID int64) (bo.ProductPriceSchedule, error) {
+	var schedule bo.ProductPriceSchedule
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		var status string
+		err := tx.QueryRow(ctx, `SELECT status FROM product_price_schedules WHERE id = $1 AND product_id = $2 FOR UPDATE`,
+			scheduleID, productID).Scan(&status)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrPriceScheduleNotFound
+			}
+			return err
+		}
+
+		switch bo.PriceScheduleStatus(status) {
+		case bo.PriceSchedulePending:
+		case bo.PriceScheduleActive:
+			if err := revertProductPriceSchedule(ctx, tx, scheduleID); err != nil {
+				return err
+			}
+		default:
+			return bo.ErrPriceScheduleNotCancellable
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE product_price_schedules SET status = $1 WHERE id = $2`,
+			string(bo.PriceScheduleCancelled), scheduleID); err != nil {
+			slog.Error("failed to cancel product price schedule", slog.Int64("scheduleID", scheduleID), "cause", err)
+			return fmt.Errorf("failed to cancel product price schedule: %w", err)
+		}
+
+		schedule, err = getProductPriceSchedule(ctx, tx, productID, scheduleID)
+		return err
+	})
+	if err != nil {
+		return bo.ProductPriceSchedule{}, err
+	}
+
+	return schedule, nil
+}
+
+func (s *productPriceStore) ApplyDueProductPriceSchedules(ctx context.Context) (int64, error) {
+	var count int64
+	for {
+		found, err := s.applyNextPriceSchedule(ctx)
+		if err != nil {
+			return count, err
+		}
+		if !found {
+			return count, nil
+		}
+		count++
+	}
+}
+
+// applyNextPriceSchedule goes through the due schedule whose start or end came first, in its own
+// transaction. The schedules locked by another instance are left to it.
+func (s *productPriceStore) applyNextPriceSchedule(ctx context.Context) (bool, error) {
+	found := false
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		var (
+			scheduleID int64
+			status     string
+			ended      bool
+		)
+		err := tx.QueryRow(ctx, `SELECT id, status, COALESCE(ends_at <= CURRENT_TIMESTAMP, false)
+			FROM product_price_schedules
+			WHERE (status = 'pending' AND starts_at <= CURRENT_TIMESTAMP) OR (status = 'active' AND ends_at <= CURRENT_TIMESTAMP)
+			ORDER BY CASE WHEN status = 'active' THEN ends_at ELSE starts_at END ASC, id ASC
+			LIMIT 1 FOR UPDATE SKIP LOCKED`).Scan(&scheduleID, &status, &ended)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return nil
+			}
+			slog.Error("failed to claim a due price schedule", "cause", err)
+			return err
+		}
+		found = true
+
+		switch {
+		case bo.PriceScheduleStatus(status) == bo.PriceScheduleActive:
+			if err := revertProductPriceSchedule(ctx, tx, scheduleID); err != nil {
+				return err
+			}
+			_, err = tx.Exec(ctx, `UPDATE product_price_schedules SET status = $1 WHERE id = $2`, string(bo.PriceScheduleCompleted), scheduleID)
+		case ended:
+			// the whole schedule went by while no scheduler was running
+			_, err = tx.Exec(ctx, `UPDATE product_price_schedules SET status = $1 WHERE id = $2`, string(bo.PriceScheduleExpired), scheduleID)
+		default:
+			err = applyProductPriceSchedule(ctx, tx, scheduleID)
+		}
+		if err != nil {
+			slog.Error("failed to go through price schedule", slog.Int64("scheduleID", scheduleID), "cause", err)
+			return err
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+// setPriceChangeReason tells the price history trigger which schedule changes the prices in the transaction
+func setPriceChangeReason(ctx context.Context, tx pgx.Tx, reason bo.PriceChangeReason, scheduleID int64) error {
+	_, err := tx.Exec(ctx, `SELECT set_config('techno_store.price_reason', $1, true), set_config('techno_store.price_schedule_id', $2, true)`,
+		string(reason), strconv.FormatInt(scheduleID, 10))
+	return err
+}
+
+// applyProductPriceSchedule keeps the prices of the product in the schedule and sets the scheduled ones
+func applyProductPriceSchedule(ctx context.Context, tx pgx.Tx, scheduleID int64) error {
+	if err := setPriceChangeReason(ctx, tx, bo.PriceScheduled, scheduleID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `UPDATE product_price_schedules s
+		SET status = CASE WHEN s.ends_at IS NULL THEN 'completed' ELSE 'active' END, applied_at = CURRENT_TIMESTAMP,
+			previous_unit_price = p.unit_price, previous_discount_price = p.discount_price
+		FROM products p WHERE s.id = $1 AND p.id = s.product_id`, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE products p
+		SET unit_price = COALESCE(s.unit_price, p.unit_price),
+			discount_price = CASE WHEN s.discount_price IS NULL THEN p.discount_price ELSE s.discount_price END
+		FROM product_price_schedules s WHERE s.id = $1 AND p.id = s.product_id`, scheduleID)
+	return err
+}
+
+// revertProductPriceSchedule puts back the prices replaced by the schedule, each one only when it
+// still is the scheduled price, a price changed since the schedule was applied is kept
+func revertProductPriceSchedule(ctx context.Context, tx pgx.Tx, scheduleID int64) error {
+	if err := setPriceChangeReason(ctx, tx, bo.PriceReverted, scheduleID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `UPDATE products p
+		SET unit_price = CASE WHEN s.unit_price IS NOT NULL AND p.unit_price = s.unit_price
+				THEN s.previous_unit_price ELSE p.unit_price END,
+			discount_price = CASE WHEN s.discount_price IS NOT NULL AND p.discount_price IS NOT DISTINCT FROM s.discount_price
+				THEN s.previous_discount_price ELSE p.discount_price END
+		FROM product_price_schedules s WHERE s.id = $1 AND p.id = s.product_id`, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE product_price_schedules SET reverted_at = CURRENT_TIMESTAMP WHERE id = $1`, scheduleID)
+	return err
+}
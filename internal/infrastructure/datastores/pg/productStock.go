@@ -6,10 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"strconv"
 	"strings"
 
 	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -19,10 +19,22 @@ type productStockStore struct {
 	dbPool *pgxpool.Pool
 }
 
+// productStockAggregate sums the available stock, on hand minus live reservations,
+// of a product over all its variants and warehouses
+const productStockAggregate = `(SELECT ps.product_id, SUM(COALESCE(ps.stock_quantity, 0) - COALESCE(rs.reserved_quantity, 0)) AS available_quantity
+	FROM product_stocks ps LEFT JOIN ` + reservedQuantityAggregate + ` rs ON rs.product_stock_id = ps.id
+	GROUP BY ps.product_id)`
+
+// productStockReservedJoin adds the reserved_quantity of live reservations to a product_stocks select
+const productStockReservedJoin = ` LEFT JOIN ` + reservedQuantityAggregate + ` rs ON rs.product_stock_id = product_stocks.id`
+
 var productStockFields = []string{
 	"id",
 	"product_id",
+	"variant_id",
+	"warehouse_id",
 	"stock_quantity",
+	"version",
 	"updated_at",
 }
 
@@ -30,8 +42,12 @@ func (s *productStockStore) GetProductStockByID(ctx context.Context, productStoc
 	var (
 		id            sql.NullInt64
 		productID     sql.NullInt64
+		variantID     sql.NullInt64
+		warehouseID   sql.NullInt64
 		stockQuantity sql.NullInt64
+		version       sql.NullInt64
 		updatedAt     sql.NullTime
+		reserved      sql.NullInt64
 	)
 
 	conn, err := s.dbPool.Acquire(ctx)
@@ -40,10 +56,10 @@ func (s *productStockStore) GetProductStockByID(ctx context.Context, productStoc
 	}
 	defer conn.Release()
 
-	dbQuery := fmt.Sprintf("SELECT %s FROM product_stocks WHERE id = $1", strings.Join(productStockFields, ","))
+	dbQuery := fmt.Sprintf("SELECT %s, COALESCE(rs.reserved_quantity, 0) FROM product_stocks%s WHERE product_stocks.id = $1", strings.Join(productStockFields, ","), productStockReservedJoin)
 	row := conn.QueryRow(ctx, dbQuery, productStockID)
 
-	if err = row.Scan(&id, &productID, &stockQuantity, &updatedAt); err != nil {
+	if err = row.Scan(&id, &productID, &variantID, &warehouseID, &stockQuantity, &version, &updatedAt, &reserved); err != nil {
 		if err == pgx.ErrNoRows {
 			slog.Error("product stock id does not exist", slog.Int64("id", productStockID))
 			return bo.ProductStock{}, bo.ErrProductStockNotFound
@@ -53,10 +69,14 @@ func (s *productStockStore) GetProductStockByID(ctx context.Context, productStoc
 	}
 
 	return bo.ProductStock{
-		ID:            id.Int64,
-		ProductID:     productID.Int64,
-		StockQuantity: stockQuantity.Int64,
-		UpdatedAt:     updatedAt.Time,
+		ID:               id.Int64,
+		ProductID:        productID.Int64,
+		VariantID:        variantID.Int64,
+		WarehouseID:      warehouseID.Int64,
+		StockQuantity:    stockQuantity.Int64,
+		Version:          version.Int64,
+		UpdatedAt:        updatedAt.Time,
+		ReservedQuantity: reserved.Int64,
 	}, nil
 }
 
@@ -67,33 +87,29 @@ func (s *productStockStore) CreateProductStock(ctx context.Context, productStock
 		return fmt.Errorf("empty core insert for product stock")
 	}
 
-	start := 1
-	arguments := make([]interface{}, 0, len(insertMap))
-	fields := []string{}
-	placeholders := []string{}
-
-	for field, v := range insertMap {
-		fields = append(fields, field)
-		arguments = append(arguments, v)
-		placeholders = append(placeholders, "$"+strconv.Itoa(start))
-		start++
-	}
-
-	sqlQuery := fmt.Sprintf("INSERT INTO product_stocks(%s)VALUES (%s) RETURNING id", strings.Join(fields, ","), strings.Join(placeholders, ","))
+	sqlQuery, arguments := sqlbuilder.Insert("product_stocks").Values(insertMap).Returning("id", "warehouse_id").Build()
 
-	conn, err := s.dbPool.Acquire(ctx)
-	if err != nil {
-		return err
-	}
-	defer conn.Release()
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		var id, warehouseID sql.NullInt64
+		if err := tx.QueryRow(ctx, sqlQuery, arguments...).Scan(&id, &warehouseID); err != nil {
+			return err
+		}
 
-	var id sql.NullInt64
-	if err := conn.QueryRow(ctx, sqlQuery, arguments...).Scan(&id); err != nil {
-		return err
-	}
+		productStock.ID = id.Int64
+		productStock.WarehouseID = warehouseID.Int64
+		if productStock.StockQuantity == 0 {
+			return nil
+		}
 
-	productStock.ID = id.Int64
-	return nil
+		return insertStockMovement(ctx, tx, &bo.StockMovement{
+			ProductID:     productStock.ProductID,
+			VariantID:     productStock.VariantID,
+			WarehouseID:   productStock.WarehouseID,
+			QuantityDelta: productStock.StockQuantity,
+			BalanceAfter:  productStock.StockQuantity,
+			Reason:        bo.StockMovementReceipt,
+		})
+	})
 }
 
 func buildProductStockInsertMap(i bo.ProductStock) map[string]interface{} {
@@ -103,6 +119,15 @@ func buildProductStockInsertMap(i bo.ProductStock) map[string]interface{} {
 		switch value {
 		case "product_id":
 			insertedFields[value] = i.ProductID
+		case "variant_id":
+			if i.VariantID != 0 {
+				insertedFields[value] = i.VariantID
+			}
+		case "warehouse_id":
+			// The column defaults to the main warehouse
+			if i.WarehouseID != 0 {
+				insertedFields[value] = i.WarehouseID
+			}
 		case "stock_quantity":
 			insertedFields[value] = i.StockQuantity
 		}
@@ -119,37 +144,172 @@ func (s *productStockStore) UpdateProductStock(ctx context.Context, updateProduc
 			return errors.New("empty core update for product stock")
 		}
 
-		sqlQuery := "UPDATE product_stocks SET "
-		start := 1
-		arguments := make([]interface{}, 0, len(updateMap)+1)
+		reason := updateProductStock.Reason
+		if reason == "" {
+			reason = bo.StockMovementAdjustment
+		}
+		if !reason.Valid() {
+			return bo.ErrInvalidStockMovementReason
+		}
 
-		for k, v := range updateMap {
-			sqlQuery = sqlQuery + k + "=$" + strconv.Itoa(start)
-			arguments = append(arguments, v)
-			if start < len(updateMap) {
-				sqlQuery = sqlQuery + ", "
+		if updateProductStock.WarehouseID == 0 {
+			warehouseID, err := defaultStockWarehouse(ctx, tx, updateProductStock.ProductID, updateProductStock.VariantID)
+			if err != nil {
+				return err
 			}
+			updateProductStock.WarehouseID = warehouseID
+		}
 
-			start++
+		// Lock the row before reading its reservations, a reservation created
+		// meanwhile would otherwise not be seen by the update
+		if _, err := lockProductStockRows(ctx, tx, updateProductStock.ProductID, updateProductStock.VariantID, updateProductStock.WarehouseID); err != nil {
+			return err
 		}
 
-		sqlQuery = sqlQuery + fmt.Sprintf(" WHERE product_id = $%d", start)
-		arguments = append(arguments, updateProductStock.ProductID)
+		sqlQuery, arguments, err := buildProductStockUpdateQuery(updateProductStock, updateMap)
+		if err != nil {
+			return err
+		}
 
-		commandTag, err := tx.Exec(ctx, sqlQuery, arguments...)
+		rows, err := tx.Query(ctx, sqlQuery, arguments...)
 		if err != nil {
 			slog.Error("failed to update product stock in database", "cause", err)
 			return fmt.Errorf("failed to update product stock in database: %w", err)
 		}
 
-		if commandTag.RowsAffected() == 0 {
-			slog.Warn("no rows affected when updating product stock", slog.Int64("ProductID", updateProductStock.ProductID))
+		var movements []bo.StockMovement
+		for rows.Next() {
+			var (
+				variantID   sql.NullInt64
+				warehouseID sql.NullInt64
+				newQuantity sql.NullInt64
+				oldQuantity sql.NullInt64
+				reserved    sql.NullInt64
+			)
+			if err := rows.Scan(&variantID, &warehouseID, &newQuantity, &oldQuantity, &reserved); err != nil {
+				rows.Close()
+				slog.Error("failed to scan updated product stock row", "cause", err)
+				return err
+			}
+			// The units of live reservations stay on hand, the transaction is rolled back
+			if newQuantity.Int64 < reserved.Int64 {
+				rows.Close()
+				return bo.ErrInsufficientStock
+			}
+			if newQuantity.Int64 == oldQuantity.Int64 {
+				continue
+			}
+			movements = append(movements, bo.StockMovement{
+				ProductID:     updateProductStock.ProductID,
+				VariantID:     variantID.Int64,
+				WarehouseID:   warehouseID.Int64,
+				QuantityDelta: newQuantity.Int64 - oldQuantity.Int64,
+				BalanceAfter:  newQuantity.Int64,
+				Reason:        reason,
+				Reference:     updateProductStock.Reference,
+				CreatedBy:     updateProductStock.ChangedBy,
+			})
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			slog.Error("failed to update product stock in database", "cause", err)
+			return fmt.Errorf("failed to update product stock in database: %w", err)
+		}
+
+		if rows.CommandTag().RowsAffected() == 0 {
+			slog.Error("product stock location does not exist", slog.Int64("productID", updateProductStock.ProductID),
+				slog.Int64("variantID", updateProductStock.VariantID), slog.Int64("warehouseID", updateProductStock.WarehouseID))
+			return bo.ErrProductStockNotFound
+		}
+
+		for i := range movements {
+			if err := insertStockMovement(ctx, tx, &movements[i]); err != nil {
+				return err
+			}
 		}
 
 		return nil
 	})
 }
 
+// mainWarehouseCode is the code of the warehouse new stock rows default to
+const mainWarehouseCode = "main"
+
+// defaultStockWarehouse picks the warehouse of an absolute update which names none:
+// the only stock row of the product (variant), else its row in the main warehouse.
+// A product stocked in several warehouses but not the main one is ambiguous.
+func defaultStockWarehouse(ctx context.Context, tx pgx.Tx, productID, variantID int64) (int64, error) {
+	rows, err := tx.Query(ctx, `SELECT ps.warehouse_id, w.code FROM product_stocks ps
+		INNER JOIN warehouses w ON w.id = ps.warehouse_id
+		WHERE ps.product_id = $1 AND ps.variant_id IS NOT DISTINCT FROM $2`,
+		productID, sql.NullInt64{Int64: variantID, Valid: variantID != 0})
+	if err != nil {
+		slog.Error("failed to list product stock warehouses", "cause", err)
+		return 0, err
+	}
+	defer rows.Close()
+
+	var warehouseIDs []int64
+	var mainWarehouseID int64
+	for rows.Next() {
+		var (
+			warehouseID sql.NullInt64
+			code        sql.NullString
+		)
+		if err := rows.Scan(&warehouseID, &code); err != nil {
+			slog.Error("failed to scan product stock warehouse row", "cause", err)
+			return 0, err
+		}
+		warehouseIDs = append(warehouseIDs, warehouseID.Int64)
+		if code.String == mainWarehouseCode {
+			mainWarehouseID = warehouseID.Int64
+		}
+	}
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return 0, err
+	}
+
+	switch {
+	case len(warehouseIDs) == 0:
+		return 0, bo.ErrProductStockNotFound
+	case len(warehouseIDs) == 1:
+		return warehouseIDs[0], nil
+	case mainWarehouseID != 0:
+		return mainWarehouseID, nil
+	}
+	return 0, bo.ErrStockLocationRequired
+}
+
+// buildProductStockUpdateQuery sets the single stock row of the product at the
+// update's warehouse, for its variant or for the product itself when it has
+// none. The row is locked and its previous quantity kept for the ledger, along
+// with its reserved units which the new quantity may not go below.
+func buildProductStockUpdateQuery(u bo.ProductStockUpdate, updateMap map[string]interface{}) (string, []any, error) {
+	if u.WarehouseID == 0 {
+		return "", nil, bo.ErrStockLocationRequired
+	}
+
+	conditions := []string{"product_id = ?", "warehouse_id = ?"}
+	lockArgs := []any{u.ProductID, u.WarehouseID}
+	if u.VariantID != 0 {
+		conditions = append(conditions, "variant_id = ?")
+		lockArgs = append(lockArgs, u.VariantID)
+	} else {
+		conditions = append(conditions, "variant_id IS NULL")
+	}
+
+	sqlQuery, arguments := sqlbuilder.Update("product_stocks ps").
+		Set(updateMap).
+		SetRaw("version = ps.version + 1", "updated_at = CURRENT_TIMESTAMP").
+		From("(SELECT product_stocks.id, product_stocks.stock_quantity, COALESCE(rs.reserved_quantity, 0) AS reserved_quantity FROM product_stocks"+
+			productStockReservedJoin+" WHERE "+strings.Join(conditions, " AND ")+" FOR UPDATE OF product_stocks) old", lockArgs...).
+		Where("ps.id = old.id").
+		Returning("ps.variant_id", "ps.warehouse_id", "ps.stock_quantity", "old.stock_quantity", "old.reserved_quantity").
+		Build()
+	return sqlQuery, arguments, nil
+}
+
 func buildProductStockUpdateMap(u bo.ProductStockUpdate) map[string]interface{} {
 	updateFields := make(map[string]interface{})
 
@@ -160,31 +320,200 @@ func buildProductStockUpdateMap(u bo.ProductStockUpdate) map[string]interface{}
 	return updateFields
 }
 
+// AdjustProductStock applies a relative delta in a single UPDATE, so concurrent
+// adjustments never overwrite each other. The row is only changed when the result
+// stays non-negative, a decrease leaves the units of live reservations on hand,
+// and, if given, the expected version still matches.
+func (s *productStockStore) AdjustProductStock(ctx context.Context, adjustment bo.ProductStockAdjustment) (bo.ProductStock, error) {
+	var productStock bo.ProductStock
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		var err error
+		productStock, err = adjustStockInTx(ctx, tx, adjustment)
+		return err
+	})
+
+	return productStock, err
+}
+
+// adjustStockInTx is the guarded relative update behind AdjustProductStock, it
+// also records the matching stock movement within the caller's transaction.
+// A decrease may not take units held by live reservations.
+func adjustStockInTx(ctx context.Context, tx pgx.Tx, adjustment bo.ProductStockAdjustment) (bo.ProductStock, error) {
+	return adjustStock(ctx, tx, adjustment, false)
+}
+
+// adjustReservedStockInTx takes units off hand which are held by a reservation
+// being confirmed, so the reserved quantity does not guard the decrease.
+func adjustReservedStockInTx(ctx context.Context, tx pgx.Tx, adjustment bo.ProductStockAdjustment) (bo.ProductStock, error) {
+	return adjustStock(ctx, tx, adjustment, true)
+}
+
+func adjustStock(ctx context.Context, tx pgx.Tx, adjustment bo.ProductStockAdjustment, spendReserved bool) (bo.ProductStock, error) {
+	if adjustment.Delta == 0 {
+		return bo.ProductStock{}, bo.ErrInvalidStockAdjustment
+	}
+
+	reason := adjustment.Reason
+	if reason == "" {
+		reason = bo.StockMovementAdjustment
+	}
+	if !reason.Valid() {
+		return bo.ProductStock{}, bo.ErrInvalidStockMovementReason
+	}
+
+	var (
+		expectedVersion sql.NullInt64
+		id              sql.NullInt64
+		productID       sql.NullInt64
+		variantID       sql.NullInt64
+		warehouseID     sql.NullInt64
+		stockQuantity   sql.NullInt64
+		version         sql.NullInt64
+		updatedAt       sql.NullTime
+		reserved        sql.NullInt64
+	)
+	if adjustment.ExpectedVersion != nil {
+		expectedVersion = sql.NullInt64{Int64: *adjustment.ExpectedVersion, Valid: true}
+	}
+
+	// Lock the row before reading its reservations, a reservation created
+	// meanwhile would otherwise not be seen by the guard
+	guardReserved := adjustment.Delta < 0 && !spendReserved
+	if guardReserved {
+		if _, err := tx.Exec(ctx, `SELECT id FROM product_stocks WHERE id = $1 FOR UPDATE`, adjustment.ProductStockID); err != nil {
+			slog.Error("failed to lock product stock", slog.Int64("productStockID", adjustment.ProductStockID), "cause", err)
+			return bo.ProductStock{}, err
+		}
+	}
+
+	// The reserved units are returned as well, the available quantity is told from them
+	reservedQuantity := `COALESCE((SELECT rs.reserved_quantity FROM ` + reservedQuantityAggregate + ` rs
+		WHERE rs.product_stock_id = product_stocks.id), 0)`
+	sqlQuery := fmt.Sprintf(`UPDATE product_stocks
+		SET stock_quantity = COALESCE(stock_quantity, 0) + $1, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND COALESCE(stock_quantity, 0) + $1 >= 0 AND ($3::INT IS NULL OR version = $3)
+			AND (NOT $4::BOOLEAN OR COALESCE(stock_quantity, 0) + $1 - %s >= 0)
+		RETURNING %s, %s`, reservedQuantity, strings.Join(productStockFields, ","), reservedQuantity)
+	err := tx.QueryRow(ctx, sqlQuery, adjustment.Delta, adjustment.ProductStockID, expectedVersion, guardReserved).
+		Scan(&id, &productID, &variantID, &warehouseID, &stockQuantity, &version, &updatedAt, &reserved)
+	if err == pgx.ErrNoRows {
+		return bo.ProductStock{}, adjustmentFailure(ctx, tx, adjustment)
+	}
+	if err != nil {
+		slog.Error("failed to adjust product stock", slog.Int64("productStockID", adjustment.ProductStockID), "cause", err)
+		return bo.ProductStock{}, fmt.Errorf("failed to adjust product stock: %w", err)
+	}
+
+	productStock := bo.ProductStock{
+		ID:               id.Int64,
+		ProductID:        productID.Int64,
+		VariantID:        variantID.Int64,
+		WarehouseID:      warehouseID.Int64,
+		StockQuantity:    stockQuantity.Int64,
+		Version:          version.Int64,
+		UpdatedAt:        updatedAt.Time,
+		ReservedQuantity: reserved.Int64,
+	}
+
+	err = insertStockMovement(ctx, tx, &bo.StockMovement{
+		ProductID:     productStock.ProductID,
+		VariantID:     productStock.VariantID,
+		WarehouseID:   productStock.WarehouseID,
+		QuantityDelta: adjustment.Delta,
+		BalanceAfter:  productStock.StockQuantity,
+		Reason:        reason,
+		Reference:     adjustment.Reference,
+		CreatedBy:     adjustment.ChangedBy,
+	})
+	return productStock, err
+}
+
+// adjustmentFailure tells why a guarded adjustment did not match its stock row.
+func adjustmentFailure(ctx context.Context, tx pgx.Tx, adjustment bo.ProductStockAdjustment) error {
+	var stockQuantity, version sql.NullInt64
+	err := tx.QueryRow(ctx, `SELECT stock_quantity, version FROM product_stocks WHERE id = $1`, adjustment.ProductStockID).Scan(&stockQuantity, &version)
+	if err == pgx.ErrNoRows {
+		return bo.ErrProductStockNotFound
+	}
+	if err != nil {
+		slog.Error("failed to scan product stock table row", "cause", err)
+		return err
+	}
+
+	if adjustment.ExpectedVersion != nil && *adjustment.ExpectedVersion != version.Int64 {
+		return bo.ErrStockVersionConflict
+	}
+	return bo.ErrInsufficientStock
+}
+
 func (s *productStockStore) DeleteProductStock(ctx context.Context, productStockID int64) error {
 	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
-		sqlQuery := `DELETE FROM product_stocks WHERE id = $1`
-		if commandTag, err := tx.Exec(ctx, sqlQuery, productStockID); err != nil {
+		var (
+			productID     sql.NullInt64
+			variantID     sql.NullInt64
+			warehouseID   sql.NullInt64
+			stockQuantity sql.NullInt64
+		)
+
+		sqlQuery := `DELETE FROM product_stocks WHERE id = $1 RETURNING product_id, variant_id, warehouse_id, stock_quantity`
+		if err := tx.QueryRow(ctx, sqlQuery, productStockID).Scan(&productID, &variantID, &warehouseID, &stockQuantity); err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrProductStockNotFound
+			}
 			slog.Error("failed to delete product stock", slog.Int64("productStockID", productStockID), "cause", err)
 			return err
-		} else if commandTag.RowsAffected() == 0 {
-			return bo.ErrProductStockNotFound
 		}
 
-		return nil
+		if stockQuantity.Int64 == 0 {
+			return nil
+		}
+
+		// Write off the remaining quantity so the ledger still balances
+		return insertStockMovement(ctx, tx, &bo.StockMovement{
+			ProductID:     productID.Int64,
+			VariantID:     variantID.Int64,
+			WarehouseID:   warehouseID.Int64,
+			QuantityDelta: -stockQuantity.Int64,
+			BalanceAfter:  0,
+			Reason:        bo.StockMovementAdjustment,
+			Reference:     "stock row deleted",
+		})
 	})
 }
 
+// productStockListSort names the only order product stocks are listed in, by id
+const productStockListSort = "id"
+
+func productStockCursor(productStock bo.ProductStock) *bo.PageCursor {
+	return &bo.PageCursor{Sort: productStockListSort, ID: productStock.ID}
+}
+
 func (s *productStockStore) ListProductStocks(ctx context.Context, productStockQuery bo.ProductStockQuery) (bo.PaginatedProductStockCollection, error) {
 	pagingCollection := bo.PaginatedProductStockCollection{}
 
+	cursor, backward, err := pageCursor(productStockQuery.PageCursors, productStockListSort)
+	if err != nil {
+		return pagingCollection, err
+	}
+
 	conn, err := s.dbPool.Acquire(ctx)
 	if err != nil {
 		return pagingCollection, err
 	}
 	defer conn.Release()
 
-	dbQuery := fmt.Sprintf("SELECT %s FROM product_stocks ORDER BY id ASC LIMIT $1 OFFSET $2", strings.Join(productStockFields, ","))
-	rows, err := conn.Query(ctx, dbQuery, productStockQuery.Limit, productStockQuery.Offset)
+	query := sqlbuilder.Select(append(productStockFields, "COALESCE(rs.reserved_quantity, 0)")...).
+		From("product_stocks").
+		Join(strings.TrimSpace(productStockReservedJoin))
+	// warehouse_id is never 0, so a 0 filter matches every location
+	if productStockQuery.WarehouseID != 0 {
+		query.Where("warehouse_id = ?", productStockQuery.WarehouseID)
+	}
+	countQuery, countArgs := query.BuildCount()
+	keysetQuery(query, []string{"id"}, false, productStockQuery.Limit, productStockQuery.Offset, cursor, backward)
+
+	dbQuery, args := query.Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
 	if err != nil {
 		slog.Error("failed to list product stocks", "cause", err)
 		return pagingCollection, err
@@ -196,18 +525,26 @@ func (s *productStockStore) ListProductStocks(ctx context.Context, productStockQ
 		var (
 			id            sql.NullInt64
 			productID     sql.NullInt64
+			variantID     sql.NullInt64
+			warehouseID   sql.NullInt64
 			stockQuantity sql.NullInt64
+			version       sql.NullInt64
 			updatedAt     sql.NullTime
+			reserved      sql.NullInt64
 		)
-		if err := rows.Scan(&id, &productID, &stockQuantity, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &productID, &variantID, &warehouseID, &stockQuantity, &version, &updatedAt, &reserved); err != nil {
 			slog.Error("failed to scan product stock row", "cause", err)
 			return pagingCollection, err
 		}
 		productStocks = append(productStocks, bo.ProductStock{
-			ID:            id.Int64,
-			ProductID:     productID.Int64,
-			StockQuantity: stockQuantity.Int64,
-			UpdatedAt:     updatedAt.Time,
+			ID:               id.Int64,
+			ProductID:        productID.Int64,
+			VariantID:        variantID.Int64,
+			WarehouseID:      warehouseID.Int64,
+			StockQuantity:    stockQuantity.Int64,
+			Version:          version.Int64,
+			UpdatedAt:        updatedAt.Time,
+			ReservedQuantity: reserved.Int64,
 		})
 	}
 
@@ -216,9 +553,9 @@ func (s *productStockStore) ListProductStocks(ctx context.Context, productStockQ
 		return pagingCollection, err
 	}
 
-	pagingCollection.Data = productStocks
+	pagingCollection.Data, pagingCollection.PageLinks = keysetPage(productStocks, productStockQuery.Limit, productStockQuery.Offset, productStockQuery.PageCursors, productStockCursor)
 	var totalRecord sql.NullInt64
-	if err = conn.QueryRow(ctx, `SELECT COUNT(*) FROM product_stocks`).Scan(&totalRecord); err != nil {
+	if err = conn.QueryRow(ctx, countQuery, countArgs...).Scan(&totalRecord); err != nil {
 		slog.Error("error scanning COUNT product stocks row", "cause", err)
 		return pagingCollection, err
 	}
@@ -226,3 +563,87 @@ func (s *productStockStore) ListProductStocks(ctx context.Context, productStockQ
 	pagingCollection.Total = totalRecord.Int64
 	return pagingCollection, nil
 }
+
+func (s *productStockStore) GetProductStockLevel(ctx context.Context, productID int64) (bo.ProductStockLevel, error) {
+	stockLevel := bo.ProductStockLevel{ProductID: productID}
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return stockLevel, err
+	}
+	defer conn.Release()
+
+	dbQuery := `SELECT warehouse_id, variant_id, stock_quantity, COALESCE(rs.reserved_quantity, 0) FROM product_stocks` + productStockReservedJoin + `
+		WHERE product_id = $1 ORDER BY warehouse_id ASC, variant_id ASC NULLS FIRST`
+	rows, err := conn.Query(ctx, dbQuery, productID)
+	if err != nil {
+		slog.Error("failed to list product stock locations", "cause", err)
+		return stockLevel, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			warehouseID   sql.NullInt64
+			variantID     sql.NullInt64
+			stockQuantity sql.NullInt64
+			reserved      sql.NullInt64
+		)
+		if err := rows.Scan(&warehouseID, &variantID, &stockQuantity, &reserved); err != nil {
+			slog.Error("failed to scan product stock location row", "cause", err)
+			return stockLevel, err
+		}
+		stockLevel.Locations = append(stockLevel.Locations, bo.ProductStockLocation{
+			WarehouseID:      warehouseID.Int64,
+			VariantID:        variantID.Int64,
+			StockQuantity:    stockQuantity.Int64,
+			ReservedQuantity: reserved.Int64,
+		})
+		stockLevel.StockQuantity += stockQuantity.Int64
+		stockLevel.ReservedQuantity += reserved.Int64
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return stockLevel, err
+	}
+
+	if len(stockLevel.Locations) == 0 {
+		return stockLevel, bo.ErrProductStockNotFound
+	}
+
+	return stockLevel, nil
+}
+
+// lockProductStockRows locks the stock rows of a product (variant), in a single
+// warehouse when warehouseID is set, and returns their ids in lock order.
+func lockProductStockRows(ctx context.Context, tx pgx.Tx, productID, variantID, warehouseID int64) ([]int64, error) {
+	lockQuery := `SELECT id FROM product_stocks
+		WHERE product_id = $1 AND variant_id IS NOT DISTINCT FROM $2 AND ($3 = 0 OR warehouse_id = $3)
+		ORDER BY id ASC FOR UPDATE`
+	rows, err := tx.Query(ctx, lockQuery, productID, sql.NullInt64{Int64: variantID, Valid: variantID != 0}, warehouseID)
+	if err != nil {
+		slog.Error("failed to lock product stock rows", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stockIDs []int64
+	for rows.Next() {
+		var id sql.NullInt64
+		if err := rows.Scan(&id); err != nil {
+			slog.Error("failed to scan product stock row", "cause", err)
+			return nil, err
+		}
+		stockIDs = append(stockIDs, id.Int64)
+	}
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+	if len(stockIDs) == 0 {
+		return nil, bo.ErrProductStockNotFound
+	}
+
+	return stockIDs, nil
+}
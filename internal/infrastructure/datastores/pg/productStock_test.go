@@ -0,0 +1,64 @@
+package pg
+
+import (
+	"testing"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProductStockUpdateQuery(t *testing.T) {
+	quantity := int64(12)
+	const (
+		update    = "UPDATE product_stocks ps SET stock_quantity = $1, version = ps.version + 1, updated_at = CURRENT_TIMESTAMP"
+		returning = " WHERE ps.id = old.id RETURNING ps.variant_id, ps.warehouse_id, ps.stock_quantity, old.stock_quantity, old.reserved_quantity"
+		locked    = " FROM (SELECT product_stocks.id, product_stocks.stock_quantity, COALESCE(rs.reserved_quantity, 0) AS reserved_quantity FROM product_stocks" +
+			productStockReservedJoin
+	)
+
+	testCases := []struct {
+		name   string
+		update bo.ProductStockUpdate
+		from   string
+		args   []any
+		err    error
+	}{
+		{
+			name:   "Product",
+			update: bo.ProductStockUpdate{ProductID: 7, WarehouseID: 2, StockQuantity: &quantity},
+			from:   locked + " WHERE product_id = $2 AND warehouse_id = $3 AND variant_id IS NULL FOR UPDATE OF product_stocks) old",
+			args:   []any{int64(12), int64(7), int64(2)},
+		},
+		{
+			name:   "Variant",
+			update: bo.ProductStockUpdate{ProductID: 7, VariantID: 5, WarehouseID: 2, StockQuantity: &quantity},
+			from:   locked + " WHERE product_id = $2 AND warehouse_id = $3 AND variant_id = $4 FOR UPDATE OF product_stocks) old",
+			args:   []any{int64(12), int64(7), int64(2), int64(5)},
+		},
+		{
+			// without a warehouse the quantity would be written to every stock row of the product
+			name:   "NoWarehouse",
+			update: bo.ProductStockUpdate{ProductID: 7, StockQuantity: &quantity},
+			err:    bo.ErrStockLocationRequired,
+		},
+		{
+			name:   "VariantWithoutWarehouse",
+			update: bo.ProductStockUpdate{ProductID: 7, VariantID: 5, StockQuantity: &quantity},
+			err:    bo.ErrStockLocationRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlQuery, args, err := buildProductStockUpdateQuery(tc.update, buildProductStockUpdateMap(tc.update))
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, update+tc.from+returning, sqlQuery)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type productVariantStore struct {
+	dbPool *pgxpool.Pool
+}
+
+var productVariantFields = []string{
+	"id",
+	"product_id",
+	"sku",
+	"options",
+	"unit_price",
+	"discount_price",
+	"status_id",
+	"created_at",
+}
+
+func (s *productVariantStore) GetProductVariantByID(ctx context.Context, productID, variantID int64) (bo.ProductVariant, error) {
+	var (
+		id            sql.NullInt64
+		pID           sql.NullInt64
+		sku           sql.NullString
+		options       map[string]string
+		unitPrice     decimal.NullDecimal
+		discountPrice decimal.NullDecimal
+		statusID      sql.NullInt64
+		createdAt     sql.NullTime
+	)
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return bo.ProductVariant{}, err
+	}
+	defer conn.Release()
+
+	dbQuery := fmt.Sprintf("SELECT %s FROM product_variants WHERE id = $1 AND product_id = $2", strings.Join(productVariantFields, ","))
+	row := conn.QueryRow(ctx, dbQuery, variantID, productID)
+
+	if err = row.Scan(&id, &pID, &sku, &options, &unitPrice, &discountPrice, &statusID, &createdAt); err != nil {
+		if err == pgx.ErrNoRows {
+			slog.Error("product variant id does not exist", slog.Int64("id", variantID), slog.Int64("productID", productID))
+			return bo.ProductVariant{}, bo.ErrProductVariantNotFound
+		}
+		slog.Error("failed to scan product variant table row", "cause", err)
+		return bo.ProductVariant{}, err
+	}
+
+	return bo.ProductVariant{
+		ID:            id.Int64,
+		ProductID:     pID.Int64,
+		SKU:           sku.String,
+		Options:       options,
+		UnitPrice:     unitPrice.Decimal,
+		DiscountPrice: discountPrice.Decimal,
+		StatusID:      statusID.Int64,
+		CreatedAt:     createdAt.Time,
+	}, nil
+}
+
+func (s *productVariantStore) CreateProductVariant(ctx context.Context, productVariant *bo.ProductVariant) error {
+	insertMap := buildProductVariantInsertMap(*productVariant)
+	if len(insertMap) < 1 {
+		slog.Debug("empty core insert for product variant")
+		return fmt.Errorf("empty core insert for product variant")
+	}
+
+	sqlQuery, arguments := sqlbuilder.Insert("product_variants").Values(insertMap).Returning("id").Build()
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	var id sql.NullInt64
+	if err := conn.QueryRow(ctx, sqlQuery, arguments...).Scan(&id); err != nil {
+		return err
+	}
+
+	productVariant.ID = id.Int64
+	return nil
+}
+
+func buildProductVariantInsertMap(v bo.ProductVariant) map[string]interface{} {
+	insertedFields := make(map[string]interface{})
+
+	insertedFields["product_id"] = v.ProductID
+	insertedFields["unit_price"] = v.UnitPrice
+	insertedFields["discount_price"] = v.DiscountPrice
+	insertedFields["status_id"] = v.StatusID
+
+	if v.SKU != "" {
+		insertedFields["sku"] = v.SKU
+	}
+	if v.Options != nil {
+		insertedFields["options"] = v.Options
+	}
+
+	return insertedFields
+}
+
+func (s *productVariantStore) UpdateProductVariant(ctx context.Context, updateProductVariant bo.ProductVariantUpdate) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		updateMap := buildProductVariantUpdateMap(updateProductVariant)
+		if len(updateMap) < 1 {
+			slog.Debug("empty core update for product variant", slog.Int64("id", updateProductVariant.ID))
+			return errors.New("empty core update for product variant")
+		}
+
+		sqlQuery, arguments := sqlbuilder.Update("product_variants").
+			Set(updateMap).
+			Where("id = ?", updateProductVariant.ID).
+			Where("product_id = ?", updateProductVariant.ProductID).
+			Build()
+
+		commandTag, err := tx.Exec(ctx, sqlQuery, arguments...)
+		if err != nil {
+			slog.Error("failed to update product variant in database", "cause", err)
+			return fmt.Errorf("failed to update product variant in database: %w", err)
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			return bo.ErrProductVariantNotFound
+		}
+
+		return nil
+	})
+}
+
+func buildProductVariantUpdateMap(u bo.ProductVariantUpdate) map[string]interface{} {
+	updateFields := map[string]interface{}{}
+
+	if u.SKU != nil {
+		updateFields["sku"] = *u.SKU
+	}
+	if u.Options != nil {
+		updateFields["options"] = u.Options
+	}
+	if u.UnitPrice != nil {
+		updateFields["unit_price"] = *u.UnitPrice
+	}
+	if u.DiscountPrice != nil {
+		updateFields["discount_price"] = *u.DiscountPrice
+	}
+	if u.StatusID != nil {
+		updateFields["status_id"] = *u.StatusID
+	}
+
+	return updateFields
+}
+
+func (s *productVariantStore) DeleteProductVariant(ctx context.Context, productID, variantID int64) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		sqlQuery := `DELETE FROM product_variants WHERE id = $1 AND product_id = $2`
+		if commandTag, err := tx.Exec(ctx, sqlQuery, variantID, productID); err != nil {
+			slog.Error("failed to delete product variant", slog.Int64("variantID", variantID), "cause", err)
+			return err
+		} else if commandTag.RowsAffected() == 0 {
+			return bo.ErrProductVariantNotFound
+		}
+
+		return nil
+	})
+}
+
+func (s *productVariantStore) ListProductVariants(ctx context.Context, productID int64) (bo.ProductVariantCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	dbQuery := fmt.Sprintf("SELECT %s FROM product_variants WHERE product_id = $1 ORDER BY id ASC", strings.Join(productVariantFields, ","))
+	rows, err := conn.Query(ctx, dbQuery, productID)
+	if err != nil {
+		slog.Error("failed to list product variants", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var productVariants bo.ProductVariantCollection
+	for rows.Next() {
+		var (
+			id            sql.NullInt64
+			pID           sql.NullInt64
+			sku           sql.NullString
+			options       map[string]string
+			unitPrice     decimal.NullDecimal
+			discountPrice decimal.NullDecimal
+			statusID      sql.NullInt64
+			createdAt     sql.NullTime
+		)
+		if err := rows.Scan(&id, &pID, &sku, &options, &unitPrice, &discountPrice, &statusID, &createdAt); err != nil {
+			slog.Error("failed to scan product variant row", "cause", err)
+			return nil, err
+		}
+		productVariants = append(productVariants, bo.ProductVariant{
+			ID:            id.Int64,
+			ProductID:     pID.Int64,
+			SKU:           sku.String,
+			Options:       options,
+			UnitPrice:     unitPrice.Decimal,
+			DiscountPrice: discountPrice.Decimal,
+			StatusID:      statusID.Int64,
+			CreatedAt:     createdAt.Time,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return productVariants, nil
+}
@@ -0,0 +1,319 @@
+package pg
+
+import (
+	"testing"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProductQuery(t *testing.T) {
+	const (
+		columns = "SELECT p.id, p.name, p.description, p.specifications, p.brand_id, p.category_id, p.supplier_id, p.unit_price, p.discount_price, p.tags, p.status_id, p.attributes, p.currency, p.tax_class_id"
+		count   = "SELECT COUNT(*)"
+		search  = " CROSS JOIN websearch_to_tsquery('english', $1) query"
+	)
+	highlights := ", ts_headline('english', p.name, query, '" + productNameHighlightOptions + "')" +
+		", ts_headline('english', COALESCE(p.description, ''), query, '" + productDescriptionHighlightOptions + "')" +
+		", ts_rank(p.search_vector, query)"
+	from := " FROM products p" +
+		" INNER JOIN brands b ON p.brand_id = b.id" +
+		" INNER JOIN categories c ON p.category_id = c.id" +
+		" INNER JOIN suppliers s ON p.supplier_id = s.id" +
+		" INNER JOIN " + productStockAggregate + " ps ON p.id = ps.product_id"
+	available := " WHERE p.status_id = 1 AND ps.available_quantity > 0"
+
+	paging := bo.ProductPaging{Limit: 20, Offset: 40}
+	testCases := []struct {
+		name        string
+		query       bo.ProductSearchQuery
+		highlights  string
+		join        string
+		where       string
+		cursorWhere string
+		orderBy     string
+		args        []any
+		countArgs   []any
+		err         error
+	}{
+		{
+			name:    "NoFilter",
+			query:   bo.ProductSearchQuery{Paging: paging},
+			orderBy: " ORDER BY p.unit_price ASC, p.id ASC LIMIT $1 OFFSET $2",
+			args:    []any{21, 40},
+		},
+		{
+			name: "EveryFilter",
+			query: bo.ProductSearchQuery{
+				Filter: bo.ProductFilter{
+					Query:                  "wireless phone",
+					PriceRangeFilter:       bo.PriceRangeFilter{Min: 10, Max: 99.5},
+					BrandFilter:            []int64{1, 2},
+					CategoryFilter:         3,
+					SupplierFilter:         4,
+					VerifiedSupplierFilter: true,
+				},
+				Paging: paging,
+				Sort:   bo.ProductSort{Field: "name", Order: "desc"},
+			},
+			highlights: highlights,
+			join:       search,
+			where: " AND p.search_vector @@ query AND p.unit_price >= $2 AND p.unit_price <= $3 AND p.brand_id = ANY($4)" +
+				" AND p.category_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = $5) AND p.supplier_id = $6 AND s.is_verified_supplier = true",
+			orderBy:   " ORDER BY p.name DESC, p.id DESC LIMIT $7 OFFSET $8",
+			args:      []any{"wireless phone", 10.0, 99.5, []int64{1, 2}, int64(3), int64(4), 21, 40},
+			countArgs: []any{"wireless phone", 10.0, 99.5, []int64{1, 2}, int64(3), int64(4)},
+		},
+		{
+			name: "SearchByRelevance",
+			query: bo.ProductSearchQuery{
+				Filter: bo.ProductFilter{Query: "x' OR '1'='1"},
+				Paging: paging,
+			},
+			highlights: highlights,
+			join:       search,
+			where:      " AND p.search_vector @@ query",
+			orderBy:    " ORDER BY ts_rank(p.search_vector, query) DESC, p.id DESC LIMIT $2 OFFSET $3",
+			args:       []any{"x' OR '1'='1", 21, 40},
+			countArgs:  []any{"x' OR '1'='1"},
+		},
+		{
+			name: "AfterCursor",
+			query: bo.ProductSearchQuery{
+				Paging: bo.ProductPaging{Limit: 20, Offset: 40, PageCursors: bo.PageCursors{
+					After: &bo.PageCursor{Sort: "discount_price:asc", Key: "9.99", ID: 7},
+				}},
+				Sort: bo.ProductSort{Field: "discount_price", Order: "ASC"},
+			},
+			cursorWhere: " AND (COALESCE(p.discount_price, 0), p.id) > ($1, $2)",
+			orderBy:     " ORDER BY COALESCE(p.discount_price, 0) ASC, p.id ASC LIMIT $3 OFFSET $4",
+			args:        []any{"9.99", int64(7), 21, 0},
+		},
+		{
+			name: "BeforeCursorByRelevance",
+			query: bo.ProductSearchQuery{
+				Filter: bo.ProductFilter{Query: "phone"},
+				Paging: bo.ProductPaging{Limit: 20, PageCursors: bo.PageCursors{
+					Before: &bo.PageCursor{Sort: "relevance:desc", Key: 0.25, ID: 3},
+				}},
+			},
+			highlights:  highlights,
+			join:        search,
+			where:       " AND p.search_vector @@ query",
+			cursorWhere: " AND (ts_rank(p.search_vector, query), p.id) > ($2, $3)",
+			orderBy:     " ORDER BY ts_rank(p.search_vector, query) ASC, p.id ASC LIMIT $4 OFFSET $5",
+			args:        []any{"phone", 0.25, int64(3), 21, 0},
+			countArgs:   []any{"phone"},
+		},
+		{
+			name: "IDCursor",
+			query: bo.ProductSearchQuery{
+				Paging: bo.ProductPaging{Limit: 20, PageCursors: bo.PageCursors{
+					After: &bo.PageCursor{Sort: "id:desc", ID: 30},
+				}},
+				Sort: bo.ProductSort{Field: "id", Order: "desc"},
+			},
+			cursorWhere: " AND (p.id) < ($1)",
+			orderBy:     " ORDER BY p.id DESC LIMIT $2 OFFSET $3",
+			args:        []any{int64(30), 21, 0},
+		},
+		{
+			name: "CursorOfAnotherOrder",
+			query: bo.ProductSearchQuery{
+				Paging: bo.ProductPaging{Limit: 20, PageCursors: bo.PageCursors{
+					After: &bo.PageCursor{Sort: "name:asc", Key: "Galaxy", ID: 7},
+				}},
+			},
+			err: bo.ErrInvalidCursor,
+		},
+		{
+			name: "CursorKeyOfAnotherType",
+			query: bo.ProductSearchQuery{
+				Paging: bo.ProductPaging{Limit: 20, PageCursors: bo.PageCursors{
+					After: &bo.PageCursor{Sort: "unit_price:asc", Key: "1 OR 1=1", ID: 7},
+				}},
+			},
+			err: bo.ErrInvalidCursor,
+		},
+		{
+			name:  "UnknownSortField",
+			query: bo.ProductSearchQuery{Paging: paging, Sort: bo.ProductSort{Field: "p.id; DROP TABLE products", Order: "ASC"}},
+			err:   bo.ErrInvalidProductSort,
+		},
+		{
+			name:  "UnknownSortOrder",
+			query: bo.ProductSearchQuery{Paging: paging, Sort: bo.ProductSort{Field: "name", Order: "ASC, p.id"}},
+			err:   bo.ErrInvalidProductSort,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, order, err := buildProductQuery(tc.query)
+			if err == nil {
+				countSQL, countArgs := query.BuildCount()
+				require.Equal(t, count+from+tc.join+available+tc.where, countSQL)
+				require.Equal(t, tc.countArgs, countArgs)
+
+				err = pageProductQuery(query, order, tc.query.Paging)
+			}
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			sql, args := query.Build()
+			require.Equal(t, columns+tc.highlights+from+tc.join+available+tc.where+tc.cursorWhere+tc.orderBy, sql)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
+
+func TestBuildProductFacetQueries(t *testing.T) {
+	from := " FROM products p" +
+		" INNER JOIN brands b ON p.brand_id = b.id" +
+		" INNER JOIN categories c ON p.category_id = c.id" +
+		" INNER JOIN suppliers s ON p.supplier_id = s.id" +
+		" INNER JOIN " + productStockAggregate + " ps ON p.id = ps.product_id" +
+		" CROSS JOIN websearch_to_tsquery('english', $1) query"
+	available := " WHERE p.status_id = 1 AND ps.available_quantity > 0 AND p.search_vector @@ query"
+
+	filter := bo.ProductFilter{
+		Query:                  "phone",
+		PriceRangeFilter:       bo.PriceRangeFilter{Min: 10},
+		BrandFilter:            []int64{1, 2},
+		CategoryFilter:         3,
+		SupplierFilter:         4,
+		VerifiedSupplierFilter: true,
+	}
+	facetQueries := buildProductFacetQueries(filter)
+
+	// every facet keeps all filters but its own
+	testCases := []struct {
+		name  string
+		query *sqlbuilder.SelectBuilder
+		sql   string
+		args  []any
+	}{
+		{
+			name:  "Brand",
+			query: facetQueries[0],
+			sql: "SELECT b.id, b.name, COUNT(*)" + from + available +
+				" AND p.unit_price >= $2 AND p.category_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = $3) AND p.supplier_id = $4 AND s.is_verified_supplier = true" +
+				" GROUP BY b.id, b.name ORDER BY COUNT(*) DESC, b.name ASC",
+			args: []any{"phone", 10.0, int64(3), int64(4)},
+		},
+		{
+			name:  "Category",
+			query: facetQueries[1],
+			sql: "SELECT c.id, c.name, COUNT(*)" + from + available +
+				" AND p.unit_price >= $2 AND p.brand_id = ANY($3) AND p.supplier_id = $4 AND s.is_verified_supplier = true" +
+				" GROUP BY c.id, c.name ORDER BY COUNT(*) DESC, c.name ASC",
+			args: []any{"phone", 10.0, []int64{1, 2}, int64(4)},
+		},
+		{
+			name:  "Supplier",
+			query: facetQueries[2],
+			sql: "SELECT s.id, s.name, COUNT(*)" + from + available +
+				" AND p.unit_price >= $2 AND p.brand_id = ANY($3) AND p.category_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = $4) AND s.is_verified_supplier = true" +
+				" GROUP BY s.id, s.name ORDER BY COUNT(*) DESC, s.name ASC",
+			args: []any{"phone", 10.0, []int64{1, 2}, int64(3)},
+		},
+		{
+			name:  "VerifiedSupplier",
+			query: buildVerifiedSupplierFacetQuery(filter),
+			sql: "SELECT s.is_verified_supplier, COUNT(*)" + from + available +
+				" AND p.unit_price >= $2 AND p.brand_id = ANY($3) AND p.category_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = $4) AND p.supplier_id = $5" +
+				" GROUP BY s.is_verified_supplier ORDER BY s.is_verified_supplier DESC",
+			args: []any{"phone", 10.0, []int64{1, 2}, int64(3), int64(4)},
+		},
+		{
+			name:  "PriceBucket",
+			query: buildPriceFacetQuery(filter),
+			sql: "SELECT width_bucket(p.unit_price, price.bounds) AS bucket, COUNT(*)" + from +
+				" CROSS JOIN (SELECT $2::numeric[] AS bounds) price" + available +
+				" AND p.brand_id = ANY($3) AND p.category_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = $4) AND p.supplier_id = $5 AND s.is_verified_supplier = true" +
+				" GROUP BY bucket ORDER BY bucket ASC",
+			args: []any{"phone", bo.ProductPriceBuckets, []int64{1, 2}, int64(3), int64(4)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, args := tc.query.Build()
+			require.Equal(t, tc.sql, sql)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
+
+func TestBuildProductAttributeQueries(t *testing.T) {
+	from := " FROM products p" +
+		" INNER JOIN brands b ON p.brand_id = b.id" +
+		" INNER JOIN categories c ON p.category_id = c.id" +
+		" INNER JOIN suppliers s ON p.supplier_id = s.id" +
+		" INNER JOIN " + productStockAggregate + " ps ON p.id = ps.product_id"
+	available := " WHERE p.status_id = 1 AND ps.available_quantity > 0 AND p.category_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = $1)"
+
+	minSize, maxSize, minRAM := 6.1, 6.7, 8.0
+	filter := bo.ProductFilter{
+		CategoryFilter: 3,
+		AttributeFilters: []bo.AttributeFilter{
+			{Code: "color", Values: []any{"black", "blue"}},
+			{Code: "screen_size", Min: &minSize, Max: &maxSize},
+			{Code: "ram", Min: &minRAM},
+			{Code: "nfc", Values: []any{true}},
+		},
+	}
+
+	testCases := []struct {
+		name  string
+		query *sqlbuilder.SelectBuilder
+		sql   string
+		args  []any
+	}{
+		{
+			name:  "Filters",
+			query: filterProducts(sqlbuilder.Select("p.id"), filter, noProductFacet),
+			sql: "SELECT p.id" + from + available +
+				" AND p.attributes @> ANY($2::jsonb[])" +
+				" AND jsonb_path_exists(p.attributes, $3::jsonpath, $4::jsonb)" +
+				" AND jsonb_path_exists(p.attributes, $5::jsonpath, $6::jsonb)" +
+				" AND p.attributes @> ANY($7::jsonb[])",
+			args: []any{
+				int64(3),
+				[]string{`{"color":"black"}`, `{"color":"blue"}`},
+				`$."screen_size" ? (@ >= $min && @ <= $max)`, map[string]float64{"min": 6.1, "max": 6.7},
+				`$."ram" ? (@ >= $min)`, map[string]float64{"min": 8},
+				[]string{`{"nfc":true}`},
+			},
+		},
+		{
+			name:  "FacetWithoutItsOwnFilter",
+			query: buildAttributeFacetQuery(filter, "color"),
+			sql: "SELECT p.attributes ->> 'color', COUNT(*)" + from + available +
+				" AND jsonb_path_exists(p.attributes, $2::jsonpath, $3::jsonb)" +
+				" AND jsonb_path_exists(p.attributes, $4::jsonpath, $5::jsonb)" +
+				" AND p.attributes @> ANY($6::jsonb[])" +
+				" AND p.attributes ->> 'color' IS NOT NULL" +
+				" GROUP BY p.attributes ->> 'color' ORDER BY COUNT(*) DESC, p.attributes ->> 'color' ASC",
+			args: []any{
+				int64(3),
+				`$."screen_size" ? (@ >= $min && @ <= $max)`, map[string]float64{"min": 6.1, "max": 6.7},
+				`$."ram" ? (@ >= $min)`, map[string]float64{"min": 8},
+				[]string{`{"nfc":true}`},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, args := tc.query.Build()
+			require.Equal(t, tc.sql, sql)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
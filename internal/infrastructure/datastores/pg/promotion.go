@@ -0,0 +1,389 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type promotionStore struct {
+	dbPool *pgxpool.Pool
+}
+
+var promotionFields = []string{
+	"id",
+	"name",
+	"kind",
+	"value",
+	"buy_quantity",
+	"get_quantity",
+	"scope",
+	"scope_id",
+	"coupon_code",
+	"starts_at",
+	"ends_at",
+	"usage_limit",
+	"usage_count",
+	"status_id",
+	"created_at",
+	"currency",
+}
+
+func scanPromotion(row pgx.Row) (bo.Promotion, error) {
+	var (
+		id          sql.NullInt64
+		name        sql.NullString
+		kind        sql.NullString
+		value       decimal.NullDecimal
+		buyQuantity sql.NullInt64
+		getQuantity sql.NullInt64
+		scope       sql.NullString
+		scopeID     sql.NullInt64
+		couponCode  sql.NullString
+		startsAt    sql.NullTime
+		endsAt      sql.NullTime
+		usageLimit  sql.NullInt64
+		usageCount  sql.NullInt64
+		statusID    sql.NullInt64
+		createdAt   sql.NullTime
+		currency    sql.NullString
+	)
+	if err := row.Scan(&id, &name, &kind, &value, &buyQuantity, &getQuantity, &scope, &scopeID, &couponCode,
+		&startsAt, &endsAt, &usageLimit, &usageCount, &statusID, &createdAt, &currency); err != nil {
+		return bo.Promotion{}, err
+	}
+
+	return bo.Promotion{
+		ID:          id.Int64,
+		Name:        name.String,
+		Kind:        bo.PromotionKind(kind.String),
+		Value:       value.Decimal,
+		BuyQuantity: buyQuantity.Int64,
+		GetQuantity: getQuantity.Int64,
+		Scope:       bo.PromotionScope(scope.String),
+		ScopeID:     scopeID.Int64,
+		CouponCode:  couponCode.String,
+		StartsAt:    startsAt.Time,
+		EndsAt:      endsAt.Time,
+		UsageLimit:  usageLimit.Int64,
+		UsageCount:  usageCount.Int64,
+		StatusID:    statusID.Int64,
+		CreatedAt:   createdAt.Time,
+		Currency:    currency.String,
+	}, nil
+}
+
+func (s *promotionStore) GetPromotionByID(ctx context.Context, promotionID int64) (bo.Promotion, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return bo.Promotion{}, err
+	}
+	defer conn.Release()
+
+	dbQuery := fmt.Sprintf("SELECT %s FROM promotions WHERE id = $1", strings.Join(promotionFields, ","))
+	promotion, err := scanPromotion(conn.QueryRow(ctx, dbQuery, promotionID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			slog.Error("promotion id does not exist", slog.Int64("id", promotionID))
+			return bo.Promotion{}, bo.ErrPromotionNotFound
+		}
+		slog.Error("failed to scan promotion table row", "cause", err)
+		return bo.Promotion{}, err
+	}
+
+	return promotion, nil
+}
+
+func (s *promotionStore) CreatePromotion(ctx context.Context, promotion *bo.Promotion) error {
+	insertMap := buildPromotionInsertMap(*promotion)
+
+	sqlQuery, arguments := sqlbuilder.Insert("promotions").Values(insertMap).Returning("id").Build()
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	var id sql.NullInt64
+	if err := conn.QueryRow(ctx, sqlQuery, arguments...).Scan(&id); err != nil {
+		slog.Error("failed to insert promotion", "cause", err)
+		return err
+	}
+
+	promotion.ID = id.Int64
+	return nil
+}
+
+func buildPromotionInsertMap(p bo.Promotion) map[string]interface{} {
+	insertedFields := make(map[string]interface{})
+
+	for _, value := range promotionFields {
+		switch value {
+		case "name":
+			insertedFields[value] = p.Name
+		case "kind":
+			insertedFields[value] = string(p.Kind)
+		case "value":
+			insertedFields[value] = p.Value
+		case "buy_quantity":
+			insertedFields[value] = p.BuyQuantity
+		case "get_quantity":
+			insertedFields[value] = p.GetQuantity
+		case "scope":
+			insertedFields[value] = string(p.Scope)
+		case "scope_id":
+			if p.ScopeID != 0 {
+				insertedFields[value] = p.ScopeID
+			}
+		case "coupon_code":
+			if p.CouponCode != "" {
+				insertedFields[value] = strings.ToUpper(p.CouponCode)
+			}
+		case "starts_at":
+			if !p.StartsAt.IsZero() {
+				insertedFields[value] = p.StartsAt
+			}
+		case "ends_at":
+			if !p.EndsAt.IsZero() {
+				insertedFields[value] = p.EndsAt
+			}
+		case "usage_limit":
+			if p.UsageLimit != 0 {
+				insertedFields[value] = p.UsageLimit
+			}
+		case "status_id":
+			insertedFields[value] = p.StatusID
+		case "currency":
+			// Without a currency the column default applies
+			if p.Currency != "" {
+				insertedFields[value] = p.Currency
+			}
+		}
+	}
+
+	return insertedFields
+}
+
+func (s *promotionStore) UpdatePromotion(ctx context.Context, updatePromotion bo.PromotionUpdate) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		updateMap := buildPromotionUpdateMap(updatePromotion)
+		if len(updateMap) < 1 {
+			slog.Debug("empty core update for promotion", slog.Int64("id", updatePromotion.ID))
+			return errors.New("empty core update for promotion")
+		}
+
+		sqlQuery, arguments := sqlbuilder.Update("promotions").Set(updateMap).Where("id = ?", updatePromotion.ID).Build()
+
+		commandTag, err := tx.Exec(ctx, sqlQuery, arguments...)
+		if err != nil {
+			slog.Error("failed to update promotion in database", "cause", err)
+			return fmt.Errorf("failed to update promotion in database: %w", err)
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			return bo.ErrPromotionNotFound
+		}
+
+		return nil
+	})
+}
+
+// buildPromotionUpdateMap maps the update to columns, a zero time or usage
+// limit clears the column
+func buildPromotionUpdateMap(u bo.PromotionUpdate) map[string]interface{} {
+	updatedFields := make(map[string]interface{})
+
+	if u.Name != nil {
+		updatedFields["name"] = *u.Name
+	}
+	if u.Value != nil {
+		updatedFields["value"] = *u.Value
+	}
+	if u.StartsAt != nil {
+		updatedFields["starts_at"] = sql.NullTime{Time: *u.StartsAt, Valid: !u.StartsAt.IsZero()}
+	}
+	if u.EndsAt != nil {
+		updatedFields["ends_at"] = sql.NullTime{Time: *u.EndsAt, Valid: !u.EndsAt.IsZero()}
+	}
+	if u.UsageLimit != nil {
+		updatedFields["usage_limit"] = sql.NullInt64{Int64: *u.UsageLimit, Valid: *u.UsageLimit != 0}
+	}
+	if u.StatusID != nil {
+		updatedFields["status_id"] = *u.StatusID
+	}
+
+	return updatedFields
+}
+
+func (s *promotionStore) DeletePromotion(ctx context.Context, promotionID int64) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		sqlQuery := `DELETE FROM promotions WHERE id = $1`
+		if commandTag, err := tx.Exec(ctx, sqlQuery, promotionID); err != nil {
+			slog.Error("failed to delete promotion", slog.Int64("promotionID", promotionID), "cause", err)
+			return err
+		} else if commandTag.RowsAffected() == 0 {
+			return bo.ErrPromotionNotFound
+		}
+
+		return nil
+	})
+}
+
+func (s *promotionStore) ListPromotions(ctx context.Context, promotionQuery bo.PromotionQuery) (bo.PaginatedPromotionCollection, error) {
+	pagingCollection := bo.PaginatedPromotionCollection{}
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return pagingCollection, err
+	}
+	defer conn.Release()
+
+	query := sqlbuilder.Select(promotionFields...).From("promotions").OrderBy("id DESC").Page(promotionQuery.Limit, promotionQuery.Offset)
+	dbQuery, args := query.Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to list promotions", "cause", err)
+		return pagingCollection, err
+	}
+	defer rows.Close()
+
+	var promotions bo.PromotionCollection
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			slog.Error("failed to scan promotion row", "cause", err)
+			return pagingCollection, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return pagingCollection, err
+	}
+
+	pagingCollection.Data = promotions
+	var totalRecord sql.NullInt64
+	countQuery, countArgs := query.BuildCount()
+	if err = conn.QueryRow(ctx, countQuery, countArgs...).Scan(&totalRecord); err != nil {
+		slog.Error("error scanning COUNT promotions row", "cause", err)
+		return pagingCollection, err
+	}
+
+	pagingCollection.Total = totalRecord.Int64
+	return pagingCollection, nil
+}
+
+// ListApplicablePromotions lists the promotions which can be applied right now,
+// the automatic ones and the one redeemed by couponCode. Category promotions
+// come with the descendants of their category.
+func (s *promotionStore) ListApplicablePromotions(ctx context.Context, couponCode string) (bo.PromotionCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	dbQuery := fmt.Sprintf(`SELECT %s FROM promotions
+		WHERE status_id = 1
+			AND (starts_at IS NULL OR starts_at <= CURRENT_TIMESTAMP)
+			AND (ends_at IS NULL OR ends_at > CURRENT_TIMESTAMP)
+			AND (usage_limit IS NULL OR usage_count < usage_limit)
+			AND (coupon_code IS NULL OR UPPER(coupon_code) = UPPER($1))
+		ORDER BY id ASC`, strings.Join(promotionFields, ","))
+	rows, err := conn.Query(ctx, dbQuery, couponCode)
+	if err != nil {
+		slog.Error("failed to list applicable promotions", "cause", err)
+		return nil, err
+	}
+
+	promotions := bo.PromotionCollection{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			rows.Close()
+			slog.Error("failed to scan promotion row", "cause", err)
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	for i := range promotions {
+		if promotions[i].Scope != bo.PromotionScopeCategory {
+			continue
+		}
+		if promotions[i].CategoryIDs, err = listCategoryDescendants(ctx, conn, promotions[i].ScopeID); err != nil {
+			return nil, err
+		}
+	}
+
+	return promotions, nil
+}
+
+// listCategoryDescendants lists a category with every category below it, the
+// category is linked to itself at depth 0 in category_closure
+func listCategoryDescendants(ctx context.Context, q querier, categoryID int64) ([]int64, error) {
+	rows, err := q.Query(ctx, `SELECT descendant_id FROM category_closure WHERE ancestor_id = $1 ORDER BY depth, descendant_id`, categoryID)
+	if err != nil {
+		slog.Error("failed to list category descendants", slog.Int64("categoryID", categoryID), "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categoryIDs []int64
+	for rows.Next() {
+		var id sql.NullInt64
+		if err := rows.Scan(&id); err != nil {
+			slog.Error("failed to scan category row", "cause", err)
+			return nil, err
+		}
+		categoryIDs = append(categoryIDs, id.Int64)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return categoryIDs, nil
+}
+
+// redeemPromotions counts one use of every promotion applied to an order,
+// failing with ErrPromotionUsageExceeded when one was used up meanwhile.
+func redeemPromotions(ctx context.Context, tx pgx.Tx, orderID int64, applied []bo.AppliedPromotion) error {
+	for _, promotion := range applied {
+		commandTag, err := tx.Exec(ctx, `UPDATE promotions SET usage_count = usage_count + 1
+			WHERE id = $1 AND (usage_limit IS NULL OR usage_count < usage_limit)`, promotion.PromotionID)
+		if err != nil {
+			slog.Error("failed to count promotion usage", slog.Int64("promotionID", promotion.PromotionID), "cause", err)
+			return fmt.Errorf("failed to count promotion usage: %w", err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			return bo.ErrPromotionUsageExceeded
+		}
+
+		if _, err := tx.Exec(ctx, `INSERT INTO promotion_redemptions(promotion_id, order_id, discount) VALUES ($1, $2, $3)`,
+			promotion.PromotionID, orderID, promotion.Discount); err != nil {
+			slog.Error("failed to insert promotion redemption", "cause", err)
+			return fmt.Errorf("failed to insert promotion redemption: %w", err)
+		}
+	}
+
+	return nil
+}
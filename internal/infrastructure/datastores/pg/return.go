@@ -0,0 +1,444 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type returnStore struct {
+	dbPool *pgxpool.Pool
+}
+
+const returnSelect = `SELECT r.id, r.order_id, r.status, r.reason, r.refund_amount, r.payment_id, r.created_at, r.updated_at, o.currency
+	FROM returns r INNER JOIN orders o ON o.id = r.order_id`
+
+func (s *returnStore) GetReturnByID(ctx context.Context, returnID int64) (bo.Return, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return bo.Return{}, err
+	}
+	defer conn.Release()
+
+	return getReturn(ctx, conn, returnID)
+}
+
+// getReturn reads a return with its items and audit trail through a pool
+// connection or a transaction.
+func getReturn(ctx context.Context, q querier, returnID int64) (bo.Return, error) {
+	ret, err := scanReturn(q.QueryRow(ctx, returnSelect+" WHERE r.id = $1", returnID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			slog.Error("return id does not exist", slog.Int64("id", returnID))
+			return bo.Return{}, bo.ErrReturnNotFound
+		}
+		slog.Error("failed to scan return table row", "cause", err)
+		return bo.Return{}, err
+	}
+
+	if ret.Items, err = listReturnItems(ctx, q, returnID); err != nil {
+		return bo.Return{}, err
+	}
+	if ret.Events, err = listReturnEvents(ctx, q, returnID); err != nil {
+		return bo.Return{}, err
+	}
+
+	return ret, nil
+}
+
+func scanReturn(row pgx.Row) (bo.Return, error) {
+	var (
+		id           sql.NullInt64
+		orderID      sql.NullInt64
+		status       sql.NullString
+		reason       sql.NullString
+		refundAmount decimal.NullDecimal
+		paymentID    sql.NullInt64
+		createdAt    sql.NullTime
+		updatedAt    sql.NullTime
+		currency     sql.NullString
+	)
+	if err := row.Scan(&id, &orderID, &status, &reason, &refundAmount, &paymentID, &createdAt, &updatedAt, &currency); err != nil {
+		return bo.Return{}, err
+	}
+
+	return bo.Return{
+		ID:           id.Int64,
+		OrderID:      orderID.Int64,
+		Status:       bo.ReturnStatus(status.String),
+		Reason:       reason.String,
+		RefundAmount: refundAmount.Decimal,
+		PaymentID:    paymentID.Int64,
+		CreatedAt:    createdAt.Time,
+		UpdatedAt:    updatedAt.Time,
+		Currency:     currency.String,
+	}, nil
+}
+
+func listReturnItems(ctx context.Context, q querier, returnID int64) ([]bo.ReturnItem, error) {
+	rows, err := q.Query(ctx, `SELECT ri.id, ri.return_id, ri.order_item_id, oi.product_id, oi.variant_id,
+			ri.quantity, ri.received_quantity, ri.condition, oi.unit_price, oi.discount_price, oi.quantity, oi.tax, o.tax_pricing
+		FROM return_items ri INNER JOIN order_items oi ON oi.id = ri.order_item_id
+			INNER JOIN orders o ON o.id = oi.order_id
+		WHERE ri.return_id = $1 ORDER BY ri.id ASC`, returnID)
+	if err != nil {
+		slog.Error("failed to list return items", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []bo.ReturnItem{}
+	for rows.Next() {
+		var (
+			id               sql.NullInt64
+			itemReturnID     sql.NullInt64
+			orderItemID      sql.NullInt64
+			productID        sql.NullInt64
+			variantID        sql.NullInt64
+			quantity         sql.NullInt64
+			receivedQuantity sql.NullInt64
+			condition        sql.NullString
+			unitPrice        decimal.NullDecimal
+			discountPrice    decimal.NullDecimal
+			orderedQuantity  sql.NullInt64
+			tax              decimal.NullDecimal
+			taxPricing       sql.NullString
+		)
+		if err := rows.Scan(&id, &itemReturnID, &orderItemID, &productID, &variantID,
+			&quantity, &receivedQuantity, &condition, &unitPrice, &discountPrice, &orderedQuantity, &tax, &taxPricing); err != nil {
+			slog.Error("failed to scan return item row", "cause", err)
+			return nil, err
+		}
+
+		// the refund follows what was charged on the order line
+		charged := bo.OrderItem{UnitPrice: bo.NewMoney(unitPrice.Decimal, ""), DiscountPrice: bo.NewMoney(discountPrice.Decimal, "")}
+		item := bo.ReturnItem{
+			ID:               id.Int64,
+			ReturnID:         itemReturnID.Int64,
+			OrderItemID:      orderItemID.Int64,
+			ProductID:        productID.Int64,
+			VariantID:        variantID.Int64,
+			Quantity:         quantity.Int64,
+			ReceivedQuantity: receivedQuantity.Int64,
+			Condition:        bo.ReturnCondition(condition.String),
+			UnitPrice:        charged.Price().Amount,
+			OrderedQuantity:  orderedQuantity.Int64,
+		}
+		// the inclusive prices already hold the tax
+		if bo.TaxPricing(taxPricing.String) == bo.TaxExclusive {
+			item.Tax = tax.Decimal
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func listReturnEvents(ctx context.Context, q querier, returnID int64) ([]bo.ReturnEvent, error) {
+	rows, err := q.Query(ctx, `SELECT id, return_id, from_status, to_status, note, created_by, created_at
+		FROM return_events WHERE return_id = $1 ORDER BY created_at ASC, id ASC`, returnID)
+	if err != nil {
+		slog.Error("failed to list return events", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []bo.ReturnEvent{}
+	for rows.Next() {
+		var (
+			id            sql.NullInt64
+			eventReturnID sql.NullInt64
+			fromStatus    sql.NullString
+			toStatus      sql.NullString
+			note          sql.NullString
+			createdBy     sql.NullString
+			createdAt     sql.NullTime
+		)
+		if err := rows.Scan(&id, &eventReturnID, &fromStatus, &toStatus, &note, &createdBy, &createdAt); err != nil {
+			slog.Error("failed to scan return event row", "cause", err)
+			return nil, err
+		}
+		events = append(events, bo.ReturnEvent{
+			ID:         id.Int64,
+			ReturnID:   eventReturnID.Int64,
+			FromStatus: bo.ReturnStatus(fromStatus.String),
+			ToStatus:   bo.ReturnStatus(toStatus.String),
+			Note:       note.String,
+			CreatedBy:  createdBy.String,
+			CreatedAt:  createdAt.Time,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (s *returnStore) ListOrderReturns(ctx context.Context, orderID int64) (bo.ReturnCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `SELECT id FROM returns WHERE order_id = $1 ORDER BY id ASC`, orderID)
+	if err != nil {
+		slog.Error("failed to list order returns", "cause", err)
+		return nil, err
+	}
+
+	var returnIDs []int64
+	for rows.Next() {
+		var id sql.NullInt64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			slog.Error("failed to scan return row", "cause", err)
+			return nil, err
+		}
+		returnIDs = append(returnIDs, id.Int64)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	returns := bo.ReturnCollection{}
+	for _, id := range returnIDs {
+		ret, err := getReturn(ctx, conn, id)
+		if err != nil {
+			return nil, err
+		}
+		returns = append(returns, ret)
+	}
+
+	return returns, nil
+}
+
+// CreateReturn requests a return for lines of a shipped or delivered order. The
+// order row is locked so two requests cannot both claim the same quantity.
+func (s *returnStore) CreateReturn(ctx context.Context, request bo.ReturnRequest) (bo.Return, error) {
+	var ret bo.Return
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		var status sql.NullString
+		err := tx.QueryRow(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, request.OrderID).Scan(&status)
+		if err == pgx.ErrNoRows {
+			return bo.ErrOrderNotFound
+		}
+		if err != nil {
+			slog.Error("failed to lock order", slog.Int64("orderID", request.OrderID), "cause", err)
+			return err
+		}
+		if !bo.OrderStatus(status.String).Returnable() {
+			return bo.ErrOrderNotReturnable
+		}
+
+		for _, item := range request.Items {
+			// quantity already claimed by returns which were not rejected
+			var returnable sql.NullInt64
+			err := tx.QueryRow(ctx, `SELECT oi.quantity - COALESCE((SELECT SUM(ri.quantity) FROM return_items ri
+					INNER JOIN returns r ON r.id = ri.return_id
+					WHERE ri.order_item_id = oi.id AND r.status <> $3), 0)
+				FROM order_items oi WHERE oi.id = $1 AND oi.order_id = $2`,
+				item.OrderItemID, request.OrderID, string(bo.ReturnRejected)).Scan(&returnable)
+			if err == pgx.ErrNoRows {
+				return bo.ErrReturnItemNotFound
+			}
+			if err != nil {
+				slog.Error("failed to read returnable quantity", slog.Int64("orderItemID", item.OrderItemID), "cause", err)
+				return err
+			}
+			if item.Quantity > returnable.Int64 {
+				return bo.ErrReturnQuantityExceeded
+			}
+		}
+
+		var returnID sql.NullInt64
+		err = tx.QueryRow(ctx, `INSERT INTO returns(order_id, status, reason) VALUES ($1, $2, $3) RETURNING id`,
+			request.OrderID, string(bo.ReturnRequested), sql.NullString{String: request.Reason, Valid: request.Reason != ""},
+		).Scan(&returnID)
+		if err != nil {
+			slog.Error("failed to insert return", "cause", err)
+			return fmt.Errorf("failed to insert return: %w", err)
+		}
+
+		for _, item := range request.Items {
+			if _, err := tx.Exec(ctx, `INSERT INTO return_items(return_id, order_item_id, quantity) VALUES ($1, $2, $3)`,
+				returnID.Int64, item.OrderItemID, item.Quantity); err != nil {
+				slog.Error("failed to insert return item", "cause", err)
+				return fmt.Errorf("failed to insert return item: %w", err)
+			}
+		}
+
+		if err := recordReturnEvent(ctx, tx, returnID.Int64, "", bo.ReturnRequested, request.Reason, request.RequestedBy); err != nil {
+			return err
+		}
+
+		ret, err = getReturn(ctx, tx, returnID.Int64)
+		return err
+	})
+
+	return ret, err
+}
+
+// UpdateReturnStatus moves a return to its next status when it is still in
+// change.From and records the step. The return row is locked first so only
+// one of two concurrent changes from the same status goes through, the other
+// gets ErrReturnStatusConflict. The transition rules are enforced by the
+// return service.
+func (s *returnStore) UpdateReturnStatus(ctx context.Context, change bo.ReturnStatusChange) (bo.Return, error) {
+	var ret bo.Return
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		if err := updateReturnStatusInTx(ctx, tx, change); err != nil {
+			return err
+		}
+
+		var err error
+		ret, err = getReturn(ctx, tx, change.ReturnID)
+		return err
+	})
+
+	return ret, err
+}
+
+func updateReturnStatusInTx(ctx context.Context, tx pgx.Tx, change bo.ReturnStatusChange) error {
+	var status sql.NullString
+	err := tx.QueryRow(ctx, `SELECT status FROM returns WHERE id = $1 FOR UPDATE`, change.ReturnID).Scan(&status)
+	if err == pgx.ErrNoRows {
+		slog.Error("return id does not exist", slog.Int64("id", change.ReturnID))
+		return bo.ErrReturnNotFound
+	}
+	if err != nil {
+		slog.Error("failed to lock return", slog.Int64("returnID", change.ReturnID), "cause", err)
+		return err
+	}
+	if bo.ReturnStatus(status.String) != change.From {
+		return bo.ErrReturnStatusConflict
+	}
+
+	commandTag, err := tx.Exec(ctx, `UPDATE returns
+		SET status = $1, refund_amount = refund_amount + $4, payment_id = COALESCE($5, payment_id), updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND status = $3`,
+		string(change.To), change.ReturnID, string(change.From), change.RefundAmount,
+		sql.NullInt64{Int64: change.PaymentID, Valid: change.PaymentID != 0})
+	if err != nil {
+		slog.Error("failed to update return status", slog.Int64("returnID", change.ReturnID), "cause", err)
+		return fmt.Errorf("failed to update return status: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return bo.ErrReturnStatusConflict
+	}
+
+	return recordReturnEvent(ctx, tx, change.ReturnID, change.From, change.To, change.Note, change.ChangedBy)
+}
+
+func recordReturnEvent(ctx context.Context, tx pgx.Tx, returnID int64, from, to bo.ReturnStatus, note, createdBy string) error {
+	_, err := tx.Exec(ctx, `INSERT INTO return_events(return_id, from_status, to_status, note, created_by) VALUES ($1, $2, $3, $4, $5)`,
+		returnID, sql.NullString{String: string(from), Valid: from != ""}, string(to),
+		sql.NullString{String: note, Valid: note != ""}, sql.NullString{String: createdBy, Valid: createdBy != ""})
+	if err != nil {
+		slog.Error("failed to insert return event", slog.Int64("returnID", returnID), "cause", err)
+		return fmt.Errorf("failed to insert return event: %w", err)
+	}
+	return nil
+}
+
+// ReceiveReturn records the items of an approved return arriving back. Sellable
+// items go back on hand on the stock row the order line was taken from, damaged
+// items are booked back and written off so the ledger shows both.
+func (s *returnStore) ReceiveReturn(ctx context.Context, receipt bo.ReturnReceipt) (bo.Return, error) {
+	var ret bo.Return
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		err := updateReturnStatusInTx(ctx, tx, bo.ReturnStatusChange{
+			ReturnID:  receipt.ReturnID,
+			From:      bo.ReturnApproved,
+			To:        bo.ReturnReceived,
+			Note:      receipt.Note,
+			ChangedBy: receipt.ChangedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		reference := fmt.Sprintf("return:%d", receipt.ReturnID)
+		for _, item := range receipt.Items {
+			var orderItemID, receivedQuantity sql.NullInt64
+			err := tx.QueryRow(ctx, `UPDATE return_items
+				SET received_quantity = CASE WHEN $3 = 0 THEN quantity ELSE $3 END, condition = $4
+				WHERE id = $1 AND return_id = $2 AND $3 <= quantity
+				RETURNING order_item_id, received_quantity`,
+				item.ReturnItemID, receipt.ReturnID, item.Quantity, string(item.Condition),
+			).Scan(&orderItemID, &receivedQuantity)
+			if err == pgx.ErrNoRows {
+				return bo.ErrReturnItemNotFound
+			}
+			if err != nil {
+				slog.Error("failed to receive return item", slog.Int64("returnItemID", item.ReturnItemID), "cause", err)
+				return fmt.Errorf("failed to receive return item: %w", err)
+			}
+
+			if err := restockReturnItem(ctx, tx, orderItemID.Int64, receivedQuantity.Int64, item.Condition, reference, receipt.ChangedBy); err != nil {
+				return err
+			}
+		}
+
+		ret, err = getReturn(ctx, tx, receipt.ReturnID)
+		return err
+	})
+
+	return ret, err
+}
+
+// restockReturnItem books a returned quantity on the stock row its order line
+// was mostly taken from.
+func restockReturnItem(ctx context.Context, tx pgx.Tx, orderItemID, quantity int64, condition bo.ReturnCondition, reference, changedBy string) error {
+	var productStockID sql.NullInt64
+	err := tx.QueryRow(ctx, `SELECT product_stock_id FROM order_item_allocations
+		WHERE order_item_id = $1 AND product_stock_id IS NOT NULL
+		ORDER BY quantity DESC, id ASC LIMIT 1`, orderItemID).Scan(&productStockID)
+	if err == pgx.ErrNoRows {
+		return bo.ErrProductStockNotFound
+	}
+	if err != nil {
+		slog.Error("failed to read order item allocation", slog.Int64("orderItemID", orderItemID), "cause", err)
+		return err
+	}
+
+	if _, err := adjustStockInTx(ctx, tx, bo.ProductStockAdjustment{
+		ProductStockID: productStockID.Int64,
+		Delta:          quantity,
+		Reason:         bo.StockMovementReturn,
+		Reference:      reference,
+		ChangedBy:      changedBy,
+	}); err != nil {
+		return err
+	}
+
+	if condition != bo.ReturnDamaged {
+		return nil
+	}
+	// the units written off were booked back just above, so no reserved unit goes
+	_, err = adjustReservedStockInTx(ctx, tx, bo.ProductStockAdjustment{
+		ProductStockID: productStockID.Int64,
+		Delta:          -quantity,
+		Reason:         bo.StockMovementDamage,
+		Reference:      reference,
+		ChangedBy:      changedBy,
+	})
+	return err
+}
@@ -0,0 +1,194 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type searchStore struct {
+	dbPool *pgxpool.Pool
+}
+
+var searchSynonymFields = []string{
+	"id",
+	"term",
+	"synonym",
+	"created_at",
+}
+
+// suggestQuery looks the prefix and its synonyms up in the names of the active
+// products, brands and categories. A name matches when it or one of its words
+// starts with a term, or when a term is similar to one of its words (pg_trgm
+// word similarity), so "samsnug" still finds "Samsung". Popularity is the
+// quantity sold in orders which were not cancelled or refunded.
+const suggestQuery = `WITH terms AS (
+		SELECT $1::text AS term
+		UNION
+		SELECT synonym FROM search_synonyms WHERE term = $1
+	),
+	sold AS (
+		SELECT oi.product_id, SUM(oi.quantity) AS quantity
+		FROM order_items oi INNER JOIN orders o ON o.id = oi.order_id
+		WHERE o.status NOT IN ('cancelled', 'refunded')
+		GROUP BY oi.product_id
+	),
+	candidates AS (
+		SELECT 'product' AS kind, p.id, p.name, COALESCE(sold.quantity, 0) AS popularity
+		FROM products p LEFT JOIN sold ON sold.product_id = p.id
+		WHERE p.status_id = 1
+		UNION ALL
+		SELECT 'brand', b.id, b.name, COALESCE(SUM(sold.quantity), 0)
+		FROM brands b LEFT JOIN products p ON p.brand_id = b.id LEFT JOIN sold ON sold.product_id = p.id
+		WHERE b.status_id = 1
+		GROUP BY b.id, b.name
+		UNION ALL
+		SELECT 'category', c.id, c.name, COALESCE(SUM(sold.quantity), 0)
+		FROM categories c LEFT JOIN products p ON p.category_id = c.id LEFT JOIN sold ON sold.product_id = p.id
+		WHERE c.status_id = 1
+		GROUP BY c.id, c.name
+	)
+	SELECT c.kind, c.id, c.name, c.popularity
+	FROM candidates c CROSS JOIN terms t
+	WHERE starts_with(LOWER(c.name), t.term) OR POSITION(' ' || t.term IN LOWER(c.name)) > 0 OR t.term <% LOWER(c.name)
+	GROUP BY c.kind, c.id, c.name, c.popularity
+	ORDER BY MAX(CASE WHEN starts_with(LOWER(c.name), t.term) THEN 2 WHEN POSITION(' ' || t.term IN LOWER(c.name)) > 0 THEN 1 ELSE 0 END) DESC,
+		MAX(word_similarity(t.term, LOWER(c.name))) DESC, c.popularity DESC, c.name ASC
+	LIMIT $2`
+
+func (s *searchStore) Suggest(ctx context.Context, query bo.SuggestQuery) (bo.SuggestionCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, suggestQuery, query.Prefix, query.Limit)
+	if err != nil {
+		slog.Error("failed to suggest search terms", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := bo.SuggestionCollection{}
+	for rows.Next() {
+		var (
+			kind       sql.NullString
+			id         sql.NullInt64
+			name       sql.NullString
+			popularity sql.NullInt64
+		)
+		if err := rows.Scan(&kind, &id, &name, &popularity); err != nil {
+			slog.Error("failed to scan suggestion row", "cause", err)
+			return nil, err
+		}
+		suggestions = append(suggestions, bo.Suggestion{
+			Kind:       bo.SuggestionKind(kind.String),
+			ID:         id.Int64,
+			Name:       name.String,
+			Popularity: popularity.Int64,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+func (s *searchStore) ListSearchSynonyms(ctx context.Context) (bo.SearchSynonymCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	dbQuery, args := sqlbuilder.Select(searchSynonymFields...).From("search_synonyms").OrderBy("term ASC", "synonym ASC").Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to list search synonyms", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	synonyms := bo.SearchSynonymCollection{}
+	for rows.Next() {
+		var (
+			id        sql.NullInt64
+			term      sql.NullString
+			synonym   sql.NullString
+			createdAt sql.NullTime
+		)
+		if err := rows.Scan(&id, &term, &synonym, &createdAt); err != nil {
+			slog.Error("failed to scan search synonym row", "cause", err)
+			return nil, err
+		}
+		synonyms = append(synonyms, bo.SearchSynonym{
+			ID:        id.Int64,
+			Term:      term.String,
+			Synonym:   synonym.String,
+			CreatedAt: createdAt.Time,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return synonyms, nil
+}
+
+func (s *searchStore) CreateSearchSynonym(ctx context.Context, synonym *bo.SearchSynonym) error {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	sqlQuery, arguments := sqlbuilder.Insert("search_synonyms").
+		Values(map[string]any{"term": synonym.Term, "synonym": synonym.Synonym}).
+		OnConflict("(term, synonym) DO NOTHING").
+		Returning("id", "created_at").
+		Build()
+
+	var (
+		id        sql.NullInt64
+		createdAt sql.NullTime
+	)
+	if err := conn.QueryRow(ctx, sqlQuery, arguments...).Scan(&id, &createdAt); err != nil {
+		// nothing is returned when the conflict skipped the insert
+		if err == pgx.ErrNoRows {
+			return bo.ErrSearchSynonymExists
+		}
+		slog.Error("failed to insert search synonym", "cause", err)
+		return err
+	}
+
+	synonym.ID = id.Int64
+	synonym.CreatedAt = createdAt.Time
+	return nil
+}
+
+func (s *searchStore) DeleteSearchSynonym(ctx context.Context, synonymID int64) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		commandTag, err := tx.Exec(ctx, `DELETE FROM search_synonyms WHERE id = $1`, synonymID)
+		if err != nil {
+			slog.Error("failed to delete search synonym", slog.Int64("synonymID", synonymID), "cause", err)
+			return err
+		}
+		if commandTag.RowsAffected() == 0 {
+			return bo.ErrSearchSynonymNotFound
+		}
+
+		return nil
+	})
+}
@@ -23,11 +23,26 @@ func GetInstance(config *config.DBConfig) definition.DataStore {
 	dbpool := RwInstance(config)
 
 	return definition.DataStore{
-		Brand:        &brandStore{dbPool: dbpool},
-		Category:     &categoryStore{dbPool: dbpool},
-		Supplier:     &supplierStore{dbPool: dbpool},
-		Product:      &productStore{dbPool: dbpool},
-		ProductStock: &productStockStore{dbPool: dbpool},
+		Brand:            &brandStore{dbPool: dbpool},
+		Category:         &categoryStore{dbPool: dbpool},
+		Supplier:         &supplierStore{dbPool: dbpool},
+		Product:          &productStore{dbPool: dbpool},
+		ProductVariant:   &productVariantStore{dbPool: dbpool},
+		ProductMedia:     &productMediaStore{dbPool: dbpool},
+		ProductImport:    &productImportStore{dbPool: dbpool},
+		ProductPrice:     &productPriceStore{dbPool: dbpool},
+		Currency:         &currencyStore{dbPool: dbpool},
+		Tax:              &taxStore{dbPool: dbpool},
+		ProductStock:     &productStockStore{dbPool: dbpool},
+		Warehouse:        &warehouseStore{dbPool: dbpool},
+		StockMovement:    &stockMovementStore{dbPool: dbpool},
+		StockReservation: &stockReservationStore{dbPool: dbpool},
+		Cart:             &cartStore{dbPool: dbpool},
+		Order:            &orderStore{dbPool: dbpool},
+		Payment:          &paymentStore{dbPool: dbpool},
+		Return:           &returnStore{dbPool: dbpool},
+		Promotion:        &promotionStore{dbPool: dbpool},
+		Search:           &searchStore{dbPool: dbpool},
 	}
 }
 
@@ -52,12 +67,18 @@ func getInstance(config *config.DBConfig) *pgxpool.Pool {
 
 	dbpool, err = pgxpool.New(context.Background(), url)
 	if err != nil {
-		slog.Error("Unable to connect to database", err)
+		slog.Error("Unable to connect to database", "cause", err)
 	}
 
 	return dbpool
 }
 
+// querier is satisfied by both a pool connection and a transaction
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
 // WrapInTx starts a transaction on the given database connection pool, calls the
 // given transaction function with a pointer to the transaction, and either commits
 // or rolls back the transaction based on whether an error occurred or not. If the
@@ -0,0 +1,193 @@
+// Package sqlbuilder builds parameterized Postgres statements. Conditions are
+// written with ? placeholders which are numbered $1, $2... in the order they
+// appear, so values never end up in the SQL text.
+package sqlbuilder
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidSort = errors.New("the sort field or order is not allowed")
+)
+
+type clause struct {
+	expr string
+	args []any
+}
+
+// SelectBuilder builds a SELECT statement and the COUNT(*) of the same rows
+type SelectBuilder struct {
+	columns []string
+	from    string
+	joins   []clause
+	where   []clause
+	groupBy []string
+	orderBy []string
+	limit   int
+	offset  int
+	paged   bool
+}
+
+func Select(columns ...string) *SelectBuilder {
+	return &SelectBuilder{columns: columns}
+}
+
+func (b *SelectBuilder) From(table string) *SelectBuilder {
+	b.from = table
+	return b
+}
+
+// Join adds a join clause, for example "INNER JOIN brands b ON p.brand_id = b.id",
+// its ? placeholders are numbered before the ones of the conditions
+func (b *SelectBuilder) Join(join string, args ...any) *SelectBuilder {
+	b.joins = append(b.joins, clause{expr: join, args: args})
+	return b
+}
+
+// Where adds a condition, all conditions are combined with AND
+func (b *SelectBuilder) Where(expr string, args ...any) *SelectBuilder {
+	b.where = append(b.where, clause{expr: expr, args: args})
+	return b
+}
+
+func (b *SelectBuilder) GroupBy(terms ...string) *SelectBuilder {
+	b.groupBy = append(b.groupBy, terms...)
+	return b
+}
+
+// OrderBy adds trusted order terms, use Sortable for terms coming from a request
+func (b *SelectBuilder) OrderBy(terms ...string) *SelectBuilder {
+	b.orderBy = append(b.orderBy, terms...)
+	return b
+}
+
+// Keyset orders by the keys, the last one being unique, all in the same
+// direction. With the key values of a row it only selects the rows after that
+// row, or when backward the rows before it, nearest first.
+func (b *SelectBuilder) Keyset(keys []string, desc, backward bool, values ...any) *SelectBuilder {
+	// going backward through an ascending list is going forward through a descending one
+	descending := desc != backward
+	direction, operator := " ASC", " > "
+	if descending {
+		direction, operator = " DESC", " < "
+	}
+
+	if len(values) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+		b.Where("("+strings.Join(keys, ", ")+")"+operator+"("+placeholders+")", values...)
+	}
+	for _, key := range keys {
+		b.orderBy = append(b.orderBy, key+direction)
+	}
+	return b
+}
+
+func (b *SelectBuilder) Page(limit, offset int) *SelectBuilder {
+	b.limit = limit
+	b.offset = offset
+	b.paged = true
+	return b
+}
+
+func (b *SelectBuilder) Build() (string, []any) {
+	var (
+		sb   strings.Builder
+		args []any
+	)
+	sb.WriteString("SELECT ")
+	sb.WriteString(strings.Join(b.columns, ", "))
+	b.writeFrom(&sb, &args)
+
+	if len(b.groupBy) > 0 {
+		sb.WriteString(" GROUP BY ")
+		sb.WriteString(strings.Join(b.groupBy, ", "))
+	}
+	if len(b.orderBy) > 0 {
+		sb.WriteString(" ORDER BY ")
+		sb.WriteString(strings.Join(b.orderBy, ", "))
+	}
+	if b.paged {
+		args = append(args, b.limit)
+		sb.WriteString(" LIMIT $" + strconv.Itoa(len(args)))
+		args = append(args, b.offset)
+		sb.WriteString(" OFFSET $" + strconv.Itoa(len(args)))
+	}
+
+	return sb.String(), args
+}
+
+// BuildCount counts every row matching the query, ignoring grouping, order and paging
+func (b *SelectBuilder) BuildCount() (string, []any) {
+	var (
+		sb   strings.Builder
+		args []any
+	)
+	sb.WriteString("SELECT COUNT(*)")
+	b.writeFrom(&sb, &args)
+	return sb.String(), args
+}
+
+func (b *SelectBuilder) writeFrom(sb *strings.Builder, args *[]any) {
+	sb.WriteString(" FROM ")
+	sb.WriteString(b.from)
+	for _, join := range b.joins {
+		sb.WriteString(" ")
+		bind(sb, args, join)
+	}
+	writeWhere(sb, args, b.where)
+}
+
+func writeWhere(sb *strings.Builder, args *[]any, where []clause) {
+	for i, c := range where {
+		if i == 0 {
+			sb.WriteString(" WHERE ")
+		} else {
+			sb.WriteString(" AND ")
+		}
+		bind(sb, args, c)
+	}
+}
+
+// bind writes the clause with every ? replaced by the number of its argument
+func bind(sb *strings.Builder, args *[]any, c clause) {
+	next := 0
+	for _, r := range c.expr {
+		if r == '?' && next < len(c.args) {
+			*args = append(*args, c.args[next])
+			next++
+			sb.WriteString("$" + strconv.Itoa(len(*args)))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+}
+
+// Sortable maps the sort fields a list accepts to the column they order by
+type Sortable map[string]string
+
+// Key returns the column of a requested field and whether the order is
+// descending, the order is ASC or DESC in any case. Anything else is ErrInvalidSort.
+func (s Sortable) Key(field, order string) (string, bool, error) {
+	column, ok := s[field]
+	if !ok {
+		return "", false, ErrInvalidSort
+	}
+
+	switch strings.ToUpper(order) {
+	case "ASC":
+		return column, false, nil
+	case "DESC":
+		return column, true, nil
+	}
+	return "", false, ErrInvalidSort
+}
+
+// Contains is the LIKE pattern of a substring search, with the wildcards of
+// the search text escaped
+func Contains(text string) string {
+	text = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+	return "%" + text + "%"
+}
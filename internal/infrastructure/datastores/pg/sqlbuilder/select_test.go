@@ -0,0 +1,209 @@
+package sqlbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelect(t *testing.T) {
+	testCases := []struct {
+		name      string
+		builder   *SelectBuilder
+		sql       string
+		args      []any
+		countSQL  string
+		countArgs []any
+	}{
+		{
+			name:     "NoCondition",
+			builder:  Select("id", "name").From("brands"),
+			sql:      "SELECT id, name FROM brands",
+			countSQL: "SELECT COUNT(*) FROM brands",
+		},
+		{
+			name:     "OrderAndPage",
+			builder:  Select("id", "name").From("brands").OrderBy("name ASC", "id ASC").Page(20, 40),
+			sql:      "SELECT id, name FROM brands ORDER BY name ASC, id ASC LIMIT $1 OFFSET $2",
+			args:     []any{20, 40},
+			countSQL: "SELECT COUNT(*) FROM brands",
+		},
+		{
+			name: "ConditionsNumberedInOrder",
+			builder: Select("p.id").From("products p").
+				Join("INNER JOIN suppliers s ON p.supplier_id = s.id").
+				Where("p.status_id = 1").
+				Where("p.unit_price BETWEEN ? AND ?", 10.5, 99.0).
+				Where("p.brand_id = ANY(?)", []int64{1, 2}).
+				Where("s.is_verified_supplier = true").
+				OrderBy("p.unit_price DESC").
+				Page(10, 0),
+			sql: "SELECT p.id FROM products p INNER JOIN suppliers s ON p.supplier_id = s.id" +
+				" WHERE p.status_id = 1 AND p.unit_price BETWEEN $1 AND $2 AND p.brand_id = ANY($3) AND s.is_verified_supplier = true" +
+				" ORDER BY p.unit_price DESC LIMIT $4 OFFSET $5",
+			args: []any{10.5, 99.0, []int64{1, 2}, 10, 0},
+			countSQL: "SELECT COUNT(*) FROM products p INNER JOIN suppliers s ON p.supplier_id = s.id" +
+				" WHERE p.status_id = 1 AND p.unit_price BETWEEN $1 AND $2 AND p.brand_id = ANY($3) AND s.is_verified_supplier = true",
+			countArgs: []any{10.5, 99.0, []int64{1, 2}},
+		},
+		{
+			name: "JoinArgumentsFirst",
+			builder: Select("p.id", "ts_rank(p.search_vector, query)").From("products p").
+				Join("CROSS JOIN websearch_to_tsquery('english', ?) query", "phone").
+				Where("p.search_vector @@ query").
+				Where("p.brand_id = ?", int64(2)),
+			sql:       "SELECT p.id, ts_rank(p.search_vector, query) FROM products p CROSS JOIN websearch_to_tsquery('english', $1) query WHERE p.search_vector @@ query AND p.brand_id = $2",
+			args:      []any{"phone", int64(2)},
+			countSQL:  "SELECT COUNT(*) FROM products p CROSS JOIN websearch_to_tsquery('english', $1) query WHERE p.search_vector @@ query AND p.brand_id = $2",
+			countArgs: []any{"phone", int64(2)},
+		},
+		{
+			name: "GroupBy",
+			builder: Select("p.brand_id", "COUNT(*)").From("products p").
+				Where("p.unit_price >= ?", 10.0).
+				GroupBy("p.brand_id").
+				OrderBy("COUNT(*) DESC"),
+			sql:       "SELECT p.brand_id, COUNT(*) FROM products p WHERE p.unit_price >= $1 GROUP BY p.brand_id ORDER BY COUNT(*) DESC",
+			args:      []any{10.0},
+			countSQL:  "SELECT COUNT(*) FROM products p WHERE p.unit_price >= $1",
+			countArgs: []any{10.0},
+		},
+		{
+			name:      "ValueIsNeverInlined",
+			builder:   Select("id").From("products").Where("name LIKE ?", "'; DROP TABLE products; --"),
+			sql:       "SELECT id FROM products WHERE name LIKE $1",
+			args:      []any{"'; DROP TABLE products; --"},
+			countSQL:  "SELECT COUNT(*) FROM products WHERE name LIKE $1",
+			countArgs: []any{"'; DROP TABLE products; --"},
+		},
+		{
+			name:      "ReusedArgument",
+			builder:   Select("id").From("orders").Where("(? = '' OR status = ?)", "paid", "paid"),
+			sql:       "SELECT id FROM orders WHERE ($1 = '' OR status = $2)",
+			args:      []any{"paid", "paid"},
+			countSQL:  "SELECT COUNT(*) FROM orders WHERE ($1 = '' OR status = $2)",
+			countArgs: []any{"paid", "paid"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, args := tc.builder.Build()
+			require.Equal(t, tc.sql, sql)
+			require.Equal(t, tc.args, args)
+
+			countSQL, countArgs := tc.builder.BuildCount()
+			require.Equal(t, tc.countSQL, countSQL)
+			require.Equal(t, tc.countArgs, countArgs)
+		})
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	testCases := []struct {
+		name     string
+		desc     bool
+		backward bool
+		values   []any
+		sql      string
+		args     []any
+	}{
+		{
+			name: "FirstPage",
+			sql:  "SELECT id FROM brands WHERE status_id = 1 ORDER BY name ASC, id ASC LIMIT $1 OFFSET $2",
+			args: []any{21, 0},
+		},
+		{
+			name:   "After",
+			values: []any{"acme", int64(7)},
+			sql:    "SELECT id FROM brands WHERE status_id = 1 AND (name, id) > ($1, $2) ORDER BY name ASC, id ASC LIMIT $3 OFFSET $4",
+			args:   []any{"acme", int64(7), 21, 0},
+		},
+		{
+			name:     "Before",
+			backward: true,
+			values:   []any{"acme", int64(7)},
+			sql:      "SELECT id FROM brands WHERE status_id = 1 AND (name, id) < ($1, $2) ORDER BY name DESC, id DESC LIMIT $3 OFFSET $4",
+			args:     []any{"acme", int64(7), 21, 0},
+		},
+		{
+			name:   "DescendingAfter",
+			desc:   true,
+			values: []any{"acme", int64(7)},
+			sql:    "SELECT id FROM brands WHERE status_id = 1 AND (name, id) < ($1, $2) ORDER BY name DESC, id DESC LIMIT $3 OFFSET $4",
+			args:   []any{"acme", int64(7), 21, 0},
+		},
+		{
+			name:     "DescendingBefore",
+			desc:     true,
+			backward: true,
+			values:   []any{"acme", int64(7)},
+			sql:      "SELECT id FROM brands WHERE status_id = 1 AND (name, id) > ($1, $2) ORDER BY name ASC, id ASC LIMIT $3 OFFSET $4",
+			args:     []any{"acme", int64(7), 21, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, args := Select("id").From("brands").
+				Where("status_id = 1").
+				Keyset([]string{"name", "id"}, tc.desc, tc.backward, tc.values...).
+				Page(21, 0).
+				Build()
+			require.Equal(t, tc.sql, sql)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
+
+func TestSortableKey(t *testing.T) {
+	sortable := Sortable{"name": "p.name", "unit_price": "p.unit_price"}
+
+	testCases := []struct {
+		name   string
+		field  string
+		order  string
+		column string
+		desc   bool
+		err    error
+	}{
+		{name: "Ascending", field: "name", order: "ASC", column: "p.name"},
+		{name: "LowerCaseOrder", field: "unit_price", order: "desc", column: "p.unit_price", desc: true},
+		{name: "UnknownField", field: "s.verified", order: "ASC", err: ErrInvalidSort},
+		{name: "InjectedField", field: "name; DROP TABLE products", order: "ASC", err: ErrInvalidSort},
+		{name: "InjectedOrder", field: "name", order: "ASC, (SELECT 1)", err: ErrInvalidSort},
+		{name: "EmptyOrder", field: "name", order: "", err: ErrInvalidSort},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			column, desc, err := sortable.Key(tc.field, tc.order)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.column, column)
+			require.Equal(t, tc.desc, desc)
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	testCases := []struct {
+		name    string
+		text    string
+		pattern string
+	}{
+		{name: "Plain", text: "phone", pattern: "%phone%"},
+		{name: "Wildcards", text: "100%_off", pattern: `%100\%\_off%`},
+		{name: "Backslash", text: `a\b`, pattern: `%a\\b%`},
+		{name: "Quote", text: "o'neil", pattern: "%o'neil%"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.pattern, Contains(tc.text))
+		})
+	}
+}
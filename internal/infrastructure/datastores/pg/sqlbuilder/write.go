@@ -0,0 +1,139 @@
+package sqlbuilder
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InsertBuilder builds an INSERT of a single row
+type InsertBuilder struct {
+	table      string
+	values     map[string]any
+	onConflict string
+	returning  []string
+}
+
+func Insert(table string) *InsertBuilder {
+	return &InsertBuilder{table: table}
+}
+
+// Values sets the row, columns are written in name order so the statement is stable
+func (b *InsertBuilder) Values(values map[string]any) *InsertBuilder {
+	b.values = values
+	return b
+}
+
+// OnConflict sets a trusted conflict action, for example "(term) DO NOTHING"
+func (b *InsertBuilder) OnConflict(action string) *InsertBuilder {
+	b.onConflict = action
+	return b
+}
+
+func (b *InsertBuilder) Returning(columns ...string) *InsertBuilder {
+	b.returning = columns
+	return b
+}
+
+func (b *InsertBuilder) Build() (string, []any) {
+	columns := sortedColumns(b.values)
+	args := make([]any, 0, len(columns))
+	placeholders := make([]string, 0, len(columns))
+	for _, column := range columns {
+		args = append(args, b.values[column])
+		placeholders = append(placeholders, "$"+strconv.Itoa(len(args)))
+	}
+
+	sql := "INSERT INTO " + b.table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+	if b.onConflict != "" {
+		sql += " ON CONFLICT " + b.onConflict
+	}
+	if len(b.returning) > 0 {
+		sql += " RETURNING " + strings.Join(b.returning, ", ")
+	}
+	return sql, args
+}
+
+// UpdateBuilder builds an UPDATE of the rows matching its conditions
+type UpdateBuilder struct {
+	table     string
+	values    map[string]any
+	raw       []string
+	from      *clause
+	where     []clause
+	returning []string
+}
+
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{table: table}
+}
+
+// Set sets the changed columns, they are written in name order so the statement is stable
+func (b *UpdateBuilder) Set(values map[string]any) *UpdateBuilder {
+	b.values = values
+	return b
+}
+
+// SetRaw adds trusted assignments written after the Set columns, for example
+// "version = version + 1"
+func (b *UpdateBuilder) SetRaw(assignments ...string) *UpdateBuilder {
+	b.raw = append(b.raw, assignments...)
+	return b
+}
+
+// From joins other rows into the update, its ? placeholders are numbered
+// after the Set values and before the conditions
+func (b *UpdateBuilder) From(from string, args ...any) *UpdateBuilder {
+	b.from = &clause{expr: from, args: args}
+	return b
+}
+
+func (b *UpdateBuilder) Returning(columns ...string) *UpdateBuilder {
+	b.returning = columns
+	return b
+}
+
+// Where adds a condition, all conditions are combined with AND
+func (b *UpdateBuilder) Where(expr string, args ...any) *UpdateBuilder {
+	b.where = append(b.where, clause{expr: expr, args: args})
+	return b
+}
+
+func (b *UpdateBuilder) Build() (string, []any) {
+	var (
+		sb   strings.Builder
+		args []any
+	)
+	sb.WriteString("UPDATE " + b.table + " SET ")
+	for i, column := range sortedColumns(b.values) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		args = append(args, b.values[column])
+		sb.WriteString(column + " = $" + strconv.Itoa(len(args)))
+	}
+	for i, assignment := range b.raw {
+		if i > 0 || len(b.values) > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(assignment)
+	}
+	if b.from != nil {
+		sb.WriteString(" FROM ")
+		bind(&sb, &args, *b.from)
+	}
+	writeWhere(&sb, &args, b.where)
+	if len(b.returning) > 0 {
+		sb.WriteString(" RETURNING " + strings.Join(b.returning, ", "))
+	}
+	return sb.String(), args
+}
+
+func sortedColumns(values map[string]any) []string {
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
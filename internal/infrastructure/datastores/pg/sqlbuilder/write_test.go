@@ -0,0 +1,96 @@
+package sqlbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsert(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *InsertBuilder
+		sql     string
+		args    []any
+	}{
+		{
+			name:    "ColumnsInNameOrder",
+			builder: Insert("brands").Values(map[string]any{"status_id": int64(1), "name": "acme"}),
+			sql:     "INSERT INTO brands (name, status_id) VALUES ($1, $2)",
+			args:    []any{"acme", int64(1)},
+		},
+		{
+			name: "Returning",
+			builder: Insert("products").
+				Values(map[string]any{"name": "phone", "unit_price": 199.9, "brand_id": int64(3)}).
+				Returning("id"),
+			sql:  "INSERT INTO products (brand_id, name, unit_price) VALUES ($1, $2, $3) RETURNING id",
+			args: []any{int64(3), "phone", 199.9},
+		},
+		{
+			name: "OnConflict",
+			builder: Insert("search_synonyms").
+				Values(map[string]any{"term": "iphone", "synonym": "ios"}).
+				OnConflict("(term, synonym) DO NOTHING").
+				Returning("id"),
+			sql:  "INSERT INTO search_synonyms (synonym, term) VALUES ($1, $2) ON CONFLICT (term, synonym) DO NOTHING RETURNING id",
+			args: []any{"ios", "iphone"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, args := tc.builder.Build()
+			require.Equal(t, tc.sql, sql)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *UpdateBuilder
+		sql     string
+		args    []any
+	}{
+		{
+			name: "ByID",
+			builder: Update("brands").
+				Set(map[string]any{"status_id": int64(2), "name": "acme"}).
+				Where("id = ?", int64(7)),
+			sql:  "UPDATE brands SET name = $1, status_id = $2 WHERE id = $3",
+			args: []any{"acme", int64(2), int64(7)},
+		},
+		{
+			name: "SeveralConditions",
+			builder: Update("product_stocks").
+				Set(map[string]any{"stock_quantity": int64(5)}).
+				Where("id = ?", int64(1)).
+				Where("version = ?", int64(4)),
+			sql:  "UPDATE product_stocks SET stock_quantity = $1 WHERE id = $2 AND version = $3",
+			args: []any{int64(5), int64(1), int64(4)},
+		},
+		{
+			name: "FromAndReturning",
+			builder: Update("product_stocks ps").
+				Set(map[string]any{"stock_quantity": int64(5)}).
+				SetRaw("version = ps.version + 1").
+				From("(SELECT id FROM product_stocks WHERE product_id = ? FOR UPDATE) old", int64(9)).
+				Where("ps.id = old.id").
+				Returning("ps.id", "ps.version"),
+			sql: "UPDATE product_stocks ps SET stock_quantity = $1, version = ps.version + 1" +
+				" FROM (SELECT id FROM product_stocks WHERE product_id = $2 FOR UPDATE) old" +
+				" WHERE ps.id = old.id RETURNING ps.id, ps.version",
+			args: []any{int64(5), int64(9)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, args := tc.builder.Build()
+			require.Equal(t, tc.sql, sql)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type stockMovementStore struct {
+	dbPool *pgxpool.Pool
+}
+
+var stockMovementFields = []string{
+	"id",
+	"product_id",
+	"variant_id",
+	"warehouse_id",
+	"quantity_delta",
+	"balance_after",
+	"reason",
+	"reference",
+	"created_by",
+	"created_at",
+}
+
+// insertStockMovement appends a movement to the ledger, it must run in the
+// same transaction as the stock change it records.
+func insertStockMovement(ctx context.Context, tx pgx.Tx, movement *bo.StockMovement) error {
+	if !movement.Reason.Valid() {
+		return bo.ErrInvalidStockMovementReason
+	}
+
+	sqlQuery := `INSERT INTO stock_movements(product_id, variant_id, warehouse_id, quantity_delta, balance_after, reason, reference, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at`
+
+	var (
+		id        sql.NullInt64
+		createdAt sql.NullTime
+	)
+	err := tx.QueryRow(ctx, sqlQuery,
+		movement.ProductID,
+		sql.NullInt64{Int64: movement.VariantID, Valid: movement.VariantID != 0},
+		movement.WarehouseID,
+		movement.QuantityDelta,
+		movement.BalanceAfter,
+		string(movement.Reason),
+		sql.NullString{String: movement.Reference, Valid: movement.Reference != ""},
+		sql.NullString{String: movement.CreatedBy, Valid: movement.CreatedBy != ""},
+	).Scan(&id, &createdAt)
+	if err != nil {
+		slog.Error("failed to insert stock movement", slog.Int64("productID", movement.ProductID), "cause", err)
+		return fmt.Errorf("failed to insert stock movement: %w", err)
+	}
+
+	movement.ID = id.Int64
+	movement.CreatedAt = createdAt.Time
+	return nil
+}
+
+func (s *stockMovementStore) ListStockMovements(ctx context.Context, stockMovementQuery bo.StockMovementQuery) (bo.PaginatedStockMovementCollection, error) {
+	pagingCollection := bo.PaginatedStockMovementCollection{}
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return pagingCollection, err
+	}
+	defer conn.Release()
+
+	query := sqlbuilder.Select(stockMovementFields...).
+		From("stock_movements").
+		Where("product_id = ?", stockMovementQuery.ProductID).
+		OrderBy("created_at DESC", "id DESC").
+		Page(stockMovementQuery.Limit, stockMovementQuery.Offset)
+	dbQuery, args := query.Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to list stock movements", "cause", err)
+		return pagingCollection, err
+	}
+	defer rows.Close()
+
+	var movements bo.StockMovementCollection
+	for rows.Next() {
+		var (
+			id            sql.NullInt64
+			productID     sql.NullInt64
+			variantID     sql.NullInt64
+			warehouseID   sql.NullInt64
+			quantityDelta sql.NullInt64
+			balanceAfter  sql.NullInt64
+			reason        sql.NullString
+			reference     sql.NullString
+			createdBy     sql.NullString
+			createdAt     sql.NullTime
+		)
+		if err := rows.Scan(&id, &productID, &variantID, &warehouseID, &quantityDelta, &balanceAfter, &reason, &reference, &createdBy, &createdAt); err != nil {
+			slog.Error("failed to scan stock movement row", "cause", err)
+			return pagingCollection, err
+		}
+		movements = append(movements, bo.StockMovement{
+			ID:            id.Int64,
+			ProductID:     productID.Int64,
+			VariantID:     variantID.Int64,
+			WarehouseID:   warehouseID.Int64,
+			QuantityDelta: quantityDelta.Int64,
+			BalanceAfter:  balanceAfter.Int64,
+			Reason:        bo.StockMovementReason(reason.String),
+			Reference:     reference.String,
+			CreatedBy:     createdBy.String,
+			CreatedAt:     createdAt.Time,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return pagingCollection, err
+	}
+
+	pagingCollection.Data = movements
+	var totalRecord sql.NullInt64
+	countQuery, countArgs := query.BuildCount()
+	if err = conn.QueryRow(ctx, countQuery, countArgs...).Scan(&totalRecord); err != nil {
+		slog.Error("error scanning COUNT stock movements row", "cause", err)
+		return pagingCollection, err
+	}
+
+	pagingCollection.Total = totalRecord.Int64
+	return pagingCollection, nil
+}
+
+func (s *stockMovementStore) ReconcileProductStock(ctx context.Context, productID int64) ([]bo.StockDiscrepancy, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	dbQuery := `SELECT ps.warehouse_id, ps.variant_id, COALESCE(ps.stock_quantity, 0), COALESCE(SUM(m.quantity_delta), 0)
+		FROM product_stocks ps
+		LEFT JOIN stock_movements m ON m.product_id = ps.product_id
+			AND m.warehouse_id = ps.warehouse_id
+			AND m.variant_id IS NOT DISTINCT FROM ps.variant_id
+		WHERE ps.product_id = $1
+		GROUP BY ps.id
+		HAVING COALESCE(ps.stock_quantity, 0) <> COALESCE(SUM(m.quantity_delta), 0)
+		ORDER BY ps.warehouse_id ASC`
+	rows, err := conn.Query(ctx, dbQuery, productID)
+	if err != nil {
+		slog.Error("failed to reconcile product stock", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	discrepancies := []bo.StockDiscrepancy{}
+	for rows.Next() {
+		var (
+			warehouseID    sql.NullInt64
+			variantID      sql.NullInt64
+			stockQuantity  sql.NullInt64
+			ledgerQuantity sql.NullInt64
+		)
+		if err := rows.Scan(&warehouseID, &variantID, &stockQuantity, &ledgerQuantity); err != nil {
+			slog.Error("failed to scan stock discrepancy row", "cause", err)
+			return nil, err
+		}
+		discrepancies = append(discrepancies, bo.StockDiscrepancy{
+			WarehouseID:    warehouseID.Int64,
+			VariantID:      variantID.Int64,
+			StockQuantity:  stockQuantity.Int64,
+			LedgerQuantity: ledgerQuantity.Int64,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return discrepancies, nil
+}
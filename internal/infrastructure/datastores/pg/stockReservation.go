@@ -0,0 +1,227 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type stockReservationStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// reservedQuantityAggregate sums the quantity held by live reservations per stock row
+const reservedQuantityAggregate = `(SELECT product_stock_id, SUM(quantity) AS reserved_quantity FROM stock_reservations
+	WHERE status = 'active' AND expires_at > CURRENT_TIMESTAMP GROUP BY product_stock_id)`
+
+const stockReservationSelect = `SELECT r.id, r.product_stock_id, ps.product_id, ps.variant_id, ps.warehouse_id,
+	r.quantity, r.status, r.reference, r.expires_at, r.created_at, r.updated_at
+	FROM stock_reservations r INNER JOIN product_stocks ps ON ps.id = r.product_stock_id`
+
+func (s *stockReservationStore) GetStockReservationByID(ctx context.Context, reservationID int64) (bo.StockReservation, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return bo.StockReservation{}, err
+	}
+	defer conn.Release()
+
+	return getStockReservation(ctx, conn, reservationID)
+}
+
+// getStockReservation reads a reservation through a pool connection or a transaction.
+func getStockReservation(ctx context.Context, q querier, reservationID int64) (bo.StockReservation, error) {
+	var (
+		id             sql.NullInt64
+		productStockID sql.NullInt64
+		productID      sql.NullInt64
+		variantID      sql.NullInt64
+		warehouseID    sql.NullInt64
+		quantity       sql.NullInt64
+		status         sql.NullString
+		reference      sql.NullString
+		expiresAt      sql.NullTime
+		createdAt      sql.NullTime
+		updatedAt      sql.NullTime
+	)
+
+	row := q.QueryRow(ctx, stockReservationSelect+" WHERE r.id = $1", reservationID)
+	if err := row.Scan(&id, &productStockID, &productID, &variantID, &warehouseID, &quantity, &status, &reference, &expiresAt, &createdAt, &updatedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			slog.Error("stock reservation id does not exist", slog.Int64("id", reservationID))
+			return bo.StockReservation{}, bo.ErrStockReservationNotFound
+		}
+		slog.Error("failed to scan stock reservation table row", "cause", err)
+		return bo.StockReservation{}, err
+	}
+
+	return bo.StockReservation{
+		ID:             id.Int64,
+		ProductStockID: productStockID.Int64,
+		ProductID:      productID.Int64,
+		VariantID:      variantID.Int64,
+		WarehouseID:    warehouseID.Int64,
+		Quantity:       quantity.Int64,
+		Status:         bo.StockReservationStatus(status.String),
+		Reference:      reference.String,
+		ExpiresAt:      expiresAt.Time,
+		CreatedAt:      createdAt.Time,
+		UpdatedAt:      updatedAt.Time,
+	}, nil
+}
+
+// CreateStockReservation holds the quantity on the matching stock row with the
+// most available stock. The candidate rows are locked first, so two checkouts
+// can never both reserve the last units.
+func (s *stockReservationStore) CreateStockReservation(ctx context.Context, reservation *bo.StockReservation) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		stockIDs, err := lockProductStockRows(ctx, tx, reservation.ProductID, reservation.VariantID, reservation.WarehouseID)
+		if err != nil {
+			return err
+		}
+
+		var productStockID, warehouseID sql.NullInt64
+		pickQuery := `SELECT ps.id, ps.warehouse_id FROM product_stocks ps
+			LEFT JOIN ` + reservedQuantityAggregate + ` rs ON rs.product_stock_id = ps.id
+			WHERE ps.id = ANY($1) AND COALESCE(ps.stock_quantity, 0) - COALESCE(rs.reserved_quantity, 0) >= $2
+			ORDER BY COALESCE(ps.stock_quantity, 0) - COALESCE(rs.reserved_quantity, 0) DESC, ps.id ASC
+			LIMIT 1`
+		if err := tx.QueryRow(ctx, pickQuery, stockIDs, reservation.Quantity).Scan(&productStockID, &warehouseID); err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrInsufficientStock
+			}
+			slog.Error("failed to pick product stock row", "cause", err)
+			return err
+		}
+
+		ttl := reservation.TTL
+		if ttl <= 0 {
+			ttl = bo.DefaultStockReservationTTL
+		}
+
+		var (
+			id        sql.NullInt64
+			expiresAt sql.NullTime
+			createdAt sql.NullTime
+			updatedAt sql.NullTime
+		)
+		insertQuery := `INSERT INTO stock_reservations(product_stock_id, quantity, status, reference, expires_at)
+			VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP + $5::INT * INTERVAL '1 second')
+			RETURNING id, expires_at, created_at, updated_at`
+		err = tx.QueryRow(ctx, insertQuery, productStockID.Int64, reservation.Quantity, string(bo.StockReservationActive),
+			sql.NullString{String: reservation.Reference, Valid: reservation.Reference != ""}, int64(ttl.Seconds()),
+		).Scan(&id, &expiresAt, &createdAt, &updatedAt)
+		if err != nil {
+			slog.Error("failed to insert stock reservation", "cause", err)
+			return fmt.Errorf("failed to insert stock reservation: %w", err)
+		}
+
+		reservation.ID = id.Int64
+		reservation.ProductStockID = productStockID.Int64
+		reservation.WarehouseID = warehouseID.Int64
+		reservation.Status = bo.StockReservationActive
+		reservation.ExpiresAt = expiresAt.Time
+		reservation.CreatedAt = createdAt.Time
+		reservation.UpdatedAt = updatedAt.Time
+		return nil
+	})
+}
+
+// ConfirmStockReservation turns a live reservation into a sale, taking its
+// quantity off hand in the same transaction.
+func (s *stockReservationStore) ConfirmStockReservation(ctx context.Context, reservationID int64) (bo.StockReservation, error) {
+	var reservation bo.StockReservation
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		var (
+			productStockID sql.NullInt64
+			quantity       sql.NullInt64
+			status         sql.NullString
+			reference      sql.NullString
+			expired        sql.NullBool
+		)
+		lockQuery := `SELECT product_stock_id, quantity, status, reference, expires_at <= CURRENT_TIMESTAMP
+			FROM stock_reservations WHERE id = $1 FOR UPDATE`
+		if err := tx.QueryRow(ctx, lockQuery, reservationID).Scan(&productStockID, &quantity, &status, &reference, &expired); err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrStockReservationNotFound
+			}
+			slog.Error("failed to lock stock reservation", "cause", err)
+			return err
+		}
+		if bo.StockReservationStatus(status.String) != bo.StockReservationActive || expired.Bool {
+			return bo.ErrStockReservationNotActive
+		}
+
+		ref := reference.String
+		if ref == "" {
+			ref = fmt.Sprintf("reservation:%d", reservationID)
+		}
+		if _, err := adjustReservedStockInTx(ctx, tx, bo.ProductStockAdjustment{
+			ProductStockID: productStockID.Int64,
+			Delta:          -quantity.Int64,
+			Reason:         bo.StockMovementSale,
+			Reference:      ref,
+		}); err != nil {
+			return err
+		}
+
+		if err := setStockReservationStatus(ctx, tx, reservationID, bo.StockReservationConfirmed); err != nil {
+			return err
+		}
+
+		var err error
+		reservation, err = getStockReservation(ctx, tx, reservationID)
+		return err
+	})
+
+	return reservation, err
+}
+
+func (s *stockReservationStore) ReleaseStockReservation(ctx context.Context, reservationID int64) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		commandTag, err := tx.Exec(ctx, `UPDATE stock_reservations SET status = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2 AND status = $3`, string(bo.StockReservationReleased), reservationID, string(bo.StockReservationActive))
+		if err != nil {
+			slog.Error("failed to release stock reservation", slog.Int64("reservationID", reservationID), "cause", err)
+			return err
+		}
+		if commandTag.RowsAffected() > 0 {
+			return nil
+		}
+
+		if _, err := getStockReservation(ctx, tx, reservationID); err != nil {
+			return err
+		}
+		return bo.ErrStockReservationNotActive
+	})
+}
+
+func (s *stockReservationStore) ExpireStockReservations(ctx context.Context) (int64, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	commandTag, err := conn.Exec(ctx, `UPDATE stock_reservations SET status = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE status = $2 AND expires_at <= CURRENT_TIMESTAMP`, string(bo.StockReservationExpired), string(bo.StockReservationActive))
+	if err != nil {
+		slog.Error("failed to expire stock reservations", "cause", err)
+		return 0, err
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+func setStockReservationStatus(ctx context.Context, tx pgx.Tx, reservationID int64, status bo.StockReservationStatus) error {
+	if _, err := tx.Exec(ctx, `UPDATE stock_reservations SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`, string(status), reservationID); err != nil {
+		slog.Error("failed to update stock reservation status", slog.Int64("reservationID", reservationID), "cause", err)
+		return fmt.Errorf("failed to update stock reservation status: %w", err)
+	}
+	return nil
+}
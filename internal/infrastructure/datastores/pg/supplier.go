@@ -5,10 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -60,19 +60,7 @@ func (s *supplierStore) CreateSupplier(ctx context.Context, supplier *bo.Supplie
 		return fmt.Errorf("empty core insert for supplier")
 	}
 
-	start := 1
-	arguments := make([]interface{}, 0, len(insertMap))
-	fields := []string{}
-	placeholders := []string{}
-
-	for field, v := range insertMap {
-		fields = append(fields, field)
-		arguments = append(arguments, v)
-		placeholders = append(placeholders, "$"+strconv.Itoa(start))
-		start++
-	}
-
-	sqlQuery := fmt.Sprintf("INSERT INTO suppliers(%s)VALUES (%s) RETURNING id", strings.Join(fields, ","), strings.Join(placeholders, ","))
+	sqlQuery, arguments := sqlbuilder.Insert("suppliers").Values(insertMap).Returning("id").Build()
 
 	conn, err := s.dbPool.Acquire(ctx)
 	if err != nil {
@@ -123,22 +111,7 @@ func (s *supplierStore) UpdateSupplier(ctx context.Context, updateSupplier bo.Su
 		return errors.New("empty core update for supplier")
 	}
 
-	sqlQuery := "UPDATE suppliers SET "
-	start := 1
-	arguments := make([]interface{}, 0, len(updateMap)+1)
-
-	for k, v := range updateMap {
-		sqlQuery = sqlQuery + k + "=$" + strconv.Itoa(start)
-		arguments = append(arguments, v)
-		if start < len(updateMap) {
-			sqlQuery = sqlQuery + ", "
-		}
-
-		start++
-	}
-
-	sqlQuery = sqlQuery + fmt.Sprintf(" WHERE id = $%d", start)
-	arguments = append(arguments, updateSupplier.ID)
+	sqlQuery, arguments := sqlbuilder.Update("suppliers").Set(updateMap).Where("id = ?", updateSupplier.ID).Build()
 
 	conn, err := s.dbPool.Acquire(ctx)
 	if err != nil {
@@ -193,17 +166,32 @@ func (s *supplierStore) DeleteSupplier(ctx context.Context, supplierID int64) er
 }
 
 // ListSuppliers retrieves a list of suppliers based on the query parameters
+// supplierListSort names the only order suppliers are listed in, by name
+const supplierListSort = "name"
+
+func supplierCursor(supplier bo.Supplier) *bo.PageCursor {
+	return &bo.PageCursor{Sort: supplierListSort, Key: supplier.Name, ID: supplier.ID}
+}
+
 func (s *supplierStore) ListSuppliers(ctx context.Context, supplierQuery bo.SupplierQuery) (bo.PaginatedSupplierCollection, error) {
 	pagingCollection := bo.PaginatedSupplierCollection{}
 
+	cursor, backward, err := pageCursor(supplierQuery.PageCursors, supplierListSort)
+	if err != nil || !cursorKeyIs[string](cursor) {
+		return pagingCollection, bo.ErrInvalidCursor
+	}
+
 	conn, err := s.dbPool.Acquire(ctx)
 	if err != nil {
 		return pagingCollection, err
 	}
 	defer conn.Release()
 
-	dbQuery := fmt.Sprintf("SELECT %s FROM suppliers ORDER BY name ASC LIMIT $1 OFFSET $2", strings.Join(supplierFields, ","))
-	rows, err := conn.Query(ctx, dbQuery, supplierQuery.Limit, supplierQuery.Offset)
+	query := sqlbuilder.Select(supplierFields...).From("suppliers")
+	countQuery, countArgs := query.BuildCount()
+	keysetQuery(query, []string{"name", "id"}, false, supplierQuery.Limit, supplierQuery.Offset, cursor, backward)
+	dbQuery, args := query.Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
 	if err != nil {
 		return pagingCollection, err
 	}
@@ -222,9 +210,9 @@ func (s *supplierStore) ListSuppliers(ctx context.Context, supplierQuery bo.Supp
 		return pagingCollection, err
 	}
 
-	pagingCollection.Data = suppliers
+	pagingCollection.Data, pagingCollection.PageLinks = keysetPage(suppliers, supplierQuery.Limit, supplierQuery.Offset, supplierQuery.PageCursors, supplierCursor)
 	var totalRecord sql.NullInt64
-	if err = conn.QueryRow(ctx, `SELECT COUNT(*) FROM suppliers`).Scan(&totalRecord); err != nil {
+	if err = conn.QueryRow(ctx, countQuery, countArgs...).Scan(&totalRecord); err != nil {
 		return pagingCollection, err
 	}
 	pagingCollection.Total = totalRecord.Int64
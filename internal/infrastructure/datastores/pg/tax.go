@@ -0,0 +1,357 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+// taxStore reads and writes the rates as text so that they stay exact
+type taxStore struct {
+	dbPool *pgxpool.Pool
+}
+
+const (
+	taxClassColumns = `id, code, name, is_default, created_at`
+	taxRateColumns  = `id, tax_class_id, jurisdiction, name, rate::text, created_at, updated_at`
+)
+
+func scanTaxClass(row pgx.Row) (bo.TaxClass, error) {
+	var class bo.TaxClass
+	if err := row.Scan(&class.ID, &class.Code, &class.Name, &class.Default, &class.CreatedAt); err != nil {
+		return bo.TaxClass{}, err
+	}
+	return class, nil
+}
+
+func scanTaxRate(row pgx.Row) (bo.TaxRate, error) {
+	var (
+		rate     bo.TaxRate
+		rateText string
+	)
+	if err := row.Scan(&rate.ID, &rate.TaxClassID, &rate.Jurisdiction, &rate.Name, &rateText, &rate.CreatedAt, &rate.UpdatedAt); err != nil {
+		return bo.TaxRate{}, err
+	}
+	var err error
+	if rate.Rate, err = decimal.NewFromString(rateText); err != nil {
+		return bo.TaxRate{}, err
+	}
+	return rate, nil
+}
+
+// taxError maps the constraint violations of the tax tables
+func taxError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch {
+	case pgErr.Code == "23505" && pgErr.ConstraintName == "tax_classes_code_key":
+		return bo.ErrTaxClassExists
+	case pgErr.Code == "23505" && pgErr.ConstraintName == "tax_rates_tax_class_id_jurisdiction_name_key":
+		return fmt.Errorf("%w: the class already has a rate of that name in the jurisdiction", bo.ErrInvalidTaxRate)
+	case pgErr.Code == "23503":
+		return bo.ErrTaxClassNotFound
+	}
+	return err
+}
+
+// taxClassID is the tax_class_id of a product or category, NULL when it has no class of its own
+func taxClassID(classID int64) any {
+	if classID == 0 {
+		return nil
+	}
+	return classID
+}
+
+// taxClassError maps the assignment of a missing tax class to a product
+func taxClassError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "products_tax_class_id_fkey" {
+		return bo.ErrTaxClassNotFound
+	}
+	return err
+}
+
+func (s *taxStore) ListTaxClasses(ctx context.Context) (bo.TaxClassCollection, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `SELECT `+taxClassColumns+` FROM tax_classes ORDER BY is_default DESC, code ASC`)
+	if err != nil {
+		slog.Error("failed to list tax classes", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	classes := bo.TaxClassCollection{}
+	for rows.Next() {
+		class, err := scanTaxClass(rows)
+		if err != nil {
+			slog.Error("failed to scan tax class row", "cause", err)
+			return nil, err
+		}
+		classes = append(classes, class)
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return classes, nil
+}
+
+// clearDefaultTaxClass leaves the default to the class being written, there is one at most
+func clearDefaultTaxClass(ctx context.Context, tx pgx.Tx, exceptID int64) error {
+	if _, err := tx.Exec(ctx, `UPDATE tax_classes SET is_default = FALSE WHERE is_default AND id <> $1`, exceptID); err != nil {
+		slog.Error("failed to clear the default tax class", "cause", err)
+		return err
+	}
+	return nil
+}
+
+func (s *taxStore) CreateTaxClass(ctx context.Context, class *bo.TaxClass) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		if class.Default {
+			if err := clearDefaultTaxClass(ctx, tx, 0); err != nil {
+				return err
+			}
+		}
+
+		created, err := scanTaxClass(tx.QueryRow(ctx, `INSERT INTO tax_classes (code, name, is_default)
+			VALUES ($1, $2, $3) RETURNING `+taxClassColumns, class.Code, class.Name, class.Default))
+		if err != nil {
+			slog.Error("failed to insert tax class", "code", class.Code, "cause", err)
+			return taxError(err)
+		}
+
+		*class = created
+		return nil
+	})
+}
+
+func (s *taxStore) UpdateTaxClass(ctx context.Context, update bo.TaxClassUpdate) (bo.TaxClass, error) {
+	var class bo.TaxClass
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		if update.Default != nil && *update.Default {
+			if err := clearDefaultTaxClass(ctx, tx, update.ID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		class, err = scanTaxClass(tx.QueryRow(ctx, `UPDATE tax_classes
+			SET name = COALESCE($2, name), is_default = COALESCE($3, is_default)
+			WHERE id = $1 RETURNING `+taxClassColumns, update.ID, update.Name, update.Default))
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return bo.ErrTaxClassNotFound
+			}
+			slog.Error("failed to update tax class", slog.Int64("classID", update.ID), "cause", err)
+			return err
+		}
+		return nil
+	})
+
+	return class, err
+}
+
+// DeleteTaxClass drops a class with its rates, its products and categories fall back to the default class
+func (s *taxStore) DeleteTaxClass(ctx context.Context, classID int64) error {
+	commandTag, err := s.dbPool.Exec(ctx, `DELETE FROM tax_classes WHERE id = $1`, classID)
+	if err != nil {
+		slog.Error("failed to delete tax class", slog.Int64("classID", classID), "cause", err)
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return bo.ErrTaxClassNotFound
+	}
+	return nil
+}
+
+func (s *taxStore) ListTaxRates(ctx context.Context, classID int64) (bo.TaxRateCollection, error) {
+	rates := bo.TaxRateCollection{}
+
+	err := WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM tax_classes WHERE id = $1)`, classID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return bo.ErrTaxClassNotFound
+		}
+
+		rows, err := tx.Query(ctx, `SELECT `+taxRateColumns+` FROM tax_rates
+			WHERE tax_class_id = $1 ORDER BY jurisdiction ASC, name ASC`, classID)
+		if err != nil {
+			slog.Error("failed to list tax rates", slog.Int64("classID", classID), "cause", err)
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rate, err := scanTaxRate(rows)
+			if err != nil {
+				slog.Error("failed to scan tax rate row", "cause", err)
+				return err
+			}
+			rates = append(rates, rate)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (s *taxStore) CreateTaxRate(ctx context.Context, rate *bo.TaxRate) error {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	created, err := scanTaxRate(conn.QueryRow(ctx, `INSERT INTO tax_rates (tax_class_id, jurisdiction, name, rate)
+		VALUES ($1, $2, $3, $4::numeric) RETURNING `+taxRateColumns,
+		rate.TaxClassID, rate.Jurisdiction, rate.Name, rate.Rate.String()))
+	if err != nil {
+		slog.Error("failed to insert tax rate", slog.Int64("classID", rate.TaxClassID), "cause", err)
+		return taxError(err)
+	}
+
+	*rate = created
+	return nil
+}
+
+func (s *taxStore) UpdateTaxRate(ctx context.Context, update bo.TaxRateUpdate) (bo.TaxRate, error) {
+	var rateText *string
+	if update.Rate != nil {
+		text := update.Rate.String()
+		rateText = &text
+	}
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return bo.TaxRate{}, err
+	}
+	defer conn.Release()
+
+	rate, err := scanTaxRate(conn.QueryRow(ctx, `UPDATE tax_rates
+		SET name = COALESCE($3, name), rate = COALESCE($4::numeric, rate), updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND tax_class_id = $2 RETURNING `+taxRateColumns,
+		update.ID, update.TaxClassID, update.Name, rateText))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return bo.TaxRate{}, bo.ErrTaxRateNotFound
+		}
+		slog.Error("failed to update tax rate", slog.Int64("rateID", update.ID), "cause", err)
+		return bo.TaxRate{}, taxError(err)
+	}
+
+	return rate, nil
+}
+
+func (s *taxStore) DeleteTaxRate(ctx context.Context, classID, rateID int64) error {
+	commandTag, err := s.dbPool.Exec(ctx, `DELETE FROM tax_rates WHERE id = $1 AND tax_class_id = $2`, rateID, classID)
+	if err != nil {
+		slog.Error("failed to delete tax rate", slog.Int64("rateID", rateID), "cause", err)
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return bo.ErrTaxRateNotFound
+	}
+	return nil
+}
+
+// ResolveProductTaxRates takes the class of a product, else the one of its closest category
+// having one, else the default class. The rates of that class come from the longest
+// jurisdiction code matching the requested one, so US-CA wins over US.
+func (s *taxStore) ResolveProductTaxRates(ctx context.Context, productIDs []int64, jurisdiction string) (map[int64]bo.ProductTaxRates, error) {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `WITH classes AS (
+			SELECT p.id AS product_id, COALESCE(
+				p.tax_class_id,
+				(SELECT a.tax_class_id FROM category_closure cc INNER JOIN categories a ON a.id = cc.ancestor_id
+					WHERE cc.descendant_id = p.category_id AND a.tax_class_id IS NOT NULL
+					ORDER BY cc.depth ASC LIMIT 1),
+				(SELECT id FROM tax_classes WHERE is_default)
+			) AS tax_class_id
+			FROM products p WHERE p.id = ANY($1)
+		)
+		SELECT c.product_id, c.tax_class_id, r.id, r.tax_class_id, r.jurisdiction, r.name, r.rate::text, r.created_at, r.updated_at
+		FROM classes c
+		LEFT JOIN tax_rates r ON r.tax_class_id = c.tax_class_id AND r.jurisdiction = (
+			SELECT t.jurisdiction FROM tax_rates t
+			WHERE t.tax_class_id = c.tax_class_id AND (t.jurisdiction = $2 OR $2 LIKE t.jurisdiction || '-%')
+			ORDER BY length(t.jurisdiction) DESC LIMIT 1
+		)
+		ORDER BY c.product_id ASC, r.id ASC`, productIDs, jurisdiction)
+	if err != nil {
+		slog.Error("failed to resolve product tax rates", "cause", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	resolved := map[int64]bo.ProductTaxRates{}
+	for rows.Next() {
+		var (
+			productID, classID           sql.NullInt64
+			rateID, rateClassID          sql.NullInt64
+			rateJurisdiction, name, rate sql.NullString
+			createdAt, updatedAt         sql.NullTime
+		)
+		if err := rows.Scan(&productID, &classID, &rateID, &rateClassID, &rateJurisdiction, &name, &rate, &createdAt, &updatedAt); err != nil {
+			slog.Error("failed to scan product tax rate row", "cause", err)
+			return nil, err
+		}
+
+		product, ok := resolved[productID.Int64]
+		if !ok {
+			product = bo.ProductTaxRates{ProductID: productID.Int64, TaxClassID: classID.Int64, Rates: bo.TaxRateCollection{}}
+		}
+		if rateID.Valid {
+			value, err := decimal.NewFromString(rate.String)
+			if err != nil {
+				return nil, err
+			}
+			product.Rates = append(product.Rates, bo.TaxRate{
+				ID:           rateID.Int64,
+				TaxClassID:   rateClassID.Int64,
+				Jurisdiction: rateJurisdiction.String,
+				Name:         name.String,
+				Rate:         value,
+				CreatedAt:    createdAt.Time,
+				UpdatedAt:    updatedAt.Time,
+			})
+		}
+		resolved[productID.Int64] = product
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return nil, err
+	}
+
+	return resolved, nil
+}
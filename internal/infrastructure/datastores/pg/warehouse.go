@@ -0,0 +1,232 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"techno-store/internal/domain/bo"
+	"techno-store/internal/infrastructure/datastores/pg/sqlbuilder"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type warehouseStore struct {
+	dbPool *pgxpool.Pool
+}
+
+var warehouseFields = []string{
+	"id",
+	"code",
+	"name",
+	"address",
+	"status_id",
+	"created_at",
+}
+
+func (s *warehouseStore) GetWarehouseByID(ctx context.Context, warehouseID int64) (bo.Warehouse, error) {
+	var (
+		id        sql.NullInt64
+		code      sql.NullString
+		name      sql.NullString
+		address   sql.NullString
+		statusID  sql.NullInt64
+		createdAt sql.NullTime
+	)
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return bo.Warehouse{}, err
+	}
+	defer conn.Release()
+
+	dbQuery := fmt.Sprintf("SELECT %s FROM warehouses WHERE id = $1", strings.Join(warehouseFields, ","))
+	row := conn.QueryRow(ctx, dbQuery, warehouseID)
+
+	if err = row.Scan(&id, &code, &name, &address, &statusID, &createdAt); err != nil {
+		if err == pgx.ErrNoRows {
+			slog.Error("warehouse id does not exist", slog.Int64("id", warehouseID))
+			return bo.Warehouse{}, bo.ErrWarehouseNotFound
+		}
+		slog.Error("failed to scan warehouse table row", "cause", err)
+		return bo.Warehouse{}, err
+	}
+
+	return bo.Warehouse{
+		ID:        id.Int64,
+		Code:      code.String,
+		Name:      name.String,
+		Address:   address.String,
+		StatusID:  statusID.Int64,
+		CreatedAt: createdAt.Time,
+	}, nil
+}
+
+func (s *warehouseStore) CreateWarehouse(ctx context.Context, warehouse *bo.Warehouse) error {
+	insertMap := buildWarehouseInsertMap(*warehouse)
+	if len(insertMap) < 1 {
+		slog.Debug("empty core insert for warehouse")
+		return fmt.Errorf("empty core insert for warehouse")
+	}
+
+	sqlQuery, arguments := sqlbuilder.Insert("warehouses").Values(insertMap).Returning("id").Build()
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	var id sql.NullInt64
+	if err := conn.QueryRow(ctx, sqlQuery, arguments...).Scan(&id); err != nil {
+		return err
+	}
+
+	warehouse.ID = id.Int64
+	return nil
+}
+
+func buildWarehouseInsertMap(i bo.Warehouse) map[string]interface{} {
+	insertedFields := make(map[string]interface{})
+
+	for _, value := range warehouseFields {
+		switch value {
+		case "code":
+			if i.Code != "" {
+				insertedFields[value] = strings.ToLower(i.Code)
+			}
+		case "name":
+			if i.Name != "" {
+				insertedFields[value] = i.Name
+			}
+		case "address":
+			if i.Address != "" {
+				insertedFields[value] = i.Address
+			}
+		case "status_id":
+			insertedFields[value] = i.StatusID
+		}
+	}
+
+	return insertedFields
+}
+
+func (s *warehouseStore) UpdateWarehouse(ctx context.Context, updateWarehouse bo.WarehouseUpdate) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		updateMap := buildWarehouseUpdateMap(updateWarehouse)
+		if len(updateMap) < 1 {
+			slog.Debug("empty core update for warehouse", slog.Int64("id", updateWarehouse.ID))
+			return errors.New("empty core update for warehouse")
+		}
+
+		sqlQuery, arguments := sqlbuilder.Update("warehouses").Set(updateMap).Where("id = ?", updateWarehouse.ID).Build()
+
+		commandTag, err := tx.Exec(ctx, sqlQuery, arguments...)
+		if err != nil {
+			slog.Error("failed to update warehouse in database", "cause", err)
+			return fmt.Errorf("failed to update warehouse in database: %w", err)
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			slog.Warn("no rows affected when update warehouse", slog.Int64("warehouseID", updateWarehouse.ID))
+		}
+
+		return nil
+	})
+}
+
+func buildWarehouseUpdateMap(u bo.WarehouseUpdate) map[string]interface{} {
+	updatedFields := make(map[string]interface{})
+
+	if u.Code != nil {
+		updatedFields["code"] = strings.ToLower(*u.Code)
+	}
+	if u.Name != nil {
+		updatedFields["name"] = *u.Name
+	}
+	if u.Address != nil {
+		updatedFields["address"] = *u.Address
+	}
+	if u.StatusID != nil {
+		updatedFields["status_id"] = *u.StatusID
+	}
+
+	return updatedFields
+}
+
+func (s *warehouseStore) DeleteWarehouse(ctx context.Context, warehouseID int64) error {
+	return WrapInTx(ctx, s.dbPool, func(tx pgx.Tx) error {
+		sqlQuery := `DELETE FROM warehouses WHERE id = $1`
+		if commandTag, err := tx.Exec(ctx, sqlQuery, warehouseID); err != nil {
+			slog.Error("failed to delete warehouse", slog.Int64("warehouseID", warehouseID), "cause", err)
+			return err
+		} else if commandTag.RowsAffected() == 0 {
+			return bo.ErrWarehouseNotFound
+		}
+
+		return nil
+	})
+}
+
+func (s *warehouseStore) ListWarehouses(ctx context.Context, warehouseQuery bo.WarehouseQuery) (bo.PaginatedWarehouseCollection, error) {
+	pagingCollection := bo.PaginatedWarehouseCollection{}
+
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return pagingCollection, err
+	}
+	defer conn.Release()
+
+	query := sqlbuilder.Select(warehouseFields...).From("warehouses").OrderBy("name ASC").Page(warehouseQuery.Limit, warehouseQuery.Offset)
+	dbQuery, args := query.Build()
+	rows, err := conn.Query(ctx, dbQuery, args...)
+	if err != nil {
+		slog.Error("failed to list warehouses", "cause", err)
+		return pagingCollection, err
+	}
+	defer rows.Close()
+
+	var warehouses bo.WarehouseCollection
+	for rows.Next() {
+		var (
+			id        sql.NullInt64
+			code      sql.NullString
+			name      sql.NullString
+			address   sql.NullString
+			statusID  sql.NullInt64
+			createdAt sql.NullTime
+		)
+		if err := rows.Scan(&id, &code, &name, &address, &statusID, &createdAt); err != nil {
+			slog.Error("failed to scan warehouse row", "cause", err)
+			return pagingCollection, err
+		}
+		warehouses = append(warehouses, bo.Warehouse{
+			ID:        id.Int64,
+			Code:      code.String,
+			Name:      name.String,
+			Address:   address.String,
+			StatusID:  statusID.Int64,
+			CreatedAt: createdAt.Time,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		slog.Error("failed during rows iteration", "cause", err)
+		return pagingCollection, err
+	}
+
+	pagingCollection.Data = warehouses
+	var totalRecord sql.NullInt64
+	countQuery, countArgs := query.BuildCount()
+	if err = conn.QueryRow(ctx, countQuery, countArgs...).Scan(&totalRecord); err != nil {
+		slog.Error("error scanning COUNT warehouses row", "cause", err)
+		return pagingCollection, err
+	}
+
+	pagingCollection.Total = totalRecord.Int64
+	return pagingCollection, nil
+}
@@ -0,0 +1,153 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// FakeProvider is the provider name of the in-process fake gateway
+	FakeProvider = "fake"
+	// FakeDeclinedToken makes the fake gateway decline an authorization,
+	// every other token is authorized
+	FakeDeclinedToken = "tok_declined"
+)
+
+// FakeGateway is a deterministic in-process payment provider for tests and
+// local runs. Every operation succeeds except authorizations with the
+// FakeDeclinedToken or a non positive amount. Payment references carry the
+// order id and, like event ids, a sequence number counted from the start
+// the gateway was built with.
+type FakeGateway struct {
+	secret string
+	seq    atomic.Int64
+}
+
+// NewFakeGateway builds a fake gateway whose first reference is numbered
+// start+1. Webhooks must be signed with secret, every webhook is rejected
+// when it is empty.
+func NewFakeGateway(secret string, start int64) *FakeGateway {
+	g := &FakeGateway{secret: secret}
+	g.seq.Store(start)
+	return g
+}
+
+func (g *FakeGateway) Name() string {
+	return FakeProvider
+}
+
+func (g *FakeGateway) Authorize(_ context.Context, request bo.PaymentRequest) (bo.PaymentEvent, error) {
+	status := bo.PaymentAuthorized
+	if request.Token == FakeDeclinedToken || !request.Amount.Amount.IsPositive() {
+		status = bo.PaymentFailed
+	}
+
+	return bo.PaymentEvent{
+		EventID:           g.nextID("evt"),
+		Provider:          FakeProvider,
+		ProviderReference: g.nextID("pay_" + strconv.FormatInt(request.OrderID, 10)),
+		Status:            status,
+		Amount:            request.Amount.Amount,
+	}, nil
+}
+
+func (g *FakeGateway) Capture(_ context.Context, providerReference string, amount decimal.Decimal) (bo.PaymentEvent, error) {
+	return g.event(providerReference, bo.PaymentCaptured, amount)
+}
+
+func (g *FakeGateway) Refund(_ context.Context, providerReference string, amount decimal.Decimal) (bo.PaymentEvent, error) {
+	return g.event(providerReference, bo.PaymentRefunded, amount)
+}
+
+func (g *FakeGateway) Void(_ context.Context, providerReference string) (bo.PaymentEvent, error) {
+	return g.event(providerReference, bo.PaymentVoided, decimal.Zero)
+}
+
+// fakeWebhook is the callback payload of the fake provider
+type fakeWebhook struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Payment string          `json:"payment"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
+// ParseWebhook decodes a fake callback, e.g.
+// {"id":"evt_1","type":"payment.captured","payment":"fake_pay_4_1","amount":10}
+func (g *FakeGateway) ParseWebhook(payload []byte, signature string) (bo.PaymentEvent, error) {
+	if !g.verify(payload, signature) {
+		return bo.PaymentEvent{}, bo.ErrInvalidWebhookSignature
+	}
+
+	var webhook fakeWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		slog.Error("unable to decode fake webhook payload", "cause", err)
+		return bo.PaymentEvent{}, bo.ErrInvalidWebhookPayload
+	}
+
+	status := bo.PaymentStatus(strings.TrimPrefix(webhook.Type, "payment."))
+	if webhook.ID == "" || webhook.Payment == "" || !status.Valid() {
+		slog.Error("invalid fake webhook event", slog.String("id", webhook.ID), slog.String("type", webhook.Type))
+		return bo.PaymentEvent{}, bo.ErrInvalidWebhookPayload
+	}
+
+	return bo.PaymentEvent{
+		EventID:           webhook.ID,
+		Provider:          FakeProvider,
+		ProviderReference: webhook.Payment,
+		Status:            status,
+		Amount:            webhook.Amount,
+	}, nil
+}
+
+// Sign returns the hex HMAC-SHA256 signature the fake provider sends with a webhook payload
+func (g *FakeGateway) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(g.secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify compares the signature with the HMAC of the payload in constant time,
+// nothing is verified without a secret
+func (g *FakeGateway) verify(payload []byte, signature string) bool {
+	if g.secret == "" {
+		return false
+	}
+
+	received, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(g.secret))
+	mac.Write(payload)
+	return hmac.Equal(received, mac.Sum(nil))
+}
+
+func (g *FakeGateway) event(providerReference string, status bo.PaymentStatus, amount decimal.Decimal) (bo.PaymentEvent, error) {
+	if !strings.HasPrefix(providerReference, "fake_pay_") {
+		return bo.PaymentEvent{}, fmt.Errorf("unknown fake payment reference %q", providerReference)
+	}
+
+	return bo.PaymentEvent{
+		EventID:           g.nextID("evt"),
+		Provider:          FakeProvider,
+		ProviderReference: providerReference,
+		Status:            status,
+		Amount:            amount,
+	}, nil
+}
+
+func (g *FakeGateway) nextID(kind string) string {
+	return fmt.Sprintf("fake_%s_%d", kind, g.seq.Add(1))
+}
@@ -0,0 +1,66 @@
+package payments
+
+import (
+	"context"
+	"testing"
+
+	"techno-store/internal/domain/bo"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeGatewayReferences(t *testing.T) {
+	gateway := NewFakeGateway("s3cret", 10)
+
+	amount := decimal.NewFromInt(25)
+	authorized, err := gateway.Authorize(context.Background(), bo.PaymentRequest{OrderID: 4, Amount: bo.NewMoney(amount, "BDT"), Token: "tok_visa"})
+	require.NoError(t, err)
+	require.Equal(t, bo.PaymentEvent{
+		EventID:           "fake_evt_11",
+		Provider:          FakeProvider,
+		ProviderReference: "fake_pay_4_12",
+		Status:            bo.PaymentAuthorized,
+		Amount:            amount,
+	}, authorized)
+
+	refunded, err := gateway.Refund(context.Background(), authorized.ProviderReference, decimal.NewFromInt(5))
+	require.NoError(t, err)
+	require.Equal(t, "fake_evt_13", refunded.EventID)
+	require.Equal(t, "fake_pay_4_12", refunded.ProviderReference)
+}
+
+func TestFakeGatewayParseWebhook(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"payment.captured","payment":"fake_pay_4_1","amount":25}`)
+	gateway := NewFakeGateway("s3cret", 0)
+
+	testCases := []struct {
+		name      string
+		gateway   *FakeGateway
+		signature string
+		err       error
+	}{
+		{name: "Signed", gateway: gateway, signature: gateway.Sign(payload)},
+		{name: "Forged", gateway: gateway, signature: "forged", err: bo.ErrInvalidWebhookSignature},
+		{name: "Unsigned", gateway: gateway, err: bo.ErrInvalidWebhookSignature},
+		{
+			name:      "NoSecret",
+			gateway:   NewFakeGateway("", 0),
+			signature: NewFakeGateway("", 0).Sign(payload),
+			err:       bo.ErrInvalidWebhookSignature,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event, err := tc.gateway.ParseWebhook(payload, tc.signature)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "fake_pay_4_1", event.ProviderReference)
+			require.Equal(t, bo.PaymentCaptured, event.Status)
+		})
+	}
+}
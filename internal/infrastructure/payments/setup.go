@@ -0,0 +1,20 @@
+package payments
+
+import (
+	"log/slog"
+	"time"
+
+	"techno-store/config"
+	"techno-store/internal/domain/definition"
+)
+
+// GetInstance returns the gateway of the configured payment provider, the fake
+// provider is the only one so far and the config rejects any other. Its
+// references are counted from the start time so they never collide with the
+// payments stored by a previous run.
+func GetInstance(config *config.PaymentConfig) definition.PaymentGateway {
+	if config.WebhookSecret == "" {
+		slog.Warn("PAYMENT_WEBHOOK_SECRET is not set, every payment webhook will be rejected")
+	}
+	return NewFakeGateway(config.WebhookSecret, time.Now().UnixNano())
+}